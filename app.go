@@ -1,31 +1,123 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/itchyny/gojq"
+	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
+	"jtool/internal/bookmark"
+	"jtool/internal/charset"
+	"jtool/internal/codegen"
+	"jtool/internal/config"
+	"jtool/internal/convert"
+	"jtool/internal/decompress"
 	"jtool/internal/diff"
+	"jtool/internal/fetch"
+	"jtool/internal/gitfile"
+	"jtool/internal/jsonpath"
+	"jtool/internal/jsonschema"
+	"jtool/internal/jwt"
+	"jtool/internal/launch"
 	"jtool/internal/loganalyzer"
+	"jtool/internal/monitor"
 	"jtool/internal/normalize"
+	"jtool/internal/notify"
+	"jtool/internal/parser"
 	"jtool/internal/paths"
+	"jtool/internal/pretty"
+	"jtool/internal/report"
+	"jtool/internal/search"
 	"jtool/internal/storage"
+	"jtool/internal/undo"
+	"jtool/internal/unwrap"
 )
 
 // App struct holds the application state.
 type App struct {
 	ctx       context.Context
 	history   *storage.FileHistory
+	profiles  *storage.Profiles
+	layout    *storage.Layout // Window geometry, selected tab and splitter ratios
+	bookmarks *storage.Bookmarks
+	scoped    []*bookmark.Access // Files reopened through bookmarks, released at shutdown
 	configDir string
+	config    *config.Config     // Defaults from the config file and JTOOL_* variables
+	configErr error              // Why the config couldn't be used, if it couldn't
+	analyses  *loganalyzer.Cache // Cached log analyses, so unchanged files open instantly
+	monitors  *storage.Monitors  // Comparisons re-run on a schedule, with their recent runs
+	saveMu    sync.Mutex         // Serializes session autosaves, so an older one never lands last
+
+	scheduler    *monitor.Scheduler
+	stopMonitors context.CancelFunc // Stops the scheduler at shutdown
+
+	// mu guards the state below, since Wails may invoke bindings concurrently
+	mu      sync.Mutex
+	session *diffSession
+	diffs   int                      // Comparisons made so far, numbering their IDs
+	panes   map[string]*undo.History // Undo history by pane (see RecordPaneSnapshot)
+	unwatch context.CancelFunc       // Stops the log file being watched, if any
+
+	// The most recent watch, kept after it stops so watching the same file
+	// again resumes where it left off instead of re-reading it
+	follower   *loganalyzer.Follower
+	followPath string
+
+	// Files larger than this many bytes are only opened after the user
+	// confirms (0 disables the check)
+	largeFileThreshold int64
+
+	// Files to open that the OS or command line passed in, not yet taken
+	// by the frontend (see TakeOpenedFiles)
+	openQueue []string
+
+	// Working state waiting to be autosaved (see AutosaveSession), the
+	// session a crash left behind, if any, and the function that stops
+	// autosaving at shutdown
+	pendingSession   *storage.Session
+	recoveredSession *storage.Session
+	stopAutosave     context.CancelFunc
+
+	// Running cancellable operations by ID (see CancelOperation), and the
+	// function that stops every operation at shutdown
+	operations map[string]*operation
+	stopAll    context.CancelFunc
+	opsCtx     context.Context
+}
+
+// diffSession remembers the most recent comparison so edits to one pane
+// can be re-diffed incrementally instead of from scratch.
+type diffSession struct {
+	id            string                 // ID given to the frontend with the result
+	left          any                    // Normalized left document
+	right         any                    // Normalized right document
+	docs          [2]*diff.Document      // Hashed left and right, built on the first update
+	opts          normalize.Options      // Options used to normalize both sides
+	rules         []diff.IgnoreRule      // Ignore rules applied to the result
+	summarizeOver int                    // Array length above which equal elements are collapsed
+	pathFormat    string                 // Notation of the paths shown (see paths.FormatPath)
+	lenient       [2]bool                // Left and right panes accept JSON5 (see parser.Lenient)
+	schema        *jsonschema.Schema     // Schema both panes are validated against, if any
+	validation    *diff.SchemaValidation // Violations of the raw (not normalized) panes
+	result        *diff.DiffResult       // Diff produced from left and right, before ignore rules
+	view          *diff.DiffResult       // result with ignore rules applied, before summarization
 }
 
 // NewApp creates a new App application struct.
 func NewApp() *App {
-	return &App{}
+	return &App{largeFileThreshold: defaultLargeFileThreshold}
 }
 
 // startup is called when the app starts. The context is saved
@@ -50,42 +142,190 @@ func (a *App) startup(ctx context.Context) {
 		history = storage.NewFileHistory()
 	}
 	a.history = history
+
+	// Regain access to files in the history under the macOS sandbox
+	bookmarks, err := storage.LoadBookmarks(a.configDir)
+	if err != nil {
+		bookmarks = storage.NewBookmarks()
+	}
+	a.bookmarks = bookmarks
+	a.restoreBookmarks()
+
+	// Load saved normalization profiles (same fallback as history)
+	profiles, err := storage.LoadProfiles(a.configDir)
+	if err != nil {
+		profiles = storage.NewProfiles()
+	}
+	a.profiles = profiles
+
+	// Reopen the window the way it was left (same fallback as history)
+	layout, err := storage.LoadLayout(a.configDir)
+	if err != nil {
+		layout = storage.NewLayout()
+	}
+	a.layout = layout
+	a.restoreWindow()
+
+	// Settings changed in an earlier run (defaults if they can't be read)
+	a.loadSettings()
+
+	a.analyses = loganalyzer.NewCache(filepath.Join(a.configDir, "analysis-cache"))
+
+	// Set aside the session a crash left behind, then autosave this one
+	if recovered, err := storage.RecoverSession(a.configDir); err == nil {
+		a.recoveredSession = recovered
+	}
+	a.startAutosave()
+
+	// Re-run monitored comparisons on their schedules (same fallback as history)
+	monitors, err := storage.LoadMonitors(a.configDir)
+	if err != nil {
+		monitors = storage.NewMonitors()
+	}
+	a.monitors = monitors
+	a.startMonitors()
 }
 
 // shutdown is called when the app is closing.
 // Save the file history to disk.
 func (a *App) shutdown(ctx context.Context) {
+	a.StopWatchingLogFile()
+	a.cancelAllOperations()
+	a.endSession()
+	if a.stopMonitors != nil {
+		a.stopMonitors()
+	}
+
 	// Save history to disk
 	if a.history != nil {
 		_ = a.history.Save(a.configDir)
 	}
+	if a.layout != nil {
+		_ = a.layout.Save(a.configDir)
+	}
+	for _, access := range a.scoped {
+		access.Close()
+	}
+}
+
+// beforeClose is called when the window is about to close, while its
+// geometry can still be read. Returning false lets it close.
+func (a *App) beforeClose(ctx context.Context) bool {
+	a.rememberWindow()
+	return false
+}
+
+// errCancelled is returned by an operation stopped with CancelOperation.
+var errCancelled = errors.New("operation cancelled")
+
+// operation is a running cancellable binding call.
+type operation struct {
+	cancel context.CancelFunc
+}
+
+// startOperation returns the context for a long-running binding call and
+// a function to call when it returns. A non-empty opID (chosen by the
+// frontend, e.g. a UUID) lets CancelOperation stop the call; starting an
+// operation with the ID of one still running cancels the older one, so a
+// pane re-run with the same ID supersedes the previous run. Every
+// operation is cancelled at shutdown.
+func (a *App) startOperation(opID string) (context.Context, func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Long operations are where crashes happen, so don't wait for the next
+	// autosave to persist what was pasted. The write happens in the
+	// background so the operation doesn't wait on the disk.
+	if a.pendingSession != nil {
+		go a.flushSession()
+	}
+
+	if a.opsCtx == nil {
+		a.opsCtx, a.stopAll = context.WithCancel(context.Background())
+	}
+	ctx, cancel := context.WithCancel(a.opsCtx)
+	if opID == "" {
+		return ctx, cancel
+	}
+
+	if a.operations == nil {
+		a.operations = make(map[string]*operation)
+	}
+	if prev, ok := a.operations[opID]; ok {
+		prev.cancel()
+	}
+	op := &operation{cancel: cancel}
+	a.operations[opID] = op
+
+	return ctx, func() {
+		cancel()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.operations[opID] == op { // Not superseded by a newer run
+			delete(a.operations, opID)
+		}
+	}
+}
+
+// CancelOperation stops the running operation started with opID, e.g. when
+// the user presses Cancel or closes the tab that started it. The call
+// returns an "operation cancelled" error soon after. Returns false if no
+// operation with that ID is running.
+func (a *App) CancelOperation(opID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	op, ok := a.operations[opID]
+	if ok {
+		op.cancel()
+		delete(a.operations, opID)
+	}
+	return ok
+}
+
+// cancelAllOperations stops every running operation.
+func (a *App) cancelAllOperations() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopAll != nil {
+		a.stopAll()
+		a.opsCtx, a.stopAll = nil, nil
+	}
+	a.operations = nil
 }
 
 // CompareJSON takes two JSON strings, parses them, and returns the diff result.
 // This method is exposed to the frontend via Wails bindings.
 func (a *App) CompareJSON(leftJSON, rightJSON string) (*diff.DiffResult, error) {
 	// Parse left JSON
-	var left any
-	if err := json.Unmarshal([]byte(leftJSON), &left); err != nil {
+	left, err := parser.ParseString(leftJSON)
+	if err != nil {
 		return nil, fmt.Errorf("invalid left JSON: %w", err)
 	}
 
 	// Parse right JSON
-	var right any
-	if err := json.Unmarshal([]byte(rightJSON), &right); err != nil {
+	right, err := parser.ParseString(rightJSON)
+	if err != nil {
 		return nil, fmt.Errorf("invalid right JSON: %w", err)
 	}
 
 	// Perform the diff
 	result := diff.Compare(left, right)
-	return result, nil
+	return a.rememberDiff(&diffSession{
+		left:   left,
+		right:  right,
+		opts:   normalize.NoNormalization(),
+		result: result,
+	}), nil
 }
 
 // FormatJSON takes a JSON string and returns it pretty-printed.
 // Useful for normalizing user input in the UI.
+// Numbers keep their original text, so large integer IDs are not rounded.
 func (a *App) FormatJSON(jsonStr string) (string, error) {
-	var data any
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
 		return "", fmt.Errorf("invalid JSON: %w", err)
 	}
 
@@ -98,11 +338,94 @@ func (a *App) FormatJSON(jsonStr string) (string, error) {
 	return string(formatted), nil
 }
 
+// FormatJSONWithOptions pretty-prints a JSON string with configurable
+// indentation (width or tabs), key sorting, non-ASCII escaping and
+// line-width-aware wrapping (see pretty.Options). Unlike FormatJSON, keys
+// keep their source order unless SortKeys is set and numbers are written
+// exactly as in the input.
+func (a *App) FormatJSONWithOptions(jsonStr string, opts pretty.Options) (string, error) {
+	formatted, err := pretty.Format(jsonStr, opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	return formatted, nil
+}
+
+// FormatLenientJSON converts JSON5 or JSON with comments, such as a config
+// file, to pretty-printed standard JSON. Comments are dropped.
+func (a *App) FormatLenientJSON(jsonStr string) (string, error) {
+	data, err := parser.ParseLenient(jsonStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON5: %w", err)
+	}
+	return marshalIndented(data)
+}
+
+// ValidateLenientJSON is ValidateJSON for a pane in lenient mode: comments,
+// trailing commas, single quotes and unquoted keys are accepted.
+func (a *App) ValidateLenientJSON(jsonStr string) string {
+	if _, err := parser.ParseLenient(jsonStr); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// ConvertTOMLToJSON converts a TOML document to pretty-printed JSON, so
+// configuration files can be loaded into the diff and path panes.
+func (a *App) ConvertTOMLToJSON(text string) (string, error) {
+	data, err := convert.TOML(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid TOML: %w", err)
+	}
+	return marshalIndented(data)
+}
+
+// ConvertINIToJSON converts a simple INI file to pretty-printed JSON, with
+// one object per section. All values are strings, since INI is untyped.
+func (a *App) ConvertINIToJSON(text string) (string, error) {
+	data, err := convert.INI(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid INI: %w", err)
+	}
+	return marshalIndented(data)
+}
+
+// ConvertXMLToJSON converts an XML document, such as a SOAP payload, to
+// pretty-printed JSON. opts choose how attributes, text and namespaces are
+// mapped; the zero value prefixes attribute keys with "@".
+func (a *App) ConvertXMLToJSON(text string, opts convert.XMLOptions) (string, error) {
+	data, err := convert.XML(text, opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid XML: %w", err)
+	}
+	return marshalIndented(data)
+}
+
+// marshalIndented pretty-prints a converted document the way FormatJSON does.
+func marshalIndented(data any) (string, error) {
+	formatted, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error formatting JSON: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// marshalCompact renders data as JSON on one line, leaving "<", ">" and "&"
+// unescaped.
+func marshalCompact(data any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return "", fmt.Errorf("error formatting JSON: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
 // ValidateJSON checks if a string is valid JSON.
 // Returns an error message if invalid, empty string if valid.
 func (a *App) ValidateJSON(jsonStr string) string {
-	var data any
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
+	if _, err := parser.ParseString(jsonStr); err != nil {
 		return err.Error()
 	}
 	return ""
@@ -113,57 +436,640 @@ func (a *App) ValidateJSON(jsonStr string) string {
 type NormalizeOptions struct {
 	SortKeys         bool   `json:"sortKeys"`
 	NormalizeNumbers bool   `json:"normalizeNumbers"`
+	RoundNumbers     int    `json:"roundNumbers"` // Decimal places, 0 disables
 	TrimStrings      bool   `json:"trimStrings"`
 	NullEqualsAbsent bool   `json:"nullEqualsAbsent"`
 	SortArrays       bool   `json:"sortArrays"`
 	SortArraysByKey  string `json:"sortArraysByKey"`
+
+	// SortArraysByPath maps array paths to sort keys, e.g. {".users": "id"}.
+	SortArraysByPath map[string]string `json:"sortArraysByPath"`
+
+	// Date normalization: strings matching any input layout are rewritten
+	// in the output layout (Go reference-time layouts, e.g. "2006-01-02").
+	DateInputLayouts []string `json:"dateInputLayouts"`
+	DateOutputLayout string   `json:"dateOutputLayout"`
+
+	// URL canonicalization: lowercase scheme/host, strip default ports,
+	// sort query parameters and drop the listed params (globs like "utm_*").
+	NormalizeURLs bool     `json:"normalizeUrls"`
+	DropURLParams []string `json:"dropUrlParams"`
+
+	// NormalizeUUIDs lowercases UUIDs and strips {braces} / urn:uuid: prefixes.
+	NormalizeUUIDs bool `json:"normalizeUuids"`
+
+	// UnicodeNormalization is "", "NFC", "NFD", "NFKC" or "NFKD".
+	UnicodeNormalization string `json:"unicodeNormalization"`
+
+	// String coercion: "true"/"false" become booleans, numeric strings
+	// become numbers, and any of NullStrings (e.g. "N/A", "") becomes null.
+	CoerceBooleans bool     `json:"coerceBooleans"`
+	CoerceNumbers  bool     `json:"coerceNumbers"`
+	NullStrings    []string `json:"nullStrings"`
+
+	// Locale number strings such as "1.234,56" (decimal ",", thousands ".")
+	// are parsed as numbers when LocaleDecimalSeparator is set.
+	LocaleDecimalSeparator   string `json:"localeDecimalSeparator"`
+	LocaleThousandsSeparator string `json:"localeThousandsSeparator"`
+
+	// Overrides apply different options to matching paths (last match wins).
+	Overrides []NormalizeOverride `json:"overrides"`
+
+	// Transforms are custom per-path rules (round, lowercase, truncate,
+	// regex-replace, constant) applied during normalization.
+	Transforms []normalize.TransformRule `json:"transforms"`
+
+	// ExcludeKeys / ExcludePaths drop fields from both documents before
+	// diffing, and from path listings (see paths.ExtractOptions).
+	ExcludeKeys  []string `json:"excludeKeys"`
+	ExcludePaths []string `json:"excludePaths"`
+
+	// RedactPaths masks matching fields before comparison and display.
+	// With a RedactSalt, masked values are salted hashes so changes still show.
+	RedactPaths []string `json:"redactPaths"`
+	RedactSalt  string   `json:"redactSalt"`
+
+	// IgnorePaths suppresses differences at matching paths after diffing.
+	// Suppressed counts are reported per rule in the diff stats.
+	IgnorePaths []diff.IgnoreRule `json:"ignorePaths"`
+
+	// SummarizeArraysOver collapses equal elements of arrays longer than
+	// this many elements (0 disables). Use ExpandDiffNode to page through them.
+	SummarizeArraysOver int `json:"summarizeArraysOver"`
+
+	// PathFormat is how node paths are written in the result: "dotted"
+	// (default), "jsonpath" or "pointer" (see paths.FormatPath). Patterns in
+	// the other options always use the dotted form.
+	PathFormat string `json:"pathFormat"`
+
+	// LenientLeft / LenientRight accept JSON5 and JSON with comments in
+	// that pane (see parser.Lenient); other panes stay strict.
+	LenientLeft  bool `json:"lenientLeft"`
+	LenientRight bool `json:"lenientRight"`
+
+	// Schema is a JSON Schema both panes are validated against before
+	// normalization. Violations are returned in the result's Validation,
+	// with instance paths in PathFormat. Empty disables validation.
+	Schema string `json:"schema"`
+}
+
+// NormalizeOverride scopes a set of options to a path pattern
+// such as ".user.*" or ".tags". It mirrors normalize.Override: only the
+// options named in Set (by JSON name, e.g. "trimStrings") are changed, or
+// the non-zero ones when Set is empty.
+type NormalizeOverride struct {
+	Path    string           `json:"path"`
+	Options NormalizeOptions `json:"options"`
+	Set     []string         `json:"set"`
+}
+
+// overrideOptionNames maps the JSON names of NormalizeOptions fields to
+// the normalize.Options fields they fill.
+var overrideOptionNames = map[string]string{
+	"sortKeys":                 "SortKeys",
+	"normalizeNumbers":         "NormalizeNumbers",
+	"roundNumbers":             "RoundNumbers",
+	"trimStrings":              "TrimStrings",
+	"nullEqualsAbsent":         "NullEqualsAbsent",
+	"sortArrays":               "SortArrays",
+	"sortArraysByKey":          "SortArraysByKey",
+	"dateInputLayouts":         "NormalizeDates.InputLayouts",
+	"dateOutputLayout":         "NormalizeDates.OutputLayout",
+	"normalizeUrls":            "NormalizeURLs.Enabled",
+	"dropUrlParams":            "NormalizeURLs.DropParams",
+	"normalizeUuids":           "NormalizeUUIDs",
+	"unicodeNormalization":     "UnicodeNormalization",
+	"coerceBooleans":           "CoerceBooleans",
+	"coerceNumbers":            "CoerceNumbers",
+	"nullStrings":              "NullStrings",
+	"localeDecimalSeparator":   "LocaleNumbers.DecimalSeparator",
+	"localeThousandsSeparator": "LocaleNumbers.ThousandsSeparator",
+}
+
+// toNormalizeOptions converts frontend options to internal normalize.Options.
+func (o NormalizeOptions) toNormalizeOptions() normalize.Options {
+	return normalize.Options{
+		SortKeys:         o.SortKeys,
+		NormalizeNumbers: o.NormalizeNumbers,
+		RoundNumbers:     o.RoundNumbers,
+		TrimStrings:      o.TrimStrings,
+		NullEqualsAbsent: o.NullEqualsAbsent,
+		SortArrays:       o.SortArrays,
+		SortArraysByKey:  o.SortArraysByKey,
+		SortArraysByPath: o.SortArraysByPath,
+		NormalizeDates: normalize.DateOptions{
+			InputLayouts: o.DateInputLayouts,
+			OutputLayout: o.DateOutputLayout,
+		},
+		NormalizeURLs: normalize.URLOptions{
+			Enabled:    o.NormalizeURLs,
+			DropParams: o.DropURLParams,
+		},
+		NormalizeUUIDs:       o.NormalizeUUIDs,
+		UnicodeNormalization: normalize.UnicodeForm(o.UnicodeNormalization),
+		CoerceBooleans:       o.CoerceBooleans,
+		CoerceNumbers:        o.CoerceNumbers,
+		NullStrings:          o.NullStrings,
+		LocaleNumbers: normalize.LocaleNumberOptions{
+			DecimalSeparator:   o.LocaleDecimalSeparator,
+			ThousandsSeparator: o.LocaleThousandsSeparator,
+		},
+		Overrides:    toNormalizeOverrides(o.Overrides),
+		Transforms:   o.Transforms,
+		ExcludeKeys:  o.ExcludeKeys,
+		ExcludePaths: o.ExcludePaths,
+		RedactPaths:  o.RedactPaths,
+		RedactSalt:   o.RedactSalt,
+	}
+}
+
+// toNormalizeOverrides converts frontend overrides to normalize.Override values.
+func toNormalizeOverrides(overrides []NormalizeOverride) []normalize.Override {
+	if len(overrides) == 0 {
+		return nil
+	}
+	result := make([]normalize.Override, len(overrides))
+	for i, o := range overrides {
+		result[i] = normalize.Override{
+			Path:    o.Path,
+			Options: o.Options.toNormalizeOptions(),
+		}
+		// Names that aren't options an override can set are passed on
+		// unchanged for normalize.ValidateOverrides to report
+		for _, name := range o.Set {
+			if field, ok := overrideOptionNames[name]; ok {
+				name = field
+			}
+			result[i].Set = append(result[i].Set, name)
+		}
+	}
+	return result
+}
+
+// CompareJSONShape compares only the structure of two documents: which paths
+// exist and what types they hold, without comparing values. Use it to answer
+// "did the shape change?" for single documents.
+func (a *App) CompareJSONShape(leftJSON, rightJSON string) (*paths.ShapeDiff, error) {
+	left, err := parser.ParseString(leftJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid left JSON: %w", err)
+	}
+
+	right, err := parser.ParseString(rightJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid right JSON: %w", err)
+	}
+
+	return paths.CompareShapes(left, right), nil
 }
 
 // CompareJSONWithOptions compares two JSON strings with normalization options.
 // This is the "smart" comparison that handles key ordering, number formats, etc.
 func (a *App) CompareJSONWithOptions(leftJSON, rightJSON string, opts NormalizeOptions) (*diff.DiffResult, error) {
+	return a.CompareJSONCancellable("", leftJSON, rightJSON, opts)
+}
+
+// CompareJSONCancellable is CompareJSONWithOptions as an operation that
+// CancelOperation(opID) can stop.
+func (a *App) CompareJSONCancellable(opID, leftJSON, rightJSON string, opts NormalizeOptions) (*diff.DiffResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
 	// Parse left JSON
-	var left any
-	if err := json.Unmarshal([]byte(leftJSON), &left); err != nil {
+	left, err := parsePane(leftJSON, opts.LenientLeft)
+	if err != nil {
 		return nil, fmt.Errorf("invalid left JSON: %w", err)
 	}
 
 	// Parse right JSON
-	var right any
-	if err := json.Unmarshal([]byte(rightJSON), &right); err != nil {
+	right, err := parsePane(rightJSON, opts.LenientRight)
+	if err != nil {
 		return nil, fmt.Errorf("invalid right JSON: %w", err)
 	}
 
 	// Convert frontend options to internal options
-	normalizeOpts := normalize.Options{
-		SortKeys:         opts.SortKeys,
-		NormalizeNumbers: opts.NormalizeNumbers,
-		TrimStrings:      opts.TrimStrings,
-		NullEqualsAbsent: opts.NullEqualsAbsent,
-		SortArrays:       opts.SortArrays,
-		SortArraysByKey:  opts.SortArraysByKey,
+	normalizeOpts := opts.toNormalizeOptions()
+	if err := normalize.ValidateTransforms(normalizeOpts.Transforms); err != nil {
+		return nil, fmt.Errorf("invalid transform rules: %w", err)
+	}
+	if err := normalize.ValidateOverrides(normalizeOpts.Overrides); err != nil {
+		return nil, fmt.Errorf("invalid overrides: %w", err)
+	}
+
+	// Validate the panes as written, before normalization changes them
+	var schema *jsonschema.Schema
+	var validation *diff.SchemaValidation
+	if strings.TrimSpace(opts.Schema) != "" {
+		if schema, err = compileSchema(opts.Schema); err != nil {
+			return nil, err
+		}
+		validation = &diff.SchemaValidation{
+			Left:  schema.Validate(left),
+			Right: schema.Validate(right),
+		}
+	}
+
+	// Normalize both sides and diff them. Normalization is done here rather
+	// than in diff.CompareWithOptions so the session can keep the normalized
+	// values for incremental re-diffs.
+	leftNorm := normalize.Value(left, normalizeOpts)
+	rightNorm := normalize.Value(right, normalizeOpts)
+	result, err := diff.CompareWithProgress(ctx, leftNorm, rightNorm, a.diffProgressReporter(opID))
+	if err != nil {
+		return nil, errCancelled
+	}
+	return a.rememberDiff(&diffSession{
+		left:          leftNorm,
+		right:         rightNorm,
+		opts:          normalizeOpts,
+		rules:         opts.IgnorePaths,
+		summarizeOver: opts.SummarizeArraysOver,
+		pathFormat:    opts.PathFormat,
+		lenient:       [2]bool{opts.LenientLeft, opts.LenientRight},
+		schema:        schema,
+		validation:    validation,
+		result:        result,
+	}), nil
+}
+
+// DiffProgress is sent as a "diff:progress" event while a large comparison
+// runs (see diff.CompareWithProgress).
+type DiffProgress struct {
+	OperationID string `json:"operationId"` // opID of the comparison; "" for CompareJSONWithOptions
+	diff.Progress
+}
+
+// diffProgressReporter returns a callback that emits diff progress events,
+// or nil outside the desktop app where there is no frontend to notify.
+func (a *App) diffProgressReporter(opID string) func(diff.Progress) {
+	if a.ctx == nil {
+		return nil
+	}
+	return func(p diff.Progress) {
+		runtime.EventsEmit(a.ctx, "diff:progress", DiffProgress{OperationID: opID, Progress: p})
+	}
+}
+
+// parsePane parses the contents of a pane, as JSON5 if lenient is set.
+func parsePane(text string, lenient bool) (any, error) {
+	if lenient {
+		return parser.ParseLenient(text)
+	}
+	return parser.ParseString(text)
+}
+
+// NormalizationReport describes what normalization changed in a document.
+type NormalizationReport struct {
+	*normalize.Report
+	Summary string `json:"summary"` // e.g. "trimmed 14 strings, removed 3 nulls"
+}
+
+// GetNormalizationReport normalizes a JSON string with the given options
+// and reports every change that was made. Call it for each side when a diff
+// comes out equal unexpectedly, to see what normalization did to the data.
+func (a *App) GetNormalizationReport(jsonStr string, opts NormalizeOptions) (*NormalizationReport, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	normalizeOpts := opts.toNormalizeOptions()
+	if err := normalize.ValidateTransforms(normalizeOpts.Transforms); err != nil {
+		return nil, fmt.Errorf("invalid transform rules: %w", err)
+	}
+	if err := normalize.ValidateOverrides(normalizeOpts.Overrides); err != nil {
+		return nil, fmt.Errorf("invalid overrides: %w", err)
+	}
+
+	_, report := normalize.ValueWithReport(data, normalizeOpts)
+	return &NormalizationReport{
+		Report:  report,
+		Summary: report.Summary(),
+	}, nil
+}
+
+// UpdateAndRediff re-diffs after the content of one pane changed.
+// side must be "left" or "right". The other pane and the normalization
+// options are taken from the most recent CompareJSON/CompareJSONWithOptions
+// call, and only subtrees whose content changed are compared again.
+//
+// If another comparison or update replaced the session while this one was
+// being computed, the edit is applied again on top of the newer session
+// rather than overwriting it.
+func (a *App) UpdateAndRediff(side, newContent string) (*diff.DiffResult, error) {
+	var index int
+	switch side {
+	case "left":
+		index = 0
+	case "right":
+		index = 1
+	default:
+		return nil, fmt.Errorf("invalid side %q: must be \"left\" or \"right\"", side)
+	}
+
+	for {
+		a.mu.Lock()
+		s := a.session
+		a.mu.Unlock()
+
+		if s == nil {
+			return nil, fmt.Errorf("no previous comparison to update")
+		}
+
+		next, err := s.update(index, newContent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s JSON: %w", side, err)
+		}
+		if result, ok := a.replaceDiff(s, next); ok {
+			return result, nil
+		}
+	}
+}
+
+// update returns a session with the pane at index (0 for left, 1 for right)
+// replaced by content, re-diffed incrementally from s.
+func (s *diffSession) update(index int, content string) (*diffSession, error) {
+	data, err := parsePane(content, s.lenient[index])
+	if err != nil {
+		return nil, err
+	}
+
+	newLeft, newRight := s.left, s.right
+	if index == 0 {
+		newLeft = normalize.Value(data, s.opts)
+	} else {
+		newRight = normalize.Value(data, s.opts)
+	}
+
+	// s is shared, so documents built here go only into the new session
+	prevDocs := s.docs
+	for i, v := range []any{s.left, s.right} {
+		if prevDocs[i] == nil {
+			prevDocs[i] = diff.NewDocument(v)
+		}
+	}
+	docs := prevDocs
+	if index == 0 {
+		docs[0] = diff.NewDocument(newLeft)
+	} else {
+		docs[1] = diff.NewDocument(newRight)
+	}
+
+	validation := s.validation
+	if s.schema != nil {
+		updated := *s.validation
+		if index == 0 {
+			updated.Left = s.schema.Validate(data)
+		} else {
+			updated.Right = s.schema.Validate(data)
+		}
+		validation = &updated
+	}
+
+	return &diffSession{
+		left:          newLeft,
+		right:         newRight,
+		docs:          docs,
+		opts:          s.opts,
+		rules:         s.rules,
+		summarizeOver: s.summarizeOver,
+		pathFormat:    s.pathFormat,
+		lenient:       s.lenient,
+		schema:        s.schema,
+		validation:    validation,
+		result:        diff.Recompare(s.result, prevDocs[0], prevDocs[1], docs[0], docs[1]),
+	}, nil
+}
+
+// ExpandDiffNode returns the full children of a node from the most recent
+// comparison, for expanding an array that was collapsed by SummarizeArraysOver.
+// offset and limit page through the children; a limit of 0 returns all of them.
+// Long arrays nested inside the returned children are still summarized.
+func (a *App) ExpandDiffNode(path string, offset, limit int) ([]diff.DiffNode, error) {
+	a.mu.Lock()
+	s := a.session
+	a.mu.Unlock()
+
+	if s == nil {
+		return nil, fmt.Errorf("no comparison to expand")
+	}
+
+	node := diff.FindFormattedNode(&s.view.Root, path, s.pathFormat)
+	if node == nil {
+		return nil, fmt.Errorf("path not found in diff: %s", path)
+	}
+
+	children := node.Children
+	if offset < 0 || offset > len(children) {
+		return nil, fmt.Errorf("offset %d out of range (0-%d)", offset, len(children))
+	}
+	children = children[offset:]
+	if limit > 0 && limit < len(children) {
+		children = children[:limit]
 	}
 
-	// Perform the diff with normalization
-	result := diff.CompareWithOptions(left, right, normalizeOpts)
+	result := make([]diff.DiffNode, len(children))
+	for i, child := range children {
+		result[i] = diff.FormatNode(diff.SummarizeNode(child, s.summarizeOver), s.pathFormat)
+	}
 	return result, nil
 }
 
-// GetDefaultNormalizeOptions returns the default normalization options.
-// Called by frontend to initialize the UI with sensible defaults.
+// rememberDiff stores a comparison for UpdateAndRediff/ExpandDiffNode and
+// returns the result as it should be shown: ignore rules applied, long
+// arrays summarized and paths in the requested format.
+func (a *App) rememberDiff(s *diffSession) *diff.DiffResult {
+	s.view = diff.ApplyIgnoreRules(s.result, s.rules)
+
+	a.mu.Lock()
+	a.storeSession(s)
+	a.mu.Unlock()
+
+	return diffView(s)
+}
+
+// replaceDiff is rememberDiff for an update of prev. It stores s only if
+// prev is still the most recent comparison, and reports whether it did.
+func (a *App) replaceDiff(prev, s *diffSession) (*diff.DiffResult, bool) {
+	s.view = diff.ApplyIgnoreRules(s.result, s.rules)
+
+	a.mu.Lock()
+	if a.session != prev {
+		a.mu.Unlock()
+		return nil, false
+	}
+	a.storeSession(s)
+	a.mu.Unlock()
+
+	return diffView(s), true
+}
+
+// storeSession makes s the most recent comparison. a.mu must be held.
+func (a *App) storeSession(s *diffSession) {
+	a.diffs++
+	s.id = fmt.Sprintf("diff-%d", a.diffs)
+	a.session = s
+}
+
+// forgetDiff drops the comparison with the given ID if it is still the
+// most recent one, so its documents can't be expanded or updated later.
+// `jtool serve` shares one App between requests and calls it after each
+// comparison.
+func (a *App) forgetDiff(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.session != nil && a.session.id == id {
+		a.session = nil
+	}
+}
+
+// diffView returns the result of s as it should be shown.
+func diffView(s *diffSession) *diff.DiffResult {
+	// FormatPaths may hand back s.view itself, so fill in a copy
+	out := *diff.FormatPaths(diff.SummarizeArrays(s.view, s.summarizeOver), s.pathFormat)
+	out.ID = s.id
+	if s.validation != nil {
+		out.Validation = &diff.SchemaValidation{
+			Left:  formatViolationPaths(s.validation.Left, s.pathFormat),
+			Right: formatViolationPaths(s.validation.Right, s.pathFormat),
+		}
+	}
+	return &out
+}
+
+// formatViolationPaths rewrites the instance paths of a validation result
+// in the given path format (see paths.FormatPath).
+func formatViolationPaths(result *jsonschema.Result, format string) *jsonschema.Result {
+	if format == "" || format == paths.FormatDotted {
+		return result
+	}
+	out := *result
+	out.Violations = make([]jsonschema.Violation, len(result.Violations))
+	for i, v := range result.Violations {
+		if v.InstancePath == "." {
+			v.InstancePath = "" // The document itself
+		}
+		v.InstancePath = paths.FormatPath(v.InstancePath, format)
+		out.Violations[i] = v
+	}
+	return &out
+}
+
+// GetDefaultNormalizeOptions returns the default normalization options,
+// with those of the config file and JTOOL_* variables applied (see package
+// config). Called by frontend to initialize the UI with sensible defaults.
 func (a *App) GetDefaultNormalizeOptions() NormalizeOptions {
 	defaults := normalize.DefaultOptions()
-	return NormalizeOptions{
+	return a.withConfig(NormalizeOptions{
 		SortKeys:         defaults.SortKeys,
 		NormalizeNumbers: defaults.NormalizeNumbers,
+		RoundNumbers:     defaults.RoundNumbers,
 		TrimStrings:      defaults.TrimStrings,
 		NullEqualsAbsent: defaults.NullEqualsAbsent,
 		SortArrays:       defaults.SortArrays,
 		SortArraysByKey:  defaults.SortArraysByKey,
+		SortArraysByPath: defaults.SortArraysByPath,
+		DateInputLayouts: defaults.NormalizeDates.InputLayouts,
+		DateOutputLayout: defaults.NormalizeDates.OutputLayout,
+		NormalizeURLs:    defaults.NormalizeURLs.Enabled,
+		DropURLParams:    defaults.NormalizeURLs.DropParams,
+		NormalizeUUIDs:   defaults.NormalizeUUIDs,
+
+		UnicodeNormalization: string(defaults.UnicodeNormalization),
+		CoerceBooleans:       defaults.CoerceBooleans,
+		CoerceNumbers:        defaults.CoerceNumbers,
+		NullStrings:          defaults.NullStrings,
+
+		LocaleDecimalSeparator:   defaults.LocaleNumbers.DecimalSeparator,
+		LocaleThousandsSeparator: defaults.LocaleNumbers.ThousandsSeparator,
+	})
+}
+
+// GetDefaultLogCompareOptions returns the default log comparison options:
+// the ignore paths of the config file and JTOOL_IGNORE_PATHS, if any.
+func (a *App) GetDefaultLogCompareOptions() loganalyzer.CompareOptions {
+	var opts loganalyzer.CompareOptions
+	if a.config != nil {
+		opts.IgnorePaths = a.config.IgnorePaths
+	}
+	return opts
+}
+
+// ConfigStatus tells the frontend where the defaults came from.
+type ConfigStatus struct {
+	File  string `json:"file"`  // Config file read, if any
+	Error string `json:"error"` // Why the config was ignored, if it was
+}
+
+// GetConfigStatus returns the config file in use and, if it or a JTOOL_*
+// variable is invalid, the error that made the app fall back to the
+// built-in defaults.
+func (a *App) GetConfigStatus() ConfigStatus {
+	var status ConfigStatus
+	if a.config != nil {
+		status.File = a.config.File
+	}
+	if a.configErr != nil {
+		status.Error = a.configErr.Error()
+	}
+	return status
+}
+
+// loadConfig reads the config file and JTOOL_* variables. An invalid
+// config is reported by GetConfigStatus rather than stopping the GUI.
+func (a *App) loadConfig() {
+	a.config, a.configErr = loadConfig()
+}
+
+// loadConfig reads the config file and JTOOL_* variables, checking that
+// its normalize options are ones NormalizeOptions has.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := applyConfig(cfg, NormalizeOptions{}); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// withConfig returns opts with the app's config applied over it.
+func (a *App) withConfig(opts NormalizeOptions) NormalizeOptions {
+	if withConfig, err := applyConfig(a.config, opts); err == nil {
+		return withConfig
+	}
+	return opts
+}
+
+// applyConfig returns opts with the normalize options, ignore paths and
+// path format of cfg applied over it. cfg may be nil.
+func applyConfig(cfg *config.Config, opts NormalizeOptions) (NormalizeOptions, error) {
+	if cfg == nil {
+		return opts, nil
+	}
+	if len(cfg.Normalize) > 0 {
+		data, err := json.Marshal(cfg.Normalize)
+		if err != nil {
+			return opts, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&opts); err != nil {
+			return opts, fmt.Errorf("invalid normalize options in config: %w", err)
+		}
+	}
+	for _, pattern := range cfg.IgnorePaths {
+		opts.IgnorePaths = append(opts.IgnorePaths, diff.IgnoreRule{Pattern: pattern})
 	}
+	if cfg.PathFormat != "" {
+		opts.PathFormat = cfg.PathFormat
+	}
+	return opts, nil
 }
 
 // OpenJSONFile opens a file dialog for selecting a JSON file and returns its contents.
+// gzip and zstd compressed files (.json.gz, .json.zst) are decompressed.
 // Uses Wails' runtime.OpenFileDialog which is sandbox-compatible for Mac App Store.
 func (a *App) OpenJSONFile() (string, error) {
 	// Open file dialog with JSON filter
@@ -172,8 +1078,8 @@ func (a *App) OpenJSONFile() (string, error) {
 		Title: "Select JSON File",
 		Filters: []runtime.FileFilter{
 			{
-				DisplayName: "JSON Files (*.json)",
-				Pattern:     "*.json",
+				DisplayName: "JSON Files (*.json, *.json.gz, *.json.zst)",
+				Pattern:     "*.json;*.json.gz;*.json.zst",
 			},
 			{
 				DisplayName: "All Files (*.*)",
@@ -191,382 +1097,2718 @@ func (a *App) OpenJSONFile() (string, error) {
 		return "", nil
 	}
 
-	// Read file contents
-	data, err := os.ReadFile(path)
+	// Read file contents, after confirmation if the file is large
+	if !a.confirmLargeFile(path) {
+		return "", nil
+	}
+	content, _, err := a.readFileWithProgress(path)
+	if errors.Is(err, errLargeFileDeclined) {
+		return "", nil
+	}
 	if err != nil {
 		return "", fmt.Errorf("error reading file: %w", err)
 	}
 
-	return string(data), nil
+	a.recordFile(historyJSON, path)
+	return content, nil
 }
 
-// GetJSONPaths extracts all JSON paths from a JSON string.
-// Returns all paths to leaf values with occurrence counts.
-// Useful for understanding the structure/schema of a JSON document.
-func (a *App) GetJSONPaths(jsonStr string) (*paths.PathResult, error) {
-	// Parse JSON
-	var data any
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
+// DecodeJWT decodes a JSON Web Token locally and returns its header and
+// payload as formatted JSON, with a summary of the registered claims.
+// The signature isn't verified, but an unsigned algorithm, a missing or
+// past expiry and similar problems are listed in the token's warnings.
+func (a *App) DecodeJWT(token string) (*jwt.Token, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, fmt.Errorf("no token provided")
 	}
+	return jwt.Decode(token)
+}
 
-	// Extract all paths (leaf values only)
-	result := paths.Extract(data)
-	return result, nil
+// DecodeBase64 decodes standard base64 text (padding optional). If the
+// result is JSON it is pretty-printed; gzipped payloads are decompressed.
+func (a *App) DecodeBase64(text string) (*unwrap.Result, error) {
+	return unwrap.Decode(text, unwrap.Base64)
 }
 
-// GetJSONPathsWithContainers extracts all JSON paths including container paths (objects/arrays).
-// This is useful for seeing the full structure including intermediate objects.
-func (a *App) GetJSONPathsWithContainers(jsonStr string, includeContainers bool) (*paths.PathResult, error) {
-	// Parse JSON
-	var data any
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
-	}
+// DecodeBase64URL is DecodeBase64 for the URL-safe alphabet (- and _).
+func (a *App) DecodeBase64URL(text string) (*unwrap.Result, error) {
+	return unwrap.Decode(text, unwrap.Base64URL)
+}
 
-	// Extract paths with options
-	result := paths.ExtractWithOptions(data, paths.ExtractOptions{
-		IncludeContainers: includeContainers,
-	})
-	return result, nil
+// DecodeURLEncoded decodes percent-encoded text, such as a query parameter
+// holding JSON. If the result is JSON it is pretty-printed.
+func (a *App) DecodeURLEncoded(text string) (*unwrap.Result, error) {
+	return unwrap.Decode(text, unwrap.Percent)
 }
 
-// AnalyzeLogFile opens a file dialog, reads the selected file, and analyzes
-// all JSON lines within it. Non-JSON lines (like log messages) are skipped.
-//
-// This is useful for analyzing Singer tap output, JSONL files, or any log
-// file with embedded JSON objects.
-func (a *App) AnalyzeLogFile() (*loganalyzer.AnalysisResult, error) {
-	// Open file dialog
-	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
-		Title: "Select Log File",
-		Filters: []runtime.FileFilter{
-			{
-				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl)",
-				Pattern:     "*.txt;*.log;*.jsonl",
-			},
-			{
-				DisplayName: "All Files (*.*)",
-				Pattern:     "*.*",
-			},
-		},
-	})
+// ClipboardJSON is the JSON found on the clipboard by ReadClipboardJSON.
+type ClipboardJSON struct {
+	JSON      string `json:"json"`      // The document, pretty-printed; empty if none was found
+	Extracted bool   `json:"extracted"` // Text around the document (prose, a code fence...) was dropped
+	Error     string `json:"error"`     // Why no JSON was found; empty on success
+}
 
+// ReadClipboardJSON reads the system clipboard and returns the JSON document
+// on it, pretty-printed. A document surrounded by other text, such as a
+// snippet pasted into chat, is extracted on a best-effort basis. Parse
+// problems are reported in Error rather than as an error, so the UI can
+// show them next to the pane.
+func (a *App) ReadClipboardJSON() (*ClipboardJSON, error) {
+	text, err := runtime.ClipboardGetText(a.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file dialog: %w", err)
+		return nil, fmt.Errorf("error reading clipboard: %w", err)
 	}
-
-	// User cancelled
-	if path == "" {
-		return nil, nil
+	if strings.TrimSpace(text) == "" {
+		return &ClipboardJSON{Error: "clipboard is empty"}, nil
 	}
 
-	// Analyze the file
-	result, err := loganalyzer.AnalyzeFile(path)
+	doc, extracted, err := parser.Extract(text)
 	if err != nil {
-		return nil, fmt.Errorf("error analyzing file: %w", err)
+		return &ClipboardJSON{Error: fmt.Sprintf("no JSON on the clipboard: %v", err)}, nil
 	}
 
-	return result, nil
-}
-
-// AnalyzeLogString analyzes JSON lines from a string input.
-// Useful for smaller inputs pasted directly into the UI.
-func (a *App) AnalyzeLogString(content string) (*loganalyzer.AnalysisResult, error) {
-	return loganalyzer.AnalyzeString(content)
+	formatted, err := a.FormatJSON(doc)
+	if err != nil {
+		return &ClipboardJSON{Error: err.Error()}, nil
+	}
+	return &ClipboardJSON{JSON: formatted, Extracted: extracted}, nil
 }
 
-// AnalyzeLogFilePath analyzes a log file at the given path.
-// Unlike AnalyzeLogFile, this doesn't open a file dialog - it uses the provided path directly.
-// Returns the path along with the analysis result so the frontend can display it.
-func (a *App) AnalyzeLogFilePath(path string) (*loganalyzer.AnalysisResult, error) {
-	if path == "" {
-		return nil, fmt.Errorf("no file path provided")
+// CompareWithClipboard diffs the JSON on the clipboard against the document
+// in one pane. clipboardSide says which side the clipboard takes, "left" or
+// "right"; currentJSON is the other side.
+func (a *App) CompareWithClipboard(currentJSON, clipboardSide string, opts NormalizeOptions) (*diff.DiffResult, error) {
+	clip, err := a.ReadClipboardJSON()
+	if err != nil {
+		return nil, err
+	}
+	if clip.Error != "" {
+		return nil, fmt.Errorf("%s", clip.Error)
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, fmt.Errorf("file not found: %s", path)
+	switch clipboardSide {
+	case "left":
+		return a.CompareJSONWithOptions(clip.JSON, currentJSON, opts)
+	case "right":
+		return a.CompareJSONWithOptions(currentJSON, clip.JSON, opts)
+	default:
+		return nil, fmt.Errorf("invalid side %q: must be \"left\" or \"right\"", clipboardSide)
 	}
+}
 
-	// Analyze the file
-	result, err := loganalyzer.AnalyzeFile(path)
+// ReadGitRevision returns a file as it is at a git revision (a branch, tag
+// or commit, e.g. "v2.3.0"), to load into a pane. repoPath is the
+// repository or a directory in it, and filePath is absolute or relative to
+// repoPath. The git command must be installed.
+func (a *App) ReadGitRevision(repoPath, filePath, ref string) (string, error) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	content, err := gitfile.Show(ctx, repoPath, filePath, ref)
 	if err != nil {
-		return nil, fmt.Errorf("error analyzing file: %w", err)
+		return "", fmt.Errorf("error reading %s at %s: %w", filePath, ref, err)
 	}
 
-	return result, nil
+	text, _, err := charset.Decode(content)
+	if err != nil {
+		return "", fmt.Errorf("error decoding %s at %s: %w", filePath, ref, err)
+	}
+	return text, nil
 }
 
-// SelectAndAnalyzeLogFile opens a file dialog and returns both the path and analysis result.
-// This replaces AnalyzeLogFile when the frontend needs to know the selected path.
-func (a *App) SelectAndAnalyzeLogFile() (*LogFileResult, error) {
-	// Open file dialog
-	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
-		Title: "Select Log File",
-		Filters: []runtime.FileFilter{
-			{
-				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl)",
-				Pattern:     "*.txt;*.log;*.jsonl",
-			},
-			{
-				DisplayName: "All Files (*.*)",
-				Pattern:     "*.*",
-			},
-		},
-	})
+// CompareGitRevisions diffs a JSON file as it is at refA (left) and refB
+// (right), e.g. a config on "main" against a release tag, with the default
+// normalization. See ReadGitRevision for the arguments.
+func (a *App) CompareGitRevisions(repoPath, filePath, refA, refB string) (*diff.DiffResult, error) {
+	return a.CompareGitRevisionsWithOptions(repoPath, filePath, refA, refB, a.GetDefaultNormalizeOptions())
+}
 
+// CompareGitRevisionsWithOptions is CompareGitRevisions with normalization
+// options.
+func (a *App) CompareGitRevisionsWithOptions(repoPath, filePath, refA, refB string, opts NormalizeOptions) (*diff.DiffResult, error) {
+	left, err := a.ReadGitRevision(repoPath, filePath, refA)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file dialog: %w", err)
+		return nil, err
 	}
+	right, err := a.ReadGitRevision(repoPath, filePath, refB)
+	if err != nil {
+		return nil, err
+	}
+	return a.CompareJSONWithOptions(left, right, opts)
+}
 
-	// User cancelled
-	if path == "" {
-		return nil, nil
+// CopyToClipboard puts text on the system clipboard.
+func (a *App) CopyToClipboard(content string) error {
+	if err := runtime.ClipboardSetText(a.ctx, content); err != nil {
+		return fmt.Errorf("error writing clipboard: %w", err)
 	}
+	return nil
+}
 
-	// Analyze the file
-	result, err := loganalyzer.AnalyzeFile(path)
+// FormatDiffValue returns the value of a node from the most recent
+// comparison, ready to copy. path is as shown in the diff and side is
+// "left" or "right". format is one of:
+//   - "pretty":   indented JSON (the default)
+//   - "minified": JSON on one line
+//   - "shell":    minified JSON in single quotes, for pasting into a shell
+//
+// The value is taken from the normalized document, so it matches what the
+// diff compared.
+func (a *App) FormatDiffValue(path, side, format string) (string, error) {
+	s, node, err := a.findDiffNode(path)
 	if err != nil {
-		return nil, fmt.Errorf("error analyzing file: %w", err)
+		return "", err
 	}
 
-	return &LogFileResult{
-		Path:   path,
-		Result: result,
-	}, nil
-}
+	var doc any
+	switch side {
+	case "left":
+		doc = s.left
+	case "right":
+		doc = s.right
+	default:
+		return "", fmt.Errorf("invalid side %q: must be \"left\" or \"right\"", side)
+	}
+	value, ok := paths.Lookup(doc, node.Path)
+	if !ok {
+		return "", fmt.Errorf("%s has no value on the %s", path, side)
+	}
 
-// LogFileResult combines the file path with analysis results.
-type LogFileResult struct {
-	Path   string                      `json:"path"`
-	Result *loganalyzer.AnalysisResult `json:"result"`
+	switch format {
+	case "", "pretty":
+		return marshalIndented(value)
+	case "minified":
+		return marshalCompact(value)
+	case "shell":
+		compact, err := marshalCompact(value)
+		if err != nil {
+			return "", err
+		}
+		return "'" + strings.ReplaceAll(compact, "'", `'\''`) + "'", nil
+	default:
+		return "", fmt.Errorf("unknown value format %q: must be \"pretty\", \"minified\" or \"shell\"", format)
+	}
 }
 
-// OpenJSONFileWithPath opens a file dialog and returns both path and contents.
-func (a *App) OpenJSONFileWithPath() (*FileResult, error) {
-	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
-		Title: "Select JSON File",
-		Filters: []runtime.FileFilter{
-			{
-				DisplayName: "JSON Files (*.json)",
-				Pattern:     "*.json",
-			},
-			{
-				DisplayName: "All Files (*.*)",
-				Pattern:     "*.*",
-			},
-		},
-	})
-
+// FormatDiffPath returns the path of a node from the most recent
+// comparison, ready to copy. path is as shown in the diff; format is
+// "dotted", "jsonpath", "jq" or "pointer". Keys containing "." or "[" are
+// matched against the documents, so they're quoted correctly in jq and
+// pointers.
+func (a *App) FormatDiffPath(path, format string) (string, error) {
+	s, node, err := a.findDiffNode(path)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file dialog: %w", err)
+		return "", err
 	}
 
-	if path == "" {
-		return nil, nil
+	segments, ok := paths.ResolvePath(s.left, node.Path)
+	if !ok {
+		segments, ok = paths.ResolvePath(s.right, node.Path)
 	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	if !ok {
+		segments = paths.ParsePath(node.Path)
 	}
 
-	return &FileResult{
-		Path:    path,
-		Content: string(data),
-	}, nil
+	switch format {
+	case paths.FormatDotted, paths.FormatJSONPath:
+		return paths.FormatPath(node.Path, format), nil
+	case "jq":
+		return paths.ToJQ(segments), nil
+	case paths.FormatPointer:
+		return paths.ToPointer(segments), nil
+	default:
+		return "", fmt.Errorf("unknown path format %q: must be \"dotted\", \"jsonpath\", \"jq\" or \"pointer\"", format)
+	}
 }
 
-// ReadFilePath reads a file from a given path.
-func (a *App) ReadFilePath(path string) (string, error) {
-	if path == "" {
-		return "", fmt.Errorf("no file path provided")
+// findDiffNode looks up a node of the most recent comparison by its path
+// as shown in the diff.
+func (a *App) findDiffNode(path string) (*diffSession, *diff.DiffNode, error) {
+	a.mu.Lock()
+	s := a.session
+	a.mu.Unlock()
+
+	if s == nil {
+		return nil, nil, fmt.Errorf("no comparison to copy from")
+	}
+	node := diff.FindFormattedNode(&s.view.Root, path, s.pathFormat)
+	if node == nil {
+		return nil, nil, fmt.Errorf("path not found in diff: %s", path)
 	}
+	return s, node, nil
+}
 
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return "", fmt.Errorf("file not found: %s", path)
+// FetchJSON downloads a JSON document with an HTTP GET and returns its body
+// for either compare pane. headers are sent with the request (e.g.
+// Authorization); the default timeout, size limit and TLS verification apply.
+func (a *App) FetchJSON(url string, headers map[string]string) (string, error) {
+	return a.FetchJSONWithOptions(url, fetch.Options{Headers: headers})
+}
+
+// FetchJSONWithOptions is FetchJSON with control over the timeout, size
+// limit and TLS verification, e.g. {insecureSkipVerify: true} for a dev
+// server with a self-signed certificate.
+func (a *App) FetchJSONWithOptions(url string, opts fetch.Options) (string, error) {
+	if strings.TrimSpace(url) == "" {
+		return "", fmt.Errorf("no URL provided")
 	}
 
-	data, err := os.ReadFile(path)
+	ctx, done := a.startOperation("")
+	defer done()
+	result, err := fetch.Get(ctx, strings.TrimSpace(url), opts)
 	if err != nil {
-		return "", fmt.Errorf("error reading file: %w", err)
+		return "", fmt.Errorf("error fetching %s: %w", url, err)
 	}
 
-	return string(data), nil
+	if _, err := parser.ParseString(result.Body); err != nil {
+		return "", fmt.Errorf("response from %s is not valid JSON: %w", url, err)
+	}
+	return result.Body, nil
 }
 
-// FileResult combines a file path with its contents.
-type FileResult struct {
-	Path    string `json:"path"`
-	Content string `json:"content"`
+// FetchObject downloads a log file or JSON document from cloud storage
+// (s3://bucket/key or gs://bucket/object) with the credentials the aws or
+// gcloud CLI already uses, and returns it as text for a compare pane or
+// AnalyzeLogString. gzip and zstd objects are decompressed. The default
+// timeout and size limit apply.
+func (a *App) FetchObject(uri string) (string, error) {
+	return a.FetchObjectWithOptions(uri, fetch.Options{})
 }
 
-// CompareLogAnalyses compares two log analysis results and returns a structured comparison.
-// This is the core comparison method used by other comparison functions.
-func (a *App) CompareLogAnalyses(left, right *loganalyzer.AnalysisResult, leftFile, rightFile string) *loganalyzer.ComparisonResult {
-	return loganalyzer.CompareAnalyses(left, right, leftFile, rightFile)
+// FetchObjectWithOptions is FetchObject with control over the timeout and
+// size limit (e.g. {maxBytes: 500000000} for a large log). The limit
+// applies to the object as stored, before decompression.
+func (a *App) FetchObjectWithOptions(uri string, opts fetch.Options) (string, error) {
+	ctx, done := a.startOperation("")
+	defer done()
+	return a.fetchObject(ctx, uri, opts)
 }
 
-// CompareLogFiles analyzes and compares two log files at the given paths.
-// This is a convenience method that combines file analysis and comparison.
-func (a *App) CompareLogFiles(leftPath, rightPath string) (*loganalyzer.ComparisonResult, error) {
-	// Validate inputs
-	if leftPath == "" || rightPath == "" {
-		return nil, fmt.Errorf("both file paths are required")
+// fetchObject is FetchObjectWithOptions, giving up when ctx is cancelled.
+func (a *App) fetchObject(ctx context.Context, uri string, opts fetch.Options) (string, error) {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return "", fmt.Errorf("no URI provided")
 	}
 
-	// Analyze left file
-	leftResult, err := loganalyzer.AnalyzeFile(leftPath)
+	body, err := fetch.Object(ctx, uri, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error analyzing left file: %w", err)
+		return "", fmt.Errorf("error fetching %s: %w", uri, err)
 	}
 
-	// Analyze right file
-	rightResult, err := loganalyzer.AnalyzeFile(rightPath)
+	decompressed, err := decompress.NewReader(bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("error analyzing right file: %w", err)
+		return "", fmt.Errorf("error decompressing %s: %w", uri, err)
+	}
+	defer decompressed.Close()
+	content, err := io.ReadAll(decompress.Limit(decompressed, maxDecompressedSize))
+	if errors.Is(err, decompress.ErrTooLarge) {
+		return "", fmt.Errorf("%s decompresses to more than %s", uri, formatFileSize(maxDecompressedSize))
+	}
+	if err != nil {
+		return "", fmt.Errorf("error decompressing %s: %w", uri, err)
 	}
 
-	// Compare the results
-	comparison := loganalyzer.CompareAnalyses(leftResult, rightResult, leftPath, rightPath)
-	return comparison, nil
+	text, _, err := charset.Decode(content)
+	if err != nil {
+		return "", fmt.Errorf("error decoding %s: %w", uri, err)
+	}
+	return text, nil
 }
 
-// SelectAndCompareLogFiles opens two file dialogs (left/baseline and right/comparison)
-// and returns the comparison result. This is the main entry point for the compare mode UI.
-func (a *App) SelectAndCompareLogFiles() (*loganalyzer.ComparisonResult, error) {
-	// Open first dialog for left/baseline file
-	leftPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
-		Title: "Select Baseline Log File (Left)",
-		Filters: []runtime.FileFilter{
-			{
-				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl)",
-				Pattern:     "*.txt;*.log;*.jsonl",
-			},
-			{
-				DisplayName: "All Files (*.*)",
-				Pattern:     "*.*",
-			},
-		},
-	})
+// GetJSONPaths extracts the JSON paths of a JSON string, with occurrence
+// counts, as configured by opts: containers, sample values, indexed arrays,
+// include/exclude patterns, the diff's ExcludeKeys/ExcludePaths, limits,
+// path format and tree output can all be combined. Useful for understanding
+// the structure/schema of a JSON document. CancelOperation(opID) stops it.
+func (a *App) GetJSONPaths(opID, jsonStr string, opts paths.ExtractOptions) (*paths.PathResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
 
+	data, err := parser.ParseString(jsonStr)
 	if err != nil {
-		return nil, fmt.Errorf("error opening left file dialog: %w", err)
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	// User cancelled first dialog
-	if leftPath == "" {
-		return nil, nil
+	if err := paths.ValidatePatterns(append(append([]string{}, opts.IncludePatterns...), opts.ExcludePatterns...)); err != nil {
+		return nil, err
 	}
 
-	// Open second dialog for right/comparison file
-	rightPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
-		Title: "Select Comparison Log File (Right)",
-		Filters: []runtime.FileFilter{
-			{
-				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl)",
-				Pattern:     "*.txt;*.log;*.jsonl",
-			},
-			{
-				DisplayName: "All Files (*.*)",
-				Pattern:     "*.*",
-			},
-		},
-	})
+	result, err := paths.ExtractContext(ctx, data, opts)
+	if err != nil {
+		return nil, errCancelled
+	}
+	return result, nil
+}
 
+// QueryJSONPath runs a JSONPath expression (e.g. "$.store.book[?(@.price < 10)].title")
+// against a JSON string and returns the matched values with their concrete paths.
+func (a *App) QueryJSONPath(jsonStr, expr string) ([]jsonpath.Match, error) {
+	data, err := parser.ParseString(jsonStr)
 	if err != nil {
-		return nil, fmt.Errorf("error opening right file dialog: %w", err)
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	// User cancelled second dialog
-	if rightPath == "" {
-		return nil, nil
+	return jsonpath.Query(data, expr)
+}
+
+// ValidateAgainstSchema validates a JSON string against a JSON Schema and
+// returns every violation with the path of the offending value, the path of
+// the failing schema keyword and a message. See package jsonschema for the
+// supported keywords.
+func (a *App) ValidateAgainstSchema(jsonStr, schemaStr string) (*jsonschema.Result, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	// Analyze and compare the files
-	return a.CompareLogFiles(leftPath, rightPath)
+	schema, err := compileSchema(schemaStr)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Validate(data), nil
 }
 
-// ============================================================
-// File Path History Methods
-// ============================================================
+// compileSchema parses and compiles a JSON Schema.
+func compileSchema(schemaStr string) (*jsonschema.Schema, error) {
+	raw, err := parser.ParseString(schemaStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	schema, err := jsonschema.Compile(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return schema, nil
+}
 
-// GetFileHistory returns the file path history for a specific key.
-// Keys are: "diff-left", "diff-right", "paths", "logs", "compare-left", "compare-right"
-func (a *App) GetFileHistory(key string) []string {
-	if a.history == nil {
-		return []string{}
+// maxJQResults caps the outputs of RunJQ, so a program like repeat(.)
+// fails instead of exhausting memory.
+const maxJQResults = 100_000
+
+// jqTimeout bounds how long RunJQ lets a program run.
+const jqTimeout = 30 * time.Second
+
+// RunJQ runs a jq program (e.g. ".users[] | select(.active) | {id, name}")
+// against a JSON string and returns each result pretty-printed, so a pane
+// can be filtered or reshaped before diffing. Programs run with gojq, which
+// implements the whole jq language except for input/inputs and modules.
+func (a *App) RunJQ(jsonStr, program string) ([]string, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	query, err := gojq.Parse(program)
+	if err != nil {
+		return nil, fmt.Errorf("jq: %w", err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("jq: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jqTimeout)
+	defer cancel()
+	out := []string{}
+	iter := code.RunWithContext(ctx, data)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return out, nil
+		}
+		if err, ok := v.(error); ok {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, fmt.Errorf("jq: the program ran for more than %s", jqTimeout)
+			}
+			return nil, fmt.Errorf("jq: error: %w", err)
+		}
+		if len(out) == maxJQResults {
+			return nil, fmt.Errorf("jq: more than %d results", maxJQResults)
+		}
+
+		// gojq.Marshal writes NaN as null and infinities as the largest
+		// float, as jq prints them
+		compact, err := gojq.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting JSON: %w", err)
+		}
+		var formatted bytes.Buffer
+		if err := json.Indent(&formatted, compact, "", "  "); err != nil {
+			return nil, fmt.Errorf("error formatting JSON: %w", err)
+		}
+		out = append(out, formatted.String())
 	}
-	return a.history.Get(key)
 }
 
-// GetMostRecentFilePath returns the most recent file path for a specific key.
-// Returns empty string if no history exists.
-func (a *App) GetMostRecentFilePath(key string) string {
-	if a.history == nil {
-		return ""
+// GetPathExpressions converts a jtool path (e.g. ".users[].profile.city")
+// into an equivalent jq filter and JavaScript accessor, for "copy as jq".
+// jsonStr is the document the path came from; it's used to tell keys that
+// contain dots apart from nested keys. Pass "" to skip that lookup.
+func (a *App) GetPathExpressions(jsonStr string, path string) (*paths.PathExpressions, error) {
+	var data any
+	if jsonStr != "" {
+		var err error
+		data, err = parser.ParseString(jsonStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
 	}
-	return a.history.GetMostRecent(key)
+
+	expressions := paths.Expressions(data, path)
+	return &expressions, nil
 }
 
-// SaveFilePathToHistory adds a file path to the history for a specific key.
-// This is called automatically when files are loaded, but can also be called manually.
-func (a *App) SaveFilePathToHistory(key, path string) error {
-	if a.history == nil {
-		return fmt.Errorf("history not initialized")
+// InferJSONSchema infers a JSON Schema from a sample document.
+// draft selects the dialect: "draft-07" or "2020-12" (the default).
+// Log analyses include a schema aggregated over all lines in
+// AnalysisResult.Schema; either can be saved with ExportJSONSchema.
+func (a *App) InferJSONSchema(jsonStr string, draft string) (*paths.Schema, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	a.history.Add(key, path)
+	b := paths.NewSchemaBuilder()
+	b.Add(data)
+	return b.Schema(schemaDraftURI(draft)), nil
+}
 
-	// Save to disk immediately
-	return a.history.Save(a.configDir)
+// schemaDraftURI maps a draft name from the UI to its "$schema" URI.
+func schemaDraftURI(draft string) string {
+	switch draft {
+	case "draft-07", "07", "7":
+		return paths.Draft07
+	default:
+		return paths.Draft202012
+	}
 }
 
-// GetAllFileHistory returns the entire file history map.
-// This is useful for initializing all dropdowns on app startup.
-func (a *App) GetAllFileHistory() map[string][]string {
-	if a.history == nil {
-		return map[string][]string{}
+// ExportJSONSchema opens a save dialog and writes the schema to the chosen
+// file. Returns the path written, or "" if the user cancelled.
+func (a *App) ExportJSONSchema(schema *paths.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("no schema to export")
 	}
 
-	// Build a copy of all history
-	result := make(map[string][]string)
-	keys := []string{
-		"diff-left",
-		"diff-right",
-		"paths",
-		"logs",
-		"compare-left",
-		"compare-right",
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export JSON Schema",
+		DefaultFilename: "schema.json",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "JSON Schema (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
 	}
 
-	for _, key := range keys {
-		result[key] = a.history.Get(key)
+	// User cancelled - return empty string (not an error)
+	if path == "" {
+		return "", nil
 	}
 
-	return result
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding schema: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+
+	return path, nil
 }
 
-// ClearFileHistory clears all file path history and saves the empty state.
-func (a *App) ClearFileHistory() error {
-	if a.history == nil {
-		return nil
+// SearchDocument finds matches of a regular expression in a document's paths
+// and/or values. searchIn is "paths", "values" or "both" (the default).
+// Match positions are JavaScript string indices into the path or value text.
+func (a *App) SearchDocument(jsonStr string, pattern string, searchIn string) (*search.Result, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
-	a.history.Clear()
+	return search.Document(data, pattern, search.Options{In: searchIn})
+}
 
-	// Save the empty history
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return err
+// FindInDiff finds matches of query in the paths and values of a comparison,
+// so the UI can scroll to them without walking the whole tree in JavaScript.
+// diffID is the ID of the result being shown; only the most recent
+// comparison is held, so searching an older one is an error. Paths in the
+// matches use the comparison's path format, and match positions are
+// JavaScript string indices into the path or value text.
+func (a *App) FindInDiff(diffID string, query string, opts search.Options) (*search.DiffResult, error) {
+	a.mu.Lock()
+	s := a.session
+	a.mu.Unlock()
+
+	if s == nil {
+		return nil, fmt.Errorf("no comparison to search")
 	}
-	appConfigDir := filepath.Join(configDir, "jtool")
-	return a.history.Save(appConfigDir)
+	if diffID != "" && diffID != s.id {
+		return nil, fmt.Errorf("comparison %s is no longer available; compare again", diffID)
+	}
+	return search.Diff(s.view.Root, s.left, query, s.pathFormat, opts)
 }
 
-// ShowSettingsTab emits an event to the frontend to switch to the Settings tab.
+// GenerateGoTypes generates Go struct definitions with json tags for a
+// document, naming the root type typeName ("Root" if empty).
+func (a *App) GenerateGoTypes(jsonStr string, typeName string) (string, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return codegen.Go(paths.InferSchema(data), codegen.GoOptions{TypeName: typeName})
+}
+
+// GenerateGoTypesFromSchema generates Go struct definitions from an inferred
+// schema, e.g. AnalysisResult.Schema from a log analysis, where members
+// missing from some lines become optional.
+func (a *App) GenerateGoTypesFromSchema(schema *paths.Schema, typeName string) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("no schema to generate from")
+	}
+	return codegen.Go(schema, codegen.GoOptions{TypeName: typeName})
+}
+
+// GenerateTypeScriptTypes generates TypeScript interfaces for a document,
+// naming the root type typeName ("Root" if empty).
+func (a *App) GenerateTypeScriptTypes(jsonStr string, typeName string) (string, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return codegen.TypeScript(paths.InferSchema(data), codegen.TypeScriptOptions{TypeName: typeName}), nil
+}
+
+// GenerateTypeScriptTypesFromSchema generates TypeScript interfaces from an
+// inferred schema. For a log analysis (AnalysisResult.Schema), members not
+// present in every object are marked optional and members whose type varies
+// between lines become unions.
+func (a *App) GenerateTypeScriptTypesFromSchema(schema *paths.Schema, typeName string) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("no schema to generate from")
+	}
+	return codegen.TypeScript(schema, codegen.TypeScriptOptions{TypeName: typeName}), nil
+}
+
+// GenerateSQLDDL generates a CREATE TABLE statement for a document holding
+// one record or an array of records. dialect is "postgres" (the default),
+// "bigquery" or "snowflake"; tableName defaults to "records".
+func (a *App) GenerateSQLDDL(jsonStr string, dialect string, tableName string) (string, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return codegen.SQL(paths.InferSchema(data), codegen.SQLOptions{Dialect: dialect, TableName: tableName})
+}
+
+// GenerateSQLDDLFromSchema generates a CREATE TABLE statement from an inferred
+// schema, e.g. AnalysisResult.Schema from a log analysis where every JSON
+// line is a record.
+func (a *App) GenerateSQLDDLFromSchema(schema *paths.Schema, dialect string, tableName string) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("no schema to generate from")
+	}
+	return codegen.SQL(schema, codegen.SQLOptions{Dialect: dialect, TableName: tableName})
+}
+
+// FlattenJSON explodes a document into (path, value, type) rows, one per
+// leaf value, for a table view of unfamiliar payloads.
+func (a *App) FlattenJSON(jsonStr string) ([]paths.Row, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return paths.Flatten(data), nil
+}
+
+// ExportFlattenedCSV flattens a document like FlattenJSON and saves the rows
+// as a CSV file chosen with a save dialog.
+// Returns the path written, or an empty string if the user cancelled.
+func (a *App) ExportFlattenedCSV(jsonStr string) (string, error) {
+	data, err := parser.ParseString(jsonStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export as CSV",
+		DefaultFilename: "flattened.csv",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "CSV Files (*.csv)",
+				Pattern:     "*.csv",
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
+	}
+
+	// User cancelled - return empty string (not an error)
+	if path == "" {
+		return "", nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	if err := paths.WriteCSV(file, paths.Flatten(data)); err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+
+	return path, nil
+}
+
+// AnalyzeLogFile opens a file dialog, reads the selected file, and analyzes
+// all JSON lines within it. Non-JSON lines (like log messages) are skipped.
+//
+// This is useful for analyzing Singer tap output, JSONL files, or any log
+// file with embedded JSON objects.
+func (a *App) AnalyzeLogFile() (*loganalyzer.AnalysisResult, error) {
+	// Open file dialog
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Log File",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl, *.gz, *.zst)",
+				Pattern:     "*.txt;*.log;*.jsonl;*.gz;*.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening file dialog: %w", err)
+	}
+
+	// User cancelled
+	if path == "" {
+		return nil, nil
+	}
+
+	// Analyze the file
+	return a.AnalyzeLogFileCancellable("", path, loganalyzer.Options{})
+}
+
+// AnalyzeLogString analyzes JSON lines from a string input.
+// Useful for smaller inputs pasted directly into the UI.
+func (a *App) AnalyzeLogString(content string) (*loganalyzer.AnalysisResult, error) {
+	return loganalyzer.AnalyzeString(content)
+}
+
+// AnalyzeLogStringWithOptions analyzes JSON lines from a string input with
+// the given options (e.g. {topN: 25}).
+func (a *App) AnalyzeLogStringWithOptions(content string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+	return loganalyzer.AnalyzeStringWithOptions(content, opts)
+}
+
+// GetLogValueFrequencies returns the full value-frequency table for one path
+// in a log file, for drilling into a path beyond its top values. Pass the
+// options the file was analyzed with.
+func (a *App) GetLogValueFrequencies(path, jsonPath string, opts loganalyzer.Options) ([]loganalyzer.ValueFrequency, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no file path provided")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
+
+	freqs, err := loganalyzer.ValueFrequencies(path, jsonPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing file: %w", err)
+	}
+
+	return freqs, nil
+}
+
+// GetLogStringValueFrequencies is GetLogValueFrequencies for pasted content.
+func (a *App) GetLogStringValueFrequencies(content, jsonPath string, opts loganalyzer.Options) []loganalyzer.ValueFrequency {
+	return loganalyzer.ValueFrequenciesString(content, jsonPath, opts)
+}
+
+// GetLinesForPath re-reads a log file and returns up to limit source records
+// containing a JSON path, for drilling down from the path table. limit <= 0
+// returns the default of 10.
+func (a *App) GetLinesForPath(file, path string, limit int) ([]loganalyzer.SourceRecord, error) {
+	return a.GetLinesForPathWithOptions(file, path, limit, loganalyzer.Options{})
+}
+
+// GetLinesForPathWithOptions is GetLinesForPath for a file analyzed with
+// options, e.g. EmbeddedJSON.
+func (a *App) GetLinesForPathWithOptions(file, path string, limit int, opts loganalyzer.Options) ([]loganalyzer.SourceRecord, error) {
+	if file == "" {
+		return nil, fmt.Errorf("no file path provided")
+	}
+
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", file)
+	}
+
+	records, err := loganalyzer.ExamplesForPath(file, path, limit, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	return records, nil
+}
+
+// AnalyzeLogFilePath analyzes a log file at the given path.
+// Unlike AnalyzeLogFile, this doesn't open a file dialog - it uses the provided path directly.
+// Returns the path along with the analysis result so the frontend can display it.
+func (a *App) AnalyzeLogFilePath(path string) (*loganalyzer.AnalysisResult, error) {
+	return a.AnalyzeLogFileCancellable("", path, loganalyzer.Options{})
+}
+
+// AnalyzeLogFilePathWithOptions analyzes a log file at the given path with
+// the given options, e.g. {approximate: true} to bound memory on files with
+// high-cardinality fields, {topN: 25} to report more values per path, or
+// {singer: true} to lint Singer tap output. {embeddedJSON: true} picks up
+// JSON that follows a timestamp or log-level prefix.
+func (a *App) AnalyzeLogFilePathWithOptions(path string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+	return a.AnalyzeLogFileCancellable("", path, opts)
+}
+
+// AnalyzeLogFileCancellable is AnalyzeLogFilePathWithOptions as an
+// operation that CancelOperation(opID) can stop.
+func (a *App) AnalyzeLogFileCancellable(opID, path string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
+	if path == "" {
+		return nil, fmt.Errorf("no file path provided")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
+
+	result, err := a.analyzeLogFile(ctx, path, opts)
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing file: %w", err)
+	}
+	a.recordFile(historyLogs, path)
+
+	return result, nil
+}
+
+// ValidateLogFile analyzes a log file and validates every JSON document
+// against a JSON Schema, such as one exported from an earlier analysis.
+// Violations are reported by path and keyword in the result's validation
+// section; opts are applied as for AnalyzeLogFilePathWithOptions.
+// CancelOperation(opID) stops it.
+func (a *App) ValidateLogFile(opID, path, schemaJSON string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
+	if path == "" {
+		return nil, fmt.Errorf("no file path provided")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", path)
+	}
+
+	schema, err := parser.ParseString(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	if _, err := jsonschema.Compile(schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	opts.Schema = schema
+
+	result, err := a.analyzeLogFile(ctx, path, opts)
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing file: %w", err)
+	}
+	a.recordFile(historyLogs, path)
+
+	return result, nil
+}
+
+// GetLogDecoders returns the input formats the log analyzer can unwrap,
+// for the decoder option (e.g. "docker", "csv").
+func (a *App) GetLogDecoders() []string {
+	return loganalyzer.Decoders()
+}
+
+// analyzeLogFile analyzes a log file through the analysis cache, if there is
+// one (it's created at startup).
+func (a *App) analyzeLogFile(ctx context.Context, path string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+	if a.analyses == nil {
+		return loganalyzer.AnalyzeFileContext(ctx, path, opts)
+	}
+	return a.analyses.AnalyzeFileContext(ctx, path, opts)
+}
+
+// SelectAndAnalyzeLogFile opens a file dialog and returns both the path and analysis result.
+// This replaces AnalyzeLogFile when the frontend needs to know the selected path.
+// The analysis is an operation that CancelOperation(opID) can stop.
+func (a *App) SelectAndAnalyzeLogFile(opID string) (*LogFileResult, error) {
+	// Open file dialog
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Log File",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl, *.gz, *.zst)",
+				Pattern:     "*.txt;*.log;*.jsonl;*.gz;*.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening file dialog: %w", err)
+	}
+
+	// User cancelled
+	if path == "" {
+		return nil, nil
+	}
+
+	// Analyze the file
+	result, err := a.AnalyzeLogFileCancellable(opID, path, loganalyzer.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogFileResult{
+		Path:   path,
+		Result: result,
+	}, nil
+}
+
+// logWatchInterval is how often a watched log file is checked for new lines.
+const logWatchInterval = 500 * time.Millisecond
+
+// WatchLogFile follows a log file like `tail -f`. It returns the analysis of
+// the current contents, then keeps reading appended lines and emits a
+// "logWatch:update" event with the updated AnalysisResult whenever new lines
+// arrive. Read errors are emitted as "logWatch:error" and watching continues,
+// so a file that is briefly missing during rotation is picked up again.
+//
+// Only one file is watched at a time; watching another file or calling
+// StopWatchingLogFile stops the previous watch, and also stops the first
+// read of a large file. Watching the same file again later, even after a
+// restart, resumes from where the previous watch stopped.
+func (a *App) WatchLogFile(path string) (*loganalyzer.AnalysisResult, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no file path provided")
+	}
+
+	// Resume the previous watch of this file from where it stopped
+	a.mu.Lock()
+	follower := a.follower
+	resume := follower != nil && a.followPath == path
+	a.mu.Unlock()
+	if !resume {
+		follower = a.newFollower(path)
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.mu.Lock()
+	prev, prevUnwatch := a.follower, a.unwatch
+	a.unwatch = cancel
+	a.follower = follower
+	a.followPath = path
+	a.mu.Unlock()
+	if prevUnwatch != nil {
+		prevUnwatch()
+		a.saveFollower(prev)
+	}
+
+	// The first read covers the whole file, so run it as an operation that
+	// stopping the watch or shutting down cancels
+	opCtx, done := a.startOperation("")
+	stop := context.AfterFunc(ctx, done)
+	_, err := follower.PollContext(opCtx)
+	stop()
+	done()
+	if opCtx.Err() != nil && err != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("error analyzing file: %w", err)
+	}
+	a.recordFile(historyLogs, path)
+
+	go func() {
+		a.saveFollower(follower)
+
+		ticker := time.NewTicker(logWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changed, err := follower.PollContext(ctx)
+			if ctx.Err() != nil {
+				return // Stopped while reading; don't emit for an old watch
+			}
+			if err != nil {
+				runtime.EventsEmit(a.ctx, "logWatch:error", err.Error())
+				continue
+			}
+			if changed {
+				runtime.EventsEmit(a.ctx, "logWatch:update", follower.Result())
+			}
+		}
+	}()
+
+	return follower.Result(), nil
+}
+
+// StopWatchingLogFile stops the watch started by WatchLogFile, if any, and
+// saves how far it got.
+func (a *App) StopWatchingLogFile() {
+	a.mu.Lock()
+	unwatch, follower := a.unwatch, a.follower
+	a.unwatch = nil
+	a.mu.Unlock()
+
+	if unwatch != nil {
+		unwatch()
+		a.saveFollower(follower)
+	}
+}
+
+// newFollower returns a follower for a log file, resuming a watch saved in
+// the analysis cache if there is one.
+func (a *App) newFollower(path string) *loganalyzer.Follower {
+	if a.analyses == nil {
+		return loganalyzer.NewFollower(path, loganalyzer.Options{})
+	}
+	return a.analyses.Follower(path, loganalyzer.Options{})
+}
+
+// saveFollower saves a watch's progress in the analysis cache, so watching
+// the file again after a restart resumes from it. Failures only mean the
+// next watch starts over, so they're ignored.
+func (a *App) saveFollower(f *loganalyzer.Follower) {
+	if a.analyses != nil && f != nil {
+		_ = a.analyses.SaveFollower(f)
+	}
+}
+
+// SelectAndAnalyzeLogFiles opens a multi-select file dialog and analyzes all
+// chosen files together, with combined and per-file totals.
+func (a *App) SelectAndAnalyzeLogFiles() (*loganalyzer.BatchResult, error) {
+	paths, err := runtime.OpenMultipleFilesDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Log Files",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl, *.gz, *.zst)",
+				Pattern:     "*.txt;*.log;*.jsonl;*.gz;*.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening file dialog: %w", err)
+	}
+
+	// User cancelled
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	return a.AnalyzeLogFilePaths(paths, loganalyzer.Options{})
+}
+
+// AnalyzeLogFilePaths analyzes several log files together, e.g. to reopen a
+// batch whose paths the frontend already knows.
+func (a *App) AnalyzeLogFilePaths(paths []string, opts loganalyzer.Options) (*loganalyzer.BatchResult, error) {
+	return a.AnalyzeLogFilesCancellable("", paths, opts)
+}
+
+// AnalyzeLogFilesCancellable is AnalyzeLogFilePaths as an operation that
+// CancelOperation(opID) can stop.
+func (a *App) AnalyzeLogFilesCancellable(opID string, paths []string, opts loganalyzer.Options) (*loganalyzer.BatchResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no file paths provided")
+	}
+
+	result, err := loganalyzer.AnalyzeFilesContext(ctx, paths, opts)
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing files: %w", err)
+	}
+	for _, path := range paths {
+		a.recordFile(historyLogs, path)
+	}
+
+	return result, nil
+}
+
+// SelectAndAnalyzeLogDirectory opens a directory dialog and analyzes every
+// file in the chosen directory matching pattern (e.g. "*.jsonl"; empty means
+// all files), returning the combined result with a per-file breakdown.
+func (a *App) SelectAndAnalyzeLogDirectory(pattern string) (*loganalyzer.BatchResult, error) {
+	dir, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Log Directory",
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening directory dialog: %w", err)
+	}
+
+	// User cancelled
+	if dir == "" {
+		return nil, nil
+	}
+
+	return a.AnalyzeLogDirectory(dir, pattern, loganalyzer.Options{})
+}
+
+// AnalyzeLogDirectory analyzes every file in dir matching pattern.
+// Unlike SelectAndAnalyzeLogDirectory, this doesn't open a dialog.
+func (a *App) AnalyzeLogDirectory(dir, pattern string, opts loganalyzer.Options) (*loganalyzer.BatchResult, error) {
+	return a.AnalyzeLogDirectoryCancellable("", dir, pattern, opts)
+}
+
+// AnalyzeLogDirectoryCancellable is AnalyzeLogDirectory as an operation
+// that CancelOperation(opID) can stop.
+func (a *App) AnalyzeLogDirectoryCancellable(opID, dir, pattern string, opts loganalyzer.Options) (*loganalyzer.BatchResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
+	if dir == "" {
+		return nil, fmt.Errorf("no directory provided")
+	}
+
+	result, err := loganalyzer.AnalyzeDirectoryContext(ctx, dir, pattern, opts)
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing directory: %w", err)
+	}
+
+	return result, nil
+}
+
+// ExportLogAnalysisCSV opens a save dialog and writes the path table of an
+// analysis as CSV. Returns the saved path, or "" if the user cancelled.
+func (a *App) ExportLogAnalysisCSV(result *loganalyzer.AnalysisResult) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("no analysis to export")
+	}
+	return a.saveExport("analysis.csv", csvFilter, func(w io.Writer) error {
+		return loganalyzer.WriteAnalysisCSV(w, result)
+	})
+}
+
+// ExportLogComparisonCSV opens a save dialog and writes the path table of a
+// log comparison as CSV. Returns the saved path, or "" if the user cancelled.
+func (a *App) ExportLogComparisonCSV(result *loganalyzer.ComparisonResult) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("no comparison to export")
+	}
+	return a.saveExport("comparison.csv", csvFilter, func(w io.Writer) error {
+		return loganalyzer.WriteComparisonCSV(w, result)
+	})
+}
+
+// ExportLogComparisonHTML opens a save dialog and writes a log comparison as
+// a standalone HTML report. Returns the saved path, or "" if the user
+// cancelled.
+func (a *App) ExportLogComparisonHTML(result *loganalyzer.ComparisonResult) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("no comparison to export")
+	}
+	return a.saveExport("comparison.html", htmlFilter, func(w io.Writer) error {
+		return loganalyzer.WriteComparisonHTML(w, result)
+	})
+}
+
+// ExportDiffReport saves the most recent comparison as a print-ready
+// report for audit sign-off: summary, stats and the first opts.TopN
+// differences, with an optional sign-off block. format is "html" or "pdf";
+// PDFs are printed with an installed Chrome, Chromium or Edge. Returns the
+// saved path, or "" if the user cancelled.
+func (a *App) ExportDiffReport(format string, opts report.Options) (string, error) {
+	a.mu.Lock()
+	s := a.session
+	a.mu.Unlock()
+
+	if s == nil {
+		return "", fmt.Errorf("no comparison to export")
+	}
+	result := diff.FormatPaths(s.view, s.pathFormat)
+	return a.saveReport("diff-report", format, func(w io.Writer) error {
+		return report.WriteDiffHTML(w, result, opts)
+	})
+}
+
+// ExportLogComparisonReport is ExportDiffReport for a log comparison. The
+// differing paths listed are the removed, added and changed paths with the
+// largest count deltas.
+func (a *App) ExportLogComparisonReport(result *loganalyzer.ComparisonResult, format string, opts report.Options) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("no comparison to export")
+	}
+	return a.saveReport("comparison-report", format, func(w io.Writer) error {
+		return report.WriteLogComparisonHTML(w, result, opts)
+	})
+}
+
+// saveReport asks where to save a report rendered as HTML by render, and
+// writes it in format ("html" or "pdf").
+func (a *App) saveReport(name, format string, render func(w io.Writer) error) (string, error) {
+	switch format {
+	case "", "html":
+		return a.saveExport(name+".html", htmlFilter, render)
+	case "pdf":
+		// Render before asking, so a report that can't be printed fails
+		// without leaving an empty file behind
+		var html bytes.Buffer
+		if err := render(&html); err != nil {
+			return "", fmt.Errorf("error rendering report: %w", err)
+		}
+		var pdf bytes.Buffer
+		if err := report.PrintPDF(a.ctx, html.Bytes(), &pdf); err != nil {
+			return "", err
+		}
+		return a.saveExport(name+".pdf", pdfFilter, func(w io.Writer) error {
+			_, err := w.Write(pdf.Bytes())
+			return err
+		})
+	default:
+		return "", fmt.Errorf("unknown report format %q: must be \"html\" or \"pdf\"", format)
+	}
+}
+
+var (
+	csvFilter  = runtime.FileFilter{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"}
+	htmlFilter = runtime.FileFilter{DisplayName: "HTML Files (*.html)", Pattern: "*.html"}
+	jsonFilter = runtime.FileFilter{DisplayName: "JSON Files (*.json)", Pattern: "*.json"}
+	pdfFilter  = runtime.FileFilter{DisplayName: "PDF Files (*.pdf)", Pattern: "*.pdf"}
+)
+
+// saveExport asks where to save an exported file and writes it with write.
+func (a *App) saveExport(defaultFilename string, filter runtime.FileFilter, write func(w io.Writer) error) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export as " + strings.ToUpper(strings.TrimPrefix(filter.Pattern, "*.")),
+		DefaultFilename: defaultFilename,
+		Filters:         []runtime.FileFilter{filter},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
+	}
+
+	// User cancelled - return empty string (not an error)
+	if path == "" {
+		return "", nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("error creating file: %w", err)
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+
+	return path, nil
+}
+
+// LogFileResult combines the file path with analysis results.
+type LogFileResult struct {
+	Path   string                      `json:"path"`
+	Result *loganalyzer.AnalysisResult `json:"result"`
+}
+
+// OpenJSONFileWithPath opens a file dialog and returns both path and contents.
+// Compressed files are decompressed as in OpenJSONFile.
+func (a *App) OpenJSONFileWithPath() (*FileResult, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select JSON File",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "JSON Files (*.json, *.json.gz, *.json.zst)",
+				Pattern:     "*.json;*.json.gz;*.json.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening file dialog: %w", err)
+	}
+
+	if path == "" || !a.confirmLargeFile(path) {
+		return nil, nil
+	}
+
+	content, encoding, err := a.readFileWithProgress(path)
+	if errors.Is(err, errLargeFileDeclined) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	a.recordFile(historyJSON, path)
+	return &FileResult{
+		Path:     path,
+		Content:  content,
+		Encoding: encoding,
+	}, nil
+}
+
+// ReadFilePath reads a file from a given path.
+func (a *App) ReadFilePath(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("no file path provided")
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", fmt.Errorf("file not found: %s", path)
+	}
+
+	// Declining leaves the pane as it was, so report it as an error
+	if !a.confirmLargeFile(path) {
+		return "", fmt.Errorf("not opened: %s is larger than %s", filepath.Base(path), formatFileSize(a.GetLargeFileThreshold()))
+	}
+	content, _, err := a.readFileWithProgress(path)
+	if errors.Is(err, errLargeFileDeclined) {
+		return "", fmt.Errorf("not opened: %s decompresses to more than %s", filepath.Base(path), formatFileSize(a.GetLargeFileThreshold()))
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading file: %w", err)
+	}
+
+	a.recordFile(historyJSON, path)
+	return content, nil
+}
+
+// RevealInFinder shows a file in the system file manager (Finder, Explorer,
+// or its folder on Linux), e.g. to get from a loaded log file to a terminal
+// in the same place.
+func (a *App) RevealInFinder(path string) error {
+	if path == "" {
+		return fmt.Errorf("no file path provided")
+	}
+	if err := launch.Reveal(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file not found: %s", path)
+		}
+		return fmt.Errorf("error revealing file: %w", err)
+	}
+	return nil
+}
+
+// OpenInEditor opens a file in the system's default text editor (Notepad on
+// Windows).
+func (a *App) OpenInEditor(path string) error {
+	if path == "" {
+		return fmt.Errorf("no file path provided")
+	}
+	if err := launch.Edit(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file not found: %s", path)
+		}
+		return fmt.Errorf("error opening editor: %w", err)
+	}
+	return nil
+}
+
+// OpenedFiles are files jtool was asked to open by the OS (double-clicking a
+// .json file, "Open With") or on the command line.
+type OpenedFiles struct {
+	Tab     string       `json:"tab"`               // "diff" if every file is JSON, otherwise "logs"
+	Files   []FileResult `json:"files"`             // For "diff", one document for the left pane or two to compare; for "logs", paths only
+	Skipped []string     `json:"skipped,omitempty"` // JSON documents beyond the two a comparison can show, not opened
+}
+
+// openFiles queues files to open and tells the frontend with a "files:open"
+// event. Files that arrive before the frontend is listening wait for its
+// first TakeOpenedFiles call.
+func (a *App) openFiles(paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	a.mu.Lock()
+	for _, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		a.openQueue = append(a.openQueue, path)
+	}
+	a.mu.Unlock()
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "files:open")
+	}
+}
+
+// onSecondInstance opens the files of a second launch, e.g. "Open With" on
+// Windows or Linux while jtool is running, in this window instead.
+func (a *App) onSecondInstance(data options.SecondInstanceData) {
+	runtime.WindowUnminimise(a.ctx)
+	runtime.WindowShow(a.ctx)
+	a.openFiles(fileArgs(data.Args))
+}
+
+// TakeOpenedFiles returns the files waiting to be opened, or nil if there
+// are none, and clears the queue. The frontend calls it when it starts and
+// after every "files:open" event.
+//
+// JSON documents (.json, also gzip or zstd compressed) open in the Diff tab:
+// the first two are loaded for a comparison and the rest are listed in
+// Skipped, so the user can be told. Anything else, or a mix, opens in the
+// Log Analyzer, which is given the paths to analyze.
+func (a *App) TakeOpenedFiles() (*OpenedFiles, error) {
+	a.mu.Lock()
+	queue := a.openQueue
+	a.openQueue = nil
+	a.mu.Unlock()
+
+	if len(queue) == 0 {
+		return nil, nil
+	}
+
+	allJSON := true
+	for _, path := range queue {
+		allJSON = allJSON && isJSONDocument(path)
+	}
+	if !allJSON {
+		opened := &OpenedFiles{Tab: "logs"}
+		for _, path := range queue {
+			opened.Files = append(opened.Files, FileResult{Path: path})
+		}
+		return opened, nil
+	}
+
+	opened := &OpenedFiles{Tab: "diff"}
+	if len(queue) > 2 {
+		queue, opened.Skipped = queue[:2], queue[2:]
+	}
+	for _, path := range queue {
+		if !a.confirmLargeFile(path) {
+			continue
+		}
+		content, encoding, err := a.readFileWithProgress(path)
+		if errors.Is(err, errLargeFileDeclined) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", filepath.Base(path), err)
+		}
+		a.recordFile(historyJSON, path)
+		opened.Files = append(opened.Files, FileResult{Path: path, Content: content, Encoding: encoding})
+	}
+	return opened, nil
+}
+
+// isJSONDocument reports whether path names a single JSON document rather
+// than a log, going by its extension: ".json", optionally compressed.
+func isJSONDocument(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	name = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".zst")
+	return filepath.Ext(name) == ".json"
+}
+
+// defaultLargeFileThreshold is the size above which opening a file asks
+// for confirmation, unless changed with SetLargeFileThreshold.
+const defaultLargeFileThreshold = 100 << 20
+
+// readChunkSize is how much of a file is read between progress events.
+const readChunkSize = 4 << 20
+
+// maxDecompressedSize is the most a compressed JSON file may expand to.
+const maxDecompressedSize = 1 << 30
+
+// FileProgress is sent as a "fileOpen:progress" event while a file larger
+// than one chunk is read.
+type FileProgress struct {
+	Path       string `json:"path"`
+	BytesRead  int64  `json:"bytesRead"`
+	TotalBytes int64  `json:"totalBytes"`
+}
+
+// SetLargeFileThreshold sets the size in bytes above which opening a JSON
+// file asks for confirmation first, and saves it for later runs. 0 disables
+// the check.
+func (a *App) SetLargeFileThreshold(bytes int64) error {
+	if bytes < 0 {
+		return fmt.Errorf("threshold must not be negative")
+	}
+	a.mu.Lock()
+	a.largeFileThreshold = bytes
+	a.mu.Unlock()
+	return a.saveSettings()
+}
+
+// GetLargeFileThreshold returns the size in bytes above which opening a
+// JSON file asks for confirmation (0 if the check is disabled).
+func (a *App) GetLargeFileThreshold() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.largeFileThreshold
+}
+
+// confirmLargeFile reports whether path should be read: always for files
+// up to the large-file threshold, otherwise only if the user confirms in a
+// dialog. Errors from Stat are left for the read to report. Compressed
+// files can expand past the threshold, so readFileWithProgress asks again
+// once the content does.
+func (a *App) confirmLargeFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	return a.confirmLargeSize(fmt.Sprintf("%s is %s", filepath.Base(path), formatFileSize(info.Size())), info.Size())
+}
+
+// confirmLargeSize reports whether content of size bytes should be loaded,
+// asking the user with what (e.g. "big.json is 2.1 GB") when it exceeds the
+// large-file threshold. Without a GUI (`jtool serve`) there is no one to
+// ask, so it's loaded.
+func (a *App) confirmLargeSize(what string, size int64) bool {
+	threshold := a.GetLargeFileThreshold()
+	if threshold == 0 || size <= threshold || a.ctx == nil {
+		return true
+	}
+
+	answer, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:    runtime.QuestionDialog,
+		Title:   "Open Large File?",
+		Message: fmt.Sprintf("%s. Loading files this large can make jtool slow or unresponsive.\n\nOpen it anyway?", what),
+		// Windows always shows Yes/No for questions, so use them everywhere
+		Buttons:       []string{"Yes", "No"},
+		DefaultButton: "No",
+		CancelButton:  "No",
+	})
+	return err == nil && answer == "Yes"
+}
+
+// errLargeFileDeclined is returned by readFileWithProgress when a
+// compressed file expands past the large-file threshold and the user
+// chooses not to open it.
+var errLargeFileDeclined = errors.New("large file not opened")
+
+// readFileWithProgress reads a file in chunks, emitting "fileOpen:progress"
+// events for files larger than one chunk so the UI can show a progress bar
+// (when there is a UI to show one).
+// gzip and zstd files are decompressed, asking again if they expand past
+// the large-file threshold (errLargeFileDeclined if the user says no), and
+// the content is converted to UTF-8 and returned with the encoding that
+// was detected (see charset.Decode).
+func (a *App) readFileWithProgress(path string) (string, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", "", err
+	}
+	total := info.Size()
+	report := total > readChunkSize && a.ctx != nil
+
+	// Archived snapshots are often gzip or zstd compressed. Progress counts
+	// the bytes of the file itself, since only its size is known up front.
+	counter := &countingReader{r: file}
+	decompressed, err := decompress.NewReader(counter)
+	if err != nil {
+		return "", "", err
+	}
+	defer decompressed.Close()
+	limited := decompress.Limit(decompressed, maxDecompressedSize)
+
+	// The file itself was checked by confirmLargeFile; if it was under the
+	// threshold, check the decompressed content against it as it streams
+	threshold := a.GetLargeFileThreshold()
+	checkExpanded := threshold > 0 && total <= threshold
+
+	var content bytes.Buffer
+	content.Grow(int(total))
+	chunk := make([]byte, readChunkSize)
+	for {
+		n, err := limited.Read(chunk)
+		content.Write(chunk[:n])
+		if checkExpanded && int64(content.Len()) > threshold {
+			checkExpanded = false
+			what := fmt.Sprintf("%s decompresses to more than %s", filepath.Base(path), formatFileSize(threshold))
+			if !a.confirmLargeSize(what, int64(content.Len())) {
+				return "", "", errLargeFileDeclined
+			}
+		}
+		if report && n > 0 {
+			runtime.EventsEmit(a.ctx, "fileOpen:progress", FileProgress{Path: path, BytesRead: counter.n, TotalBytes: total})
+		}
+		if err == io.EOF {
+			return charset.Decode(content.Bytes())
+		}
+		if errors.Is(err, decompress.ErrTooLarge) {
+			return "", "", fmt.Errorf("%s decompresses to more than %s", filepath.Base(path), formatFileSize(maxDecompressedSize))
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// formatFileSize writes a byte count for people, e.g. "2.1 GB".
+func formatFileSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+	size, suffix := float64(bytes)/unit, "KB"
+	for _, next := range []string{"MB", "GB", "TB"} {
+		if size < unit {
+			break
+		}
+		size, suffix = size/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", size, suffix)
+}
+
+// FileResult combines a file path with its contents.
+type FileResult struct {
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"` // Encoding the file was converted from, e.g. "UTF-16LE" (see charset)
+}
+
+// CompareLogAnalyses compares two log analysis results and returns a structured comparison.
+// This is the core comparison method used by other comparison functions.
+func (a *App) CompareLogAnalyses(left, right *loganalyzer.AnalysisResult, leftFile, rightFile string) *loganalyzer.ComparisonResult {
+	return loganalyzer.CompareAnalyses(left, right, leftFile, rightFile)
+}
+
+// CompareLogAnalysesWithOptions is CompareLogAnalyses with control over what
+// counts as a change, e.g. a relative threshold.
+func (a *App) CompareLogAnalysesWithOptions(left, right *loganalyzer.AnalysisResult, leftFile, rightFile string, opts loganalyzer.CompareOptions) *loganalyzer.ComparisonResult {
+	return loganalyzer.CompareAnalysesWithOptions(left, right, leftFile, rightFile, opts)
+}
+
+// CompareLogFiles analyzes and compares two log files at the given paths.
+// This is a convenience method that combines file analysis and comparison.
+func (a *App) CompareLogFiles(leftPath, rightPath string) (*loganalyzer.ComparisonResult, error) {
+	// Validate inputs
+	if leftPath == "" || rightPath == "" {
+		return nil, fmt.Errorf("both file paths are required")
+	}
+
+	return a.CompareLogFilesCancellable("", leftPath, rightPath, loganalyzer.CompareOptions{})
+}
+
+// CompareLogFilesWithOptions is CompareLogFiles with comparison options such
+// as a change threshold, volume normalization or ignored paths.
+func (a *App) CompareLogFilesWithOptions(leftPath, rightPath string, opts loganalyzer.CompareOptions) (*loganalyzer.ComparisonResult, error) {
+	return a.CompareLogFilesCancellable("", leftPath, rightPath, opts)
+}
+
+// CompareLogFilesCancellable is CompareLogFilesWithOptions as an operation
+// that CancelOperation(opID) can stop.
+func (a *App) CompareLogFilesCancellable(opID, leftPath, rightPath string, opts loganalyzer.CompareOptions) (*loganalyzer.ComparisonResult, error) {
+	ctx, done := a.startOperation(opID)
+	defer done()
+
+	if leftPath == "" || rightPath == "" {
+		return nil, fmt.Errorf("both file paths are required")
+	}
+
+	if err := paths.ValidatePatterns(opts.IgnorePaths); err != nil {
+		return nil, err
+	}
+
+	leftResult, err := a.analyzeLogFile(ctx, leftPath, loganalyzer.Options{})
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing left file: %w", err)
+	}
+
+	rightResult, err := a.analyzeLogFile(ctx, rightPath, loganalyzer.Options{})
+	if ctx.Err() != nil {
+		return nil, errCancelled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error analyzing right file: %w", err)
+	}
+
+	a.recordFile(historyCompareLeft, leftPath)
+	a.recordFile(historyCompareRight, rightPath)
+	return loganalyzer.CompareAnalysesWithOptions(leftResult, rightResult, leftPath, rightPath, opts), nil
+}
+
+// SelectAndCompareLogFiles opens two file dialogs (left/baseline and right/comparison)
+// and returns the comparison result. This is the main entry point for the compare mode UI.
+// Analyzing the files is an operation that CancelOperation(opID) can stop.
+func (a *App) SelectAndCompareLogFiles(opID string) (*loganalyzer.ComparisonResult, error) {
+	// Open first dialog for left/baseline file
+	leftPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Baseline Log File (Left)",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl, *.gz, *.zst)",
+				Pattern:     "*.txt;*.log;*.jsonl;*.gz;*.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening left file dialog: %w", err)
+	}
+
+	// User cancelled first dialog
+	if leftPath == "" {
+		return nil, nil
+	}
+
+	// Open second dialog for right/comparison file
+	rightPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select Comparison Log File (Right)",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Text/Log Files (*.txt, *.log, *.jsonl, *.gz, *.zst)",
+				Pattern:     "*.txt;*.log;*.jsonl;*.gz;*.zst",
+			},
+			{
+				DisplayName: "All Files (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error opening right file dialog: %w", err)
+	}
+
+	// User cancelled second dialog
+	if rightPath == "" {
+		return nil, nil
+	}
+
+	// Analyze and compare the files
+	return a.CompareLogFilesCancellable(opID, leftPath, rightPath, loganalyzer.CompareOptions{})
+}
+
+// ============================================================
+// File Path History Methods
+// ============================================================
+
+// History keys recorded automatically by the file-open bindings. The
+// frontend records its own keys ("diff-left", "diff-right", "paths") through
+// SaveFilePathToHistory.
+const (
+	historyJSON         = "json"          // Any JSON file opened or read by path
+	historyLogs         = "logs"          // Log files analyzed or watched
+	historyCompareLeft  = "compare-left"  // Baseline of a log comparison
+	historyCompareRight = "compare-right" // Other side of a log comparison
+)
+
+// recordFile adds a successfully opened file to the history and saves it, so
+// recent files survive a crash. Saving is best effort: failing to remember a
+// path shouldn't fail the open.
+func (a *App) recordFile(key, path string) {
+	if a.history == nil {
+		return
+	}
+	a.history.Add(key, path)
+	_ = a.history.Save(a.configDir)
+	a.bookmarkFile(path)
+}
+
+// bookmarkFile stores a security-scoped bookmark for a file the user just
+// picked, so it can be reopened from the history after a restart under
+// the macOS sandbox. Elsewhere bookmarks aren't supported and it does
+// nothing.
+func (a *App) bookmarkFile(path string) {
+	if a.bookmarks == nil || a.bookmarks.Has(path) {
+		return
+	}
+	data, err := bookmark.Create(path)
+	if err != nil {
+		return
+	}
+	a.bookmarks.Set(path, data)
+	_ = a.bookmarks.Save(a.configDir)
+}
+
+// restoreBookmarks starts accessing every file in the history that has a
+// bookmark, for the rest of the session. Bookmarks of files no longer in
+// the history, or that can't be resolved, are dropped; stale ones are
+// recreated.
+func (a *App) restoreBookmarks() {
+	inHistory := make(map[string]bool)
+	for _, paths := range a.GetAllFileHistory() {
+		for _, path := range paths {
+			inHistory[path] = true
+		}
+	}
+
+	changed := a.bookmarks.Prune(inHistory, func(path string, data []byte) ([]byte, error) {
+		access, err := bookmark.Resolve(data)
+		if err != nil {
+			return nil, err
+		}
+		a.scoped = append(a.scoped, access)
+		if !access.Stale {
+			return nil, nil
+		}
+		// A failure to recreate keeps the stale bookmark, which still resolves
+		fresh, _ := bookmark.Create(access.Path)
+		return fresh, nil
+	})
+	if changed {
+		_ = a.bookmarks.Save(a.configDir)
+	}
+}
+
+// GetFileHistory returns the file path history for a specific key, newest
+// first. Keys are: "json", "diff-left", "diff-right", "paths", "logs",
+// "compare-left", "compare-right"
+func (a *App) GetFileHistory(key string) []string {
+	if a.history == nil {
+		return []string{}
+	}
+	return a.history.Get(key)
+}
+
+// GetMostRecentFilePath returns the most recent file path for a specific key.
+// Returns empty string if no history exists.
+func (a *App) GetMostRecentFilePath(key string) string {
+	if a.history == nil {
+		return ""
+	}
+	return a.history.GetMostRecent(key)
+}
+
+// SaveFilePathToHistory adds a file path to the history for a specific key.
+// This is called automatically when files are loaded, but can also be called manually.
+func (a *App) SaveFilePathToHistory(key, path string) error {
+	if a.history == nil {
+		return fmt.Errorf("history not initialized")
+	}
+
+	a.history.Add(key, path)
+	a.bookmarkFile(path)
+
+	// Save to disk immediately
+	return a.history.Save(a.configDir)
+}
+
+// GetAllFileHistory returns the entire file history map.
+// This is useful for initializing all dropdowns on app startup.
+func (a *App) GetAllFileHistory() map[string][]string {
+	if a.history == nil {
+		return map[string][]string{}
+	}
+
+	// Build a copy of all history
+	result := make(map[string][]string)
+	keys := []string{
+		historyJSON,
+		"diff-left",
+		"diff-right",
+		"paths",
+		historyLogs,
+		historyCompareLeft,
+		historyCompareRight,
+	}
+
+	for _, key := range keys {
+		result[key] = a.history.Get(key)
+	}
+
+	return result
+}
+
+// ClearFileHistory clears all file path history and saves the empty state.
+func (a *App) ClearFileHistory() error {
+	if a.history == nil {
+		return nil
+	}
+
+	a.history.Clear()
+	if a.bookmarks != nil {
+		a.bookmarks.Clear()
+		_ = a.bookmarks.Save(a.configDir)
+	}
+
+	// Save the empty history where startup loads it from
+	return a.history.Save(a.configDir)
+}
+
+// ShowSettingsTab emits an event to the frontend to switch to the Settings tab.
 // This is called from the Help menu in the application menu bar (works on all platforms).
 func (a *App) ShowSettingsTab() {
 	runtime.EventsEmit(a.ctx, "switchTab", "settings")
 }
+
+// ============================================================
+// Normalization Profile Methods
+// ============================================================
+
+// ListNormalizeProfiles returns the names of all saved normalization profiles.
+func (a *App) ListNormalizeProfiles() []string {
+	if a.profiles == nil {
+		return []string{}
+	}
+	return a.profiles.Names()
+}
+
+// SaveNormalizeProfile saves the options under a name (e.g. "api-contract"),
+// replacing any existing profile with that name.
+func (a *App) SaveNormalizeProfile(name string, opts NormalizeOptions) error {
+	if a.profiles == nil {
+		return fmt.Errorf("profiles not initialized")
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name is required")
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return fmt.Errorf("error encoding profile: %w", err)
+	}
+
+	a.profiles.Set(name, data)
+	return a.profiles.Save(a.configDir)
+}
+
+// LoadNormalizeProfile returns the options saved under a name.
+func (a *App) LoadNormalizeProfile(name string) (NormalizeOptions, error) {
+	if a.profiles == nil {
+		return NormalizeOptions{}, fmt.Errorf("profiles not initialized")
+	}
+
+	data, ok := a.profiles.Get(name)
+	if !ok {
+		return NormalizeOptions{}, fmt.Errorf("profile not found: %s", name)
+	}
+
+	var opts NormalizeOptions
+	if err := json.Unmarshal(data, &opts); err != nil {
+		return NormalizeOptions{}, fmt.Errorf("error reading profile %s: %w", name, err)
+	}
+	return opts, nil
+}
+
+// DeleteNormalizeProfile removes a saved profile.
+func (a *App) DeleteNormalizeProfile(name string) error {
+	if a.profiles == nil {
+		return fmt.Errorf("profiles not initialized")
+	}
+	if !a.profiles.Delete(name) {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+	return a.profiles.Save(a.configDir)
+}
+
+// ============================================================
+// App Data Export and Import
+// ============================================================
+
+// AppSettings are the settings carried in an app data bundle.
+type AppSettings struct {
+	LargeFileThreshold int64 `json:"largeFileThreshold"` // See SetLargeFileThreshold
+}
+
+// AppDataSummary describes a bundle before it is imported, so the user can
+// choose what to import and how to resolve conflicts.
+type AppDataSummary struct {
+	Exported     time.Time `json:"exported"`
+	Profiles     []string  `json:"profiles"`     // Profile names, sorted
+	Conflicts    []string  `json:"conflicts"`    // Profiles whose name is taken here by different options
+	HistoryPaths int       `json:"historyPaths"` // File history entries
+	HasLayout    bool      `json:"hasLayout"`
+	HasSettings  bool      `json:"hasSettings"`
+}
+
+// AppDataImportOptions choose what ImportAppData takes from a bundle.
+type AppDataImportOptions struct {
+	Profiles bool   `json:"profiles"` // Add normalization profiles
+	History  bool   `json:"history"`  // Merge file history after the existing entries
+	Layout   bool   `json:"layout"`   // Replace window and pane layout
+	Settings bool   `json:"settings"` // Replace app settings
+	Conflict string `json:"conflict"` // For a profile name already in use: "skip" (default), "overwrite" or "rename"
+}
+
+// AppDataImportResult reports what ImportAppData changed.
+type AppDataImportResult struct {
+	Profiles     *storage.MergeResult `json:"profiles,omitempty"` // nil unless profiles were imported
+	HistoryAdded int                  `json:"historyAdded"`
+	Layout       bool                 `json:"layout"`   // Layout was replaced
+	Settings     bool                 `json:"settings"` // Settings were replaced
+}
+
+// settings returns the current AppSettings.
+func (a *App) settings() AppSettings {
+	return AppSettings{LargeFileThreshold: a.GetLargeFileThreshold()}
+}
+
+// saveSettings writes the AppSettings to the config directory, so they
+// last beyond this run. Without one (`jtool serve`) they only live in memory.
+func (a *App) saveSettings() error {
+	if a.configDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(a.settings())
+	if err != nil {
+		return fmt.Errorf("error encoding settings: %w", err)
+	}
+	if err := storage.SaveSettings(a.configDir, data); err != nil {
+		return fmt.Errorf("error saving settings: %w", err)
+	}
+	return nil
+}
+
+// loadSettings applies the AppSettings saved by an earlier run. Settings
+// that can't be read are left at their defaults.
+func (a *App) loadSettings() {
+	data, err := storage.LoadSettings(a.configDir)
+	if err != nil || data == nil {
+		return
+	}
+	var settings AppSettings
+	if err := json.Unmarshal(data, &settings); err != nil || settings.LargeFileThreshold < 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.largeFileThreshold = settings.LargeFileThreshold
+}
+
+// ExportAppData returns normalization profiles, file history, layout and
+// settings as one JSON bundle, for ImportAppData on another machine.
+func (a *App) ExportAppData() (string, error) {
+	settings, err := json.Marshal(a.settings())
+	if err != nil {
+		return "", fmt.Errorf("error encoding settings: %w", err)
+	}
+
+	bundle := storage.Bundle{
+		Format:   storage.BundleFormat,
+		Version:  storage.BundleVersion,
+		Exported: time.Now().UTC(),
+		Profiles: map[string]json.RawMessage{},
+		History:  a.GetAllFileHistory(),
+		Layout:   a.GetLayoutState(),
+		Settings: settings,
+	}
+	if a.profiles != nil {
+		bundle.Profiles = a.profiles.All()
+	}
+	return marshalIndented(bundle)
+}
+
+// ExportAppDataToFile asks where to save the ExportAppData bundle and
+// writes it there. Returns the path, or "" if the user cancelled.
+func (a *App) ExportAppDataToFile() (string, error) {
+	data, err := a.ExportAppData()
+	if err != nil {
+		return "", err
+	}
+	return a.saveExport("jtool-data.json", jsonFilter, func(w io.Writer) error {
+		_, err := io.WriteString(w, data)
+		return err
+	})
+}
+
+// InspectAppData summarizes a bundle from ExportAppData without importing
+// anything.
+func (a *App) InspectAppData(bundleJSON string) (*AppDataSummary, error) {
+	bundle, err := parseBundle(bundleJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AppDataSummary{
+		Exported:    bundle.Exported,
+		Profiles:    []string{},
+		Conflicts:   []string{},
+		HasLayout:   bundle.Layout != nil,
+		HasSettings: len(bundle.Settings) > 0,
+	}
+	var existing map[string]json.RawMessage
+	if a.profiles != nil {
+		existing = a.profiles.All()
+	}
+	for name, options := range bundle.Profiles {
+		summary.Profiles = append(summary.Profiles, name)
+		if current, ok := existing[name]; ok && !storage.SameProfile(current, options) {
+			summary.Conflicts = append(summary.Conflicts, name)
+		}
+	}
+	sort.Strings(summary.Profiles)
+	sort.Strings(summary.Conflicts)
+	for _, paths := range bundle.History {
+		summary.HistoryPaths += len(paths)
+	}
+	return summary, nil
+}
+
+// ImportAppData imports the parts of a bundle from ExportAppData chosen in
+// opts and saves them. Profiles are added alongside the existing ones,
+// with name conflicts resolved as opts.Conflict says.
+func (a *App) ImportAppData(bundleJSON string, opts AppDataImportOptions) (*AppDataImportResult, error) {
+	switch opts.Conflict {
+	case "", storage.ConflictSkip, storage.ConflictOverwrite, storage.ConflictRename:
+	default:
+		return nil, fmt.Errorf("invalid conflict handling %q: must be \"skip\", \"overwrite\" or \"rename\"", opts.Conflict)
+	}
+	bundle, err := parseBundle(bundleJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check settings before changing anything, so a bad bundle imports nothing
+	var settings AppSettings
+	if opts.Settings && len(bundle.Settings) > 0 {
+		if err := json.Unmarshal(bundle.Settings, &settings); err != nil {
+			return nil, fmt.Errorf("invalid settings in bundle: %w", err)
+		}
+		if settings.LargeFileThreshold < 0 {
+			return nil, fmt.Errorf("invalid settings in bundle: threshold must not be negative")
+		}
+	}
+	if opts.Profiles {
+		if err := checkImportedProfiles(bundle.Profiles); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &AppDataImportResult{}
+	if opts.Profiles && a.profiles != nil {
+		merged := a.profiles.Merge(bundle.Profiles, opts.Conflict)
+		result.Profiles = &merged
+		if err := a.profiles.Save(a.configDir); err != nil {
+			return nil, fmt.Errorf("error saving profiles: %w", err)
+		}
+	}
+	if opts.History && a.history != nil {
+		result.HistoryAdded = a.history.Merge(bundle.History)
+		if err := a.history.Save(a.configDir); err != nil {
+			return nil, fmt.Errorf("error saving history: %w", err)
+		}
+	}
+	if opts.Layout && bundle.Layout != nil && a.layout != nil {
+		a.layout.Replace(bundle.Layout)
+		result.Layout = true
+		if err := a.layout.Save(a.configDir); err != nil {
+			return nil, fmt.Errorf("error saving layout: %w", err)
+		}
+	}
+	if opts.Settings && len(bundle.Settings) > 0 {
+		if err := a.SetLargeFileThreshold(settings.LargeFileThreshold); err != nil {
+			return nil, err
+		}
+		result.Settings = true
+	}
+	return result, nil
+}
+
+// checkImportedProfiles checks that every profile in a bundle has a name
+// and holds options this version of jtool understands, the way
+// SaveNormalizeProfile would have stored them.
+func checkImportedProfiles(profiles map[string]json.RawMessage) error {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid profile in bundle: profile name is required")
+		}
+		var opts NormalizeOptions
+		dec := json.NewDecoder(bytes.NewReader(profiles[name]))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&opts); err != nil {
+			return fmt.Errorf("invalid profile %q in bundle: %w", name, err)
+		}
+		normalizeOpts := opts.toNormalizeOptions()
+		if err := normalize.ValidateTransforms(normalizeOpts.Transforms); err != nil {
+			return fmt.Errorf("invalid profile %q in bundle: %w", name, err)
+		}
+		if err := normalize.ValidateOverrides(normalizeOpts.Overrides); err != nil {
+			return fmt.Errorf("invalid profile %q in bundle: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// parseBundle reads an app data bundle, rejecting other JSON documents and
+// bundles from newer versions of jtool.
+func parseBundle(text string) (*storage.Bundle, error) {
+	var bundle storage.Bundle
+	if err := json.Unmarshal([]byte(text), &bundle); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if bundle.Format != storage.BundleFormat {
+		return nil, fmt.Errorf("not a jtool app data bundle")
+	}
+	if bundle.Version > storage.BundleVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than this version of jtool supports (%d)", bundle.Version, storage.BundleVersion)
+	}
+	return &bundle, nil
+}
+
+// ============================================================
+// Pane Undo Methods
+// ============================================================
+
+// paneHistory returns the undo history of a pane: "left" or "right" in the
+// Diff tab, or "paths" in the Path Explorer.
+func (a *App) paneHistory(side string) (*undo.History, error) {
+	switch side {
+	case "left", "right", "paths":
+	default:
+		return nil, fmt.Errorf("invalid pane %q: must be \"left\", \"right\" or \"paths\"", side)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.panes == nil {
+		a.panes = make(map[string]*undo.History)
+	}
+	history, ok := a.panes[side]
+	if !ok {
+		history = undo.New(0, 0)
+		a.panes[side] = history
+	}
+	return history, nil
+}
+
+// RecordPaneSnapshot saves the content of a pane just before an action
+// replaces it, e.g. "format", "minify", "normalize" or "load", so Undo can
+// bring it back.
+func (a *App) RecordPaneSnapshot(side, content, action string) error {
+	history, err := a.paneHistory(side)
+	if err != nil {
+		return err
+	}
+	history.Record(content, action)
+	return nil
+}
+
+// Undo returns the content of a pane from before its latest recorded
+// action, or nil if there is nothing to undo. current is the content
+// being replaced, so Redo can restore it, hand edits included.
+func (a *App) Undo(side, current string) (*undo.Snapshot, error) {
+	history, err := a.paneHistory(side)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot, ok := history.Undo(current); ok {
+		return &snapshot, nil
+	}
+	return nil, nil
+}
+
+// Redo reverses the latest Undo of a pane, or returns nil if there is
+// nothing to redo. current is saved for Undo as in Undo.
+func (a *App) Redo(side, current string) (*undo.Snapshot, error) {
+	history, err := a.paneHistory(side)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot, ok := history.Redo(current); ok {
+		return &snapshot, nil
+	}
+	return nil, nil
+}
+
+// GetUndoState reports whether a pane can undo or redo, and which actions,
+// for labelling "Undo Load" and similar.
+func (a *App) GetUndoState(side string) (undo.State, error) {
+	history, err := a.paneHistory(side)
+	if err != nil {
+		return undo.State{}, err
+	}
+	return history.State(), nil
+}
+
+// ============================================================
+// Session Autosave Methods
+// ============================================================
+
+// autosaveInterval is how often the working state is written to disk if
+// it changed.
+const autosaveInterval = 10 * time.Second
+
+// maxAutosavePaneSize is the largest pane content that is autosaved.
+// Bigger panes usually hold an opened file, which Files lets the user
+// reopen instead.
+const maxAutosavePaneSize = 5 << 20
+
+// AutosaveSession records the working state (pane contents, selected
+// options and loaded file paths) to restore if the app crashes. The
+// frontend can call it on every change: the state is only written to disk
+// every few seconds and before long-running operations. Panes larger than
+// 5MB are left out; their names are returned.
+func (a *App) AutosaveSession(session storage.Session) []string {
+	panes := make(map[string]string, len(session.Panes))
+	dropped := []string{}
+	for name, content := range session.Panes {
+		if len(content) > maxAutosavePaneSize {
+			dropped = append(dropped, name)
+			continue
+		}
+		panes[name] = content
+	}
+	sort.Strings(dropped)
+
+	session.Saved = time.Now()
+	session.Panes = panes
+	session.Dropped = dropped
+
+	a.mu.Lock()
+	a.pendingSession = &session
+	a.mu.Unlock()
+	return dropped
+}
+
+// GetRecoveredSession returns the session autosaved before the app last
+// crashed or was killed, so the frontend can offer to restore it, or nil
+// if the app last shut down cleanly. It stays available until
+// DiscardRecoveredSession is called.
+func (a *App) GetRecoveredSession() *storage.Session {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.recoveredSession
+}
+
+// DiscardRecoveredSession forgets the recovered session, once the user has
+// restored or declined it.
+func (a *App) DiscardRecoveredSession() error {
+	a.mu.Lock()
+	a.recoveredSession = nil
+	a.mu.Unlock()
+
+	if err := storage.DiscardRecoveredSession(a.configDir); err != nil {
+		return fmt.Errorf("error discarding recovered session: %w", err)
+	}
+	return nil
+}
+
+// startAutosave writes the working state to disk every autosaveInterval
+// until endSession.
+func (a *App) startAutosave() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	a.stopAutosave = cancel
+	a.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(autosaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.flushSession()
+			}
+		}
+	}()
+}
+
+// flushSession writes the working state to disk if it changed since the
+// last autosave. Saving is best effort, like the file history.
+func (a *App) flushSession() {
+	a.saveMu.Lock()
+	defer a.saveMu.Unlock()
+
+	a.mu.Lock()
+	session := a.pendingSession
+	a.pendingSession = nil
+	a.mu.Unlock()
+
+	if session != nil {
+		_ = storage.SaveSession(a.configDir, session)
+	}
+}
+
+// endSession stops autosaving and removes the autosaved session, since
+// after a clean shutdown there is nothing to recover.
+func (a *App) endSession() {
+	a.mu.Lock()
+	stop := a.stopAutosave
+	a.pendingSession = nil
+	a.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+
+	// Wait for an autosave in progress, which would otherwise recreate the file
+	a.saveMu.Lock()
+	defer a.saveMu.Unlock()
+	_ = storage.ClearSession(a.configDir)
+}
+
+// ============================================================
+// Monitor Methods
+// ============================================================
+
+// MonitorRunEvent is sent as a "monitor:run" event after each run of a
+// monitored comparison.
+type MonitorRunEvent struct {
+	Job monitor.Job `json:"job"`
+	Run monitor.Run `json:"run"`
+}
+
+// ListMonitors returns the comparisons re-run on a schedule, by name.
+func (a *App) ListMonitors() []monitor.Job {
+	if a.monitors == nil {
+		return []monitor.Job{}
+	}
+	return a.monitors.All()
+}
+
+// SaveMonitor schedules a comparison of two URLs or files (e.g. the same
+// endpoint on staging and production), or updates the monitor with the
+// same ID. The comparison runs every job.Interval with opts, and a desktop
+// notification is shown when the differences it finds change. An empty
+// ID adds a new monitor; the saved monitor is returned.
+func (a *App) SaveMonitor(job monitor.Job, opts NormalizeOptions) (*monitor.Job, error) {
+	if a.monitors == nil || a.scheduler == nil {
+		return nil, fmt.Errorf("monitors not initialized")
+	}
+	if job.ID == "" {
+		job.ID = fmt.Sprintf("monitor-%d", time.Now().UnixNano())
+	}
+	job.Name = strings.TrimSpace(job.Name)
+	if job.Name == "" {
+		job.Name = job.Left.String() + " vs " + job.Right.String()
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding monitor options: %w", err)
+	}
+	job.Options = data
+
+	if err := a.scheduler.Set(job); err != nil {
+		return nil, fmt.Errorf("invalid monitor: %w", err)
+	}
+	a.monitors.Set(job)
+	if err := a.monitors.Save(a.configDir); err != nil {
+		return nil, fmt.Errorf("error saving monitors: %w", err)
+	}
+	return &job, nil
+}
+
+// GetMonitorOptions returns the options a monitor compares with.
+func (a *App) GetMonitorOptions(id string) (NormalizeOptions, error) {
+	job, err := a.getMonitor(id)
+	if err != nil {
+		return NormalizeOptions{}, err
+	}
+	return a.monitorOptions(job)
+}
+
+// DeleteMonitor stops a monitor and forgets its runs.
+func (a *App) DeleteMonitor(id string) error {
+	if _, err := a.getMonitor(id); err != nil {
+		return err
+	}
+	a.scheduler.Remove(id)
+	a.monitors.Delete(id)
+	if err := a.monitors.Save(a.configDir); err != nil {
+		return fmt.Errorf("error saving monitors: %w", err)
+	}
+	return nil
+}
+
+// RunMonitorNow runs a monitor straight away, outside its schedule, and
+// returns the run. It is recorded and notified like a scheduled run.
+func (a *App) RunMonitorNow(id string) (*monitor.Run, error) {
+	job, err := a.getMonitor(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := a.startOperation("")
+	defer done()
+	run := a.runMonitor(ctx, job)
+	return &run, nil
+}
+
+// GetMonitorRuns returns the recent runs of a monitor, newest first. Runs
+// that found changed differences include the diff.
+func (a *App) GetMonitorRuns(id string) []monitor.Run {
+	if a.monitors == nil {
+		return []monitor.Run{}
+	}
+	return a.monitors.GetRuns(id)
+}
+
+// getMonitor returns the monitor with the given ID.
+func (a *App) getMonitor(id string) (monitor.Job, error) {
+	if a.monitors == nil || a.scheduler == nil {
+		return monitor.Job{}, fmt.Errorf("monitors not initialized")
+	}
+	job, ok := a.monitors.Get(id)
+	if !ok {
+		return monitor.Job{}, fmt.Errorf("monitor not found: %s", id)
+	}
+	return job, nil
+}
+
+// startMonitors schedules the saved monitors until shutdown. Monitors that
+// no longer validate are kept but not run.
+func (a *App) startMonitors() {
+	a.scheduler = monitor.NewScheduler(func(ctx context.Context, job monitor.Job) {
+		a.runMonitor(ctx, job)
+	})
+	for _, job := range a.monitors.All() {
+		_ = a.scheduler.Set(job)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopMonitors = cancel
+	go a.scheduler.Start(ctx)
+}
+
+// runMonitor compares job, records the run and, if the differences
+// changed, sends a "monitor:run" event and a desktop notification. A run
+// cut short by shutdown isn't recorded.
+func (a *App) runMonitor(ctx context.Context, job monitor.Job) monitor.Run {
+	run := monitor.Check(ctx, job, a.compareMonitor, a.monitors.LastSuccess(job.ID))
+	if ctx.Err() != nil {
+		return run
+	}
+
+	a.monitors.AddRun(job.ID, run)
+	_ = a.monitors.Save(a.configDir) // Best effort, like the file history
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "monitor:run", MonitorRunEvent{Job: job, Run: run})
+	}
+
+	if run.Changed {
+		body := "Both sides match again"
+		if run.Differences > 0 {
+			body = fmt.Sprintf("New differences: %d added, %d removed, %d changed", run.Stats.Added, run.Stats.Removed, run.Stats.Changed)
+		}
+		_ = notify.Send("jtool: "+job.Name, body)
+	}
+	return run
+}
+
+// compareMonitor diffs the two sides of a monitor with its options, as
+// CompareJSONWithOptions would but without replacing the comparison shown
+// in the app. Ignore rules are applied.
+func (a *App) compareMonitor(ctx context.Context, job monitor.Job) (*diff.DiffResult, error) {
+	opts, err := a.monitorOptions(job)
+	if err != nil {
+		return nil, err
+	}
+
+	leftText, err := a.readMonitorSource(ctx, job.Left)
+	if err != nil {
+		return nil, err
+	}
+	rightText, err := a.readMonitorSource(ctx, job.Right)
+	if err != nil {
+		return nil, err
+	}
+	left, err := parsePane(leftText, opts.LenientLeft)
+	if err != nil {
+		return nil, fmt.Errorf("invalid left JSON: %w", err)
+	}
+	right, err := parsePane(rightText, opts.LenientRight)
+	if err != nil {
+		return nil, fmt.Errorf("invalid right JSON: %w", err)
+	}
+
+	normalizeOpts := opts.toNormalizeOptions()
+	if err := normalize.ValidateTransforms(normalizeOpts.Transforms); err != nil {
+		return nil, fmt.Errorf("invalid transform rules: %w", err)
+	}
+	if err := normalize.ValidateOverrides(normalizeOpts.Overrides); err != nil {
+		return nil, fmt.Errorf("invalid overrides: %w", err)
+	}
+	result, err := diff.CompareContext(ctx, normalize.Value(left, normalizeOpts), normalize.Value(right, normalizeOpts))
+	if err != nil {
+		return nil, errCancelled
+	}
+	return diff.ApplyIgnoreRules(result, opts.IgnorePaths), nil
+}
+
+// readMonitorSource returns the content of one side of a monitor.
+func (a *App) readMonitorSource(ctx context.Context, source monitor.Source) (string, error) {
+	switch {
+	case strings.HasPrefix(source.URL, "s3://") || strings.HasPrefix(source.URL, "gs://"):
+		return a.fetchObject(ctx, source.URL, fetch.Options{})
+	case source.URL != "":
+		result, err := fetch.Get(ctx, source.URL, fetch.Options{})
+		if err != nil {
+			return "", fmt.Errorf("error fetching %s: %w", source.URL, err)
+		}
+		return result.Body, nil
+	default:
+		data, err := os.ReadFile(source.File)
+		if err != nil {
+			return "", fmt.Errorf("error reading file: %w", err)
+		}
+		text, _, err := charset.Decode(data)
+		return text, err
+	}
+}
+
+// monitorOptions returns the options saved with a monitor, or the defaults
+// if it has none.
+func (a *App) monitorOptions(job monitor.Job) (NormalizeOptions, error) {
+	if len(job.Options) == 0 {
+		return a.GetDefaultNormalizeOptions(), nil
+	}
+	var opts NormalizeOptions
+	if err := json.Unmarshal(job.Options, &opts); err != nil {
+		return NormalizeOptions{}, fmt.Errorf("error reading options of monitor %s: %w", job.Name, err)
+	}
+	return opts, nil
+}
+
+// ============================================================
+// Window and Layout Methods
+// ============================================================
+
+// GetLayoutState returns the tab and pane splitter ratios saved at the end
+// of the last session, so the frontend can restore them on load. The
+// window geometry is restored at startup.
+func (a *App) GetLayoutState() *storage.Layout {
+	if a.layout == nil {
+		return storage.NewLayout()
+	}
+	return a.layout.Snapshot()
+}
+
+// SetSelectedTab records the selected tab (e.g. "compare") to reopen next
+// time.
+func (a *App) SetSelectedTab(tab string) {
+	if a.layout != nil {
+		a.layout.SetTab(tab)
+	}
+}
+
+// SetSplitterRatio records the share of the space taken by the first pane
+// of a splitter (e.g. "compare-panes": 0.4) to restore next time.
+func (a *App) SetSplitterRatio(name string, ratio float64) error {
+	if ratio <= 0 || ratio >= 1 {
+		return fmt.Errorf("splitter ratio must be between 0 and 1, got %g", ratio)
+	}
+	if a.layout != nil {
+		a.layout.SetSplitter(name, ratio)
+	}
+	return nil
+}
+
+// rememberWindow records the current window geometry. While the window is
+// maximised the size it had before is kept, so un-maximising after a
+// restart goes back to it.
+func (a *App) rememberWindow() {
+	if a.layout == nil || runtime.WindowIsMinimised(a.ctx) {
+		return
+	}
+
+	state := a.layout.GetWindow()
+	state.Maximised = runtime.WindowIsMaximised(a.ctx)
+	if !state.Maximised {
+		state.Width, state.Height = runtime.WindowGetSize(a.ctx)
+		state.X, state.Y = runtime.WindowGetPosition(a.ctx)
+	}
+	a.layout.SetWindow(state)
+}
+
+// restoreWindow applies the saved window geometry. A position that can't
+// be on any screen (see onScreens) is dropped so the window stays centred.
+func (a *App) restoreWindow() {
+	state := a.layout.GetWindow()
+	if state.Width <= 0 || state.Height <= 0 {
+		return // Never saved; keep the defaults from main.go
+	}
+
+	runtime.WindowSetSize(a.ctx, state.Width, state.Height)
+	if screens, err := runtime.ScreenGetAll(a.ctx); err == nil && onScreens(state, screens) {
+		runtime.WindowSetPosition(a.ctx, state.X, state.Y)
+	}
+	if state.Maximised {
+		runtime.WindowMaximise(a.ctx)
+	}
+}
+
+// onScreens reports whether a saved window position can still be on one of
+// the screens. Wails doesn't report where each screen is, and a screen left
+// of or above the primary one has negative coordinates, so the position is
+// only rejected if it is further away than all the screens side by side
+// could reach, e.g. after a large external monitor was unplugged.
+func onScreens(state storage.WindowState, screens []runtime.Screen) bool {
+	width, height := 0, 0
+	for _, screen := range screens {
+		width += screen.Size.Width
+		height += screen.Size.Height
+	}
+	return state.X > -width && state.X < width && state.Y > -height && state.Y < height
+}
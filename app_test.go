@@ -0,0 +1,540 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"jtool/internal/loganalyzer"
+	"jtool/internal/paths"
+	"jtool/internal/storage"
+)
+
+// newTestApp returns an App storing its data in a temporary directory,
+// without a GUI.
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	a := NewApp()
+	a.configDir = t.TempDir()
+	a.profiles = storage.NewProfiles()
+	return a
+}
+
+// bundleWithProfiles returns an app data bundle holding the profiles.
+func bundleWithProfiles(t *testing.T, profiles map[string]string) string {
+	t.Helper()
+	bundle := storage.Bundle{
+		Format:   storage.BundleFormat,
+		Version:  storage.BundleVersion,
+		Profiles: make(map[string]json.RawMessage),
+	}
+	for name, options := range profiles {
+		bundle.Profiles[name] = json.RawMessage(options)
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestImportAppData_Profiles(t *testing.T) {
+	a := newTestApp(t)
+	if err := a.SaveNormalizeProfile("strict", NormalizeOptions{SortKeys: true}); err != nil {
+		t.Fatal(err)
+	}
+	bundle := bundleWithProfiles(t, map[string]string{
+		"strict": `{"trimStrings": true}`,
+		"api":    `{"sortArrays": true}`,
+	})
+
+	result, err := a.ImportAppData(bundle, AppDataImportOptions{Profiles: true, Conflict: storage.ConflictRename})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Profiles.Renamed["strict"]; got != "strict (2)" {
+		t.Errorf(`"strict" renamed to %q, want "strict (2)"`, got)
+	}
+	opts, err := a.LoadNormalizeProfile("strict (2)")
+	if err != nil || !opts.TrimStrings {
+		t.Errorf(`LoadNormalizeProfile("strict (2)") = %+v, %v`, opts, err)
+	}
+
+	// The profiles were saved
+	saved, err := storage.LoadProfiles(a.configDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := strings.Join(saved.Names(), ", "); names != "api, strict, strict (2)" {
+		t.Errorf("saved profiles = %s", names)
+	}
+}
+
+func TestImportAppData_Settings(t *testing.T) {
+	a := newTestApp(t)
+	bundle := `{"format": "jtool-app-data", "version": 1, "settings": {"largeFileThreshold": 1048576}}`
+
+	result, err := a.ImportAppData(bundle, AppDataImportOptions{Settings: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Settings {
+		t.Error("expected settings to be imported")
+	}
+
+	// The settings were saved
+	next := NewApp()
+	next.configDir = a.configDir
+	next.loadSettings()
+	if got := next.GetLargeFileThreshold(); got != 1<<20 {
+		t.Errorf("saved threshold = %d, want %d", got, 1<<20)
+	}
+
+	// A settings file that can't be written is reported
+	blocked := newTestApp(t)
+	blocked.configDir = filepath.Join(blocked.configDir, "file")
+	if err := os.WriteFile(blocked.configDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := blocked.ImportAppData(bundle, AppDataImportOptions{Settings: true}); err == nil {
+		t.Error("expected an error saving settings")
+	}
+}
+
+func TestImportAppData_InvalidProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]string
+		want     string
+	}{
+		{"unknown option", map[string]string{"ok": `{}`, "typo": `{"sortKey": true}`}, `invalid profile "typo" in bundle: json: unknown field "sortKey"`},
+		{"wrong type", map[string]string{"bad": `{"sortKeys": "yes"}`}, `invalid profile "bad" in bundle`},
+		{"not an object", map[string]string{"bad": `[1]`}, `invalid profile "bad" in bundle`},
+		{"empty name", map[string]string{" ": `{}`}, "profile name is required"},
+		{"bad transform", map[string]string{"bad": `{"transforms": [{"path": ".a", "kind": "explode"}]}`}, `unknown kind "explode"`},
+		{"bad override", map[string]string{"bad": `{"overrides": [{"path": ".a", "set": ["sortKeyz"]}]}`}, `unknown option "sortKeyz"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t)
+			_, err := a.ImportAppData(bundleWithProfiles(t, tt.profiles), AppDataImportOptions{Profiles: true})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+			if names := a.ListNormalizeProfiles(); len(names) != 0 {
+				t.Errorf("profiles %q were imported from an invalid bundle", names)
+			}
+		})
+	}
+}
+
+func TestGetJSONPaths_CombinedOptions(t *testing.T) {
+	a := newTestApp(t)
+	doc := `{"users": [{"id": 1, "name": "a", "etag": "x"}, {"id": 2, "name": "b"}], "meta": {"v": 1}}`
+
+	result, err := a.GetJSONPaths("", doc, paths.ExtractOptions{
+		IndexedArrays:   true,
+		IncludePatterns: []string{".users.**"},
+		ExcludePatterns: []string{"**.name"},
+		ExcludeKeys:     []string{"etag"},
+		Format:          paths.FormatPointer,
+		Tree:            true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := []string{}
+	for _, p := range result.Paths {
+		got = append(got, p.Path)
+	}
+	if want := []string{"/users/0/id", "/users/1/id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetJSONPaths() paths = %v, want %v", got, want)
+	}
+	if result.Tree == nil {
+		t.Error("expected a tree")
+	}
+
+	if _, err := a.GetJSONPaths("", doc, paths.ExtractOptions{IncludePatterns: []string{"/(/"}}); err == nil {
+		t.Error("expected an invalid pattern to be an error")
+	}
+}
+
+func TestSetLargeFileThreshold_Persists(t *testing.T) {
+	a := newTestApp(t)
+	if err := a.SetLargeFileThreshold(5 << 20); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The next run reads it back
+	next := NewApp()
+	next.configDir = a.configDir
+	next.loadSettings()
+	if got := next.GetLargeFileThreshold(); got != 5<<20 {
+		t.Errorf("GetLargeFileThreshold() = %d after restart, want %d", got, 5<<20)
+	}
+
+	// Nothing saved yet: the default applies
+	fresh := newTestApp(t)
+	fresh.loadSettings()
+	if got := fresh.GetLargeFileThreshold(); got != defaultLargeFileThreshold {
+		t.Errorf("GetLargeFileThreshold() = %d without saved settings, want %d", got, defaultLargeFileThreshold)
+	}
+}
+
+func TestReadFilePath_CompressedExpansion(t *testing.T) {
+	a := newTestApp(t)
+	if err := a.SetLargeFileThreshold(1 << 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// A few hundred bytes on disk that expand well past the threshold
+	doc := `{"items": [` + strings.Repeat(`"aaaaaaaaaa", `, 1000) + `"end"]}`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(doc))
+	gz.Close()
+	path := filepath.Join(t.TempDir(), "big.json.gz")
+	if err := os.WriteFile(path, compressed.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if compressed.Len() > 1<<10 {
+		t.Fatalf("test file is %d bytes on disk, want it under the threshold", compressed.Len())
+	}
+
+	// Without a GUI there is no one to ask, so it's read in full
+	got, err := a.ReadFilePath(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != doc {
+		t.Errorf("ReadFilePath() returned %d bytes, want %d", len(got), len(doc))
+	}
+}
+
+func TestRunJQ(t *testing.T) {
+	a := newTestApp(t)
+	doc := `{"users": [{"id": 1, "active": true}, {"id": 12345678901234567890, "active": false}]}`
+
+	tests := []struct {
+		program string
+		want    []string
+	}{
+		{".users[] | select(.active) | .id", []string{"1"}},
+		{".users[1].id", []string{"12345678901234567890"}},
+		{"[.users[].id] | length, (0 / 0 | isnan)", []string{"2", "true"}},
+		{`def double: . * 2; .users | map(.id | select(. < 10) | double)`, []string{"[\n  2\n]"}},
+		{"infinite, nan", []string{"1.7976931348623157e+308", "null"}},
+		{"empty", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.program, func(t *testing.T) {
+			got, err := a.RunJQ(doc, tt.program)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RunJQ() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunJQ_Errors(t *testing.T) {
+	a := newTestApp(t)
+
+	tests := []struct {
+		name, doc, program, want string
+	}{
+		{"invalid JSON", `{`, ".", "invalid JSON"},
+		{"syntax error", `{}`, ".a |", "jq: unexpected EOF"},
+		{"unknown function", `{}`, "frobnicate", "jq: function not defined: frobnicate/0"},
+		{"runtime error", `{"a": "x"}`, ".a + 1", "jq: error: cannot add"},
+		{"too many results", `{}`, "repeat(1)", "jq: more than 100000 results"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.RunJQ(tt.doc, tt.program)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateAndRediff(t *testing.T) {
+	a := newTestApp(t)
+	if _, err := a.CompareJSON(`{"a": [1, 2], "b": 1}`, `{"a": [1, 2], "b": 1}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Edit both panes in turn; each update must build on the last
+	if _, err := a.UpdateAndRediff("right", `{"a": [1, 3], "b": 1}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := a.UpdateAndRediff("left", `{"a": [1, 2], "b": 2}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := newTestApp(t).CompareJSON(`{"a": [1, 2], "b": 2}`, `{"a": [1, 3], "b": 1}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Root, want.Root) || !reflect.DeepEqual(got.Stats, want.Stats) {
+		t.Errorf("UpdateAndRediff() = %+v, want %+v", got.Stats, want.Stats)
+	}
+
+	if _, err := a.UpdateAndRediff("middle", `{}`); err == nil || !strings.Contains(err.Error(), "invalid side") {
+		t.Errorf("error = %v, want one containing %q", err, "invalid side")
+	}
+	if _, err := a.UpdateAndRediff("left", `{`); err == nil || !strings.Contains(err.Error(), "invalid left JSON") {
+		t.Errorf("error = %v, want one containing %q", err, "invalid left JSON")
+	}
+}
+
+func TestReplaceDiff_StaleSession(t *testing.T) {
+	a := newTestApp(t)
+	if _, err := a.CompareJSON(`{"a": 1}`, `{"a": 1}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stale := a.session
+	next, err := stale.update(1, `{"a": 2}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Another comparison lands while the update is being computed
+	if _, err := a.CompareJSON(`{"b": 1}`, `{"b": 1}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	current := a.session
+
+	if _, ok := a.replaceDiff(stale, next); ok {
+		t.Error("expected an update of a replaced session to be refused")
+	}
+	if a.session != current {
+		t.Error("expected the newer session to be kept")
+	}
+}
+
+// waitForOperation waits until an operation with opID is running.
+func waitForOperation(t *testing.T, a *App, opID string) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		a.mu.Lock()
+		_, ok := a.operations[opID]
+		a.mu.Unlock()
+		if ok {
+			return
+		}
+	}
+	t.Fatalf("operation %q never started", opID)
+}
+
+func TestAnalyzeLogFile_Cancel(t *testing.T) {
+	// Large enough that the analysis is still running when it's cancelled
+	path := filepath.Join(t.TempDir(), "big.jsonl")
+	line := `{"level": "info", "user": {"id": 12345, "email": "someone@example.com"}, "tags": ["a", "b", "c"]}` + "\n"
+	if err := os.WriteFile(path, []byte(strings.Repeat(line, 200000)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("CancelOperation", func(t *testing.T) {
+		a := newTestApp(t)
+		errs := make(chan error, 1)
+		go func() {
+			_, err := a.AnalyzeLogFileCancellable("logs", path, loganalyzer.Options{})
+			errs <- err
+		}()
+		waitForOperation(t, a, "logs")
+		if !a.CancelOperation("logs") {
+			t.Fatal("expected the analysis to be running")
+		}
+		if err := <-errs; !errors.Is(err, errCancelled) {
+			t.Errorf("expected errCancelled, got %v", err)
+		}
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		// Bindings without an operation ID are stopped at shutdown too
+		a := newTestApp(t)
+		errs := make(chan error, 1)
+		go func() {
+			_, err := a.CompareLogFiles(path, path)
+			errs <- err
+		}()
+		for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+			a.mu.Lock()
+			started := a.opsCtx != nil
+			a.mu.Unlock()
+			if started {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("the comparison never started")
+			}
+		}
+		a.cancelAllOperations()
+		if err := <-errs; !errors.Is(err, errCancelled) {
+			t.Errorf("expected errCancelled, got %v", err)
+		}
+	})
+}
+
+func TestOnScreens(t *testing.T) {
+	screen := func(width, height int) runtime.Screen {
+		var s runtime.Screen
+		s.Size.Width, s.Size.Height = width, height
+		return s
+	}
+	laptop, monitor := screen(1440, 900), screen(2560, 1440)
+
+	tests := []struct {
+		name    string
+		x, y    int
+		screens []runtime.Screen
+		want    bool
+	}{
+		{"primary screen", 100, 50, []runtime.Screen{laptop}, true},
+		{"secondary screen to the right", 2000, 100, []runtime.Screen{laptop, monitor}, true},
+		{"secondary screen to the left", -1800, 100, []runtime.Screen{laptop, monitor}, true},
+		{"secondary screen above", 200, -1200, []runtime.Screen{laptop, monitor}, true},
+		{"monitor unplugged", 2000, 100, []runtime.Screen{laptop}, false},
+		{"far off", 100, 5000, []runtime.Screen{laptop, monitor}, false},
+		{"no screens reported", 0, 0, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := storage.WindowState{X: tt.x, Y: tt.y, Width: 800, Height: 600}
+			if got := onScreens(state, tt.screens); got != tt.want {
+				t.Errorf("onScreens(%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsJSONDocument(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"a.json", true},
+		{"/data/Report.JSON", true},
+		{"snapshot.json.gz", true},
+		{"snapshot.json.zst", true},
+		{"app.jsonl", false},
+		{"app.log", false},
+		{"app.log.gz", false},
+		{"json", false},
+		{"/data/json/app.log", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isJSONDocument(tt.path); got != tt.want {
+				t.Errorf("isJSONDocument(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeOpenedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.json":   `{"a": 1}`,
+		"b.json":   `{"a": 2}`,
+		"c.json":   `{"a": 3}`,
+		"app.log":  `{"level": "info"}`,
+		"app.json": `{"level": "warn"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name        string
+		open        []string
+		wantTab     string
+		wantFiles   []string // Paths, in order
+		wantContent bool     // Documents are loaded
+		wantSkipped []string
+	}{
+		{"one document", []string{"a.json"}, "diff", []string{"a.json"}, true, nil},
+		{"two documents to compare", []string{"a.json", "b.json"}, "diff", []string{"a.json", "b.json"}, true, nil},
+		{"more documents than panes", []string{"a.json", "b.json", "c.json"}, "diff", []string{"a.json", "b.json"}, true, []string{"c.json"}},
+		{"a log", []string{"app.log"}, "logs", []string{"app.log"}, false, nil},
+		{"a mix opens as logs", []string{"app.json", "app.log"}, "logs", []string{"app.json", "app.log"}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t)
+			var open []string
+			for _, name := range tt.open {
+				open = append(open, path(name))
+			}
+			a.openFiles(open)
+
+			opened, err := a.TakeOpenedFiles()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if opened == nil || opened.Tab != tt.wantTab {
+				t.Fatalf("TakeOpenedFiles() = %+v, want tab %q", opened, tt.wantTab)
+			}
+
+			var gotFiles []string
+			for _, file := range opened.Files {
+				gotFiles = append(gotFiles, filepath.Base(file.Path))
+				if loaded := file.Content == files[filepath.Base(file.Path)]; loaded != tt.wantContent {
+					t.Errorf("%s: content %q, want loaded=%v", file.Path, file.Content, tt.wantContent)
+				}
+			}
+			if !reflect.DeepEqual(gotFiles, tt.wantFiles) {
+				t.Errorf("files = %q, want %q", gotFiles, tt.wantFiles)
+			}
+
+			var gotSkipped []string
+			for _, skipped := range opened.Skipped {
+				gotSkipped = append(gotSkipped, filepath.Base(skipped))
+			}
+			if !reflect.DeepEqual(gotSkipped, tt.wantSkipped) {
+				t.Errorf("skipped = %q, want %q", gotSkipped, tt.wantSkipped)
+			}
+
+			// The queue is emptied
+			if again, err := a.TakeOpenedFiles(); again != nil || err != nil {
+				t.Errorf("second TakeOpenedFiles() = %+v, %v, want nil, nil", again, err)
+			}
+		})
+	}
+
+	// Relative paths are made absolute when they're queued
+	a := newTestApp(t)
+	a.openFiles([]string{"relative.log"})
+	opened, err := a.TakeOpenedFiles()
+	if err != nil || opened == nil || len(opened.Files) != 1 || !filepath.IsAbs(opened.Files[0].Path) {
+		t.Errorf("TakeOpenedFiles() = %+v, %v, want one absolute path", opened, err)
+	}
+
+	// Nothing queued
+	if opened, err := newTestApp(t).TakeOpenedFiles(); opened != nil || err != nil {
+		t.Errorf("TakeOpenedFiles() = %+v, %v, want nil, nil", opened, err)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"jtool/internal/config"
+	"jtool/internal/jsonschema"
+	"jtool/internal/loganalyzer"
+	"jtool/internal/parser"
+	"jtool/internal/paths"
+)
+
+// runCLI handles headless invocations such as `tap-foo | jtool analyze -`
+// or `jtool serve`. It returns false if args aren't a CLI command, in which
+// case the GUI starts as usual; otherwise it returns true along with the
+// exit code. Commands take their defaults from the config file and JTOOL_*
+// variables (see package config); an invalid config fails the command.
+func runCLI(args []string, stdin io.Reader, stdout, stderr io.Writer) (bool, int) {
+	if len(args) == 0 {
+		return false, 0
+	}
+	if args[0] != "analyze" && args[0] != "serve" {
+		return false, 0
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "jtool: %v\n", err)
+		return true, 2
+	}
+	if args[0] == "analyze" {
+		return true, runAnalyze(args[1:], cfg, stdin, stdout, stderr)
+	}
+	return true, runServe(args[1:], cfg, stderr)
+}
+
+// fileArgs returns the arguments that name files for the GUI to open, as
+// passed by file associations on Windows and Linux or `jtool a.json b.json`.
+// Flags, such as the -psn_ argument older macOS versions add, are dropped.
+func fileArgs(args []string) []string {
+	var files []string
+	for _, arg := range args {
+		if arg != "" && !strings.HasPrefix(arg, "-") {
+			files = append(files, arg)
+		}
+	}
+	return files
+}
+
+// runAnalyze implements `jtool analyze [flags] [file|-]`: it analyzes a log
+// file, named pipe or stdin with the same pipeline as the Log Analyzer tab
+// and writes the result as JSON (or CSV) to stdout. The path format and
+// output format default to cfg's.
+func runAnalyze(args []string, cfg *config.Config, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: jtool analyze [flags] [file|-]")
+		fmt.Fprintln(stderr, "Reads stdin when the file is \"-\" or omitted.")
+		fs.PrintDefaults()
+	}
+
+	var opts loganalyzer.Options
+	fs.BoolVar(&opts.Approximate, "approximate", false, "estimate distinct counts and top values to bound memory")
+	fs.IntVar(&opts.TopN, "top", loganalyzer.DefaultTopN, "number of top values to report per path")
+	fs.BoolVar(&opts.Singer, "singer", false, "treat lines as Singer messages and validate RECORDs")
+	fs.BoolVar(&opts.EmbeddedJSON, "embedded", false, "find JSON after a prefix on each log line")
+	fs.IntVar(&opts.MaxLineSize, "max-line-size", loganalyzer.DefaultMaxLineSize, "largest line or multi-line document analyzed, in bytes")
+	pathFormat := paths.FormatDotted
+	if cfg.PathFormat != "" {
+		pathFormat = cfg.PathFormat
+	}
+	fs.StringVar(&opts.PathFormat, "path-format", pathFormat, "path notation: dotted, jsonpath or pointer")
+	fs.StringVar(&opts.Decoder, "decoder", "", "unwrap input lines first: "+strings.Join(loganalyzer.Decoders(), ", "))
+	fs.StringVar(&opts.CSVColumn, "csv-column", "", "column holding JSON for -decoder csv")
+	schemaFile := fs.String("schema", "", "validate every document against this JSON Schema file")
+	asCSV := fs.Bool("csv", cfg.Output == config.OutputCSV, "write the path table as CSV instead of JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	if opts.Decoder != "" && !slices.Contains(loganalyzer.Decoders(), opts.Decoder) {
+		fmt.Fprintf(stderr, "jtool: unknown decoder %q\n", opts.Decoder)
+		return 2
+	}
+	if *schemaFile != "" {
+		data, err := os.ReadFile(*schemaFile)
+		if err == nil {
+			opts.Schema, err = parser.Parse(data)
+		}
+		if err == nil {
+			_, err = jsonschema.Compile(opts.Schema)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "jtool: error reading schema: %v\n", err)
+			return 1
+		}
+	}
+
+	var result *loganalyzer.AnalysisResult
+	var err error
+	if path := fs.Arg(0); path == "" || path == "-" {
+		result, err = loganalyzer.AnalyzeReader(stdin, opts)
+	} else {
+		result, err = loganalyzer.AnalyzeFileWithOptions(path, opts)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "jtool: error analyzing input: %v\n", err)
+		return 1
+	}
+
+	if *asCSV {
+		err = loganalyzer.WriteAnalysisCSV(stdout, result)
+	} else {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(result)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "jtool: error writing output: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// exitIfCLI runs a CLI command and exits if the process was started as one.
+func exitIfCLI() {
+	if ok, code := runCLI(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); ok {
+		os.Exit(code)
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"none", nil, nil},
+		{"files", []string{"a.json", "/tmp/b.json"}, []string{"a.json", "/tmp/b.json"}},
+		{"macOS process serial number", []string{"-psn_0_12345", "a.json"}, []string{"a.json"}},
+		{"flags and empty arguments", []string{"--verbose", "", "logs/app.log"}, []string{"logs/app.log"}},
+		{"only flags", []string{"-v"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fileArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fileArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,7 +12,6 @@ import {
     OpenJSONFileWithPath,
     ReadFilePath,
     GetJSONPaths,
-    GetJSONPathsWithContainers,
     SelectAndAnalyzeLogFile,
     AnalyzeLogFilePath,
     CompareLogAnalyses,
@@ -924,7 +923,7 @@ async function handleExtractPaths() {
 
     try {
         const includeContainers = optIncludeContainers.checked;
-        const result = await GetJSONPathsWithContainers(value, includeContainers);
+        const result = await GetJSONPaths('paths', value, { includeContainers });
 
         displayPathsStats(result);
         displayPaths(result.paths);
@@ -1017,7 +1016,7 @@ async function handleAnalyzeLogFile() {
     logStatsDiv.textContent = '';
 
     try {
-        const response = await SelectAndAnalyzeLogFile();
+        const response = await SelectAndAnalyzeLogFile('logs');
 
         // User cancelled file dialog
         if (!response) {
@@ -1390,7 +1389,7 @@ async function handleCompareLoadFile(side) {
     infoDiv.innerHTML = 'Loading file...';
 
     try {
-        const response = await SelectAndAnalyzeLogFile();
+        const response = await SelectAndAnalyzeLogFile(`compare-${side}`);
 
         // User cancelled
         if (!response) {
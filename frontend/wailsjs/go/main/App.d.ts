@@ -1,52 +1,262 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {loganalyzer} from '../models';
+import {storage} from '../models';
 import {diff} from '../models';
 import {main} from '../models';
 import {paths} from '../models';
+import {convert} from '../models';
+import {unwrap} from '../models';
+import {jwt} from '../models';
+import {report} from '../models';
+import {fetch} from '../models';
+import {search} from '../models';
+import {pretty} from '../models';
+import {monitor} from '../models';
+import {undo} from '../models';
+import {jsonpath} from '../models';
+import {jsonschema} from '../models';
+
+export function AnalyzeLogDirectory(arg1:string,arg2:string,arg3:loganalyzer.Options):Promise<loganalyzer.BatchResult>;
+
+export function AnalyzeLogDirectoryCancellable(arg1:string,arg2:string,arg3:string,arg4:loganalyzer.Options):Promise<loganalyzer.BatchResult>;
 
 export function AnalyzeLogFile():Promise<loganalyzer.AnalysisResult>;
 
+export function AnalyzeLogFileCancellable(arg1:string,arg2:string,arg3:loganalyzer.Options):Promise<loganalyzer.AnalysisResult>;
+
 export function AnalyzeLogFilePath(arg1:string):Promise<loganalyzer.AnalysisResult>;
 
+export function AnalyzeLogFilePathWithOptions(arg1:string,arg2:loganalyzer.Options):Promise<loganalyzer.AnalysisResult>;
+
+export function AnalyzeLogFilePaths(arg1:Array<string>,arg2:loganalyzer.Options):Promise<loganalyzer.BatchResult>;
+
+export function AnalyzeLogFilesCancellable(arg1:string,arg2:Array<string>,arg3:loganalyzer.Options):Promise<loganalyzer.BatchResult>;
+
 export function AnalyzeLogString(arg1:string):Promise<loganalyzer.AnalysisResult>;
 
+export function AnalyzeLogStringWithOptions(arg1:string,arg2:loganalyzer.Options):Promise<loganalyzer.AnalysisResult>;
+
+export function AutosaveSession(arg1:storage.Session):Promise<Array<string>>;
+
+export function CancelOperation(arg1:string):Promise<boolean>;
+
 export function ClearFileHistory():Promise<void>;
 
+export function CompareGitRevisions(arg1:string,arg2:string,arg3:string,arg4:string):Promise<diff.DiffResult>;
+
+export function CompareGitRevisionsWithOptions(arg1:string,arg2:string,arg3:string,arg4:string,arg5:main.NormalizeOptions):Promise<diff.DiffResult>;
+
 export function CompareJSON(arg1:string,arg2:string):Promise<diff.DiffResult>;
 
+export function CompareJSONCancellable(arg1:string,arg2:string,arg3:string,arg4:main.NormalizeOptions):Promise<diff.DiffResult>;
+
+export function CompareJSONShape(arg1:string,arg2:string):Promise<paths.ShapeDiff>;
+
 export function CompareJSONWithOptions(arg1:string,arg2:string,arg3:main.NormalizeOptions):Promise<diff.DiffResult>;
 
 export function CompareLogAnalyses(arg1:loganalyzer.AnalysisResult,arg2:loganalyzer.AnalysisResult,arg3:string,arg4:string):Promise<loganalyzer.ComparisonResult>;
 
+export function CompareLogAnalysesWithOptions(arg1:loganalyzer.AnalysisResult,arg2:loganalyzer.AnalysisResult,arg3:string,arg4:string,arg5:loganalyzer.CompareOptions):Promise<loganalyzer.ComparisonResult>;
+
 export function CompareLogFiles(arg1:string,arg2:string):Promise<loganalyzer.ComparisonResult>;
 
+export function CompareLogFilesCancellable(arg1:string,arg2:string,arg3:string,arg4:loganalyzer.CompareOptions):Promise<loganalyzer.ComparisonResult>;
+
+export function CompareLogFilesWithOptions(arg1:string,arg2:string,arg3:loganalyzer.CompareOptions):Promise<loganalyzer.ComparisonResult>;
+
+export function CompareWithClipboard(arg1:string,arg2:string,arg3:main.NormalizeOptions):Promise<diff.DiffResult>;
+
+export function ConvertINIToJSON(arg1:string):Promise<string>;
+
+export function ConvertTOMLToJSON(arg1:string):Promise<string>;
+
+export function ConvertXMLToJSON(arg1:string,arg2:convert.XMLOptions):Promise<string>;
+
+export function CopyToClipboard(arg1:string):Promise<void>;
+
+export function DecodeBase64(arg1:string):Promise<unwrap.Result>;
+
+export function DecodeBase64URL(arg1:string):Promise<unwrap.Result>;
+
+export function DecodeJWT(arg1:string):Promise<jwt.Token>;
+
+export function DecodeURLEncoded(arg1:string):Promise<unwrap.Result>;
+
+export function DeleteMonitor(arg1:string):Promise<void>;
+
+export function DeleteNormalizeProfile(arg1:string):Promise<void>;
+
+export function DiscardRecoveredSession():Promise<void>;
+
+export function ExpandDiffNode(arg1:string,arg2:number,arg3:number):Promise<Array<diff.DiffNode>>;
+
+export function ExportAppData():Promise<string>;
+
+export function ExportAppDataToFile():Promise<string>;
+
+export function ExportDiffReport(arg1:string,arg2:report.Options):Promise<string>;
+
+export function ExportFlattenedCSV(arg1:string):Promise<string>;
+
+export function ExportJSONSchema(arg1:paths.Schema):Promise<string>;
+
+export function ExportLogAnalysisCSV(arg1:loganalyzer.AnalysisResult):Promise<string>;
+
+export function ExportLogComparisonCSV(arg1:loganalyzer.ComparisonResult):Promise<string>;
+
+export function ExportLogComparisonHTML(arg1:loganalyzer.ComparisonResult):Promise<string>;
+
+export function ExportLogComparisonReport(arg1:loganalyzer.ComparisonResult,arg2:string,arg3:report.Options):Promise<string>;
+
+export function FetchJSON(arg1:string,arg2:Record<string, string>):Promise<string>;
+
+export function FetchJSONWithOptions(arg1:string,arg2:fetch.Options):Promise<string>;
+
+export function FetchObject(arg1:string):Promise<string>;
+
+export function FetchObjectWithOptions(arg1:string,arg2:fetch.Options):Promise<string>;
+
+export function FindInDiff(arg1:string,arg2:string,arg3:search.Options):Promise<search.DiffResult>;
+
+export function FlattenJSON(arg1:string):Promise<Array<paths.Row>>;
+
+export function FormatDiffPath(arg1:string,arg2:string):Promise<string>;
+
+export function FormatDiffValue(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function FormatJSON(arg1:string):Promise<string>;
 
+export function FormatJSONWithOptions(arg1:string,arg2:pretty.Options):Promise<string>;
+
+export function FormatLenientJSON(arg1:string):Promise<string>;
+
+export function GenerateGoTypes(arg1:string,arg2:string):Promise<string>;
+
+export function GenerateGoTypesFromSchema(arg1:paths.Schema,arg2:string):Promise<string>;
+
+export function GenerateSQLDDL(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function GenerateSQLDDLFromSchema(arg1:paths.Schema,arg2:string,arg3:string):Promise<string>;
+
+export function GenerateTypeScriptTypes(arg1:string,arg2:string):Promise<string>;
+
+export function GenerateTypeScriptTypesFromSchema(arg1:paths.Schema,arg2:string):Promise<string>;
+
 export function GetAllFileHistory():Promise<Record<string, Array<string>>>;
 
+export function GetConfigStatus():Promise<main.ConfigStatus>;
+
+export function GetDefaultLogCompareOptions():Promise<loganalyzer.CompareOptions>;
+
 export function GetDefaultNormalizeOptions():Promise<main.NormalizeOptions>;
 
 export function GetFileHistory(arg1:string):Promise<Array<string>>;
 
-export function GetJSONPaths(arg1:string):Promise<paths.PathResult>;
+export function GetJSONPaths(arg1:string,arg2:string,arg3:paths.ExtractOptions):Promise<paths.PathResult>;
+
+export function GetLargeFileThreshold():Promise<number>;
+
+export function GetLayoutState():Promise<storage.Layout>;
+
+export function GetLinesForPath(arg1:string,arg2:string,arg3:number):Promise<Array<loganalyzer.SourceRecord>>;
+
+export function GetLinesForPathWithOptions(arg1:string,arg2:string,arg3:number,arg4:loganalyzer.Options):Promise<Array<loganalyzer.SourceRecord>>;
+
+export function GetLogDecoders():Promise<Array<string>>;
+
+export function GetLogStringValueFrequencies(arg1:string,arg2:string,arg3:loganalyzer.Options):Promise<Array<loganalyzer.ValueFrequency>>;
+
+export function GetLogValueFrequencies(arg1:string,arg2:string,arg3:loganalyzer.Options):Promise<Array<loganalyzer.ValueFrequency>>;
 
-export function GetJSONPathsWithContainers(arg1:string,arg2:boolean):Promise<paths.PathResult>;
+export function GetMonitorOptions(arg1:string):Promise<main.NormalizeOptions>;
+
+export function GetMonitorRuns(arg1:string):Promise<Array<monitor.Run>>;
 
 export function GetMostRecentFilePath(arg1:string):Promise<string>;
 
+export function GetNormalizationReport(arg1:string,arg2:main.NormalizeOptions):Promise<main.NormalizationReport>;
+
+export function GetPathExpressions(arg1:string,arg2:string):Promise<paths.PathExpressions>;
+
+export function GetRecoveredSession():Promise<storage.Session>;
+
+export function GetUndoState(arg1:string):Promise<undo.State>;
+
+export function ImportAppData(arg1:string,arg2:main.AppDataImportOptions):Promise<main.AppDataImportResult>;
+
+export function InferJSONSchema(arg1:string,arg2:string):Promise<paths.Schema>;
+
+export function InspectAppData(arg1:string):Promise<main.AppDataSummary>;
+
+export function ListMonitors():Promise<Array<monitor.Job>>;
+
+export function ListNormalizeProfiles():Promise<Array<string>>;
+
+export function LoadNormalizeProfile(arg1:string):Promise<main.NormalizeOptions>;
+
+export function OpenInEditor(arg1:string):Promise<void>;
+
 export function OpenJSONFile():Promise<string>;
 
 export function OpenJSONFileWithPath():Promise<main.FileResult>;
 
+export function QueryJSONPath(arg1:string,arg2:string):Promise<Array<jsonpath.Match>>;
+
+export function ReadClipboardJSON():Promise<main.ClipboardJSON>;
+
 export function ReadFilePath(arg1:string):Promise<string>;
 
+export function ReadGitRevision(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function RecordPaneSnapshot(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function Redo(arg1:string,arg2:string):Promise<undo.Snapshot>;
+
+export function RevealInFinder(arg1:string):Promise<void>;
+
+export function RunJQ(arg1:string,arg2:string):Promise<Array<string>>;
+
+export function RunMonitorNow(arg1:string):Promise<monitor.Run>;
+
 export function SaveFilePathToHistory(arg1:string,arg2:string):Promise<void>;
 
-export function SelectAndAnalyzeLogFile():Promise<main.LogFileResult>;
+export function SaveMonitor(arg1:monitor.Job,arg2:main.NormalizeOptions):Promise<monitor.Job>;
+
+export function SaveNormalizeProfile(arg1:string,arg2:main.NormalizeOptions):Promise<void>;
+
+export function SearchDocument(arg1:string,arg2:string,arg3:string):Promise<search.Result>;
+
+export function SelectAndAnalyzeLogDirectory(arg1:string):Promise<loganalyzer.BatchResult>;
+
+export function SelectAndAnalyzeLogFile(arg1:string):Promise<main.LogFileResult>;
 
-export function SelectAndCompareLogFiles():Promise<loganalyzer.ComparisonResult>;
+export function SelectAndAnalyzeLogFiles():Promise<loganalyzer.BatchResult>;
+
+export function SelectAndCompareLogFiles(arg1:string):Promise<loganalyzer.ComparisonResult>;
+
+export function SetLargeFileThreshold(arg1:number):Promise<void>;
+
+export function SetSelectedTab(arg1:string):Promise<void>;
+
+export function SetSplitterRatio(arg1:string,arg2:number):Promise<void>;
 
 export function ShowSettingsTab():Promise<void>;
 
+export function StopWatchingLogFile():Promise<void>;
+
+export function TakeOpenedFiles():Promise<main.OpenedFiles>;
+
+export function Undo(arg1:string,arg2:string):Promise<undo.Snapshot>;
+
+export function UpdateAndRediff(arg1:string,arg2:string):Promise<diff.DiffResult>;
+
+export function ValidateAgainstSchema(arg1:string,arg2:string):Promise<jsonschema.Result>;
+
 export function ValidateJSON(arg1:string):Promise<string>;
+
+export function ValidateLenientJSON(arg1:string):Promise<string>;
+
+export function ValidateLogFile(arg1:string,arg2:string,arg3:string,arg4:loganalyzer.Options):Promise<loganalyzer.AnalysisResult>;
+
+export function WatchLogFile(arg1:string):Promise<loganalyzer.AnalysisResult>;
@@ -2,26 +2,78 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AnalyzeLogDirectory(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AnalyzeLogDirectory'](arg1, arg2, arg3);
+}
+
+export function AnalyzeLogDirectoryCancellable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AnalyzeLogDirectoryCancellable'](arg1, arg2, arg3, arg4);
+}
+
 export function AnalyzeLogFile() {
   return window['go']['main']['App']['AnalyzeLogFile']();
 }
 
+export function AnalyzeLogFileCancellable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AnalyzeLogFileCancellable'](arg1, arg2, arg3);
+}
+
 export function AnalyzeLogFilePath(arg1) {
   return window['go']['main']['App']['AnalyzeLogFilePath'](arg1);
 }
 
+export function AnalyzeLogFilePathWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeLogFilePathWithOptions'](arg1, arg2);
+}
+
+export function AnalyzeLogFilePaths(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeLogFilePaths'](arg1, arg2);
+}
+
+export function AnalyzeLogFilesCancellable(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AnalyzeLogFilesCancellable'](arg1, arg2, arg3);
+}
+
 export function AnalyzeLogString(arg1) {
   return window['go']['main']['App']['AnalyzeLogString'](arg1);
 }
 
+export function AnalyzeLogStringWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeLogStringWithOptions'](arg1, arg2);
+}
+
+export function AutosaveSession(arg1) {
+  return window['go']['main']['App']['AutosaveSession'](arg1);
+}
+
+export function CancelOperation(arg1) {
+  return window['go']['main']['App']['CancelOperation'](arg1);
+}
+
 export function ClearFileHistory() {
   return window['go']['main']['App']['ClearFileHistory']();
 }
 
+export function CompareGitRevisions(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CompareGitRevisions'](arg1, arg2, arg3, arg4);
+}
+
+export function CompareGitRevisionsWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CompareGitRevisionsWithOptions'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function CompareJSON(arg1, arg2) {
   return window['go']['main']['App']['CompareJSON'](arg1, arg2);
 }
 
+export function CompareJSONCancellable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CompareJSONCancellable'](arg1, arg2, arg3, arg4);
+}
+
+export function CompareJSONShape(arg1, arg2) {
+  return window['go']['main']['App']['CompareJSONShape'](arg1, arg2);
+}
+
 export function CompareJSONWithOptions(arg1, arg2, arg3) {
   return window['go']['main']['App']['CompareJSONWithOptions'](arg1, arg2, arg3);
 }
@@ -30,18 +82,190 @@ export function CompareLogAnalyses(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['CompareLogAnalyses'](arg1, arg2, arg3, arg4);
 }
 
+export function CompareLogAnalysesWithOptions(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CompareLogAnalysesWithOptions'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function CompareLogFiles(arg1, arg2) {
   return window['go']['main']['App']['CompareLogFiles'](arg1, arg2);
 }
 
+export function CompareLogFilesCancellable(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CompareLogFilesCancellable'](arg1, arg2, arg3, arg4);
+}
+
+export function CompareLogFilesWithOptions(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CompareLogFilesWithOptions'](arg1, arg2, arg3);
+}
+
+export function CompareWithClipboard(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CompareWithClipboard'](arg1, arg2, arg3);
+}
+
+export function ConvertINIToJSON(arg1) {
+  return window['go']['main']['App']['ConvertINIToJSON'](arg1);
+}
+
+export function ConvertTOMLToJSON(arg1) {
+  return window['go']['main']['App']['ConvertTOMLToJSON'](arg1);
+}
+
+export function ConvertXMLToJSON(arg1, arg2) {
+  return window['go']['main']['App']['ConvertXMLToJSON'](arg1, arg2);
+}
+
+export function CopyToClipboard(arg1) {
+  return window['go']['main']['App']['CopyToClipboard'](arg1);
+}
+
+export function DecodeBase64(arg1) {
+  return window['go']['main']['App']['DecodeBase64'](arg1);
+}
+
+export function DecodeBase64URL(arg1) {
+  return window['go']['main']['App']['DecodeBase64URL'](arg1);
+}
+
+export function DecodeJWT(arg1) {
+  return window['go']['main']['App']['DecodeJWT'](arg1);
+}
+
+export function DecodeURLEncoded(arg1) {
+  return window['go']['main']['App']['DecodeURLEncoded'](arg1);
+}
+
+export function DeleteMonitor(arg1) {
+  return window['go']['main']['App']['DeleteMonitor'](arg1);
+}
+
+export function DeleteNormalizeProfile(arg1) {
+  return window['go']['main']['App']['DeleteNormalizeProfile'](arg1);
+}
+
+export function DiscardRecoveredSession() {
+  return window['go']['main']['App']['DiscardRecoveredSession']();
+}
+
+export function ExpandDiffNode(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExpandDiffNode'](arg1, arg2, arg3);
+}
+
+export function ExportAppData() {
+  return window['go']['main']['App']['ExportAppData']();
+}
+
+export function ExportAppDataToFile() {
+  return window['go']['main']['App']['ExportAppDataToFile']();
+}
+
+export function ExportDiffReport(arg1, arg2) {
+  return window['go']['main']['App']['ExportDiffReport'](arg1, arg2);
+}
+
+export function ExportFlattenedCSV(arg1) {
+  return window['go']['main']['App']['ExportFlattenedCSV'](arg1);
+}
+
+export function ExportJSONSchema(arg1) {
+  return window['go']['main']['App']['ExportJSONSchema'](arg1);
+}
+
+export function ExportLogAnalysisCSV(arg1) {
+  return window['go']['main']['App']['ExportLogAnalysisCSV'](arg1);
+}
+
+export function ExportLogComparisonCSV(arg1) {
+  return window['go']['main']['App']['ExportLogComparisonCSV'](arg1);
+}
+
+export function ExportLogComparisonHTML(arg1) {
+  return window['go']['main']['App']['ExportLogComparisonHTML'](arg1);
+}
+
+export function ExportLogComparisonReport(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportLogComparisonReport'](arg1, arg2, arg3);
+}
+
+export function FetchJSON(arg1, arg2) {
+  return window['go']['main']['App']['FetchJSON'](arg1, arg2);
+}
+
+export function FetchJSONWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['FetchJSONWithOptions'](arg1, arg2);
+}
+
+export function FetchObject(arg1) {
+  return window['go']['main']['App']['FetchObject'](arg1);
+}
+
+export function FetchObjectWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['FetchObjectWithOptions'](arg1, arg2);
+}
+
+export function FindInDiff(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FindInDiff'](arg1, arg2, arg3);
+}
+
+export function FlattenJSON(arg1) {
+  return window['go']['main']['App']['FlattenJSON'](arg1);
+}
+
+export function FormatDiffPath(arg1, arg2) {
+  return window['go']['main']['App']['FormatDiffPath'](arg1, arg2);
+}
+
+export function FormatDiffValue(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FormatDiffValue'](arg1, arg2, arg3);
+}
+
 export function FormatJSON(arg1) {
   return window['go']['main']['App']['FormatJSON'](arg1);
 }
 
+export function FormatJSONWithOptions(arg1, arg2) {
+  return window['go']['main']['App']['FormatJSONWithOptions'](arg1, arg2);
+}
+
+export function FormatLenientJSON(arg1) {
+  return window['go']['main']['App']['FormatLenientJSON'](arg1);
+}
+
+export function GenerateGoTypes(arg1, arg2) {
+  return window['go']['main']['App']['GenerateGoTypes'](arg1, arg2);
+}
+
+export function GenerateGoTypesFromSchema(arg1, arg2) {
+  return window['go']['main']['App']['GenerateGoTypesFromSchema'](arg1, arg2);
+}
+
+export function GenerateSQLDDL(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GenerateSQLDDL'](arg1, arg2, arg3);
+}
+
+export function GenerateSQLDDLFromSchema(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GenerateSQLDDLFromSchema'](arg1, arg2, arg3);
+}
+
+export function GenerateTypeScriptTypes(arg1, arg2) {
+  return window['go']['main']['App']['GenerateTypeScriptTypes'](arg1, arg2);
+}
+
+export function GenerateTypeScriptTypesFromSchema(arg1, arg2) {
+  return window['go']['main']['App']['GenerateTypeScriptTypesFromSchema'](arg1, arg2);
+}
+
 export function GetAllFileHistory() {
   return window['go']['main']['App']['GetAllFileHistory']();
 }
 
+export function GetConfigStatus() {
+  return window['go']['main']['App']['GetConfigStatus']();
+}
+
+export function GetDefaultLogCompareOptions() {
+  return window['go']['main']['App']['GetDefaultLogCompareOptions']();
+}
+
 export function GetDefaultNormalizeOptions() {
   return window['go']['main']['App']['GetDefaultNormalizeOptions']();
 }
@@ -50,18 +274,94 @@ export function GetFileHistory(arg1) {
   return window['go']['main']['App']['GetFileHistory'](arg1);
 }
 
-export function GetJSONPaths(arg1) {
-  return window['go']['main']['App']['GetJSONPaths'](arg1);
+export function GetJSONPaths(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetJSONPaths'](arg1, arg2, arg3);
+}
+
+export function GetLargeFileThreshold() {
+  return window['go']['main']['App']['GetLargeFileThreshold']();
+}
+
+export function GetLayoutState() {
+  return window['go']['main']['App']['GetLayoutState']();
+}
+
+export function GetLinesForPath(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetLinesForPath'](arg1, arg2, arg3);
+}
+
+export function GetLinesForPathWithOptions(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetLinesForPathWithOptions'](arg1, arg2, arg3, arg4);
+}
+
+export function GetLogDecoders() {
+  return window['go']['main']['App']['GetLogDecoders']();
+}
+
+export function GetLogStringValueFrequencies(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetLogStringValueFrequencies'](arg1, arg2, arg3);
 }
 
-export function GetJSONPathsWithContainers(arg1, arg2) {
-  return window['go']['main']['App']['GetJSONPathsWithContainers'](arg1, arg2);
+export function GetLogValueFrequencies(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetLogValueFrequencies'](arg1, arg2, arg3);
+}
+
+export function GetMonitorOptions(arg1) {
+  return window['go']['main']['App']['GetMonitorOptions'](arg1);
+}
+
+export function GetMonitorRuns(arg1) {
+  return window['go']['main']['App']['GetMonitorRuns'](arg1);
 }
 
 export function GetMostRecentFilePath(arg1) {
   return window['go']['main']['App']['GetMostRecentFilePath'](arg1);
 }
 
+export function GetNormalizationReport(arg1, arg2) {
+  return window['go']['main']['App']['GetNormalizationReport'](arg1, arg2);
+}
+
+export function GetPathExpressions(arg1, arg2) {
+  return window['go']['main']['App']['GetPathExpressions'](arg1, arg2);
+}
+
+export function GetRecoveredSession() {
+  return window['go']['main']['App']['GetRecoveredSession']();
+}
+
+export function GetUndoState(arg1) {
+  return window['go']['main']['App']['GetUndoState'](arg1);
+}
+
+export function ImportAppData(arg1, arg2) {
+  return window['go']['main']['App']['ImportAppData'](arg1, arg2);
+}
+
+export function InferJSONSchema(arg1, arg2) {
+  return window['go']['main']['App']['InferJSONSchema'](arg1, arg2);
+}
+
+export function InspectAppData(arg1) {
+  return window['go']['main']['App']['InspectAppData'](arg1);
+}
+
+export function ListMonitors() {
+  return window['go']['main']['App']['ListMonitors']();
+}
+
+export function ListNormalizeProfiles() {
+  return window['go']['main']['App']['ListNormalizeProfiles']();
+}
+
+export function LoadNormalizeProfile(arg1) {
+  return window['go']['main']['App']['LoadNormalizeProfile'](arg1);
+}
+
+export function OpenInEditor(arg1) {
+  return window['go']['main']['App']['OpenInEditor'](arg1);
+}
+
 export function OpenJSONFile() {
   return window['go']['main']['App']['OpenJSONFile']();
 }
@@ -70,26 +370,122 @@ export function OpenJSONFileWithPath() {
   return window['go']['main']['App']['OpenJSONFileWithPath']();
 }
 
+export function QueryJSONPath(arg1, arg2) {
+  return window['go']['main']['App']['QueryJSONPath'](arg1, arg2);
+}
+
+export function ReadClipboardJSON() {
+  return window['go']['main']['App']['ReadClipboardJSON']();
+}
+
 export function ReadFilePath(arg1) {
   return window['go']['main']['App']['ReadFilePath'](arg1);
 }
 
+export function ReadGitRevision(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReadGitRevision'](arg1, arg2, arg3);
+}
+
+export function RecordPaneSnapshot(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RecordPaneSnapshot'](arg1, arg2, arg3);
+}
+
+export function Redo(arg1, arg2) {
+  return window['go']['main']['App']['Redo'](arg1, arg2);
+}
+
+export function RevealInFinder(arg1) {
+  return window['go']['main']['App']['RevealInFinder'](arg1);
+}
+
+export function RunJQ(arg1, arg2) {
+  return window['go']['main']['App']['RunJQ'](arg1, arg2);
+}
+
+export function RunMonitorNow(arg1) {
+  return window['go']['main']['App']['RunMonitorNow'](arg1);
+}
+
 export function SaveFilePathToHistory(arg1, arg2) {
   return window['go']['main']['App']['SaveFilePathToHistory'](arg1, arg2);
 }
 
-export function SelectAndAnalyzeLogFile() {
-  return window['go']['main']['App']['SelectAndAnalyzeLogFile']();
+export function SaveMonitor(arg1, arg2) {
+  return window['go']['main']['App']['SaveMonitor'](arg1, arg2);
+}
+
+export function SaveNormalizeProfile(arg1, arg2) {
+  return window['go']['main']['App']['SaveNormalizeProfile'](arg1, arg2);
+}
+
+export function SearchDocument(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchDocument'](arg1, arg2, arg3);
+}
+
+export function SelectAndAnalyzeLogDirectory(arg1) {
+  return window['go']['main']['App']['SelectAndAnalyzeLogDirectory'](arg1);
+}
+
+export function SelectAndAnalyzeLogFile(arg1) {
+  return window['go']['main']['App']['SelectAndAnalyzeLogFile'](arg1);
 }
 
-export function SelectAndCompareLogFiles() {
-  return window['go']['main']['App']['SelectAndCompareLogFiles']();
+export function SelectAndAnalyzeLogFiles() {
+  return window['go']['main']['App']['SelectAndAnalyzeLogFiles']();
+}
+
+export function SelectAndCompareLogFiles(arg1) {
+  return window['go']['main']['App']['SelectAndCompareLogFiles'](arg1);
+}
+
+export function SetLargeFileThreshold(arg1) {
+  return window['go']['main']['App']['SetLargeFileThreshold'](arg1);
+}
+
+export function SetSelectedTab(arg1) {
+  return window['go']['main']['App']['SetSelectedTab'](arg1);
+}
+
+export function SetSplitterRatio(arg1, arg2) {
+  return window['go']['main']['App']['SetSplitterRatio'](arg1, arg2);
 }
 
 export function ShowSettingsTab() {
   return window['go']['main']['App']['ShowSettingsTab']();
 }
 
+export function StopWatchingLogFile() {
+  return window['go']['main']['App']['StopWatchingLogFile']();
+}
+
+export function TakeOpenedFiles() {
+  return window['go']['main']['App']['TakeOpenedFiles']();
+}
+
+export function Undo(arg1, arg2) {
+  return window['go']['main']['App']['Undo'](arg1, arg2);
+}
+
+export function UpdateAndRediff(arg1, arg2) {
+  return window['go']['main']['App']['UpdateAndRediff'](arg1, arg2);
+}
+
+export function ValidateAgainstSchema(arg1, arg2) {
+  return window['go']['main']['App']['ValidateAgainstSchema'](arg1, arg2);
+}
+
 export function ValidateJSON(arg1) {
   return window['go']['main']['App']['ValidateJSON'](arg1);
 }
+
+export function ValidateLenientJSON(arg1) {
+  return window['go']['main']['App']['ValidateLenientJSON'](arg1);
+}
+
+export function ValidateLogFile(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ValidateLogFile'](arg1, arg2, arg3, arg4);
+}
+
+export function WatchLogFile(arg1) {
+  return window['go']['main']['App']['WatchLogFile'](arg1);
+}
@@ -1,11 +1,58 @@
+export namespace convert {
+	
+	export class XMLOptions {
+	    attributes: string;
+	    attributePrefix: string;
+	    textKey: string;
+	    stripNamespaces: boolean;
+	    arrayElements: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new XMLOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.attributes = source["attributes"];
+	        this.attributePrefix = source["attributePrefix"];
+	        this.textKey = source["textKey"];
+	        this.stripNamespaces = source["stripNamespaces"];
+	        this.arrayElements = source["arrayElements"];
+	    }
+	}
+
+}
+
 export namespace diff {
 	
+	export class ArraySummary {
+	    length: number;
+	    equalCount: number;
+	    diffIndices: number[];
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArraySummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.length = source["length"];
+	        this.equalCount = source["equalCount"];
+	        this.diffIndices = source["diffIndices"];
+	        this.text = source["text"];
+	    }
+	}
 	export class DiffNode {
 	    path: string;
 	    type: string;
 	    left?: any;
 	    right?: any;
+	    leftText?: string;
+	    rightText?: string;
 	    children?: DiffNode[];
+	    ignored?: string;
+	    summary?: ArraySummary;
 	
 	    static createFrom(source: any = {}) {
 	        return new DiffNode(source);
@@ -17,7 +64,43 @@ export namespace diff {
 	        this.type = source["type"];
 	        this.left = source["left"];
 	        this.right = source["right"];
+	        this.leftText = source["leftText"];
+	        this.rightText = source["rightText"];
 	        this.children = this.convertValues(source["children"], DiffNode);
+	        this.ignored = source["ignored"];
+	        this.summary = this.convertValues(source["summary"], ArraySummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SchemaValidation {
+	    left?: jsonschema.Result;
+	    right?: jsonschema.Result;
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaValidation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.left = this.convertValues(source["left"], jsonschema.Result);
+	        this.right = this.convertValues(source["right"], jsonschema.Result);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -43,6 +126,8 @@ export namespace diff {
 	    removed: number;
 	    changed: number;
 	    equal: number;
+	    suppressed: number;
+	    suppressedByRule?: Record<string, number>;
 	
 	    static createFrom(source: any = {}) {
 	        return new DiffStats(source);
@@ -54,11 +139,15 @@ export namespace diff {
 	        this.removed = source["removed"];
 	        this.changed = source["changed"];
 	        this.equal = source["equal"];
+	        this.suppressed = source["suppressed"];
+	        this.suppressedByRule = source["suppressedByRule"];
 	    }
 	}
 	export class DiffResult {
+	    id?: string;
 	    root: DiffNode;
 	    stats: DiffStats;
+	    validation?: SchemaValidation;
 	
 	    static createFrom(source: any = {}) {
 	        return new DiffResult(source);
@@ -66,8 +155,10 @@ export namespace diff {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
 	        this.root = this.convertValues(source["root"], DiffNode);
 	        this.stats = this.convertValues(source["stats"], DiffStats);
+	        this.validation = this.convertValues(source["validation"], SchemaValidation);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -88,43 +179,102 @@ export namespace diff {
 		    return a;
 		}
 	}
+	
+	export class IgnoreRule {
+	    name: string;
+	    pattern: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IgnoreRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pattern = source["pattern"];
+	    }
+	}
 
 }
 
-export namespace loganalyzer {
+export namespace fetch {
 	
-	export class ValueFrequency {
-	    value: string;
-	    count: number;
+	export class Options {
+	    headers: Record<string, string>;
+	    timeoutSeconds: number;
+	    maxBytes: number;
+	    insecureSkipVerify: boolean;
+	    caFile: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ValueFrequency(source);
+	        return new Options(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.value = source["value"];
-	        this.count = source["count"];
+	        this.headers = source["headers"];
+	        this.timeoutSeconds = source["timeoutSeconds"];
+	        this.maxBytes = source["maxBytes"];
+	        this.insecureSkipVerify = source["insecureSkipVerify"];
+	        this.caFile = source["caFile"];
 	    }
 	}
-	export class PathSummary {
+
+}
+
+export namespace jsonpath {
+	
+	export class Match {
 	    path: string;
-	    count: number;
-	    objectHits: number;
-	    distinctCount: number;
-	    topValues: ValueFrequency[];
+	    value: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new PathSummary(source);
+	        return new Match(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.path = source["path"];
-	        this.count = source["count"];
-	        this.objectHits = source["objectHits"];
-	        this.distinctCount = source["distinctCount"];
-	        this.topValues = this.convertValues(source["topValues"], ValueFrequency);
+	        this.value = source["value"];
+	    }
+	}
+
+}
+
+export namespace jsonschema {
+	
+	export class Violation {
+	    instancePath: string;
+	    schemaPath: string;
+	    keyword: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Violation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.instancePath = source["instancePath"];
+	        this.schemaPath = source["schemaPath"];
+	        this.keyword = source["keyword"];
+	        this.message = source["message"];
+	    }
+	}
+	export class Result {
+	    valid: boolean;
+	    violations: Violation[];
+	    totalViolations: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.valid = source["valid"];
+	        this.violations = this.convertValues(source["violations"], Violation);
+	        this.totalViolations = source["totalViolations"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -145,26 +295,28 @@ export namespace loganalyzer {
 		    return a;
 		}
 	}
-	export class AnalysisResult {
-	    paths: PathSummary[];
-	    totalLines: number;
-	    jsonLines: number;
-	    skippedLines: number;
-	    totalPaths: number;
-	    totalPathOccurs: number;
+
+}
+
+export namespace jwt {
+	
+	export class Claim {
+	    name: string;
+	    description: string;
+	    value: any;
+	    // Go type: time
+	    time?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new AnalysisResult(source);
+	        return new Claim(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.paths = this.convertValues(source["paths"], PathSummary);
-	        this.totalLines = source["totalLines"];
-	        this.jsonLines = source["jsonLines"];
-	        this.skippedLines = source["skippedLines"];
-	        this.totalPaths = source["totalPaths"];
-	        this.totalPathOccurs = source["totalPathOccurs"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.value = source["value"];
+	        this.time = this.convertValues(source["time"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -185,54 +337,31 @@ export namespace loganalyzer {
 		    return a;
 		}
 	}
-	export class ComparisonStats {
-	    totalPaths: number;
-	    addedPaths: number;
-	    removedPaths: number;
-	    changedPaths: number;
-	    equalPaths: number;
-	    totalCountDelta: number;
-	    totalObjectsDelta: number;
-	    totalDistinctDelta: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new ComparisonStats(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.totalPaths = source["totalPaths"];
-	        this.addedPaths = source["addedPaths"];
-	        this.removedPaths = source["removedPaths"];
-	        this.changedPaths = source["changedPaths"];
-	        this.equalPaths = source["equalPaths"];
-	        this.totalCountDelta = source["totalCountDelta"];
-	        this.totalObjectsDelta = source["totalObjectsDelta"];
-	        this.totalDistinctDelta = source["totalDistinctDelta"];
-	    }
-	}
-	export class PathComparison {
-	    path: string;
-	    status: string;
-	    left?: PathSummary;
-	    right?: PathSummary;
-	    countDelta: number;
-	    objectsDelta: number;
-	    distinctDelta: number;
+	export class Token {
+	    header: string;
+	    payload: string;
+	    algorithm: string;
+	    signed: boolean;
+	    claims: Claim[];
+	    // Go type: time
+	    expiresAt?: any;
+	    expired: boolean;
+	    warnings: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new PathComparison(source);
+	        return new Token(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.status = source["status"];
-	        this.left = this.convertValues(source["left"], PathSummary);
-	        this.right = this.convertValues(source["right"], PathSummary);
-	        this.countDelta = source["countDelta"];
-	        this.objectsDelta = source["objectsDelta"];
-	        this.distinctDelta = source["distinctDelta"];
+	        this.header = source["header"];
+	        this.payload = source["payload"];
+	        this.algorithm = source["algorithm"];
+	        this.signed = source["signed"];
+	        this.claims = this.convertValues(source["claims"], Claim);
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
+	        this.expired = source["expired"];
+	        this.warnings = source["warnings"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -253,22 +382,45 @@ export namespace loganalyzer {
 		    return a;
 		}
 	}
-	export class ComparisonResult {
-	    comparisons: PathComparison[];
-	    stats: ComparisonStats;
-	    leftFile: string;
-	    rightFile: string;
+
+}
+
+export namespace loganalyzer {
+	
+	export class ParseFailure {
+	    file?: string;
+	    line: number;
+	    class: string;
+	    error: string;
+	    text: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ComparisonResult(source);
+	        return new ParseFailure(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.comparisons = this.convertValues(source["comparisons"], PathComparison);
-	        this.stats = this.convertValues(source["stats"], ComparisonStats);
-	        this.leftFile = source["leftFile"];
-	        this.rightFile = source["rightFile"];
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.class = source["class"];
+	        this.error = source["error"];
+	        this.text = source["text"];
+	    }
+	}
+	export class ParseFailureReport {
+	    byClass: Record<string, number>;
+	    failures: ParseFailure[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ParseFailureReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.byClass = source["byClass"];
+	        this.failures = this.convertValues(source["failures"], ParseFailure);
+	        this.total = source["total"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -289,40 +441,48 @@ export namespace loganalyzer {
 		    return a;
 		}
 	}
-	
-	
-	
-
-}
-
-export namespace main {
-	
-	export class FileResult {
+	export class ViolationGroup {
 	    path: string;
-	    content: string;
+	    keyword: string;
+	    count: number;
+	    documents: number;
+	    firstLine: number;
+	    example: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileResult(source);
+	        return new ViolationGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.path = source["path"];
-	        this.content = source["content"];
+	        this.keyword = source["keyword"];
+	        this.count = source["count"];
+	        this.documents = source["documents"];
+	        this.firstLine = source["firstLine"];
+	        this.example = source["example"];
 	    }
 	}
-	export class LogFileResult {
-	    path: string;
-	    result?: loganalyzer.AnalysisResult;
+	export class ValidationReport {
+	    documents: number;
+	    invalidDocuments: number;
+	    byPath: ViolationGroup[];
+	    violations: SchemaViolation[];
+	    totalViolations: number;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LogFileResult(source);
+	        return new ValidationReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.result = this.convertValues(source["result"], loganalyzer.AnalysisResult);
+	        this.documents = source["documents"];
+	        this.invalidDocuments = source["invalidDocuments"];
+	        this.byPath = this.convertValues(source["byPath"], ViolationGroup);
+	        this.violations = this.convertValues(source["violations"], SchemaViolation);
+	        this.totalViolations = source["totalViolations"];
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -343,61 +503,62 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class NormalizeOptions {
-	    sortKeys: boolean;
-	    normalizeNumbers: boolean;
-	    trimStrings: boolean;
-	    nullEqualsAbsent: boolean;
-	    sortArrays: boolean;
-	    sortArraysByKey: string;
+	export class SchemaViolation {
+	    file?: string;
+	    line: number;
+	    stream: string;
+	    path: string;
+	    message: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new NormalizeOptions(source);
+	        return new SchemaViolation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sortKeys = source["sortKeys"];
-	        this.normalizeNumbers = source["normalizeNumbers"];
-	        this.trimStrings = source["trimStrings"];
-	        this.nullEqualsAbsent = source["nullEqualsAbsent"];
-	        this.sortArrays = source["sortArrays"];
-	        this.sortArraysByKey = source["sortArraysByKey"];
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.stream = source["stream"];
+	        this.path = source["path"];
+	        this.message = source["message"];
 	    }
 	}
-
-}
-
-export namespace paths {
-	
-	export class PathInfo {
-	    path: string;
-	    count: number;
+	export class SingerStream {
+	    stream: string;
+	    records: number;
+	    schemas: number;
+	    invalidRecords: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new PathInfo(source);
+	        return new SingerStream(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.count = source["count"];
+	        this.stream = source["stream"];
+	        this.records = source["records"];
+	        this.schemas = source["schemas"];
+	        this.invalidRecords = source["invalidRecords"];
 	    }
 	}
-	export class PathResult {
-	    paths: PathInfo[];
-	    totalPaths: number;
-	    totalLeafs: number;
+	export class SingerReport {
+	    messageCounts: Record<string, number>;
+	    unclassified: number;
+	    streams: SingerStream[];
+	    violations: SchemaViolation[];
+	    totalViolations: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new PathResult(source);
+	        return new SingerReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.paths = this.convertValues(source["paths"], PathInfo);
-	        this.totalPaths = source["totalPaths"];
-	        this.totalLeafs = source["totalLeafs"];
+	        this.messageCounts = source["messageCounts"];
+	        this.unclassified = source["unclassified"];
+	        this.streams = this.convertValues(source["streams"], SingerStream);
+	        this.violations = this.convertValues(source["violations"], SchemaViolation);
+	        this.totalViolations = source["totalViolations"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -418,6 +579,1690 @@ export namespace paths {
 		    return a;
 		}
 	}
+	export class Anomaly {
+	    kind: string;
+	    message: string;
+	    values?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Anomaly(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.values = source["values"];
+	    }
+	}
+	export class PIIMatch {
+	    kind: string;
+	    confidence: string;
+	    matches: number;
+	    share: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PIIMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.confidence = source["confidence"];
+	        this.matches = source["matches"];
+	        this.share = source["share"];
+	    }
+	}
+	export class NumberStats {
+	    count: number;
+	    min: number;
+	    max: number;
+	    mean: number;
+	    stdDev: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NumberStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.min = source["min"];
+	        this.max = source["max"];
+	        this.mean = source["mean"];
+	        this.stdDev = source["stdDev"];
+	    }
+	}
+	export class StringStats {
+	    count: number;
+	    minLength: number;
+	    maxLength: number;
+	    avgLength: number;
+	    formats?: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new StringStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.minLength = source["minLength"];
+	        this.maxLength = source["maxLength"];
+	        this.avgLength = source["avgLength"];
+	        this.formats = source["formats"];
+	    }
+	}
+	export class ValueFrequency {
+	    value: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueFrequency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.count = source["count"];
+	    }
+	}
+	export class PathSummary {
+	    path: string;
+	    count: number;
+	    objectHits: number;
+	    presence: number;
+	    distinctCount: number;
+	    topValues: ValueFrequency[];
+	    types: Record<string, number>;
+	    strings?: StringStats;
+	    numbers?: NumberStats;
+	    pii?: PIIMatch;
+	    nulls: number;
+	    empties: number;
+	    emptyPct: number;
+	    firstSeenLine: number;
+	    lastSeenLine: number;
+	    firstSeenFile?: string;
+	    lastSeenFile?: string;
+	    appeared: boolean;
+	    disappeared: boolean;
+	    exampleLines: number[];
+	    anomalies?: Anomaly[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PathSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.count = source["count"];
+	        this.objectHits = source["objectHits"];
+	        this.presence = source["presence"];
+	        this.distinctCount = source["distinctCount"];
+	        this.topValues = this.convertValues(source["topValues"], ValueFrequency);
+	        this.types = source["types"];
+	        this.strings = this.convertValues(source["strings"], StringStats);
+	        this.numbers = this.convertValues(source["numbers"], NumberStats);
+	        this.pii = this.convertValues(source["pii"], PIIMatch);
+	        this.nulls = source["nulls"];
+	        this.empties = source["empties"];
+	        this.emptyPct = source["emptyPct"];
+	        this.firstSeenLine = source["firstSeenLine"];
+	        this.lastSeenLine = source["lastSeenLine"];
+	        this.firstSeenFile = source["firstSeenFile"];
+	        this.lastSeenFile = source["lastSeenFile"];
+	        this.appeared = source["appeared"];
+	        this.disappeared = source["disappeared"];
+	        this.exampleLines = source["exampleLines"];
+	        this.anomalies = this.convertValues(source["anomalies"], Anomaly);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AnalysisResult {
+	    paths: PathSummary[];
+	    totalLines: number;
+	    jsonLines: number;
+	    skippedLines: number;
+	    totalPaths: number;
+	    totalPathOccurs: number;
+	    schema?: paths.Schema;
+	    approximate: boolean;
+	    singer?: SingerReport;
+	    validation?: ValidationReport;
+	    parseFailures?: ParseFailureReport;
+	    encoding?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnalysisResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.paths = this.convertValues(source["paths"], PathSummary);
+	        this.totalLines = source["totalLines"];
+	        this.jsonLines = source["jsonLines"];
+	        this.skippedLines = source["skippedLines"];
+	        this.totalPaths = source["totalPaths"];
+	        this.totalPathOccurs = source["totalPathOccurs"];
+	        this.schema = this.convertValues(source["schema"], paths.Schema);
+	        this.approximate = source["approximate"];
+	        this.singer = this.convertValues(source["singer"], SingerReport);
+	        this.validation = this.convertValues(source["validation"], ValidationReport);
+	        this.parseFailures = this.convertValues(source["parseFailures"], ParseFailureReport);
+	        this.encoding = source["encoding"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class FileSummary {
+	    path: string;
+	    totalLines: number;
+	    jsonLines: number;
+	    skippedLines: number;
+	    totalPaths: number;
+	    totalPathOccurs: number;
+	    encoding: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.totalLines = source["totalLines"];
+	        this.jsonLines = source["jsonLines"];
+	        this.skippedLines = source["skippedLines"];
+	        this.totalPaths = source["totalPaths"];
+	        this.totalPathOccurs = source["totalPathOccurs"];
+	        this.encoding = source["encoding"];
+	    }
+	}
+	export class BatchResult {
+	    combined?: AnalysisResult;
+	    files: FileSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BatchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.combined = this.convertValues(source["combined"], AnalysisResult);
+	        this.files = this.convertValues(source["files"], FileSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompareOptions {
+	    changeThreshold: number;
+	    normalizeVolume: boolean;
+	    ignorePaths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CompareOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.changeThreshold = source["changeThreshold"];
+	        this.normalizeVolume = source["normalizeVolume"];
+	        this.ignorePaths = source["ignorePaths"];
+	    }
+	}
+	export class ComparisonStats {
+	    totalPaths: number;
+	    addedPaths: number;
+	    removedPaths: number;
+	    changedPaths: number;
+	    equalPaths: number;
+	    totalCountDelta: number;
+	    totalObjectsDelta: number;
+	    totalDistinctDelta: number;
+	    ignoredPaths: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparisonStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalPaths = source["totalPaths"];
+	        this.addedPaths = source["addedPaths"];
+	        this.removedPaths = source["removedPaths"];
+	        this.changedPaths = source["changedPaths"];
+	        this.equalPaths = source["equalPaths"];
+	        this.totalCountDelta = source["totalCountDelta"];
+	        this.totalObjectsDelta = source["totalObjectsDelta"];
+	        this.totalDistinctDelta = source["totalDistinctDelta"];
+	        this.ignoredPaths = source["ignoredPaths"];
+	    }
+	}
+	export class ValueChange {
+	    value: string;
+	    status: string;
+	    leftCount: number;
+	    rightCount: number;
+	    leftShare: number;
+	    rightShare: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.status = source["status"];
+	        this.leftCount = source["leftCount"];
+	        this.rightCount = source["rightCount"];
+	        this.leftShare = source["leftShare"];
+	        this.rightShare = source["rightShare"];
+	    }
+	}
+	export class PathComparison {
+	    path: string;
+	    status: string;
+	    left?: PathSummary;
+	    right?: PathSummary;
+	    countDelta: number;
+	    objectsDelta: number;
+	    distinctDelta: number;
+	    countDeltaPct?: number;
+	    objectsDeltaPct?: number;
+	    distinctDeltaPct?: number;
+	    valueChanges?: ValueChange[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PathComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.left = this.convertValues(source["left"], PathSummary);
+	        this.right = this.convertValues(source["right"], PathSummary);
+	        this.countDelta = source["countDelta"];
+	        this.objectsDelta = source["objectsDelta"];
+	        this.distinctDelta = source["distinctDelta"];
+	        this.countDeltaPct = source["countDeltaPct"];
+	        this.objectsDeltaPct = source["objectsDeltaPct"];
+	        this.distinctDeltaPct = source["distinctDeltaPct"];
+	        this.valueChanges = this.convertValues(source["valueChanges"], ValueChange);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ComparisonResult {
+	    comparisons: PathComparison[];
+	    stats: ComparisonStats;
+	    leftFile: string;
+	    rightFile: string;
+	    volumeScale?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparisonResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.comparisons = this.convertValues(source["comparisons"], PathComparison);
+	        this.stats = this.convertValues(source["stats"], ComparisonStats);
+	        this.leftFile = source["leftFile"];
+	        this.rightFile = source["rightFile"];
+	        this.volumeScale = source["volumeScale"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class Options {
+	    approximate: boolean;
+	    topN: number;
+	    singer: boolean;
+	    embeddedJSON: boolean;
+	    maxLineSize: number;
+	    pathFormat: string;
+	    schema?: any;
+	    decoder?: string;
+	    csvColumn?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.approximate = source["approximate"];
+	        this.topN = source["topN"];
+	        this.singer = source["singer"];
+	        this.embeddedJSON = source["embeddedJSON"];
+	        this.maxLineSize = source["maxLineSize"];
+	        this.pathFormat = source["pathFormat"];
+	        this.schema = source["schema"];
+	        this.decoder = source["decoder"];
+	        this.csvColumn = source["csvColumn"];
+	    }
+	}
+	
+	
+	
+	
+	
+	
+	
+	
+	export class SourceRecord {
+	    line: number;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.text = source["text"];
+	    }
+	}
+	
+	
+	
+	
+
+}
+
+export namespace main {
+	
+	export class AppDataImportOptions {
+	    profiles: boolean;
+	    history: boolean;
+	    layout: boolean;
+	    settings: boolean;
+	    conflict: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppDataImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profiles = source["profiles"];
+	        this.history = source["history"];
+	        this.layout = source["layout"];
+	        this.settings = source["settings"];
+	        this.conflict = source["conflict"];
+	    }
+	}
+	export class AppDataImportResult {
+	    profiles?: storage.MergeResult;
+	    historyAdded: number;
+	    layout: boolean;
+	    settings: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppDataImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.profiles = this.convertValues(source["profiles"], storage.MergeResult);
+	        this.historyAdded = source["historyAdded"];
+	        this.layout = source["layout"];
+	        this.settings = source["settings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AppDataSummary {
+	    // Go type: time
+	    exported: any;
+	    profiles: string[];
+	    conflicts: string[];
+	    historyPaths: number;
+	    hasLayout: boolean;
+	    hasSettings: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppDataSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exported = this.convertValues(source["exported"], null);
+	        this.profiles = source["profiles"];
+	        this.conflicts = source["conflicts"];
+	        this.historyPaths = source["historyPaths"];
+	        this.hasLayout = source["hasLayout"];
+	        this.hasSettings = source["hasSettings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ClipboardJSON {
+	    json: string;
+	    extracted: boolean;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClipboardJSON(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.json = source["json"];
+	        this.extracted = source["extracted"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ConfigStatus {
+	    file: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConfigStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.error = source["error"];
+	    }
+	}
+	export class FileResult {
+	    path: string;
+	    content: string;
+	    encoding: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.content = source["content"];
+	        this.encoding = source["encoding"];
+	    }
+	}
+	export class LogFileResult {
+	    path: string;
+	    result?: loganalyzer.AnalysisResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogFileResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.result = this.convertValues(source["result"], loganalyzer.AnalysisResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NormalizationReport {
+	    counts: Record<string, number>;
+	    changes: normalize.Change[];
+	    summary: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NormalizationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.counts = source["counts"];
+	        this.changes = this.convertValues(source["changes"], normalize.Change);
+	        this.summary = source["summary"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NormalizeOverride {
+	    path: string;
+	    options: NormalizeOptions;
+	    set: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NormalizeOverride(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.options = this.convertValues(source["options"], NormalizeOptions);
+	        this.set = source["set"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NormalizeOptions {
+	    sortKeys: boolean;
+	    normalizeNumbers: boolean;
+	    roundNumbers: number;
+	    trimStrings: boolean;
+	    nullEqualsAbsent: boolean;
+	    sortArrays: boolean;
+	    sortArraysByKey: string;
+	    sortArraysByPath: Record<string, string>;
+	    dateInputLayouts: string[];
+	    dateOutputLayout: string;
+	    normalizeUrls: boolean;
+	    dropUrlParams: string[];
+	    normalizeUuids: boolean;
+	    unicodeNormalization: string;
+	    coerceBooleans: boolean;
+	    coerceNumbers: boolean;
+	    nullStrings: string[];
+	    localeDecimalSeparator: string;
+	    localeThousandsSeparator: string;
+	    overrides: NormalizeOverride[];
+	    transforms: normalize.TransformRule[];
+	    excludeKeys: string[];
+	    excludePaths: string[];
+	    redactPaths: string[];
+	    redactSalt: string;
+	    ignorePaths: diff.IgnoreRule[];
+	    summarizeArraysOver: number;
+	    pathFormat: string;
+	    lenientLeft: boolean;
+	    lenientRight: boolean;
+	    schema: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NormalizeOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sortKeys = source["sortKeys"];
+	        this.normalizeNumbers = source["normalizeNumbers"];
+	        this.roundNumbers = source["roundNumbers"];
+	        this.trimStrings = source["trimStrings"];
+	        this.nullEqualsAbsent = source["nullEqualsAbsent"];
+	        this.sortArrays = source["sortArrays"];
+	        this.sortArraysByKey = source["sortArraysByKey"];
+	        this.sortArraysByPath = source["sortArraysByPath"];
+	        this.dateInputLayouts = source["dateInputLayouts"];
+	        this.dateOutputLayout = source["dateOutputLayout"];
+	        this.normalizeUrls = source["normalizeUrls"];
+	        this.dropUrlParams = source["dropUrlParams"];
+	        this.normalizeUuids = source["normalizeUuids"];
+	        this.unicodeNormalization = source["unicodeNormalization"];
+	        this.coerceBooleans = source["coerceBooleans"];
+	        this.coerceNumbers = source["coerceNumbers"];
+	        this.nullStrings = source["nullStrings"];
+	        this.localeDecimalSeparator = source["localeDecimalSeparator"];
+	        this.localeThousandsSeparator = source["localeThousandsSeparator"];
+	        this.overrides = this.convertValues(source["overrides"], NormalizeOverride);
+	        this.transforms = this.convertValues(source["transforms"], normalize.TransformRule);
+	        this.excludeKeys = source["excludeKeys"];
+	        this.excludePaths = source["excludePaths"];
+	        this.redactPaths = source["redactPaths"];
+	        this.redactSalt = source["redactSalt"];
+	        this.ignorePaths = this.convertValues(source["ignorePaths"], diff.IgnoreRule);
+	        this.summarizeArraysOver = source["summarizeArraysOver"];
+	        this.pathFormat = source["pathFormat"];
+	        this.lenientLeft = source["lenientLeft"];
+	        this.lenientRight = source["lenientRight"];
+	        this.schema = source["schema"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class OpenedFiles {
+	    tab: string;
+	    files: FileResult[];
+	    skipped?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenedFiles(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tab = source["tab"];
+	        this.files = this.convertValues(source["files"], FileResult);
+	        this.skipped = source["skipped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace monitor {
+	
+	export class Source {
+	    url?: string;
+	    file?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Source(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.file = source["file"];
+	    }
+	}
+	export class Job {
+	    id: string;
+	    name: string;
+	    left: Source;
+	    right: Source;
+	    interval: string;
+	    paused: boolean;
+	    options?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Job(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.left = this.convertValues(source["left"], Source);
+	        this.right = this.convertValues(source["right"], Source);
+	        this.interval = source["interval"];
+	        this.paused = source["paused"];
+	        this.options = source["options"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Run {
+	    // Go type: time
+	    time: any;
+	    error?: string;
+	    stats: diff.DiffStats;
+	    differences: number;
+	    fingerprint?: string;
+	    changed: boolean;
+	    result?: diff.DiffResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new Run(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = this.convertValues(source["time"], null);
+	        this.error = source["error"];
+	        this.stats = this.convertValues(source["stats"], diff.DiffStats);
+	        this.differences = source["differences"];
+	        this.fingerprint = source["fingerprint"];
+	        this.changed = source["changed"];
+	        this.result = this.convertValues(source["result"], diff.DiffResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace normalize {
+	
+	export class TransformRule {
+	    path: string;
+	    kind: string;
+	    n: number;
+	    pattern: string;
+	    replacement: string;
+	    value: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TransformRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.kind = source["kind"];
+	        this.n = source["n"];
+	        this.pattern = source["pattern"];
+	        this.replacement = source["replacement"];
+	        this.value = source["value"];
+	    }
+	}
+
+}
+
+export namespace paths {
+	
+	export class ExtractOptions {
+	    includeContainers: boolean;
+	    sampleValues: number;
+	    indexedArrays: boolean;
+	    includePatterns: string[];
+	    excludePatterns: string[];
+	    excludeKeys: string[];
+	    excludePaths: string[];
+	    maxPaths: number;
+	    maxDepth: number;
+	    format: string;
+	    tree: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExtractOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.includeContainers = source["includeContainers"];
+	        this.sampleValues = source["sampleValues"];
+	        this.indexedArrays = source["indexedArrays"];
+	        this.includePatterns = source["includePatterns"];
+	        this.excludePatterns = source["excludePatterns"];
+	        this.excludeKeys = source["excludeKeys"];
+	        this.excludePaths = source["excludePaths"];
+	        this.maxPaths = source["maxPaths"];
+	        this.maxDepth = source["maxDepth"];
+	        this.format = source["format"];
+	        this.tree = source["tree"];
+	    }
+	}
+	export class Segment {
+	    key?: string;
+	    index?: number;
+	    isIndex: boolean;
+	    isAll: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Segment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.index = source["index"];
+	        this.isIndex = source["isIndex"];
+	        this.isAll = source["isAll"];
+	    }
+	}
+	export class PathExpressions {
+	    path: string;
+	    segments: Segment[];
+	    jq: string;
+	    javascript: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PathExpressions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.segments = this.convertValues(source["segments"], Segment);
+	        this.jq = source["jq"];
+	        this.javascript = source["javascript"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PathInfo {
+	    path: string;
+	    count: number;
+	    depth: number;
+	    presence?: number;
+	    types: Record<string, number>;
+	    samples?: any[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PathInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.count = source["count"];
+	        this.depth = source["depth"];
+	        this.presence = source["presence"];
+	        this.types = source["types"];
+	        this.samples = source["samples"];
+	    }
+	}
+	export class PathNode {
+	    segment: string;
+	    path: string;
+	    info?: PathInfo;
+	    children?: PathNode[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PathNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.segment = source["segment"];
+	        this.path = source["path"];
+	        this.info = this.convertValues(source["info"], PathInfo);
+	        this.children = this.convertValues(source["children"], PathNode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PathResult {
+	    paths: PathInfo[];
+	    totalPaths: number;
+	    totalLeafs: number;
+	    maxDepth: number;
+	    depthCounts: Record<number, number>;
+	    deepestPath: string;
+	    truncated: boolean;
+	    tree?: PathNode;
+	
+	    static createFrom(source: any = {}) {
+	        return new PathResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.paths = this.convertValues(source["paths"], PathInfo);
+	        this.totalPaths = source["totalPaths"];
+	        this.totalLeafs = source["totalLeafs"];
+	        this.maxDepth = source["maxDepth"];
+	        this.depthCounts = source["depthCounts"];
+	        this.deepestPath = source["deepestPath"];
+	        this.truncated = source["truncated"];
+	        this.tree = this.convertValues(source["tree"], PathNode);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Row {
+	    path: string;
+	    value: any;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Row(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.value = source["value"];
+	        this.type = source["type"];
+	    }
+	}
+	export class Schema {
+	    $schema?: string;
+	    type?: any;
+	    format?: string;
+	    properties?: Record<string, Schema>;
+	    required?: string[];
+	    items?: Schema;
+	
+	    static createFrom(source: any = {}) {
+	        return new Schema(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.$schema = source["$schema"];
+	        this.type = source["type"];
+	        this.format = source["format"];
+	        this.properties = this.convertValues(source["properties"], Schema, true);
+	        this.required = source["required"];
+	        this.items = this.convertValues(source["items"], Schema);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ShapeChange {
+	    path: string;
+	    status: string;
+	    leftTypes?: string[];
+	    rightTypes?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.leftTypes = source["leftTypes"];
+	        this.rightTypes = source["rightTypes"];
+	    }
+	}
+	export class ShapeDiff {
+	    changes: ShapeChange[];
+	    added: number;
+	    removed: number;
+	    typeChanged: number;
+	    equal: number;
+	    identical: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShapeDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.changes = this.convertValues(source["changes"], ShapeChange);
+	        this.added = source["added"];
+	        this.removed = source["removed"];
+	        this.typeChanged = source["typeChanged"];
+	        this.equal = source["equal"];
+	        this.identical = source["identical"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace pretty {
+	
+	export class Options {
+	    indent: number;
+	    useTabs: boolean;
+	    sortKeys: boolean;
+	    escapeNonAscii: boolean;
+	    compactArraysUnder: number;
+	    lineWidth: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.indent = source["indent"];
+	        this.useTabs = source["useTabs"];
+	        this.sortKeys = source["sortKeys"];
+	        this.escapeNonAscii = source["escapeNonAscii"];
+	        this.compactArraysUnder = source["compactArraysUnder"];
+	        this.lineWidth = source["lineWidth"];
+	    }
+	}
+
+}
+
+export namespace report {
+	
+	export class Options {
+	    title: string;
+	    leftLabel: string;
+	    rightLabel: string;
+	    topN: number;
+	    notes: string;
+	    signOff: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.leftLabel = source["leftLabel"];
+	        this.rightLabel = source["rightLabel"];
+	        this.topN = source["topN"];
+	        this.notes = source["notes"];
+	        this.signOff = source["signOff"];
+	    }
+	}
+
+}
+
+export namespace search {
+	
+	export class DiffMatch {
+	    path: string;
+	    node: string;
+	    type: string;
+	    side: string;
+	    in: string;
+	    text: string;
+	    start: number;
+	    end: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.node = source["node"];
+	        this.type = source["type"];
+	        this.side = source["side"];
+	        this.in = source["in"];
+	        this.text = source["text"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class DiffResult {
+	    matches: DiffMatch[];
+	    total: number;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.matches = this.convertValues(source["matches"], DiffMatch);
+	        this.total = source["total"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Match {
+	    path: string;
+	    value: any;
+	    in: string;
+	    text: string;
+	    start: number;
+	    end: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Match(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.value = source["value"];
+	        this.in = source["in"];
+	        this.text = source["text"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class Options {
+	    in: string;
+	    maxMatches: number;
+	    literal: boolean;
+	    ignoreCase: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Options(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.in = source["in"];
+	        this.maxMatches = source["maxMatches"];
+	        this.literal = source["literal"];
+	        this.ignoreCase = source["ignoreCase"];
+	    }
+	}
+	export class Result {
+	    matches: Match[];
+	    total: number;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.matches = this.convertValues(source["matches"], Match);
+	        this.total = source["total"];
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace storage {
+	
+	export class WindowState {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	    maximised: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.maximised = source["maximised"];
+	    }
+	}
+	export class Layout {
+	    window: WindowState;
+	    tab: string;
+	    splitters: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Layout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.window = this.convertValues(source["window"], WindowState);
+	        this.tab = source["tab"];
+	        this.splitters = source["splitters"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MergeResult {
+	    added: string[];
+	    unchanged: string[];
+	    replaced: string[];
+	    renamed: Record<string, string>;
+	    skipped: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.unchanged = source["unchanged"];
+	        this.replaced = source["replaced"];
+	        this.renamed = source["renamed"];
+	        this.skipped = source["skipped"];
+	    }
+	}
+	export class Session {
+	    // Go type: time
+	    saved: any;
+	    tab: string;
+	    panes: Record<string, string>;
+	    options?: Record<string, any>;
+	    files: string[];
+	    dropped?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Session(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.saved = this.convertValues(source["saved"], null);
+	        this.tab = source["tab"];
+	        this.panes = source["panes"];
+	        this.options = source["options"];
+	        this.files = source["files"];
+	        this.dropped = source["dropped"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace undo {
+	
+	export class Snapshot {
+	    content: string;
+	    action: string;
+	    // Go type: time
+	    time: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Snapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.content = source["content"];
+	        this.action = source["action"];
+	        this.time = this.convertValues(source["time"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class State {
+	    canUndo: boolean;
+	    canRedo: boolean;
+	    undoAction: string;
+	    redoAction: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new State(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.canUndo = source["canUndo"];
+	        this.canRedo = source["canRedo"];
+	        this.undoAction = source["undoAction"];
+	        this.redoAction = source["redoAction"];
+	    }
+	}
+
+}
+
+export namespace unwrap {
+	
+	export class Result {
+	    text: string;
+	    isJSON: boolean;
+	    gzipped: boolean;
+	    binary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.isJSON = source["isJSON"];
+	        this.gzipped = source["gzipped"];
+	        this.binary = source["binary"];
+	    }
+	}
 
 }
 
@@ -0,0 +1,35 @@
+// Package bookmark keeps access to user-picked files across sessions under
+// the Mac App Store sandbox.
+//
+// A sandboxed app may only read files the user chose in an open dialog, and
+// only until it quits: a path saved in the file history can't be opened
+// after a restart. A security-scoped bookmark, created while access is
+// granted, restores it in a later session. The app needs the
+// com.apple.security.files.bookmarks.app-scope entitlement.
+//
+// Bookmarks only exist on macOS; elsewhere every function returns
+// ErrUnsupported and plain paths work as they are.
+package bookmark
+
+import "errors"
+
+// ErrUnsupported is returned on platforms without security-scoped
+// bookmarks.
+var ErrUnsupported = errors.New("security-scoped bookmarks are only supported on macOS")
+
+// Access is a file reached through a bookmark. The file stays readable
+// until Close.
+type Access struct {
+	Path  string // Where the file is now; it may have moved since the bookmark was created
+	Stale bool   // The bookmark is outdated and should be created again from Path
+
+	release func()
+}
+
+// Close gives up access to the file.
+func (a *Access) Close() {
+	if a.release != nil {
+		a.release()
+		a.release = nil
+	}
+}
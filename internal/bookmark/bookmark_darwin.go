@@ -0,0 +1,114 @@
+//go:build darwin && cgo
+
+package bookmark
+
+/*
+#cgo CFLAGS: -x objective-c -fobjc-arc
+#cgo LDFLAGS: -framework Foundation
+
+#import <Foundation/Foundation.h>
+#include <stdlib.h>
+#include <string.h>
+
+// createBookmark returns a security-scoped bookmark for path, or NULL with
+// *err set. The caller frees the result and *err.
+static void *createBookmark(const char *path, int *length, char **err) {
+	@autoreleasepool {
+		NSURL *url = [NSURL fileURLWithPath:[NSString stringWithUTF8String:path]];
+		NSError *error = nil;
+		NSData *data = [url bookmarkDataWithOptions:NSURLBookmarkCreationWithSecurityScope
+		             includingResourceValuesForKeys:nil
+		                              relativeToURL:nil
+		                                      error:&error];
+		if (data == nil) {
+			*err = strdup(error.localizedDescription.UTF8String);
+			return NULL;
+		}
+		void *bytes = malloc(data.length);
+		memcpy(bytes, data.bytes, data.length);
+		*length = (int)data.length;
+		return bytes;
+	}
+}
+
+// resolveBookmark resolves a bookmark and starts accessing its file. It
+// returns the URL, retained, for stopAccessing, or NULL with *err set. The
+// caller frees *path and *err.
+static void *resolveBookmark(const void *bytes, int length, char **path, int *stale, int *accessing, char **err) {
+	@autoreleasepool {
+		NSData *data = [NSData dataWithBytes:bytes length:length];
+		BOOL isStale = NO;
+		NSError *error = nil;
+		NSURL *url = [NSURL URLByResolvingBookmarkData:data
+		                                       options:NSURLBookmarkResolutionWithSecurityScope
+		                                 relativeToURL:nil
+		                           bookmarkDataIsStale:&isStale
+		                                         error:&error];
+		if (url == nil) {
+			*err = strdup(error.localizedDescription.UTF8String);
+			return NULL;
+		}
+		// Outside the sandbox access isn't needed and this returns NO
+		*accessing = [url startAccessingSecurityScopedResource];
+		*stale = isStale;
+		*path = strdup(url.path.UTF8String);
+		return (__bridge_retained void *)url;
+	}
+}
+
+// stopAccessing releases a URL from resolveBookmark.
+static void stopAccessing(void *handle, int accessing) {
+	NSURL *url = (__bridge_transfer NSURL *)handle;
+	if (accessing) {
+		[url stopAccessingSecurityScopedResource];
+	}
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Create returns a security-scoped bookmark for path. Call it while the
+// app has access, e.g. right after the user picked the file.
+func Create(path string) ([]byte, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var length C.int
+	var cErr *C.char
+	bytes := C.createBookmark(cPath, &length, &cErr)
+	if bytes == nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(bytes)
+	return C.GoBytes(bytes, length), nil
+}
+
+// Resolve finds the file a bookmark from Create refers to and starts
+// accessing it.
+func Resolve(data []byte) (*Access, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty bookmark")
+	}
+	cData := C.CBytes(data)
+	defer C.free(cData)
+
+	var cPath, cErr *C.char
+	var stale, accessing C.int
+	handle := C.resolveBookmark(cData, C.int(len(data)), &cPath, &stale, &accessing, &cErr)
+	if handle == nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(unsafe.Pointer(cPath))
+
+	return &Access{
+		Path:    C.GoString(cPath),
+		Stale:   stale != 0,
+		release: func() { C.stopAccessing(handle, accessing) },
+	}, nil
+}
@@ -0,0 +1,14 @@
+//go:build !darwin || !cgo
+
+package bookmark
+
+// Create returns a security-scoped bookmark for path.
+func Create(path string) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+// Resolve finds the file a bookmark from Create refers to and starts
+// accessing it.
+func Resolve(data []byte) (*Access, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,39 @@
+package bookmark
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := Create(path)
+	if errors.Is(err, ErrUnsupported) {
+		if _, err := Resolve([]byte("bookmark")); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Resolve: expected ErrUnsupported, got %v", err)
+		}
+		t.Skip("security-scoped bookmarks aren't supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	access, err := Resolve(data)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	defer access.Close()
+
+	// The temp directory may be reached through a symlink (/var → /private/var)
+	want, _ := filepath.EvalSymlinks(path)
+	got, _ := filepath.EvalSymlinks(access.Path)
+	if got != want {
+		t.Errorf("expected the bookmark to resolve to %s, got %s", want, access.Path)
+	}
+}
@@ -0,0 +1,227 @@
+// Package charset detects the text encoding of opened files and converts
+// them to UTF-8 for parsing.
+//
+// Files exported on Windows are often UTF-16 or start with a UTF-8 byte
+// order mark, which JSON parsers reject with confusing errors about
+// invalid characters. Detection looks for a byte order mark first, then
+// for the NUL bytes UTF-16 puts next to ASCII characters (every JSON
+// document starts with one), and falls back to Latin-1 when the content
+// isn't valid UTF-8.
+package charset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encodings reported by Detect and NewReader.
+const (
+	UTF8    = "UTF-8"
+	UTF8BOM = "UTF-8 with BOM"
+	UTF16LE = "UTF-16LE"
+	UTF16BE = "UTF-16BE"
+	Latin1  = "ISO-8859-1"
+)
+
+// sampleSize is how much of a stream NewReader looks at to detect its
+// encoding.
+const sampleSize = 64 * 1024
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+var errOddLength = errors.New("UTF-16 text has an odd number of bytes")
+
+// Detect returns the encoding of data, one of the constants above.
+func Detect(data []byte) string {
+	return detect(data, false)
+}
+
+// detect is Detect for a sample that may have been cut off in the middle
+// of a character if truncated is set.
+func detect(sample []byte, truncated bool) string {
+	switch {
+	case bytes.HasPrefix(sample, bomUTF8):
+		return UTF8BOM
+	case bytes.HasPrefix(sample, bomUTF16LE):
+		return UTF16LE
+	case bytes.HasPrefix(sample, bomUTF16BE):
+		return UTF16BE
+	case len(sample) >= 2 && sample[0] != 0 && sample[1] == 0:
+		return UTF16LE
+	case len(sample) >= 2 && sample[0] == 0 && sample[1] != 0:
+		return UTF16BE
+	}
+
+	if truncated {
+		// Drop a last character that doesn't fit in the sample
+		start := len(sample) - 1
+		for start > 0 && start > len(sample)-utf8.UTFMax && !utf8.RuneStart(sample[start]) {
+			start--
+		}
+		if start >= 0 && !utf8.FullRune(sample[start:]) {
+			sample = sample[:start]
+		}
+	}
+	if utf8.Valid(sample) {
+		return UTF8
+	}
+	return Latin1
+}
+
+// Decode converts data to UTF-8, dropping any byte order mark, and returns
+// it with the encoding that was detected.
+func Decode(data []byte) (string, string, error) {
+	enc := Detect(data)
+	if enc == UTF8 {
+		return string(data), enc, nil
+	}
+
+	// Decode with the encoding found in all of data: NewReader would
+	// detect it again from only the first 64KB, and miss Latin-1 bytes
+	// that come later
+	text, err := io.ReadAll(decoder(bufio.NewReader(bytes.NewReader(data)), data, enc))
+	if err != nil {
+		return "", "", err
+	}
+	return string(text), enc, nil
+}
+
+// NewReader detects the encoding of r from its first 64KB and returns a
+// reader of its content as UTF-8, without any byte order mark.
+func NewReader(r io.Reader) (io.Reader, string, error) {
+	br := bufio.NewReaderSize(r, sampleSize)
+	sample, err := br.Peek(sampleSize)
+	if err != nil && err != io.EOF {
+		return nil, "", err
+	}
+
+	enc := detect(sample, err == nil)
+	return decoder(br, sample, enc), enc, nil
+}
+
+// decoder returns a reader converting br, whose content is in encoding enc
+// and starts with sample, to UTF-8 without a byte order mark.
+func decoder(br *bufio.Reader, sample []byte, enc string) io.Reader {
+	switch enc {
+	case UTF8BOM:
+		br.Discard(len(bomUTF8))
+		return br
+	case UTF16LE, UTF16BE:
+		if bytes.HasPrefix(sample, bomUTF16LE) || bytes.HasPrefix(sample, bomUTF16BE) {
+			br.Discard(2)
+		}
+		u := &utf16Reader{r: br, order: binary.BigEndian}
+		if enc == UTF16LE {
+			u.order = binary.LittleEndian
+		}
+		return u
+	case Latin1:
+		return &latin1Reader{r: br}
+	default:
+		return br
+	}
+}
+
+// utf16Reader converts UTF-16 read from r to UTF-8. Unpaired surrogates
+// become U+FFFD.
+type utf16Reader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	in    [4096]byte
+	buf   []byte // Backing array of out, reused for every chunk
+	out   []byte // Converted text not yet returned
+	high  rune   // High surrogate waiting for its pair, or 0
+	err   error  // Returned once out is drained
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.out) == 0 {
+		if u.err != nil {
+			return 0, u.err
+		}
+		u.fill()
+	}
+	n := copy(p, u.out)
+	u.out = u.out[n:]
+	return n, nil
+}
+
+// fill converts the next chunk of r into out.
+func (u *utf16Reader) fill() {
+	u.out = u.buf[:0]
+	n, err := io.ReadFull(u.r, u.in[:])
+	for i := 0; i+1 < n; i += 2 {
+		u.decode(rune(u.order.Uint16(u.in[i:])))
+	}
+
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		if u.high != 0 {
+			u.out = utf8.AppendRune(u.out, utf8.RuneError)
+		}
+		u.err = io.EOF
+		if n%2 == 1 {
+			u.err = errOddLength
+		}
+	default:
+		u.err = err
+	}
+	u.buf = u.out[:0]
+}
+
+// decode appends the character ending with code unit c to out.
+func (u *utf16Reader) decode(c rune) {
+	if u.high != 0 {
+		r := utf16.DecodeRune(u.high, c)
+		u.high = 0
+		if r != utf8.RuneError {
+			u.out = utf8.AppendRune(u.out, r)
+			return
+		}
+		u.out = utf8.AppendRune(u.out, utf8.RuneError) // Unpaired high surrogate
+	}
+
+	switch {
+	case c >= 0xd800 && c < 0xdc00:
+		u.high = c
+	case utf16.IsSurrogate(c):
+		u.out = utf8.AppendRune(u.out, utf8.RuneError) // Unpaired low surrogate
+	default:
+		u.out = utf8.AppendRune(u.out, c)
+	}
+}
+
+// latin1Reader converts ISO-8859-1 read from r to UTF-8.
+type latin1Reader struct {
+	r   io.Reader
+	in  [4096]byte
+	buf []byte // Backing array of out, reused for every chunk
+	out []byte // Converted text not yet returned
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	if len(l.out) == 0 {
+		n, err := l.r.Read(l.in[:])
+		if n == 0 {
+			return 0, err
+		}
+		l.out = l.buf[:0]
+		for _, b := range l.in[:n] {
+			l.out = utf8.AppendRune(l.out, rune(b))
+		}
+		l.buf = l.out[:0]
+	}
+	n := copy(p, l.out)
+	l.out = l.out[n:]
+	return n, nil
+}
@@ -0,0 +1,108 @@
+package charset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, after bom.
+func encodeUTF16(s string, order binary.AppendByteOrder, bom []byte) []byte {
+	out := append([]byte{}, bom...)
+	for _, unit := range utf16.Encode([]rune(s)) {
+		out = order.AppendUint16(out, unit)
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	const text = `{"name": "café 😀"}`
+
+	tests := []struct {
+		name    string
+		input   []byte
+		wantEnc string
+		want    string
+	}{
+		{"UTF-8", []byte(text), UTF8, text},
+		{"UTF-8 with BOM", append([]byte{0xef, 0xbb, 0xbf}, text...), UTF8BOM, text},
+		{"UTF-16LE with BOM", encodeUTF16(text, binary.LittleEndian, []byte{0xff, 0xfe}), UTF16LE, text},
+		{"UTF-16BE with BOM", encodeUTF16(text, binary.BigEndian, []byte{0xfe, 0xff}), UTF16BE, text},
+		{"UTF-16LE without BOM", encodeUTF16(text, binary.LittleEndian, nil), UTF16LE, text},
+		{"UTF-16BE without BOM", encodeUTF16(text, binary.BigEndian, nil), UTF16BE, text},
+		{"Latin-1", []byte("{\"name\": \"caf\xe9\"}"), Latin1, `{"name": "café"}`},
+		{"unpaired surrogates", []byte{'"', 0, 0x00, 0xd8, '"', 0, 0x00, 0xdc, '"', 0}, UTF16LE, "\"�\"�\""},
+		{"empty", nil, UTF8, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enc, err := Decode(tt.input)
+			if err != nil {
+				t.Fatalf("Decode error: %v", err)
+			}
+			if enc != tt.wantEnc {
+				t.Errorf("encoding = %s, want %s", enc, tt.wantEnc)
+			}
+			if got != tt.want {
+				t.Errorf("Decode = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_OddLengthUTF16(t *testing.T) {
+	input := append(encodeUTF16(`{}`, binary.LittleEndian, []byte{0xff, 0xfe}), '\n')
+	if _, _, err := Decode(input); err == nil {
+		t.Error("expected an error for a truncated UTF-16 file")
+	}
+}
+
+func TestDecode_Latin1AfterSample(t *testing.T) {
+	// The only non-UTF-8 byte is past the 64KB NewReader detects from
+	input := append([]byte(`{"pad": "`+strings.Repeat("x", sampleSize)+`", "name": "caf`), "\xe9\"}"...)
+
+	got, enc, err := Decode(input)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if enc != Latin1 {
+		t.Errorf("encoding = %s, want %s", enc, Latin1)
+	}
+	if !strings.HasSuffix(got, `"name": "café"}`) {
+		t.Errorf("Decode = ...%q, want the é converted", got[len(got)-20:])
+	}
+}
+
+func TestNewReader_Large(t *testing.T) {
+	// Longer than the detection sample and the conversion chunks, with
+	// surrogate pairs and multi-byte characters straddling their ends
+	line := `{"emoji": "😀", "accent": "é"}` + "\n"
+	text := strings.Repeat(line, 5000)
+
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+		r, _, err := NewReader(bytes.NewReader(encodeUTF16(text, order, nil)))
+		if err != nil {
+			t.Fatalf("NewReader error: %v", err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("read error: %v", err)
+		}
+		if string(got) != text {
+			t.Errorf("%v: converted text differs from the original", order)
+		}
+	}
+
+	// A sample cut off in the middle of a character is still UTF-8
+	r, enc, err := NewReader(strings.NewReader(strings.Repeat("é", sampleSize)))
+	if err != nil || enc != UTF8 {
+		t.Fatalf("NewReader = %s, %v; want UTF-8", enc, err)
+	}
+	if got, _ := io.ReadAll(r); string(got) != strings.Repeat("é", sampleSize) {
+		t.Error("UTF-8 text was changed")
+	}
+}
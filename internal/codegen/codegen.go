@@ -0,0 +1,107 @@
+// Package codegen generates type definitions (Go structs, TypeScript
+// interfaces, SQL tables) from a JSON Schema inferred by the paths package.
+//
+// Working from the inferred schema rather than the raw document means the
+// same code handles a single payload and a whole JSONL file: a member is
+// optional when some objects lacked it, and nullable when it was null in
+// some samples.
+package codegen
+
+import (
+	"sort"
+
+	"jtool/internal/paths"
+)
+
+// schemaTypes returns the type names of a schema, sorted. Type is a string
+// or a list; a schema decoded from JSON has the list as []any.
+func schemaTypes(s *paths.Schema) []string {
+	if s == nil {
+		return nil
+	}
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = []string{t}
+	case []string:
+		types = append(types, t...)
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok {
+				types = append(types, name)
+			}
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// splitNull separates "null" from the other types of a schema.
+func splitNull(types []string) (rest []string, nullable bool) {
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	return rest, nullable
+}
+
+// sortedKeys returns the property names of an object schema in order.
+func sortedKeys(props map[string]*paths.Schema) []string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isRequired reports whether key is listed in the schema's required members.
+func isRequired(s *paths.Schema, key string) bool {
+	for _, r := range s.Required {
+		if r == key {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingType is a named object type waiting to be written out.
+type pendingType struct {
+	name   string
+	schema *paths.Schema
+}
+
+// typeNamer names the object types found while generating code and queues
+// them to be written after the type that refers to them.
+type typeNamer struct {
+	names nameSet       // Type names used so far
+	queue []pendingType // Object types to write, in order of discovery
+}
+
+func newTypeNamer(rootName string) *typeNamer {
+	n := &typeNamer{names: nameSet{}}
+	n.names.unique(rootName)
+	return n
+}
+
+// declare queues an object type for schema and returns its name: hint if
+// it's free, else prefixed with the parent ("OrderItem"), else numbered.
+func (n *typeNamer) declare(hint, parent string, schema *paths.Schema) string {
+	name := hint
+	if n.names[name] {
+		name = parent + hint
+	}
+	name = n.names.unique(name)
+	n.queue = append(n.queue, pendingType{name: name, schema: schema})
+	return name
+}
+
+// isObjectRoot reports whether schema describes objects with known members,
+// which are written as a named struct/interface rather than a type alias.
+func isObjectRoot(schema *paths.Schema) bool {
+	rest, _ := splitNull(schemaTypes(schema))
+	return len(rest) == 1 && rest[0] == "object" && len(schema.Properties) > 0
+}
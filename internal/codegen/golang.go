@@ -0,0 +1,159 @@
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"jtool/internal/paths"
+)
+
+// GoOptions configures Go code generation.
+type GoOptions struct {
+	TypeName string // Name of the root type (default "Root")
+	Package  string // Package clause to emit; empty leaves it out
+}
+
+// Go generates Go type definitions with json tags for values described by
+// schema. Nested objects become named structs (the elements of "users"
+// become "User"), written after the root type.
+//
+// Members that are optional (not required) get ",omitempty", and members
+// that are optional or nullable are pointers so a missing/null value is
+// distinguishable from the zero value. Slices and maps are never pointers,
+// since nil already means absent. Members whose type varies between
+// samples (e.g. a string in one record and a number in another) become any.
+//
+// Example: {"id": 1, "tags": ["a"], "owner": {"name": "Ada"}} with
+// TypeName "Repo" becomes
+//
+//	type Repo struct {
+//		ID    int64    `json:"id"`
+//		Owner Owner    `json:"owner"`
+//		Tags  []string `json:"tags"`
+//	}
+//
+//	type Owner struct {
+//		Name string `json:"name"`
+//	}
+func Go(schema *paths.Schema, opts GoOptions) (string, error) {
+	rootName := opts.TypeName
+	if rootName == "" {
+		rootName = "Root"
+	}
+	rootName = pascalCase(rootName)
+
+	g := &goGenerator{newTypeNamer(rootName)}
+
+	var out strings.Builder
+	if opts.Package != "" {
+		fmt.Fprintf(&out, "package %s\n\n", opts.Package)
+	}
+
+	// An object root is a struct; anything else is a named type for its Go type
+	if isObjectRoot(schema) {
+		g.queue = append(g.queue, pendingType{name: rootName, schema: schema})
+	} else {
+		fmt.Fprintf(&out, "type %s %s\n\n", rootName, g.typeExpr(schema, singular(rootName), "", false))
+	}
+
+	// Writing a struct can queue more structs for its nested objects
+	for i := 0; i < len(g.queue); i++ {
+		g.writeStruct(&out, g.queue[i])
+	}
+
+	src, err := format.Source([]byte(strings.TrimRight(out.String(), "\n") + "\n"))
+	if err != nil {
+		return "", fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return string(src), nil
+}
+
+// goGenerator holds state while generating Go types.
+type goGenerator struct {
+	*typeNamer
+}
+
+// writeStruct writes one struct type definition.
+func (g *goGenerator) writeStruct(out *strings.Builder, t pendingType) {
+	fmt.Fprintf(out, "type %s struct {\n", t.name)
+
+	fields := nameSet{}
+	for _, key := range sortedKeys(t.schema.Properties) {
+		if !isValidTagKey(key) {
+			fmt.Fprintf(out, "\t// Member %q can't be expressed in a struct tag\n", key)
+			continue
+		}
+
+		optional := !isRequired(t.schema, key)
+		fieldName := fields.unique(pascalCase(key))
+		fieldType := g.typeExpr(t.schema.Properties[key], pascalCase(key), t.name, optional)
+
+		tag := key
+		if optional {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(out, "\t%s %s `json:\"%s\"`\n", fieldName, fieldType, tag)
+	}
+
+	out.WriteString("}\n\n")
+}
+
+// typeExpr returns the Go type for a value described by s. nameHint names
+// the struct if s is an object (parent disambiguates clashes), and optional
+// says whether the value may be absent.
+func (g *goGenerator) typeExpr(s *paths.Schema, nameHint, parent string, optional bool) string {
+	rest, nullable := splitNull(schemaTypes(s))
+	if len(rest) != 1 {
+		// Only null, no samples, or mixed types
+		return "any"
+	}
+
+	var base string
+	switch rest[0] {
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]any"
+		}
+		base = g.declare(nameHint, parent, s)
+	case "array":
+		if s.Items == nil {
+			return "[]any"
+		}
+		return "[]" + g.typeExpr(s.Items, singular(nameHint), parent, false)
+	case "string":
+		base = "string"
+	case "integer":
+		base = "int64"
+	case "number":
+		base = "float64"
+	case "boolean":
+		base = "bool"
+	default:
+		return "any"
+	}
+
+	if nullable || optional {
+		return "*" + base
+	}
+	return base
+}
+
+// isValidTagKey reports whether key can be used as the name in a json struct
+// tag. encoding/json ignores tag names with other punctuation (quotes,
+// backslashes, commas).
+func isValidTagKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c > 127:
+		default:
+			return false
+		}
+	}
+	return true
+}
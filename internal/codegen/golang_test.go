@@ -0,0 +1,120 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"jtool/internal/paths"
+)
+
+// schemaOf infers a schema from JSONL-style samples.
+func schemaOf(t *testing.T, samples ...string) *paths.Schema {
+	t.Helper()
+	b := paths.NewSchemaBuilder()
+	for _, s := range samples {
+		var v any
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatalf("bad sample %s: %v", s, err)
+		}
+		b.Add(v)
+	}
+	return b.Schema("")
+}
+
+func TestGo(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []string
+		opts     GoOptions
+		expected string
+	}{
+		{
+			name:    "nested objects and arrays",
+			samples: []string{`{"id": 1, "tags": ["a"], "owner": {"name": "Ada"}, "users": [{"user_id": 2, "score": 1.5}]}`},
+			opts:    GoOptions{TypeName: "Repo"},
+			expected: "type Repo struct {\n" +
+				"\tID    int64    `json:\"id\"`\n" +
+				"\tOwner Owner    `json:\"owner\"`\n" +
+				"\tTags  []string `json:\"tags\"`\n" +
+				"\tUsers []User   `json:\"users\"`\n" +
+				"}\n\n" +
+				"type Owner struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}\n\n" +
+				"type User struct {\n" +
+				"\tScore  float64 `json:\"score\"`\n" +
+				"\tUserID int64   `json:\"user_id\"`\n" +
+				"}\n",
+		},
+		{
+			name:    "optional, nullable and mixed members",
+			samples: []string{`{"a": 1, "b": null, "c": "x", "d": [1]}`, `{"a": 2, "b": "y", "c": 3}`},
+			expected: "type Root struct {\n" +
+				"\tA int64   `json:\"a\"`\n" +
+				"\tB *string `json:\"b\"`\n" +
+				"\tC any     `json:\"c\"`\n" +
+				"\tD []int64 `json:\"d,omitempty\"`\n" +
+				"}\n",
+		},
+		{
+			name:    "array root with package",
+			samples: []string{`[{"id": 1}, {"id": 2, "meta": {}}]`},
+			opts:    GoOptions{TypeName: "events", Package: "model"},
+			expected: "package model\n\n" +
+				"type Events []Event\n\n" +
+				"type Event struct {\n" +
+				"\tID   int64          `json:\"id\"`\n" +
+				"\tMeta map[string]any `json:\"meta,omitempty\"`\n" +
+				"}\n",
+		},
+		{
+			name:    "name clashes",
+			samples: []string{`{"item": {"x": 1}, "order": {"item": {"y": 2}}, "id": 1, "ID": 2}`},
+			expected: "type Root struct {\n" +
+				"\tID    int64 `json:\"ID\"`\n" +
+				"\tID2   int64 `json:\"id\"`\n" +
+				"\tItem  Item  `json:\"item\"`\n" +
+				"\tOrder Order `json:\"order\"`\n" +
+				"}\n\n" +
+				"type Item struct {\n" +
+				"\tX int64 `json:\"x\"`\n" +
+				"}\n\n" +
+				"type Order struct {\n" +
+				"\tItem OrderItem `json:\"item\"`\n" +
+				"}\n\n" +
+				"type OrderItem struct {\n" +
+				"\tY int64 `json:\"y\"`\n" +
+				"}\n",
+		},
+		{
+			name:     "scalar root",
+			samples:  []string{`"hello"`},
+			expected: "type Root string\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Go(schemaOf(t, tt.samples...), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGoCompiles(t *testing.T) {
+	schema := schemaOf(t, `{"weird key": 1, "quote\"d": 2, "list": [[{"a": null}]], "empty": []}`)
+	src, err := Go(schema, GoOptions{Package: "p"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0); err != nil {
+		t.Errorf("generated code doesn't parse: %v\n%s", err, src)
+	}
+}
@@ -0,0 +1,108 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms are written in all caps in exported names, following Go
+// convention (golint's list, trimmed to what shows up in JSON payloads).
+var commonInitialisms = map[string]bool{
+	"API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "SQL": true, "SSH": true,
+	"TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"URI": true, "URL": true, "UTF8": true, "UUID": true, "XML": true,
+}
+
+// splitWords breaks a JSON key into words at separators and case changes:
+// "user_id" → [user id], "createdAt" → [created At], "HTTPStatus" → [HTTP Status].
+func splitWords(key string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(key)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// "userID" splits before I; "HTTPStatus" splits before S
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// pascalCase converts a JSON key to an exported identifier:
+// "user_id" → "UserID", "created-at" → "CreatedAt", "2fa" → "X2fa".
+// Keys with no letters or digits become "Field".
+func pascalCase(key string) string {
+	var b strings.Builder
+	for _, word := range splitWords(key) {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])))
+		b.WriteString(string(runes[1:]))
+	}
+
+	name := b.String()
+	switch {
+	case name == "":
+		return "Field"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "X" + name
+	default:
+		return name
+	}
+}
+
+// singular makes a best-effort singular of a type name, so the elements of
+// "users" become "User": "Categories" → "Category", "Addresses" → "Address".
+func singular(name string) string {
+	switch {
+	case len(name) <= 3:
+		return name
+	case strings.HasSuffix(name, "ies"):
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "us"):
+		return name
+	case strings.HasSuffix(name, "s"):
+		return name[:len(name)-1]
+	default:
+		return name
+	}
+}
+
+// nameSet hands out unique names, adding a numeric suffix on collision.
+type nameSet map[string]bool
+
+// unique returns name, or name2, name3, ... if it's already taken.
+func (s nameSet) unique(name string) string {
+	candidate := name
+	for i := 2; s[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	s[candidate] = true
+	return candidate
+}
@@ -0,0 +1,41 @@
+package codegen
+
+import "testing"
+
+func TestPascalCase(t *testing.T) {
+	tests := map[string]string{
+		"name":        "Name",
+		"user_id":     "UserID",
+		"createdAt":   "CreatedAt",
+		"HTTPStatus":  "HTTPStatus",
+		"api-url":     "APIURL",
+		"first name":  "FirstName",
+		"userID":      "UserID",
+		"2fa_enabled": "X2faEnabled",
+		"$ref":        "Ref",
+		"---":         "Field",
+	}
+	for input, want := range tests {
+		if got := pascalCase(input); got != want {
+			t.Errorf("pascalCase(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"Users":      "User",
+		"Categories": "Category",
+		"Addresses":  "Address",
+		"Boxes":      "Box",
+		"Status":     "Status",
+		"Class":      "Class",
+		"Data":       "Data",
+		"Ids":        "Ids",
+	}
+	for input, want := range tests {
+		if got := singular(input); got != want {
+			t.Errorf("singular(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"jtool/internal/paths"
+)
+
+// SQL dialects supported by SQL.
+const (
+	DialectPostgres  = "postgres"
+	DialectBigQuery  = "bigquery"
+	DialectSnowflake = "snowflake"
+)
+
+// SQLOptions configures CREATE TABLE generation.
+type SQLOptions struct {
+	Dialect   string // DialectPostgres (default), DialectBigQuery or DialectSnowflake
+	TableName string // Table name (default "records"); may be qualified, e.g. "raw.users"
+}
+
+// sqlTypes maps an inferred column kind to each dialect's type.
+var sqlTypes = map[string]map[string]string{
+	DialectPostgres: {
+		"string": "TEXT", "date-time": "TIMESTAMPTZ", "date": "DATE", "uuid": "UUID",
+		"integer": "BIGINT", "number": "DOUBLE PRECISION", "boolean": "BOOLEAN", "json": "JSONB",
+	},
+	DialectBigQuery: {
+		"string": "STRING", "date-time": "TIMESTAMP", "date": "DATE", "uuid": "STRING",
+		"integer": "INT64", "number": "FLOAT64", "boolean": "BOOL", "json": "JSON",
+	},
+	DialectSnowflake: {
+		"string": "VARCHAR", "date-time": "TIMESTAMP_TZ", "date": "DATE", "uuid": "VARCHAR",
+		"integer": "NUMBER(38,0)", "number": "FLOAT", "boolean": "BOOLEAN", "json": "VARIANT",
+	},
+}
+
+// column is one column of the generated table.
+type column struct {
+	name    string
+	kind    string // Key into sqlTypes
+	notNull bool
+}
+
+// SQL generates a CREATE TABLE statement for records described by schema,
+// for designing landing tables from sample payloads or tap output.
+//
+// The records are the schema itself if it describes objects, or its items if
+// it describes an array of objects. Nested objects are flattened into
+// columns joined with "_" ({"address": {"city": ...}} → address_city), while
+// arrays, empty objects and members of mixed type are stored in the
+// dialect's JSON type (JSONB, JSON, VARIANT). A column is NOT NULL only if
+// the member, and every object it was flattened out of, was present and
+// non-null in every record.
+//
+// Column names are lowercased snake_case; names that are SQL keywords are
+// quoted.
+func SQL(schema *paths.Schema, opts SQLOptions) (string, error) {
+	dialect := opts.Dialect
+	if dialect == "" {
+		dialect = DialectPostgres
+	}
+	types, ok := sqlTypes[dialect]
+	if !ok {
+		return "", fmt.Errorf("unknown SQL dialect %q (want %q, %q or %q)", dialect, DialectPostgres, DialectBigQuery, DialectSnowflake)
+	}
+
+	record := schema
+	if rest, _ := splitNull(schemaTypes(schema)); len(rest) == 1 && rest[0] == "array" {
+		record = schema.Items
+	}
+	if record == nil || !isObjectRoot(record) {
+		return "", fmt.Errorf("expected objects or an array of objects to generate a table from")
+	}
+
+	var columns []column
+	flattenColumns(record, "", true, nameSet{}, &columns)
+
+	tableName := opts.TableName
+	if tableName == "" {
+		tableName = "records"
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "CREATE TABLE %s (\n", quoteTableName(tableName, dialect))
+	for i, col := range columns {
+		fmt.Fprintf(&out, "  %s %s", quoteIdentifier(col.name, dialect), types[col.kind])
+		if col.notNull {
+			out.WriteString(" NOT NULL")
+		}
+		if i < len(columns)-1 {
+			out.WriteString(",")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(");\n")
+
+	return out.String(), nil
+}
+
+// flattenColumns appends a column for each member of an object schema,
+// recursing into nested objects. notNull says whether the object itself is
+// always present and non-null.
+func flattenColumns(s *paths.Schema, prefix string, notNull bool, names nameSet, columns *[]column) {
+	for _, key := range sortedKeys(s.Properties) {
+		member := s.Properties[key]
+		rest, nullable := splitNull(schemaTypes(member))
+		memberNotNull := notNull && isRequired(s, key) && !nullable
+
+		name := snakeCase(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		if len(rest) == 1 && rest[0] == "object" && len(member.Properties) > 0 {
+			flattenColumns(member, name, memberNotNull, names, columns)
+			continue
+		}
+
+		*columns = append(*columns, column{
+			name:    names.unique(name),
+			kind:    columnKind(member, rest),
+			notNull: memberNotNull,
+		})
+	}
+}
+
+// columnKind picks the sqlTypes key for a member with the given non-null types.
+func columnKind(s *paths.Schema, types []string) string {
+	switch {
+	case len(types) == 0:
+		return "string" // Only ever null - nothing better to go on
+	case len(types) > 1:
+		return "json"
+	}
+
+	switch types[0] {
+	case "string":
+		switch s.Format {
+		case "date-time", "date", "uuid":
+			return s.Format
+		}
+		return "string"
+	case "integer", "number", "boolean":
+		return types[0]
+	default:
+		return "json" // Arrays and objects with no known members
+	}
+}
+
+// snakeCase converts a JSON key to a lowercase column name:
+// "createdAt" → "created_at", "First Name" → "first_name", "2fa" → "_2fa".
+func snakeCase(key string) string {
+	words := splitWords(key)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	name := strings.Join(words, "_")
+	switch {
+	case name == "":
+		return "column"
+	case unicode.IsDigit([]rune(name)[0]):
+		return "_" + name
+	default:
+		return name
+	}
+}
+
+// sqlKeywords are reserved words that would clash with common JSON keys.
+var sqlKeywords = map[string]bool{
+	"all": true, "and": true, "array": true, "as": true, "asc": true, "between": true,
+	"by": true, "case": true, "cast": true, "check": true, "column": true, "constraint": true,
+	"create": true, "cross": true, "current_date": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "default": true, "desc": true,
+	"distinct": true, "else": true, "end": true, "exists": true, "false": true, "for": true,
+	"foreign": true, "from": true, "full": true, "grant": true, "group": true, "having": true,
+	"in": true, "inner": true, "interval": true, "into": true, "is": true, "join": true,
+	"lateral": true, "left": true, "like": true, "limit": true, "natural": true, "not": true,
+	"null": true, "offset": true, "on": true, "or": true, "order": true, "outer": true,
+	"partition": true, "primary": true, "range": true, "references": true, "right": true,
+	"row": true, "rows": true, "select": true, "table": true, "then": true, "to": true,
+	"true": true, "union": true, "unique": true, "user": true, "using": true, "values": true,
+	"when": true, "where": true, "window": true, "with": true,
+}
+
+// quoteIdentifier quotes a column name if it's a keyword. BigQuery uses
+// backticks; Postgres and Snowflake use double quotes (Snowflake's then
+// preserve case, which is why names aren't quoted unless they must be).
+func quoteIdentifier(name, dialect string) string {
+	if !sqlKeywords[name] {
+		return name
+	}
+	if dialect == DialectBigQuery {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+// quoteTableName sanitizes and quotes each part of a possibly qualified name.
+func quoteTableName(name, dialect string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(snakeCase(part), dialect)
+	}
+	return strings.Join(parts, ".")
+}
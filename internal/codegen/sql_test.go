@@ -0,0 +1,89 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSQL(t *testing.T) {
+	records := []string{
+		`{"id": 1, "createdAt": "2024-01-02T03:04:05Z", "price": 9.5, "active": true, "address": {"city": "Oslo", "zip": null}, "tags": ["a"], "order": "x"}`,
+		`{"id": 2, "createdAt": "2024-02-03T03:04:05Z", "price": 10, "active": false, "address": {"city": "Rome", "zip": "00100"}, "tags": [], "order": 5}`,
+	}
+
+	tests := []struct {
+		name     string
+		samples  []string
+		opts     SQLOptions
+		expected string
+	}{
+		{
+			name:    "postgres",
+			samples: records,
+			opts:    SQLOptions{TableName: "orders"},
+			expected: "CREATE TABLE orders (\n" +
+				"  active BOOLEAN NOT NULL,\n" +
+				"  address_city TEXT NOT NULL,\n" +
+				"  address_zip TEXT,\n" +
+				"  created_at TIMESTAMPTZ NOT NULL,\n" +
+				"  id BIGINT NOT NULL,\n" +
+				"  \"order\" JSONB NOT NULL,\n" +
+				"  price DOUBLE PRECISION NOT NULL,\n" +
+				"  tags JSONB NOT NULL\n" +
+				");\n",
+		},
+		{
+			name:    "bigquery",
+			samples: records,
+			opts:    SQLOptions{Dialect: DialectBigQuery, TableName: "raw.Orders"},
+			expected: "CREATE TABLE raw.orders (\n" +
+				"  active BOOL NOT NULL,\n" +
+				"  address_city STRING NOT NULL,\n" +
+				"  address_zip STRING,\n" +
+				"  created_at TIMESTAMP NOT NULL,\n" +
+				"  id INT64 NOT NULL,\n" +
+				"  `order` JSON NOT NULL,\n" +
+				"  price FLOAT64 NOT NULL,\n" +
+				"  tags JSON NOT NULL\n" +
+				");\n",
+		},
+		{
+			name:    "snowflake with optional nested object",
+			samples: []string{`[{"id": "6f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b", "meta": {"page": 1}}, {"id": "7f1c2a9e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"}]`},
+			opts:    SQLOptions{Dialect: DialectSnowflake},
+			expected: "CREATE TABLE records (\n" +
+				"  id VARCHAR NOT NULL,\n" +
+				"  meta_page NUMBER(38,0)\n" +
+				");\n",
+		},
+		{
+			name:    "column name clashes",
+			samples: []string{`{"a": {"b": 1}, "a_b": 2}`},
+			expected: "CREATE TABLE records (\n" +
+				"  a_b BIGINT NOT NULL,\n" +
+				"  a_b2 BIGINT NOT NULL\n" +
+				");\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SQL(schemaOf(t, tt.samples...), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQLErrors(t *testing.T) {
+	if _, err := SQL(schemaOf(t, `{"a": 1}`), SQLOptions{Dialect: "oracle"}); err == nil || !strings.Contains(err.Error(), "unknown SQL dialect") {
+		t.Errorf("expected an unknown dialect error, got %v", err)
+	}
+	if _, err := SQL(schemaOf(t, `[1, 2]`), SQLOptions{}); err == nil {
+		t.Error("expected an error for an array of numbers")
+	}
+}
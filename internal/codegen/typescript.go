@@ -0,0 +1,144 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"jtool/internal/paths"
+)
+
+// TypeScriptOptions configures TypeScript code generation.
+type TypeScriptOptions struct {
+	TypeName string // Name of the root type (default "Root")
+}
+
+// TypeScript generates exported TypeScript interfaces for values described
+// by schema. Nested objects become named interfaces, written after the root.
+//
+// A member whose type varies between samples becomes a union
+// ("string | number | null"), and a member that only some objects had is
+// marked optional ("email?: string"). Over a JSONL file this is the
+// difference between a path's ObjectHits and the number of objects in the
+// log analysis, which the inferred schema records as "required".
+//
+// Example: {"id": 1, "tags": ["a"]} and {"id": "2"} become
+//
+//	export interface Root {
+//	  id: number | string;
+//	  tags?: string[];
+//	}
+func TypeScript(schema *paths.Schema, opts TypeScriptOptions) string {
+	rootName := opts.TypeName
+	if rootName == "" {
+		rootName = "Root"
+	}
+	rootName = pascalCase(rootName)
+
+	g := &tsGenerator{newTypeNamer(rootName)}
+
+	var out strings.Builder
+	if isObjectRoot(schema) {
+		g.queue = append(g.queue, pendingType{name: rootName, schema: schema})
+	} else {
+		fmt.Fprintf(&out, "export type %s = %s;\n\n", rootName, g.typeExpr(schema, singular(rootName), ""))
+	}
+
+	// Writing an interface can queue more interfaces for its nested objects
+	for i := 0; i < len(g.queue); i++ {
+		g.writeInterface(&out, g.queue[i])
+	}
+
+	return strings.TrimRight(out.String(), "\n") + "\n"
+}
+
+// tsGenerator holds state while generating TypeScript types.
+type tsGenerator struct {
+	*typeNamer
+}
+
+// writeInterface writes one interface declaration.
+func (g *tsGenerator) writeInterface(out *strings.Builder, t pendingType) {
+	fmt.Fprintf(out, "export interface %s {\n", t.name)
+
+	for _, key := range sortedKeys(t.schema.Properties) {
+		optional := ""
+		if !isRequired(t.schema, key) {
+			optional = "?"
+		}
+		fieldType := g.typeExpr(t.schema.Properties[key], pascalCase(key), t.name)
+		fmt.Fprintf(out, "  %s%s: %s;\n", tsPropertyName(key), optional, fieldType)
+	}
+
+	out.WriteString("}\n\n")
+}
+
+// typeExpr returns the TypeScript type for a value described by s.
+// nameHint names the interface if s is an object (parent disambiguates).
+func (g *tsGenerator) typeExpr(s *paths.Schema, nameHint, parent string) string {
+	types := schemaTypes(s)
+	if len(types) == 0 {
+		return "unknown"
+	}
+
+	members := make([]string, 0, len(types))
+	for _, t := range types {
+		var member string
+		switch t {
+		case "object":
+			if len(s.Properties) == 0 {
+				member = "Record<string, unknown>"
+			} else {
+				member = g.declare(nameHint, parent, s)
+			}
+		case "array":
+			elem := "unknown"
+			if s.Items != nil {
+				elem = g.typeExpr(s.Items, singular(nameHint), parent)
+			}
+			if strings.Contains(elem, " | ") {
+				elem = "(" + elem + ")"
+			}
+			member = elem + "[]"
+		case "string":
+			member = "string"
+		case "integer", "number":
+			member = "number"
+		case "boolean":
+			member = "boolean"
+		case "null":
+			continue // Added last, after the other types
+		default:
+			member = "unknown"
+		}
+		if !containsString(members, member) {
+			members = append(members, member)
+		}
+	}
+	if containsString(types, "null") {
+		members = append(members, "null")
+	}
+
+	return strings.Join(members, " | ")
+}
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsPropertyName quotes keys that aren't valid identifiers: "first name".
+func tsPropertyName(key string) string {
+	if tsIdentifier.MatchString(key) {
+		return key
+	}
+	quoted, _ := json.Marshal(key) // Marshalling a string can't fail
+	return string(quoted)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package codegen
+
+import "testing"
+
+func TestTypeScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []string
+		opts     TypeScriptOptions
+		expected string
+	}{
+		{
+			name:    "unions and optional members",
+			samples: []string{`{"id": 1, "tags": ["a"]}`, `{"id": "2", "note": null}`},
+			expected: "export interface Root {\n" +
+				"  id: number | string;\n" +
+				"  note?: null;\n" +
+				"  tags?: string[];\n" +
+				"}\n",
+		},
+		{
+			name:    "nested interfaces",
+			samples: []string{`{"owner": {"name": "Ada", "first name": "A"}, "users": [{"score": 1.5}, {"score": 2}], "meta": {}}`},
+			opts:    TypeScriptOptions{TypeName: "repo"},
+			expected: "export interface Repo {\n" +
+				"  meta: Record<string, unknown>;\n" +
+				"  owner: Owner;\n" +
+				"  users: User[];\n" +
+				"}\n\n" +
+				"export interface Owner {\n" +
+				"  \"first name\": string;\n" +
+				"  name: string;\n" +
+				"}\n\n" +
+				"export interface User {\n" +
+				"  score: number;\n" +
+				"}\n",
+		},
+		{
+			name:    "array of mixed elements",
+			samples: []string{`{"values": [1, "a", null], "empty": []}`},
+			expected: "export interface Root {\n" +
+				"  empty: unknown[];\n" +
+				"  values: (number | string | null)[];\n" +
+				"}\n",
+		},
+		{
+			name:    "array root",
+			samples: []string{`[{"id": 1}]`},
+			opts:    TypeScriptOptions{TypeName: "Events"},
+			expected: "export type Events = Event[];\n\n" +
+				"export interface Event {\n" +
+				"  id: number;\n" +
+				"}\n",
+		},
+		{
+			name:    "object or null",
+			samples: []string{`{"parent": {"id": 1}}`, `{"parent": null}`},
+			expected: "export interface Root {\n" +
+				"  parent: Parent | null;\n" +
+				"}\n\n" +
+				"export interface Parent {\n" +
+				"  id: number;\n" +
+				"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TypeScript(schemaOf(t, tt.samples...), tt.opts)
+			if got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Package config reads the defaults jtool starts with from a config file
+// and JTOOL_* environment variables, so headless runs (`jtool analyze`,
+// `jtool serve`, CI jobs) and the GUI share one set of preferences.
+//
+// The file is $JTOOL_CONFIG if set, or else the first of config.json,
+// config.yaml and config.yml in $XDG_CONFIG_HOME/jtool (~/.config/jtool
+// when XDG_CONFIG_HOME is unset). For example:
+//
+//	normalize:
+//	  sortArrays: true
+//	  dropUrlParams: [utm_*]
+//	ignorePaths: [.meta.requestId, .**.updated_at]
+//	pathFormat: pointer
+//	output: csv
+//
+// Environment variables override the file:
+//
+//	JTOOL_PATH_FORMAT=jsonpath
+//	JTOOL_OUTPUT=csv
+//	JTOOL_IGNORE_PATHS=.meta.requestId,.**.updated_at
+//	JTOOL_NORMALIZE_SORT_ARRAYS=true
+//
+// JTOOL_NORMALIZE_<OPTION> sets any normalize option by its name in upper
+// snake case. Its value is read as JSON (true, 2, ["N/A"]), or as a string
+// if it isn't valid JSON.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"jtool/internal/paths"
+)
+
+// Output formats for Config.Output.
+const (
+	OutputJSON = "json"
+	OutputCSV  = "csv"
+)
+
+// normalizePrefix starts the environment variables setting normalize options.
+const normalizePrefix = "JTOOL_NORMALIZE_"
+
+// fileNames are the config files looked for, in order.
+var fileNames = []string{"config.json", "config.yaml", "config.yml"}
+
+// Config holds the defaults read from the config file and environment.
+// Zero fields leave the built-in defaults alone.
+type Config struct {
+	// Normalize holds normalize options by their JSON name, e.g.
+	// "sortArrays". They are kept untyped so this package doesn't depend on
+	// the app's option types; the app decodes and checks them.
+	Normalize map[string]any `json:"normalize"`
+
+	IgnorePaths []string `json:"ignorePaths"` // Path patterns left out of diffs and log comparisons
+	PathFormat  string   `json:"pathFormat"`  // How paths are written: dotted, jsonpath or pointer
+	Output      string   `json:"output"`      // What `jtool analyze` writes: json or csv
+
+	File string `json:"-"` // The config file read, if any
+}
+
+// Load reads the config file, if there is one, and applies the JTOOL_*
+// environment variables over it. Having neither isn't an error: Load
+// returns an empty Config.
+func Load() (*Config, error) {
+	home, _ := os.UserHomeDir()
+	return load(os.Environ(), home)
+}
+
+// load is Load with the environment and home directory given.
+func load(environ []string, home string) (*Config, error) {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			env[name] = value
+		}
+	}
+
+	cfg := &Config{}
+	file, err := findFile(env, home)
+	if err != nil {
+		return nil, err
+	}
+	if file != "" {
+		if cfg, err = readFile(file); err != nil {
+			return nil, fmt.Errorf("error reading config file %s: %w", file, err)
+		}
+		cfg.File = file
+	}
+
+	cfg.applyEnv(env)
+	if err := cfg.validate(); err != nil {
+		if cfg.File != "" {
+			return nil, fmt.Errorf("config file %s: %w", cfg.File, err)
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// findFile returns the path of the config file, or "" if there is none. A
+// file named by JTOOL_CONFIG must exist.
+func findFile(env map[string]string, home string) (string, error) {
+	if file := env["JTOOL_CONFIG"]; file != "" {
+		if _, err := os.Stat(file); err != nil {
+			return "", fmt.Errorf("JTOOL_CONFIG: %w", err)
+		}
+		return file, nil
+	}
+
+	dir := env["XDG_CONFIG_HOME"]
+	if dir == "" {
+		if home == "" {
+			return "", nil
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	for _, name := range fileNames {
+		file := filepath.Join(dir, "jtool", name)
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		}
+	}
+	return "", nil
+}
+
+// readFile parses a JSON or YAML (by extension) config file. Unknown keys
+// are rejected so a misspelled setting doesn't go unnoticed.
+func readFile(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// applyEnv overrides cfg with the JTOOL_* variables in env.
+func (cfg *Config) applyEnv(env map[string]string) {
+	if v, ok := env["JTOOL_PATH_FORMAT"]; ok {
+		cfg.PathFormat = v
+	}
+	if v, ok := env["JTOOL_OUTPUT"]; ok {
+		cfg.Output = v
+	}
+	if v, ok := env["JTOOL_IGNORE_PATHS"]; ok {
+		cfg.IgnorePaths = nil
+		for _, pattern := range strings.Split(v, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				cfg.IgnorePaths = append(cfg.IgnorePaths, pattern)
+			}
+		}
+	}
+
+	for name, value := range env {
+		option, ok := strings.CutPrefix(name, normalizePrefix)
+		if !ok || option == "" {
+			continue
+		}
+		if cfg.Normalize == nil {
+			cfg.Normalize = make(map[string]any)
+		}
+		cfg.Normalize[camelCase(option)] = envValue(value)
+	}
+}
+
+// validate checks the settings this package understands. Normalize options
+// are checked by the app.
+func (cfg *Config) validate() error {
+	switch cfg.PathFormat {
+	case "", paths.FormatDotted, paths.FormatJSONPath, paths.FormatPointer:
+	default:
+		return fmt.Errorf("invalid path format %q: want dotted, jsonpath or pointer", cfg.PathFormat)
+	}
+	switch cfg.Output {
+	case "", OutputJSON, OutputCSV:
+	default:
+		return fmt.Errorf("invalid output format %q: want json or csv", cfg.Output)
+	}
+	if err := paths.ValidatePatterns(cfg.IgnorePaths); err != nil {
+		return fmt.Errorf("invalid ignore path: %w", err)
+	}
+	return nil
+}
+
+// camelCase turns the SNAKE_CASE name of an environment variable into the
+// JSON name of an option: SORT_ARRAYS_BY_KEY becomes sortArraysByKey.
+func camelCase(name string) string {
+	var b strings.Builder
+	for i, word := range strings.Split(strings.ToLower(name), "_") {
+		if i > 0 && word != "" {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// envValue reads the value of a JTOOL_NORMALIZE_ variable as JSON, or as a
+// string if it isn't valid JSON.
+func envValue(s string) any {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return s
+	}
+	return v
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file under home/.config/jtool.
+func writeConfig(t *testing.T, home, name, content string) string {
+	t.Helper()
+	dir := filepath.Join(home, ".config", "jtool")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoad_None(t *testing.T) {
+	cfg, err := load(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, &Config{}) {
+		t.Errorf("load() = %+v, want an empty config", cfg)
+	}
+}
+
+func TestLoad_YAML(t *testing.T) {
+	home := t.TempDir()
+	file := writeConfig(t, home, "config.yaml", `
+normalize:
+  sortArrays: true
+  roundNumbers: 2
+  dropUrlParams: [utm_*]
+  nullStrings: &nulls
+    - N/A
+    - none
+ignorePaths:
+  - .meta.requestId
+  - .**.updated_at
+pathFormat: pointer
+output: csv
+`)
+
+	cfg, err := load(nil, home)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &Config{
+		Normalize: map[string]any{
+			"sortArrays":    true,
+			"nullStrings":   []any{"N/A", "none"},
+			"roundNumbers":  json.Number("2"),
+			"dropUrlParams": []any{"utm_*"},
+		},
+		IgnorePaths: []string{".meta.requestId", ".**.updated_at"},
+		PathFormat:  "pointer",
+		Output:      "csv",
+		File:        file,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("load() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoad_JSONFirst(t *testing.T) {
+	home := t.TempDir()
+	file := writeConfig(t, home, "config.json", `{"pathFormat": "jsonpath"}`)
+	writeConfig(t, home, "config.yaml", "pathFormat: pointer\n")
+
+	cfg, err := load(nil, home)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.File != file || cfg.PathFormat != "jsonpath" {
+		t.Errorf("load() read %s (pathFormat %q), want %s", cfg.File, cfg.PathFormat, file)
+	}
+}
+
+func TestLoad_ConfigLocations(t *testing.T) {
+	explicit := filepath.Join(t.TempDir(), "ci.yml")
+	if err := os.WriteFile(explicit, []byte("output: csv\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := load([]string{"JTOOL_CONFIG=" + explicit}, t.TempDir())
+	if err != nil || cfg.File != explicit {
+		t.Errorf("JTOOL_CONFIG: load() = %+v, %v", cfg, err)
+	}
+
+	_, err = load([]string{"JTOOL_CONFIG=" + explicit + ".missing"}, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "JTOOL_CONFIG") {
+		t.Errorf("missing JTOOL_CONFIG: error = %v", err)
+	}
+
+	xdg := t.TempDir()
+	file := filepath.Join(xdg, "jtool", "config.json")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = load([]string{"XDG_CONFIG_HOME=" + xdg}, t.TempDir())
+	if err != nil || cfg.File != file {
+		t.Errorf("XDG_CONFIG_HOME: load() = %+v, %v; want file %s", cfg, err, file)
+	}
+}
+
+func TestLoad_Env(t *testing.T) {
+	home := t.TempDir()
+	writeConfig(t, home, "config.json", `{"ignorePaths": [".a"], "pathFormat": "pointer", "normalize": {"sortKeys": false}}`)
+
+	cfg, err := load([]string{
+		"JTOOL_PATH_FORMAT=jsonpath",
+		"JTOOL_OUTPUT=csv",
+		"JTOOL_IGNORE_PATHS= .b , .c.**,",
+		"JTOOL_NORMALIZE_SORT_KEYS=true",
+		"JTOOL_NORMALIZE_SORT_ARRAYS_BY_KEY=id",
+		`JTOOL_NORMALIZE_NULL_STRINGS=["N/A", ""]`,
+		"JTOOL_NORMALIZE_DATE_OUTPUT_LAYOUT=2006-01-02",
+		"JTOOL_OTHER=1",
+	}, home)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.PathFormat != "jsonpath" || cfg.Output != "csv" {
+		t.Errorf("PathFormat, Output = %q, %q; want jsonpath, csv", cfg.PathFormat, cfg.Output)
+	}
+	if want := []string{".b", ".c.**"}; !reflect.DeepEqual(cfg.IgnorePaths, want) {
+		t.Errorf("IgnorePaths = %q, want %q", cfg.IgnorePaths, want)
+	}
+	want := map[string]any{
+		"sortKeys":         true,
+		"sortArraysByKey":  "id",
+		"nullStrings":      []any{"N/A", ""},
+		"dateOutputLayout": "2006-01-02",
+	}
+	if !reflect.DeepEqual(cfg.Normalize, want) {
+		t.Errorf("Normalize = %#v, want %#v", cfg.Normalize, want)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+		env                 []string
+		want                string
+	}{
+		{"unknown key", "config.json", `{"pathFromat": "pointer"}`, nil, `unknown field "pathFromat"`},
+		{"invalid JSON", "config.json", `{`, nil, "error reading config file"},
+		{"invalid YAML", "config.yaml", "a: b: c", nil, "mapping values are not allowed"},
+		{"path format", "config.yaml", "pathFormat: xpath", nil, `invalid path format "xpath"`},
+		{"output", "config.json", `{}`, []string{"JTOOL_OUTPUT=xml"}, `invalid output format "xml"`},
+		{"ignore path", "config.json", `{"ignorePaths": ["/[/"]}`, nil, "invalid ignore path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			writeConfig(t, home, tt.file, tt.content)
+			_, err := load(tt.env, home)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"SORT_KEYS":                  "sortKeys",
+		"SORT_ARRAYS_BY_KEY":         "sortArraysByKey",
+		"NORMALIZE_URLS":             "normalizeUrls",
+		"LOCALE_THOUSANDS_SEPARATOR": "localeThousandsSeparator",
+		"SCHEMA":                     "schema",
+	}
+	for in, want := range tests {
+		if got := camelCase(in); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// INI converts a simple INI file to a JSON object: each [section] becomes
+// an object of its keys, and keys before the first section are top-level.
+// INI has no types, so every value is a string.
+//
+// Comments start with ";" or "#" at the beginning of a line. Keys may be
+// separated from values by "=" or ":", values may be quoted, and indented
+// lines continue the previous value, as Python's configparser allows. A key
+// or section that appears twice is merged, with the last value winning.
+func INI(text string) (map[string]any, error) {
+	root := make(map[string]any)
+	section := root
+	var lastKey string // Key that an indented line continues
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(nil, 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		raw := strings.TrimSuffix(scanner.Text(), "\r")
+		line := strings.TrimSpace(raw)
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			lastKey = "" // A blank line or comment ends a multi-line value
+			continue
+		}
+
+		// Indented line: continuation of the previous value
+		if lastKey != "" && (raw[0] == ' ' || raw[0] == '\t') {
+			section[lastKey] = section[lastKey].(string) + "\n" + line
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", lineNum)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty section name", lineNum)
+			}
+			existing, ok := root[name].(map[string]any)
+			if !ok {
+				if _, isKey := root[name]; isKey {
+					return nil, fmt.Errorf("line %d: section %q has the same name as a key", lineNum, name)
+				}
+				existing = make(map[string]any)
+				root[name] = existing
+			}
+			section, lastKey = existing, ""
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNum)
+		}
+		key := strings.TrimSpace(line[:sep])
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", lineNum)
+		}
+		if _, isSection := section[key].(map[string]any); isSection {
+			return nil, fmt.Errorf("line %d: key %q has the same name as a section", lineNum, key)
+		}
+		section[key] = unquote(strings.TrimSpace(line[sep+1:]))
+		lastKey = key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// unquote strips one pair of matching quotes around an INI value.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
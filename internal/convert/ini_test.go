@@ -0,0 +1,63 @@
+package convert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestINI(t *testing.T) {
+	input := `; global settings
+debug = true
+
+[server]
+host: example.com
+port = 8080
+motd = "Hello, world"
+description = first line
+  second line
+
+# comment
+[paths]
+root = /var/www
+[server]
+port = 9090
+`
+
+	got, err := INI(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"debug": "true",
+		"server": map[string]any{
+			"host":        "example.com",
+			"port":        "9090",
+			"motd":        "Hello, world",
+			"description": "first line\nsecond line",
+		},
+		"paths": map[string]any{"root": "/var/www"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("INI() = %v, want %v", got, want)
+	}
+}
+
+func TestINI_Errors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"no separator", "[a]\njust text", "line 2: expected key = value"},
+		{"unterminated section", "[a", "line 1: unterminated section header"},
+		{"section named like a key", "a = 1\n[a]", `section "a" has the same name as a key`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := INI(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package convert turns configuration and legacy formats (TOML, INI, XML)
+// into JSON values, so documents in those formats can be diffed and
+// path-extracted like any other JSON.
+//
+// Values use the same representation as parser.Parse: objects are
+// map[string]any, arrays []any and numbers json.Number, so large integers
+// keep their exact value.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// TOML converts a TOML document to a JSON value.
+//
+// Values JSON has no type for are written as {"type": ..., "value": ...}
+// objects, as in the toml-test JSON encoding, so they keep their TOML type:
+//
+//	dob = 1979-05-27T07:32:00-08:00 → {"type": "datetime", "value": "1979-05-27T07:32:00-08:00"}
+//	born = 1979-05-27               → {"type": "date-local", "value": "1979-05-27"}
+//	ratio = +inf                    → {"type": "float", "value": "inf"}
+//
+// The other types are "datetime-local" and "time-local", and "-inf" and
+// "nan" floats.
+func TOML(text string) (map[string]any, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal([]byte(text), &doc); err != nil {
+		message := strings.TrimPrefix(err.Error(), "toml: ")
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, column := decodeErr.Position()
+			return nil, fmt.Errorf("line %d, column %d: %s", row, column, message)
+		}
+		return nil, errors.New(message)
+	}
+	if doc == nil {
+		return map[string]any{}, nil
+	}
+	return tomlToJSON(doc).(map[string]any), nil
+}
+
+// tomlToJSON converts a value decoded by go-toml to its JSON form.
+func tomlToJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			v[key] = tomlToJSON(val)
+		}
+		return v
+	case []any:
+		for i, val := range v {
+			v[i] = tomlToJSON(val)
+		}
+		return v
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return tomlTyped("float", "nan")
+		case math.IsInf(v, 1):
+			return tomlTyped("float", "inf")
+		case math.IsInf(v, -1):
+			return tomlTyped("float", "-inf")
+		}
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		return tomlTyped("datetime", v.Format(time.RFC3339Nano))
+	case toml.LocalDateTime:
+		return tomlTyped("datetime-local", v.String())
+	case toml.LocalDate:
+		return tomlTyped("date-local", v.String())
+	case toml.LocalTime:
+		return tomlTyped("time-local", v.String())
+	default:
+		return v // string, bool
+	}
+}
+
+// tomlTyped returns the JSON form of a value JSON has no type for.
+func tomlTyped(kind, value string) map[string]any {
+	return map[string]any{"type": kind, "value": value}
+}
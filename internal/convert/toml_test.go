@@ -0,0 +1,139 @@
+package convert
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mustJSON parses expected values the way the converters represent them.
+func mustJSON(t *testing.T, s string) any {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("bad expected JSON %q: %v", s, err)
+	}
+	return v
+}
+
+func TestTOML(t *testing.T) {
+	input := `# Service config
+title = "jtool"
+"quoted key" = 'C:\path'
+site."google.com" = true
+
+[owner]
+name = "Tom"
+dob = 1979-05-27 07:32:00-08:00
+born = 1979-05-27
+wake = 07:32:00
+last = 1979-05-27T07:32:00.5
+
+[database]
+ports = [ 8000, 8001, 0x1F ]
+limits = { cpu = 1.5, mem.max = 1_024 }
+data = [
+  ["gamma", "delta"], # trailing comma and comments are fine
+  [1, 2],
+]
+ratio = +inf
+big = 9223372036854775807
+
+[servers.alpha]
+ip = "10.0.0.1"
+
+[[fruits]]
+name = "apple"
+
+[[fruits.varieties]]
+name = "red delicious"
+
+[[fruits]]
+name = "banana"
+`
+
+	got, err := TOML(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := mustJSON(t, `{
+		"title": "jtool",
+		"quoted key": "C:\\path",
+		"site": {"google.com": true},
+		"owner": {
+			"name": "Tom",
+			"dob": {"type": "datetime", "value": "1979-05-27T07:32:00-08:00"},
+			"born": {"type": "date-local", "value": "1979-05-27"},
+			"wake": {"type": "time-local", "value": "07:32:00"},
+			"last": {"type": "datetime-local", "value": "1979-05-27T07:32:00.5"}
+		},
+		"database": {
+			"ports": [8000, 8001, 31],
+			"limits": {"cpu": 1.5, "mem": {"max": 1024}},
+			"data": [["gamma", "delta"], [1, 2]],
+			"ratio": {"type": "float", "value": "inf"},
+			"big": 9223372036854775807
+		},
+		"servers": {"alpha": {"ip": "10.0.0.1"}},
+		"fruits": [
+			{"name": "apple", "varieties": [{"name": "red delicious"}]},
+			{"name": "banana"}
+		]
+	}`)
+	if !reflect.DeepEqual(any(got), want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("TOML() =\n%s", gotJSON)
+	}
+}
+
+func TestTOML_Strings(t *testing.T) {
+	input := "basic = \"tab\\there \\u00e9\"\n" +
+		"multi = \"\"\"\nline one\nline \\\n    two\"\"\"\n" +
+		"literal = '''\nraw \\n text'''\n" +
+		"quotes = \"\"\"a \"quoted\" word\"\"\"\"\n"
+
+	got, err := TOML(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"basic":   "tab\there é",
+		"multi":   "line one\nline two",
+		"literal": "raw \\n text",
+		"quotes":  `a "quoted" word"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TOML() = %q, want %q", got, want)
+	}
+}
+
+func TestTOML_Errors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"duplicate key", "a = 1\na = 2", "key a is already defined"},
+		{"table defined twice", "[a]\n[a]", "table a already exists"},
+		{"extend inline table", "a = {b = 1}\n[a.c]", "expected a to be a table"},
+		{"missing equals", "a 1", "line 1, column 3: expected character ="},
+		{"bad value", "a = yes", "line 1, column 5"},
+		{"leading zero", "a = 01", "leading zero not allowed"},
+		{"trailing garbage", "a = 1 2", "expected newline"},
+		{"unterminated string", "a = \"abc\nb = 1", "line 1, column 9: basic strings cannot have new lines"},
+		{"unterminated array", "a = [1, 2", "the document ended here"},
+		{"bad escape", `a = "\q"`, "invalid escaped character"},
+		{"integer out of range", "a = 12345678901234567890", "value out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TOML(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package convert
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// How XMLOptions.Attributes maps attributes.
+const (
+	AttributesPrefix = "prefix" // Keys with AttributePrefix, e.g. "@id" (the default)
+	AttributesMerge  = "merge"  // Plain keys alongside child elements; a child wins a clash
+	AttributesDrop   = "drop"   // Attributes are left out
+)
+
+// Defaults for XMLOptions fields left empty.
+const (
+	DefaultAttributePrefix = "@"
+	DefaultTextKey         = "#text"
+)
+
+// XMLOptions controls how XML maps onto JSON.
+type XMLOptions struct {
+	Attributes      string   `json:"attributes"`      // AttributesPrefix, AttributesMerge or AttributesDrop
+	AttributePrefix string   `json:"attributePrefix"` // Prefix for attribute keys; default "@"
+	TextKey         string   `json:"textKey"`         // Key for the text of an element that also has attributes or children; default "#text"
+	StripNamespaces bool     `json:"stripNamespaces"` // Drop namespace prefixes (soap:Body -> Body) and xmlns attributes
+	ArrayElements   []string `json:"arrayElements"`   // Element names that are always arrays, even when they occur once
+}
+
+// XML converts an XML document to a JSON object with the root element as
+// its only key. An element with only text becomes a string; otherwise it
+// becomes an object of its attributes and children, with repeated child
+// elements collected into an array in document order. Comments and
+// processing instructions are dropped.
+//
+// Whether an element is an array depends on how often it occurs, so a list
+// that happens to have one item changes shape; name such elements in
+// ArrayElements to keep diffs stable.
+func XML(text string, opts XMLOptions) (map[string]any, error) {
+	if opts.Attributes == "" {
+		opts.Attributes = AttributesPrefix
+	}
+	if opts.AttributePrefix == "" {
+		opts.AttributePrefix = DefaultAttributePrefix
+	}
+	if opts.TextKey == "" {
+		opts.TextKey = DefaultTextKey
+	}
+	switch opts.Attributes {
+	case AttributesPrefix, AttributesMerge, AttributesDrop:
+	default:
+		return nil, fmt.Errorf("invalid attributes mode %q: must be %q, %q or %q", opts.Attributes, AttributesPrefix, AttributesMerge, AttributesDrop)
+	}
+
+	d := xml.NewDecoder(strings.NewReader(text))
+	var root *xmlElement
+	var stack []*xmlElement
+
+	for {
+		// RawToken keeps namespace prefixes as written (soap:Body) instead
+		// of resolving them to URLs, so nesting is checked here
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			el := &xmlElement{name: opts.name(t.Name)}
+			for _, attr := range t.Attr {
+				if opts.StripNamespaces && (attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				el.attrs = append(el.attrs, xml.Attr{Name: xml.Name{Local: opts.name(attr.Name)}, Value: attr.Value})
+			}
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, el)
+			case root != nil:
+				return nil, fmt.Errorf("line %d: more than one root element", lineOf(d, text))
+			default:
+				root = el
+			}
+			stack = append(stack, el)
+
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != opts.name(t.Name) {
+				return nil, fmt.Errorf("line %d: unexpected end element </%s>", lineOf(d, text), rawName(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			} else if strings.TrimSpace(string(t)) != "" {
+				return nil, fmt.Errorf("line %d: text outside the root element", lineOf(d, text))
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("element <%s> is not closed", stack[len(stack)-1].name)
+	}
+	return map[string]any{root.name: root.value(opts)}, nil
+}
+
+// xmlElement is an element as read, before conversion.
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// value converts an element to its JSON value.
+func (el *xmlElement) value(opts XMLOptions) any {
+	text := strings.TrimSpace(el.text.String())
+	attrs := el.attrs
+	if opts.Attributes == AttributesDrop {
+		attrs = nil
+	}
+	if len(attrs) == 0 && len(el.children) == 0 {
+		return text
+	}
+
+	obj := make(map[string]any)
+	if text != "" {
+		obj[opts.TextKey] = text
+	}
+	seen := make(map[string]int)
+	for _, child := range el.children {
+		value := child.value(opts)
+		seen[child.name]++
+		switch {
+		case seen[child.name] == 1 && slices.Contains(opts.ArrayElements, child.name):
+			obj[child.name] = []any{value}
+		case seen[child.name] == 1:
+			obj[child.name] = value
+		case seen[child.name] == 2 && !slices.Contains(opts.ArrayElements, child.name):
+			obj[child.name] = []any{obj[child.name], value}
+		default:
+			obj[child.name] = append(obj[child.name].([]any), value)
+		}
+	}
+	for _, attr := range attrs {
+		key := attr.Name.Local
+		if opts.Attributes == AttributesPrefix {
+			key = opts.AttributePrefix + key
+		}
+		if _, clash := obj[key]; !clash {
+			obj[key] = attr.Value
+		}
+	}
+	return obj
+}
+
+// name returns the key for an element or attribute name.
+func (opts XMLOptions) name(n xml.Name) string {
+	if opts.StripNamespaces {
+		return n.Local
+	}
+	return rawName(n)
+}
+
+// rawName returns a name as written, with its namespace prefix.
+func rawName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+// lineOf returns the line the decoder has read up to.
+func lineOf(d *xml.Decoder, text string) int {
+	return strings.Count(text[:min(int(d.InputOffset()), len(text))], "\n") + 1
+}
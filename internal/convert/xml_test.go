@@ -0,0 +1,118 @@
+package convert
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const soapEnvelope = `<?xml version="1.0"?>
+<!-- order lookup -->
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <Order id="42" status="open">
+      <Item sku="A1">Widget</Item>
+      <Item sku="B2">Gadget</Item>
+      <Note><![CDATA[fragile & heavy]]></Note>
+      <Empty/>
+      <Tag>rush</Tag>
+    </Order>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestXML(t *testing.T) {
+	tests := []struct {
+		name string
+		opts XMLOptions
+		want string
+	}{
+		{
+			name: "defaults",
+			want: `{"soap:Envelope": {
+				"@xmlns:soap": "http://schemas.xmlsoap.org/soap/envelope/",
+				"soap:Body": {"Order": {
+					"@id": "42", "@status": "open",
+					"Item": [{"@sku": "A1", "#text": "Widget"}, {"@sku": "B2", "#text": "Gadget"}],
+					"Note": "fragile & heavy",
+					"Empty": "",
+					"Tag": "rush"
+				}}
+			}}`,
+		},
+		{
+			name: "merged attributes, no namespaces, forced arrays",
+			opts: XMLOptions{Attributes: AttributesMerge, TextKey: "value", StripNamespaces: true, ArrayElements: []string{"Tag"}},
+			want: `{"Envelope": {"Body": {"Order": {
+				"id": "42", "status": "open",
+				"Item": [{"sku": "A1", "value": "Widget"}, {"sku": "B2", "value": "Gadget"}],
+				"Note": "fragile & heavy",
+				"Empty": "",
+				"Tag": ["rush"]
+			}}}}`,
+		},
+		{
+			name: "dropped attributes",
+			opts: XMLOptions{Attributes: AttributesDrop, StripNamespaces: true},
+			want: `{"Envelope": {"Body": {"Order": {
+				"Item": ["Widget", "Gadget"],
+				"Note": "fragile & heavy",
+				"Empty": "",
+				"Tag": "rush"
+			}}}}`,
+		},
+		{
+			name: "custom prefix",
+			opts: XMLOptions{AttributePrefix: "_", StripNamespaces: true, ArrayElements: []string{"Item"}},
+			want: `{"Envelope": {"Body": {"Order": {
+				"_id": "42", "_status": "open",
+				"Item": [{"_sku": "A1", "#text": "Widget"}, {"_sku": "B2", "#text": "Gadget"}],
+				"Note": "fragile & heavy",
+				"Empty": "",
+				"Tag": "rush"
+			}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := XML(soapEnvelope, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var want any
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("bad expected JSON: %v", err)
+			}
+			if !reflect.DeepEqual(any(got), want) {
+				gotJSON, _ := json.MarshalIndent(got, "", "  ")
+				t.Errorf("XML() =\n%s", gotJSON)
+			}
+		})
+	}
+}
+
+func TestXML_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  XMLOptions
+		want  string
+	}{
+		{"mismatched end", "<a>\n<b></a>", XMLOptions{}, "line 2: unexpected end element </a>"},
+		{"unclosed", "<a><b></b>", XMLOptions{}, "element <a> is not closed"},
+		{"two roots", "<a/><b/>", XMLOptions{}, "more than one root element"},
+		{"no root", "<!-- nothing -->", XMLOptions{}, "no root element"},
+		{"text outside root", "<a/>oops", XMLOptions{}, "text outside the root element"},
+		{"bad mode", "<a/>", XMLOptions{Attributes: "flatten"}, `invalid attributes mode "flatten"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := XML(tt.input, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package decompress reads gzip and zstd compressed files transparently,
+// for rotated logs and archived JSON snapshots.
+package decompress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// zstdMaxWindow caps the window a zstd frame may ask for, so a crafted
+// header can't make the decoder allocate gigabytes up front.
+const zstdMaxWindow = 128 << 20
+
+// NewReader wraps r so that gzip and zstd content is decoded transparently.
+//
+// The format is detected from the leading magic bytes rather than the file
+// extension, so rotated logs like "tap.log.1" or "tap.log.gz" both work.
+// Anything that isn't recognised is returned as plain text. Closing the
+// result releases decoder resources but does not close r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zstdMagic)) // Short files just won't match
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		// gzip.Reader handles concatenated members (e.g. appended rotations)
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return gz, nil
+	case bytes.Equal(magic, zstdMagic):
+		// Concatenated frames and skippable frames are decoded in order
+		dec, err := zstd.NewReader(br,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(zstdMaxWindow))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+// ErrTooLarge is returned by a Limit reader once more than its limit has
+// been read.
+var ErrTooLarge = errors.New("decompressed content is too large")
+
+// Limit returns a reader of r that fails with ErrTooLarge after max bytes,
+// so a small compressed file can't expand to fill memory.
+func Limit(r io.Reader, max int64) io.Reader {
+	return &limitReader{r: r, left: max}
+}
+
+type limitReader struct {
+	r    io.Reader
+	left int64 // Bytes that may still be read
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, ErrTooLarge
+	}
+	// Read one byte past the limit to tell "exactly max" from "more"
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n + int(l.left), ErrTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,74 @@
+package decompress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestNewReader(t *testing.T) {
+	plain, err := os.ReadFile(filepath.Join("..", "..", "testdata", "multiline_test.log"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zst, err := os.ReadFile(filepath.Join("..", "..", "testdata", "multiline_test.log.zst"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write(plain)
+	w.Close()
+
+	// Two frames back to back, as when rotated logs are appended
+	enc, _ := zstd.NewWriter(nil)
+	half := len(plain) / 2
+	frames := enc.EncodeAll(plain[half:], enc.EncodeAll(plain[:half], nil))
+	enc.Close()
+
+	tests := map[string][]byte{
+		"plain":        plain,
+		"gzip":         gz.Bytes(),
+		"zstd":         zst,
+		"zstd, frames": frames,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewReader(bytes.NewReader(input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, plain) {
+				t.Errorf("expected the original content, got %d bytes (want %d)", len(got), len(plain))
+			}
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	got, err := io.ReadAll(Limit(strings.NewReader("12345"), 5))
+	if err != nil || string(got) != "12345" {
+		t.Errorf("at the limit: got %q, %v", got, err)
+	}
+
+	got, err = io.ReadAll(Limit(strings.NewReader("123456"), 5))
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("over the limit: expected ErrTooLarge, got %v", err)
+	}
+	if string(got) != "12345" {
+		t.Errorf("over the limit: expected the first 5 bytes, got %q", got)
+	}
+}
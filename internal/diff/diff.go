@@ -5,11 +5,14 @@
 package diff
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
 
 	"jtool/internal/normalize"
+	"jtool/internal/parser"
 )
 
 // Compare performs a diff between two parsed JSON values.
@@ -17,13 +20,14 @@ import (
 //
 // Returns a DiffResult containing the full diff tree and statistics.
 func Compare(left, right any) *DiffResult {
-	root := compareValues(left, right, "")
-	stats := calculateStats(root)
+	result, _ := CompareContext(context.Background(), left, right)
+	return result
+}
 
-	return &DiffResult{
-		Root:  root,
-		Stats: stats,
-	}
+// CompareContext is Compare, giving up with ctx.Err() soon after ctx is
+// cancelled.
+func CompareContext(ctx context.Context, left, right any) (*DiffResult, error) {
+	return CompareWithProgress(ctx, left, right, nil)
 }
 
 // CompareWithOptions performs a diff with normalization applied first.
@@ -43,7 +47,8 @@ func CompareWithOptions(left, right any, opts normalize.Options) *DiffResult {
 	rightNorm := normalize.Value(right, opts)
 
 	// Now compare the normalized values
-	root := compareValues(leftNorm, rightNorm, "")
+	root := compareValues(&comparison{ctx: context.Background()}, leftNorm, rightNorm, "")
+	annotateNumbers(&root)
 	stats := calculateStats(root)
 
 	return &DiffResult{
@@ -53,7 +58,8 @@ func CompareWithOptions(left, right any, opts normalize.Options) *DiffResult {
 }
 
 // compareValues recursively compares two values and returns a DiffNode.
-// path is the JSON path to this value (e.g., "$.users[0].name").
+// path is the JSON path to this value (e.g., ".users[0].name"). Once c's
+// context is cancelled, containers stop comparing their remaining children.
 //
 // Go type assertions explained:
 //   - In Python, you'd just access dict keys or list indices directly
@@ -61,7 +67,9 @@ func CompareWithOptions(left, right any, opts normalize.Options) *DiffResult {
 //   - leftMap, ok := left.(map[string]any) attempts to convert `left` to a map
 //   - If successful, ok is true and leftMap contains the map
 //   - If not, ok is false and leftMap is the zero value (nil for maps)
-func compareValues(left, right any, path string) DiffNode {
+func compareValues(c *comparison, left, right any, path string) DiffNode {
+	c.visit()
+
 	// Both nil/null - equal
 	if left == nil && right == nil {
 		return DiffNode{
@@ -91,6 +99,7 @@ func compareValues(left, right any, path string) DiffNode {
 	//   - map[string]any for objects
 	//   - []any for arrays
 	//   - float64 for numbers (always float64, even for integers!)
+	//     or json.Number when parsed with parser.Parse
 	//   - string for strings
 	//   - bool for booleans
 	//   - nil for null
@@ -100,7 +109,7 @@ func compareValues(left, right any, path string) DiffNode {
 
 	// Both are objects - compare recursively
 	if leftIsMap && rightIsMap {
-		return compareObjects(leftMap, rightMap, path)
+		return compareObjects(c, leftMap, rightMap, path)
 	}
 
 	leftArr, leftIsArr := left.([]any)
@@ -108,7 +117,24 @@ func compareValues(left, right any, path string) DiffNode {
 
 	// Both are arrays - compare element by element
 	if leftIsArr && rightIsArr {
-		return compareArrays(leftArr, rightArr, path)
+		return compareArrays(c, leftArr, rightArr, path)
+	}
+
+	// Numbers compare by value, so json.Number "1.0" equals 1 and
+	// big integers are compared exactly instead of through float64
+	if cmp, ok := parser.CompareNumbers(left, right); ok {
+		if cmp == 0 {
+			return DiffNode{
+				Path: path,
+				Type: DiffEqual,
+			}
+		}
+		return DiffNode{
+			Path:  path,
+			Type:  DiffChanged,
+			Left:  left,
+			Right: right,
+		}
 	}
 
 	// Different types - this is a change
@@ -147,33 +173,18 @@ func compareValues(left, right any, path string) DiffNode {
 //     - If only in left: removed
 //     - If only in right: added
 //     - If in both: recurse
-func compareObjects(left, right map[string]any, path string) DiffNode {
+func compareObjects(c *comparison, left, right map[string]any, path string) DiffNode {
 	node := DiffNode{
 		Path:     path,
 		Type:     DiffEqual, // Will be updated if children have diffs
 		Children: []DiffNode{},
 	}
 
-	// Collect all unique keys from both maps
-	// Go maps don't have a union operation like Python's dict.keys() | other.keys()
-	// We build a set manually using map[string]bool
-	allKeys := make(map[string]bool)
-	for k := range left {
-		allKeys[k] = true
-	}
-	for k := range right {
-		allKeys[k] = true
-	}
-
-	// Sort keys for deterministic output
-	sortedKeys := make([]string, 0, len(allKeys))
-	for k := range allKeys {
-		sortedKeys = append(sortedKeys, k)
-	}
-	sort.Strings(sortedKeys)
-
 	// Compare each key
-	for _, key := range sortedKeys {
+	for _, key := range sortedUnionKeys(left, right) {
+		if c.ctx.Err() != nil {
+			break
+		}
 		childPath := fmt.Sprintf("%s.%s", path, key)
 
 		leftVal, inLeft := left[key]
@@ -196,7 +207,7 @@ func compareObjects(left, right map[string]any, path string) DiffNode {
 			}
 		} else {
 			// Key in both - recurse
-			child = compareValues(leftVal, rightVal, childPath)
+			child = compareValues(c, leftVal, rightVal, childPath)
 		}
 
 		node.Children = append(node.Children, child)
@@ -210,9 +221,31 @@ func compareObjects(left, right map[string]any, path string) DiffNode {
 	return node
 }
 
+// sortedUnionKeys returns every key present in either map, sorted for
+// deterministic output.
+func sortedUnionKeys(left, right map[string]any) []string {
+	// Collect all unique keys from both maps
+	// Go maps don't have a union operation like Python's dict.keys() | other.keys()
+	// We build a set manually using map[string]bool
+	allKeys := make(map[string]bool)
+	for k := range left {
+		allKeys[k] = true
+	}
+	for k := range right {
+		allKeys[k] = true
+	}
+
+	sortedKeys := make([]string, 0, len(allKeys))
+	for k := range allKeys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	return sortedKeys
+}
+
 // compareArrays compares two JSON arrays element by element.
 // Uses simple index-by-index comparison (order matters).
-func compareArrays(left, right []any, path string) DiffNode {
+func compareArrays(c *comparison, left, right []any, path string) DiffNode {
 	node := DiffNode{
 		Path:     path,
 		Type:     DiffEqual,
@@ -226,6 +259,9 @@ func compareArrays(left, right []any, path string) DiffNode {
 	}
 
 	for i := 0; i < maxLen; i++ {
+		if c.ctx.Err() != nil {
+			break
+		}
 		childPath := fmt.Sprintf("%s[%d]", path, i)
 
 		var child DiffNode
@@ -245,7 +281,7 @@ func compareArrays(left, right []any, path string) DiffNode {
 			}
 		} else {
 			// Index in both - recurse
-			child = compareValues(left[i], right[i], childPath)
+			child = compareValues(c, left[i], right[i], childPath)
 		}
 
 		node.Children = append(node.Children, child)
@@ -258,6 +294,20 @@ func compareArrays(left, right []any, path string) DiffNode {
 	return node
 }
 
+// annotateNumbers fills LeftText/RightText for json.Number values that a
+// float64 can't represent, so the UI can show them without rounding.
+func annotateNumbers(node *DiffNode) {
+	if n, ok := node.Left.(json.Number); ok && parser.IsLossy(n) {
+		node.LeftText = n.String()
+	}
+	if n, ok := node.Right.(json.Number); ok && parser.IsLossy(n) {
+		node.RightText = n.String()
+	}
+	for i := range node.Children {
+		annotateNumbers(&node.Children[i])
+	}
+}
+
 // calculateStats walks the diff tree and counts each type of difference.
 func calculateStats(node DiffNode) DiffStats {
 	stats := DiffStats{}
@@ -267,6 +317,11 @@ func calculateStats(node DiffNode) DiffStats {
 
 // walkAndCount recursively counts diff types.
 func walkAndCount(stats *DiffStats, node DiffNode) {
+	// Suppressed subtrees are reported separately (see ApplyIgnoreRules)
+	if node.Ignored != "" {
+		return
+	}
+
 	// Only count leaf nodes (nodes without children)
 	// This avoids double-counting parent objects/arrays
 	if len(node.Children) == 0 {
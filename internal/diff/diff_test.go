@@ -1,10 +1,14 @@
 package diff
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
 
 	"jtool/internal/normalize"
+	"jtool/internal/parser"
 )
 
 // TestCompare uses table-driven tests, a common Go testing pattern.
@@ -194,7 +198,7 @@ func TestCompare(t *testing.T) {
 			}
 
 			// Check stats
-			if result.Stats != tt.expectedStats {
+			if !reflect.DeepEqual(result.Stats, tt.expectedStats) {
 				t.Errorf("expected stats %+v, got %+v", tt.expectedStats, result.Stats)
 			}
 		})
@@ -633,7 +637,7 @@ func TestCompareWithOptionsNullEqualsAbsent(t *testing.T) {
 				t.Errorf("expected equal=%v, got equal=%v", tt.expectEqual, isEqual)
 			}
 
-			if result.Stats != tt.expectedStats {
+			if !reflect.DeepEqual(result.Stats, tt.expectedStats) {
 				t.Errorf("expected stats %+v, got %+v", tt.expectedStats, result.Stats)
 			}
 		})
@@ -716,7 +720,7 @@ func TestCompareWithOptionsCombined(t *testing.T) {
 					tt.expectEqual, isEqual, result.Root.Type)
 			}
 
-			if result.Stats != tt.expectedStats {
+			if !reflect.DeepEqual(result.Stats, tt.expectedStats) {
 				t.Errorf("expected stats %+v, got %+v", tt.expectedStats, result.Stats)
 			}
 		})
@@ -791,3 +795,46 @@ func TestCompareWithOptionsEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestCompareBigIntegers verifies 64-bit IDs are compared without float64 rounding
+func TestCompareBigIntegers(t *testing.T) {
+	left, _ := parser.ParseString(`{"id": 9007199254740993, "n": 1.0}`)
+	right, _ := parser.ParseString(`{"id": 9007199254740992, "n": 1}`)
+
+	result := Compare(left, right)
+
+	if result.Stats.Changed != 1 || result.Stats.Equal != 1 {
+		t.Fatalf("expected 1 changed and 1 equal, got %+v", result.Stats)
+	}
+
+	for _, child := range result.Root.Children {
+		if child.Path != ".id" {
+			continue
+		}
+		if child.LeftText != "9007199254740993" {
+			t.Errorf("expected LeftText 9007199254740993, got %q", child.LeftText)
+		}
+		if child.RightText != "" {
+			t.Errorf("expected no RightText for an exactly representable number, got %q", child.RightText)
+		}
+	}
+}
+
+func TestCompareContext_Cancelled(t *testing.T) {
+	left, _ := parser.ParseString(`{"a": [1, 2, 3], "b": {"c": true}}`)
+	right, _ := parser.ParseString(`{"a": [1, 2, 4], "b": {"c": false}}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CompareContext(ctx, left, right); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	result, err := CompareContext(context.Background(), left, right)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, Compare(left, right)) {
+		t.Errorf("CompareContext and Compare disagree")
+	}
+}
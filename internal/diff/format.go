@@ -0,0 +1,58 @@
+package diff
+
+import (
+	"strings"
+
+	"jtool/internal/paths"
+)
+
+// FormatPaths returns a copy of result with every node path written in
+// format (paths.FormatJSONPath or paths.FormatPointer), so diffs can be shown
+// and exported in the same notation as path listings and log analyses.
+// Diffing, ignore rules and summarizing work on the dotted paths, so this is
+// the last step before display. The input result is not modified.
+func FormatPaths(result *DiffResult, format string) *DiffResult {
+	if result == nil || format == "" || format == paths.FormatDotted {
+		return result
+	}
+
+	return &DiffResult{
+		Root:  FormatNode(result.Root, format),
+		Stats: result.Stats,
+	}
+}
+
+// FormatNode applies FormatPaths to a single subtree.
+func FormatNode(node DiffNode, format string) DiffNode {
+	if format == "" || format == paths.FormatDotted {
+		return node
+	}
+
+	out := node
+	out.Path = paths.FormatPath(node.Path, format)
+	if len(node.Children) > 0 {
+		out.Children = make([]DiffNode, len(node.Children))
+		for i, child := range node.Children {
+			out.Children[i] = FormatNode(child, format)
+		}
+	}
+	return out
+}
+
+// FindFormattedNode is FindNode for a path written in format, e.g. one taken
+// from a result returned by FormatPaths.
+func FindFormattedNode(root *DiffNode, path, format string) *DiffNode {
+	if paths.FormatPath(root.Path, format) == path {
+		return root
+	}
+	for i := range root.Children {
+		child := &root.Children[i]
+		// In every format a descendant's path starts with its ancestor's
+		if strings.HasPrefix(path, paths.FormatPath(child.Path, format)) {
+			if found := FindFormattedNode(child, path, format); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"testing"
+
+	"jtool/internal/paths"
+)
+
+func TestFormatPaths(t *testing.T) {
+	left := map[string]any{"users": []any{map[string]any{"name": "a"}}}
+	right := map[string]any{"users": []any{map[string]any{"name": "b"}}}
+	full := Compare(left, right)
+
+	if got := FormatPaths(full, paths.FormatDotted); got != full {
+		t.Error("expected the dotted format to return the result unchanged")
+	}
+
+	tests := []struct {
+		format   string
+		root     string
+		expected string
+	}{
+		{paths.FormatJSONPath, "$", "$.users[0].name"},
+		{paths.FormatPointer, "", "/users/0/name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			result := FormatPaths(full, tt.format)
+			if result.Root.Path != tt.root {
+				t.Errorf("expected root %q, got %q", tt.root, result.Root.Path)
+			}
+			if node := FindFormattedNode(&full.Root, tt.expected, tt.format); node == nil || node.Type != DiffChanged {
+				t.Fatalf("expected to find changed node %s, got %+v", tt.expected, node)
+			}
+			if got := result.Root.Children[0].Children[0].Children[0].Path; got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+			if result.Stats.Changed != full.Stats.Changed {
+				t.Errorf("expected stats to be kept, got %+v", result.Stats)
+			}
+		})
+	}
+
+	// The input is not modified
+	if got := full.Root.Children[0].Path; got != ".users" {
+		t.Errorf("expected original path .users, got %s", got)
+	}
+}
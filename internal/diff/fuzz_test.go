@@ -0,0 +1,73 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/normalize"
+	"jtool/internal/parser"
+)
+
+// FuzzCompare checks invariants of Compare on arbitrary pairs of documents:
+//   - it never panics
+//   - a document always equals itself
+//   - equality is symmetric, and swapping sides swaps added/removed counts
+//   - normalized equality is symmetric too
+//   - Recompare gives the same result as a fresh Compare
+func FuzzCompare(f *testing.F) {
+	seeds := [][2]string{
+		{`{"a": 1, "b": [1, 2]}`, `{"a": 1.0, "b": [2, 1], "c": null}`},
+		{`[9007199254740993]`, `[9007199254740992]`},
+		{`[1e400, 0.1]`, `[1e400, 1e-1]`},
+		{`{"a": {"b": {"c": {"d": [[[[[]]]]]}}}}`, `{"a": {"b": {"c": {"d": [[[[[1]]]]]}}}}`},
+		{`"x"`, `{"x": "x"}`},
+		{`null`, `[]`},
+		{`{"": "", "\u0000": 0}`, `{"": " "}`},
+	}
+	for _, seed := range seeds {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, leftJSON, rightJSON string) {
+		left, err := parser.ParseString(leftJSON)
+		if err != nil {
+			return
+		}
+		right, err := parser.ParseString(rightJSON)
+		if err != nil {
+			return
+		}
+
+		self := Compare(left, left)
+		if self.Root.Type != DiffEqual || self.Stats.Added+self.Stats.Removed+self.Stats.Changed != 0 {
+			t.Fatalf("document differs from itself: %q (stats %+v)", leftJSON, self.Stats)
+		}
+
+		forward := Compare(left, right)
+		backward := Compare(right, left)
+		if (forward.Root.Type == DiffEqual) != (backward.Root.Type == DiffEqual) {
+			t.Fatalf("equality is not symmetric for %q and %q", leftJSON, rightJSON)
+		}
+		if forward.Stats.Added != backward.Stats.Removed ||
+			forward.Stats.Removed != backward.Stats.Added ||
+			forward.Stats.Changed != backward.Stats.Changed {
+			t.Fatalf("stats are not symmetric for %q and %q: %+v vs %+v", leftJSON, rightJSON, forward.Stats, backward.Stats)
+		}
+
+		opts := normalize.DefaultOptions()
+		opts.NullEqualsAbsent = true
+		opts.SortArrays = true
+		normForward := CompareWithOptions(left, right, opts)
+		normBackward := CompareWithOptions(right, left, opts)
+		if (normForward.Root.Type == DiffEqual) != (normBackward.Root.Type == DiffEqual) {
+			t.Fatalf("normalized equality is not symmetric for %q and %q", leftJSON, rightJSON)
+		}
+
+		// Editing the right side from a copy of the left must match a full compare
+		leftDoc := NewDocument(left)
+		incremental := Recompare(self, leftDoc, leftDoc, leftDoc, NewDocument(right))
+		if !reflect.DeepEqual(incremental, forward) {
+			t.Fatalf("Recompare differs from Compare for %q and %q", leftJSON, rightJSON)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package diff
+
+import "jtool/internal/pathmatch"
+
+// ApplyIgnoreRules suppresses differences at paths matched by rules.
+//
+// A rule that matches a node suppresses the node and its whole subtree:
+// the nodes are marked equal and tagged with the rule name in Ignored, so
+// the UI can still show what was hidden. Suppressed differences are not
+// counted as added/removed/changed; instead they are tallied in
+// Stats.Suppressed and Stats.SuppressedByRule so reports can say
+// "12 differences ignored by rule timestamp-fields".
+//
+// The input result is not modified.
+func ApplyIgnoreRules(result *DiffResult, rules []IgnoreRule) *DiffResult {
+	if result == nil || len(rules) == 0 {
+		return result
+	}
+
+	byRule := make(map[string]int)
+	root := suppressNode(result.Root, rules, byRule)
+	stats := calculateStats(root)
+
+	for _, count := range byRule {
+		stats.Suppressed += count
+	}
+	if len(byRule) > 0 {
+		stats.SuppressedByRule = byRule
+	}
+
+	return &DiffResult{
+		Root:  root,
+		Stats: stats,
+	}
+}
+
+// suppressNode returns a copy of node with ignored subtrees marked.
+func suppressNode(node DiffNode, rules []IgnoreRule, byRule map[string]int) DiffNode {
+	// Equal subtrees have nothing to suppress
+	if node.Type == DiffEqual {
+		return node
+	}
+
+	for _, rule := range rules {
+		if pathmatch.Match(rule.Pattern, node.Path) {
+			name := rule.Name
+			if name == "" {
+				name = rule.Pattern
+			}
+			byRule[name] += countDifferences(node)
+			return markIgnored(node, name)
+		}
+	}
+
+	if len(node.Children) == 0 {
+		return node
+	}
+
+	// Recurse, then recompute this node's type from the remaining children
+	out := node
+	out.Type = DiffEqual
+	out.Children = make([]DiffNode, len(node.Children))
+	for i, child := range node.Children {
+		out.Children[i] = suppressNode(child, rules, byRule)
+		if out.Children[i].Type != DiffEqual {
+			out.Type = DiffChanged
+		}
+	}
+	return out
+}
+
+// markIgnored returns a copy of the subtree with every node marked as equal
+// and tagged with the rule name. Left/Right values are kept for display.
+func markIgnored(node DiffNode, rule string) DiffNode {
+	out := node
+	out.Type = DiffEqual
+	out.Ignored = rule
+	if len(node.Children) > 0 {
+		out.Children = make([]DiffNode, len(node.Children))
+		for i, child := range node.Children {
+			out.Children[i] = markIgnored(child, rule)
+		}
+	}
+	return out
+}
+
+// countDifferences counts the non-equal leaf nodes in a subtree,
+// using the same leaf-only rule as calculateStats.
+func countDifferences(node DiffNode) int {
+	if len(node.Children) == 0 {
+		if node.Type != DiffEqual {
+			return 1
+		}
+		return 0
+	}
+
+	count := 0
+	for _, child := range node.Children {
+		count += countDifferences(child)
+	}
+	return count
+}
@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyIgnoreRules(t *testing.T) {
+	leftJSON := `{"id": 1, "name": "Alice", "updated_at": "2024-01-01", "meta": {"updated_at": "x", "etag": "a"}}`
+	rightJSON := `{"id": 1, "name": "Bob", "updated_at": "2024-02-01", "meta": {"updated_at": "y", "etag": "b"}}`
+
+	var left, right any
+	json.Unmarshal([]byte(leftJSON), &left)
+	json.Unmarshal([]byte(rightJSON), &right)
+
+	original := Compare(left, right)
+	rules := []IgnoreRule{
+		{Name: "timestamp-fields", Pattern: ".**.updated_at"},
+		{Pattern: ".meta.etag"},
+	}
+	result := ApplyIgnoreRules(original, rules)
+
+	if result.Stats.Changed != 1 {
+		t.Errorf("expected 1 changed, got %d", result.Stats.Changed)
+	}
+	if result.Stats.Equal != 1 {
+		t.Errorf("expected 1 equal, got %d", result.Stats.Equal)
+	}
+	if result.Stats.Suppressed != 3 {
+		t.Errorf("expected 3 suppressed, got %d", result.Stats.Suppressed)
+	}
+	if got := result.Stats.SuppressedByRule["timestamp-fields"]; got != 2 {
+		t.Errorf("expected 2 suppressed by timestamp-fields, got %d", got)
+	}
+	if got := result.Stats.SuppressedByRule[".meta.etag"]; got != 1 {
+		t.Errorf("expected unnamed rule to be keyed by pattern, got %d", got)
+	}
+
+	// The original result must be untouched
+	if original.Stats.Changed != 4 || original.Stats.Suppressed != 0 {
+		t.Errorf("original result was modified: %+v", original.Stats)
+	}
+}
+
+func TestApplyIgnoreRulesWholeSubtree(t *testing.T) {
+	var left, right any
+	json.Unmarshal([]byte(`{"a": 1, "meta": {"x": 1, "y": 2}}`), &left)
+	json.Unmarshal([]byte(`{"a": 1, "meta": {"x": 2, "y": 3}}`), &right)
+
+	result := ApplyIgnoreRules(Compare(left, right), []IgnoreRule{{Name: "meta", Pattern: ".meta"}})
+
+	if result.Root.Type != DiffEqual {
+		t.Errorf("expected root to be equal after suppression, got %s", result.Root.Type)
+	}
+	if result.Stats.Suppressed != 2 {
+		t.Errorf("expected 2 suppressed, got %d", result.Stats.Suppressed)
+	}
+	for _, child := range result.Root.Children {
+		if child.Path == ".meta" && child.Ignored != "meta" {
+			t.Errorf("expected .meta to be tagged with rule name, got %q", child.Ignored)
+		}
+	}
+}
+
+func TestApplyIgnoreRulesNoRules(t *testing.T) {
+	result := Compare("a", "b")
+	if got := ApplyIgnoreRules(result, nil); got != result {
+		t.Error("expected result to be returned unchanged when there are no rules")
+	}
+}
@@ -0,0 +1,325 @@
+package diff
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Document is a parsed JSON value together with a hash of each of its
+// subtrees. Recompare uses the hashes to find the subtrees an edit left
+// alone without walking them, so keep the Document of a pane that didn't
+// change instead of building a new one.
+type Document struct {
+	root *subtree
+}
+
+// subtree is the hash of one value in a Document and of its children.
+type subtree struct {
+	sum   [16]byte
+	value any
+	keys  map[string]*subtree // Children of an object
+	elems []*subtree          // Children of an array
+}
+
+// NewDocument hashes every subtree of v, the result of json.Unmarshal or
+// parser.Parse into `any`.
+func NewDocument(v any) *Document {
+	return &Document{root: hashSubtree(fnv.New128a(), v)}
+}
+
+// Value returns the value the Document was built from.
+func (d *Document) Value() any {
+	return d.root.value
+}
+
+// hashSubtree hashes v after its children, so each hash covers the whole
+// subtree. Scalars are hashed by their exact representation rather than
+// their numeric value: a reused node keeps the Left/Right values it was
+// built with, so 1 and 1.0 must not be mistaken for each other.
+func hashSubtree(h hash.Hash, v any) *subtree {
+	t := &subtree{value: v}
+
+	// Children are hashed first, since they share h
+	switch v := v.(type) {
+	case map[string]any:
+		t.keys = make(map[string]*subtree, len(v))
+		for k, child := range v {
+			t.keys[k] = hashSubtree(h, child)
+		}
+	case []any:
+		t.elems = make([]*subtree, len(v))
+		for i, child := range v {
+			t.elems[i] = hashSubtree(h, child)
+		}
+	}
+
+	h.Reset()
+	switch v := v.(type) {
+	case nil:
+		h.Write([]byte{'z'})
+	case bool:
+		if v {
+			h.Write([]byte{'t'})
+		} else {
+			h.Write([]byte{'f'})
+		}
+	case string:
+		writeHashString(h, 's', v)
+	case json.Number:
+		writeHashString(h, 'n', string(v))
+	case float64:
+		var buf [9]byte
+		buf[0] = 'd'
+		binary.BigEndian.PutUint64(buf[1:], math.Float64bits(v))
+		h.Write(buf[:])
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		h.Write([]byte{'o'})
+		for _, k := range keys {
+			writeHashString(h, 'k', k)
+			h.Write(t.keys[k].sum[:])
+		}
+	case []any:
+		h.Write([]byte{'a'})
+		for _, elem := range t.elems {
+			h.Write(elem.sum[:])
+		}
+	default:
+		writeHashString(h, 'x', fmt.Sprintf("%T:%v", v, v))
+	}
+	h.Sum(t.sum[:0])
+	return t
+}
+
+// writeHashString writes a tagged, length-prefixed string so that
+// neighbouring strings can't run into each other.
+func writeHashString(h hash.Hash, tag byte, s string) {
+	var buf [1 + binary.MaxVarintLen64]byte
+	buf[0] = tag
+	n := binary.PutUvarint(buf[1:], uint64(len(s)))
+	h.Write(buf[:1+n])
+	h.Write([]byte(s))
+}
+
+// Recompare updates a previous diff after one or both inputs changed.
+//
+// prevLeft/prevRight are the documents that produced prev, and left/right
+// are the new documents. A subtree whose hashes are unchanged on both sides
+// reuses the node from prev without being walked, and Stats are adjusted
+// by what the replaced subtrees counted, so an edit only costs as much as
+// the branches it touched. This keeps live editing of large documents
+// responsive.
+//
+// If prev is nil this is equivalent to Compare(left.Value(), right.Value()).
+func Recompare(prev *DiffResult, prevLeft, prevRight, left, right *Document) *DiffResult {
+	if prev == nil {
+		return Compare(left.Value(), right.Value())
+	}
+
+	r := &recomparison{
+		c: &comparison{ctx: context.Background()},
+		stats: DiffStats{
+			Added:   prev.Stats.Added,
+			Removed: prev.Stats.Removed,
+			Changed: prev.Stats.Changed,
+			Equal:   prev.Stats.Equal,
+		},
+	}
+	root := r.values(&prev.Root, prevLeft.root, prevRight.root, left.root, right.root, "")
+
+	return &DiffResult{
+		Root:  root,
+		Stats: r.stats,
+	}
+}
+
+// recomparison holds the state of one Recompare call.
+type recomparison struct {
+	c     *comparison
+	stats DiffStats // prev's stats, adjusted as subtrees are replaced
+}
+
+// values reuses prevNode when both inputs are unchanged, otherwise descends
+// into matching containers so untouched siblings can be reused.
+func (r *recomparison) values(prevNode *DiffNode, prevLeft, prevRight, left, right *subtree, path string) DiffNode {
+	if prevLeft.sum == left.sum && prevRight.sum == right.sum {
+		return *prevNode
+	}
+
+	// Both sides are still objects - reuse unchanged keys
+	if prevLeft.keys != nil && prevRight.keys != nil && left.keys != nil && right.keys != nil {
+		return r.objects(prevNode, prevLeft, prevRight, left, right, path)
+	}
+
+	// Both sides are still arrays - reuse unchanged indices
+	if prevLeft.elems != nil && prevRight.elems != nil && left.elems != nil && right.elems != nil {
+		return r.arrays(prevNode, prevLeft, prevRight, left, right, path)
+	}
+
+	// Shape changed - fall back to a full comparison of this subtree
+	node := compareValues(r.c, left.value, right.value, path)
+	r.replace(prevNode, &node)
+	return node
+}
+
+// objects is the incremental counterpart of compareObjects.
+func (r *recomparison) objects(prevNode *DiffNode, prevLeft, prevRight, left, right *subtree, path string) DiffNode {
+	// Index previous children by path for O(1) lookup
+	prevChildren := make(map[string]*DiffNode, len(prevNode.Children))
+	for i := range prevNode.Children {
+		prevChildren[prevNode.Children[i].Path] = &prevNode.Children[i]
+	}
+
+	node := DiffNode{
+		Path:     path,
+		Type:     DiffEqual,
+		Children: []DiffNode{},
+	}
+
+	for _, key := range sortedUnionKeys(left.value.(map[string]any), right.value.(map[string]any)) {
+		childPath := fmt.Sprintf("%s.%s", path, key)
+
+		leftVal, inLeft := left.keys[key]
+		rightVal, inRight := right.keys[key]
+		wasLeft, wasInLeft := prevLeft.keys[key]
+		wasRight, wasInRight := prevRight.keys[key]
+		prev, hadChild := prevChildren[childPath]
+		delete(prevChildren, childPath)
+
+		var child DiffNode
+		if inLeft && inRight && hadChild && wasInLeft && wasInRight {
+			child = r.values(prev, wasLeft, wasRight, leftVal, rightVal, childPath)
+		} else {
+			if !inLeft {
+				child = DiffNode{
+					Path:  childPath,
+					Type:  DiffAdded,
+					Right: rightVal.value,
+				}
+			} else if !inRight {
+				child = DiffNode{
+					Path: childPath,
+					Type: DiffRemoved,
+					Left: leftVal.value,
+				}
+			} else {
+				child = compareValues(r.c, leftVal.value, rightVal.value, childPath)
+			}
+			r.replace(prev, &child)
+		}
+
+		node.Children = append(node.Children, child)
+
+		if child.Type != DiffEqual {
+			node.Type = DiffChanged
+		}
+	}
+
+	// Keys that are gone from both sides
+	for _, prev := range prevChildren {
+		r.count(*prev, -1)
+	}
+	r.recount(prevNode, &node)
+	return node
+}
+
+// arrays is the incremental counterpart of compareArrays.
+func (r *recomparison) arrays(prevNode *DiffNode, prevLeft, prevRight, left, right *subtree, path string) DiffNode {
+	node := DiffNode{
+		Path:     path,
+		Type:     DiffEqual,
+		Children: []DiffNode{},
+	}
+
+	maxLen := len(left.elems)
+	if len(right.elems) > maxLen {
+		maxLen = len(right.elems)
+	}
+
+	for i := 0; i < maxLen; i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+
+		// Children of a compared array are laid out one per index
+		var prev *DiffNode
+		if i < len(prevNode.Children) {
+			prev = &prevNode.Children[i]
+		}
+
+		var child DiffNode
+		if prev != nil && i < len(left.elems) && i < len(right.elems) && i < len(prevLeft.elems) && i < len(prevRight.elems) {
+			child = r.values(prev, prevLeft.elems[i], prevRight.elems[i], left.elems[i], right.elems[i], childPath)
+		} else {
+			if i >= len(left.elems) {
+				child = DiffNode{
+					Path:  childPath,
+					Type:  DiffAdded,
+					Right: right.elems[i].value,
+				}
+			} else if i >= len(right.elems) {
+				child = DiffNode{
+					Path: childPath,
+					Type: DiffRemoved,
+					Left: left.elems[i].value,
+				}
+			} else {
+				child = compareValues(r.c, left.elems[i].value, right.elems[i].value, childPath)
+			}
+			r.replace(prev, &child)
+		}
+
+		node.Children = append(node.Children, child)
+
+		if child.Type != DiffEqual {
+			node.Type = DiffChanged
+		}
+	}
+
+	// Indices past the end of both sides
+	for i := maxLen; i < len(prevNode.Children); i++ {
+		r.count(prevNode.Children[i], -1)
+	}
+	r.recount(prevNode, &node)
+	return node
+}
+
+// replace accounts for a newly compared node taking the place of prev,
+// which is nil when nothing was at its path before.
+func (r *recomparison) replace(prev, node *DiffNode) {
+	annotateNumbers(node)
+	if prev != nil {
+		r.count(*prev, -1)
+	}
+	r.count(*node, 1)
+}
+
+// recount accounts for a container that was rebuilt from its children.
+// The children are counted as they are replaced, but a container without
+// children is a leaf and counts itself.
+func (r *recomparison) recount(prev, node *DiffNode) {
+	if len(prev.Children) == 0 {
+		r.count(*prev, -1)
+	}
+	if len(node.Children) == 0 {
+		r.count(*node, 1)
+	}
+}
+
+// count adds the stats of node's subtree, negated when sign is -1.
+func (r *recomparison) count(node DiffNode, sign int) {
+	var stats DiffStats
+	walkAndCount(&stats, node)
+	r.stats.Added += sign * stats.Added
+	r.stats.Removed += sign * stats.Removed
+	r.stats.Changed += sign * stats.Changed
+	r.stats.Equal += sign * stats.Equal
+}
@@ -0,0 +1,177 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+func TestRecompareMatchesFullCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		leftJSON  string
+		rightJSON string
+		newLeft   string
+		newRight  string
+	}{
+		{
+			name:      "leaf edit in nested object",
+			leftJSON:  `{"user": {"name": "Alice", "age": 30}, "tags": ["a", "b"]}`,
+			rightJSON: `{"user": {"name": "Alice", "age": 30}, "tags": ["a", "b"]}`,
+			newLeft:   `{"user": {"name": "Alice", "age": 30}, "tags": ["a", "b"]}`,
+			newRight:  `{"user": {"name": "Bob", "age": 30}, "tags": ["a", "b"]}`,
+		},
+		{
+			name:      "key added and removed",
+			leftJSON:  `{"a": 1, "b": 2}`,
+			rightJSON: `{"a": 1, "b": 2}`,
+			newLeft:   `{"a": 1, "c": 3}`,
+			newRight:  `{"a": 1, "b": 2}`,
+		},
+		{
+			name:      "array grows",
+			leftJSON:  `[1, 2, 3]`,
+			rightJSON: `[1, 2, 3]`,
+			newLeft:   `[1, 2, 3]`,
+			newRight:  `[1, 2, 3, 4]`,
+		},
+		{
+			name:      "type change object to array",
+			leftJSON:  `{"a": {"b": 1}}`,
+			rightJSON: `{"a": {"b": 1}}`,
+			newLeft:   `{"a": {"b": 1}}`,
+			newRight:  `{"a": [1]}`,
+		},
+		{
+			name:      "edit reverts to equal",
+			leftJSON:  `{"a": 1}`,
+			rightJSON: `{"a": 2}`,
+			newLeft:   `{"a": 1}`,
+			newRight:  `{"a": 1}`,
+		},
+		{
+			name:      "key removed from both sides",
+			leftJSON:  `{"a": {"b": 1, "c": [1, 2]}, "d": 1}`,
+			rightJSON: `{"a": {"b": 2, "c": [1, 3]}, "d": 1}`,
+			newLeft:   `{"a": {"b": 1}, "d": 1}`,
+			newRight:  `{"a": {"b": 2}, "d": 1}`,
+		},
+		{
+			name:      "arrays shrink",
+			leftJSON:  `[[1, 2], 3, 4]`,
+			rightJSON: `[[1, 5], 3]`,
+			newLeft:   `[[1]]`,
+			newRight:  `[[1, 5]]`,
+		},
+		{
+			name:      "containers become empty",
+			leftJSON:  `{"a": [1, 2], "b": {"c": 1}}`,
+			rightJSON: `{"a": [1, 3], "b": {"c": 2}}`,
+			newLeft:   `{"a": [], "b": {}}`,
+			newRight:  `{"a": [], "b": {}}`,
+		},
+		{
+			name:      "empty containers fill up",
+			leftJSON:  `{"a": [], "b": {}}`,
+			rightJSON: `{"a": [], "b": {}}`,
+			newLeft:   `{"a": [1], "b": {}}`,
+			newRight:  `{"a": [], "b": {"c": 1}}`,
+		},
+		{
+			name:      "same number, different representation",
+			leftJSON:  `{"a": 1, "b": [2]}`,
+			rightJSON: `{"a": 1, "b": [2]}`,
+			newLeft:   `{"a": 1, "b": [2]}`,
+			newRight:  `{"a": 1.0, "b": [2.0]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var left, right, newLeft, newRight any
+			json.Unmarshal([]byte(tt.leftJSON), &left)
+			json.Unmarshal([]byte(tt.rightJSON), &right)
+			json.Unmarshal([]byte(tt.newLeft), &newLeft)
+			json.Unmarshal([]byte(tt.newRight), &newRight)
+
+			prev := Compare(left, right)
+			got := Recompare(prev, NewDocument(left), NewDocument(right), NewDocument(newLeft), NewDocument(newRight))
+			want := Compare(newLeft, newRight)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Recompare() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRecompareNilPrevious(t *testing.T) {
+	left := map[string]any{"a": 1.0}
+	right := map[string]any{"a": 2.0}
+
+	got := Recompare(nil, nil, nil, NewDocument(left), NewDocument(right))
+	if got.Stats.Changed != 1 {
+		t.Errorf("expected 1 changed, got %d", got.Stats.Changed)
+	}
+}
+
+func TestRecompareReusesUnchangedSubtrees(t *testing.T) {
+	left := map[string]any{
+		"big":   []any{1.0, 2.0, 3.0},
+		"small": "a",
+	}
+	right := map[string]any{
+		"big":   []any{1.0, 2.0, 4.0},
+		"small": "a",
+	}
+	leftDoc, rightDoc := NewDocument(left), NewDocument(right)
+	prev := Compare(left, right)
+
+	// Only "small" changes; "big" must come back as the very same node
+	newRight := map[string]any{
+		"big":   right["big"],
+		"small": "b",
+	}
+	got := Recompare(prev, leftDoc, rightDoc, leftDoc, NewDocument(newRight))
+
+	if &got.Root.Children[0].Children[0] != &prev.Root.Children[0].Children[0] {
+		t.Error("expected the unchanged subtree to be reused, not compared again")
+	}
+	if got.Stats.Changed != 2 || got.Stats.Equal != 2 {
+		t.Errorf("expected 2 changed and 2 equal, got %+v", got.Stats)
+	}
+}
+
+func TestNewDocumentHashes(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"key order", `{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`, true},
+		{"nested edit", `{"a": {"b": [1, 2]}}`, `{"a": {"b": [1, 3]}}`, false},
+		{"number text", `[1]`, `[1.0]`, false},
+		{"string vs number", `["1"]`, `[1]`, false},
+		{"keys run together", `{"ab": "c"}`, `{"a": "bc"}`, false},
+		{"null vs empty", `[null]`, `[{}]`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := parser.ParseString(tt.a)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := parser.ParseString(tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			same := NewDocument(a).root.sum == NewDocument(b).root.sum
+			if same != tt.same {
+				t.Errorf("same hash = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package diff
+
+import (
+	"context"
+	"time"
+)
+
+// minProgressNodes is the smallest comparison, in estimated nodes, that
+// reports progress. Smaller ones finish before a progress bar would help.
+const minProgressNodes = 10_000
+
+// progressSteps is about how many times progress is reported while
+// comparing.
+const progressSteps = 100
+
+// Progress reports how far a comparison has got.
+type Progress struct {
+	Compared    int   `json:"compared"`    // Nodes compared so far
+	Total       int   `json:"total"`       // Estimated nodes in all: those of the larger document
+	RemainingMs int64 `json:"remainingMs"` // Estimated time left, from the rate so far
+	Done        bool  `json:"done"`        // Set on the last report, once the diff is complete
+}
+
+// CompareWithProgress is CompareContext, calling report about every 1% of
+// the way through comparisons of at least 10,000 nodes, and once more when
+// done. report is called on the comparing goroutine and may be nil.
+func CompareWithProgress(ctx context.Context, left, right any, report func(Progress)) (*DiffResult, error) {
+	c := &comparison{ctx: ctx}
+	if report != nil {
+		if total := max(countNodes(left), countNodes(right)); total >= minProgressNodes {
+			c.report, c.total, c.start = report, total, time.Now()
+			c.every = total / progressSteps
+			c.next = c.every
+		}
+	}
+
+	root := compareValues(c, left, right, "")
+	if err := ctx.Err(); err != nil {
+		return nil, err // root is incomplete
+	}
+	annotateNumbers(&root)
+	stats := calculateStats(root)
+
+	if c.report != nil {
+		c.report(Progress{Compared: c.compared, Total: max(c.total, c.compared), Done: true})
+	}
+	return &DiffResult{
+		Root:  root,
+		Stats: stats,
+	}, nil
+}
+
+// comparison carries the cancellation and progress state of one diff
+// through the recursion.
+type comparison struct {
+	ctx context.Context
+
+	report   func(Progress) // nil when progress isn't reported
+	total    int            // Estimated nodes to compare
+	every    int            // Nodes between reports
+	next     int            // Compared count at which to report next
+	compared int
+	start    time.Time
+}
+
+// visit counts a compared node, reporting progress when due.
+func (c *comparison) visit() {
+	if c.report == nil {
+		return
+	}
+	c.compared++
+	if c.compared < c.next {
+		return
+	}
+	c.next = c.compared + c.every
+
+	// The estimate can fall short when the documents differ in shape
+	total := max(c.total, c.compared+1)
+	perNode := float64(time.Since(c.start)) / float64(c.compared)
+	remaining := time.Duration(perNode * float64(total-c.compared))
+	c.report(Progress{Compared: c.compared, Total: total, RemainingMs: remaining.Milliseconds()})
+}
+
+// countNodes returns the number of values in v, including v itself.
+func countNodes(v any) int {
+	n := 1
+	switch v := v.(type) {
+	case map[string]any:
+		for _, child := range v {
+			n += countNodes(child)
+		}
+	case []any:
+		for _, child := range v {
+			n += countNodes(child)
+		}
+	}
+	return n
+}
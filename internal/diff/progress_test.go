@@ -0,0 +1,58 @@
+package diff
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCompareWithProgress(t *testing.T) {
+	left := make([]any, 20_000)
+	right := make([]any, 20_000)
+	for i := range left {
+		left[i] = float64(i)
+		right[i] = float64(i % 1000)
+	}
+
+	var reports []Progress
+	result, err := CompareWithProgress(context.Background(), left, right, func(p Progress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, Compare(left, right)) {
+		t.Errorf("CompareWithProgress and Compare disagree")
+	}
+
+	if len(reports) < progressSteps/2 || len(reports) > progressSteps+2 {
+		t.Fatalf("expected about %d reports, got %d", progressSteps, len(reports))
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i].Compared <= reports[i-1].Compared {
+			t.Fatalf("progress went backwards: %+v then %+v", reports[i-1], reports[i])
+		}
+	}
+	last := reports[len(reports)-1]
+	if !last.Done || last.Compared != 20_001 || last.Total != 20_001 || last.RemainingMs != 0 {
+		t.Errorf("unexpected final report %+v", last)
+	}
+	for _, p := range reports[:len(reports)-1] {
+		if p.Done || p.Compared >= p.Total {
+			t.Errorf("unexpected intermediate report %+v", p)
+		}
+	}
+}
+
+func TestCompareWithProgress_SmallDocuments(t *testing.T) {
+	called := false
+	_, err := CompareWithProgress(context.Background(), []any{1.0}, []any{2.0}, func(Progress) {
+		called = true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Error("expected no progress reports for a small comparison")
+	}
+}
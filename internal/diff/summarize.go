@@ -0,0 +1,148 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxListedIndices caps how many differing indices appear in a summary's Text.
+const maxListedIndices = 10
+
+// SummarizeArrays collapses long, mostly-equal arrays in a diff result.
+//
+// An array node is summarized when it has more than threshold elements and
+// more than half of them are equal. Equal elements are dropped from Children
+// and described by the node's Summary instead, so a 5,000 element array with
+// two changes renders as two nodes rather than 5,000. Stats are unchanged.
+//
+// A threshold of 0 or less disables summarization. The input result is not
+// modified; use FindNode on the original result to expand a summarized node.
+func SummarizeArrays(result *DiffResult, threshold int) *DiffResult {
+	if result == nil || threshold <= 0 {
+		return result
+	}
+
+	return &DiffResult{
+		Root:  SummarizeNode(result.Root, threshold),
+		Stats: result.Stats,
+	}
+}
+
+// SummarizeNode applies the SummarizeArrays rules to a single subtree.
+func SummarizeNode(node DiffNode, threshold int) DiffNode {
+	if len(node.Children) == 0 || threshold <= 0 {
+		return node
+	}
+
+	out := node
+	out.Children = make([]DiffNode, 0, len(node.Children))
+
+	if isArrayNode(node) && len(node.Children) > threshold {
+		equalCount := 0
+		for _, child := range node.Children {
+			if child.Type == DiffEqual {
+				equalCount++
+			}
+		}
+
+		if equalCount*2 > len(node.Children) {
+			diffIndices := []int{}
+			for i, child := range node.Children {
+				if child.Type != DiffEqual {
+					diffIndices = append(diffIndices, i)
+					out.Children = append(out.Children, SummarizeNode(child, threshold))
+				}
+			}
+			out.Summary = &ArraySummary{
+				Length:      len(node.Children),
+				EqualCount:  equalCount,
+				DiffIndices: diffIndices,
+				Text:        summaryText(equalCount, diffIndices),
+			}
+			return out
+		}
+	}
+
+	for _, child := range node.Children {
+		out.Children = append(out.Children, SummarizeNode(child, threshold))
+	}
+	return out
+}
+
+// FindNode returns the node at the given path, or nil if there is none.
+func FindNode(root *DiffNode, path string) *DiffNode {
+	if root.Path == path {
+		return root
+	}
+	for i := range root.Children {
+		child := &root.Children[i]
+		// Only descend into children whose path is a prefix of the target
+		if child.Path == path || strings.HasPrefix(path, child.Path+".") || strings.HasPrefix(path, child.Path+"[") {
+			if found := FindNode(child, path); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// isArrayNode reports whether the node's children are array elements.
+// compareArrays always emits children in index order starting at [0].
+func isArrayNode(node DiffNode) bool {
+	return len(node.Children) > 0 && node.Children[0].Path == node.Path+"[0]"
+}
+
+// summaryText builds the human readable description of a summarized array.
+func summaryText(equalCount int, diffIndices []int) string {
+	text := fmt.Sprintf("%s equal elements", formatCount(equalCount))
+	if equalCount == 1 {
+		text = "1 equal element"
+	}
+
+	switch len(diffIndices) {
+	case 0:
+		return text
+	case 1:
+		return fmt.Sprintf("%s, index %d changed", text, diffIndices[0])
+	}
+
+	listed := diffIndices
+	if len(listed) > maxListedIndices {
+		listed = listed[:maxListedIndices]
+	}
+	parts := make([]string, len(listed))
+	for i, idx := range listed {
+		parts[i] = strconv.Itoa(idx)
+	}
+
+	indices := strings.Join(parts, ", ")
+	if more := len(diffIndices) - len(listed); more > 0 {
+		indices += fmt.Sprintf(" and %s more", formatCount(more))
+	}
+	return fmt.Sprintf("%s, indices %s changed", text, indices)
+}
+
+// formatCount formats an integer with thousands separators (4950 → "4,950").
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	if len(s) <= 3 {
+		return s
+	}
+
+	var b strings.Builder
+	lead := len(s) % 3
+	if lead > 0 {
+		b.WriteString(s[:lead])
+	}
+	for i := lead; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeArrays(t *testing.T) {
+	left := make([]any, 5000)
+	right := make([]any, 5000)
+	for i := range left {
+		left[i] = float64(i)
+		right[i] = float64(i)
+	}
+	right[12] = "changed"
+	right[107] = "changed"
+
+	full := Compare(left, right)
+	result := SummarizeArrays(full, 100)
+
+	summary := result.Root.Summary
+	if summary == nil {
+		t.Fatal("expected root array to be summarized")
+	}
+	if summary.EqualCount != 4998 {
+		t.Errorf("expected 4998 equal elements, got %d", summary.EqualCount)
+	}
+	if len(result.Root.Children) != 2 {
+		t.Errorf("expected 2 remaining children, got %d", len(result.Root.Children))
+	}
+	if want := "4,998 equal elements, indices 12, 107 changed"; summary.Text != want {
+		t.Errorf("Text = %q, want %q", summary.Text, want)
+	}
+	if !reflect.DeepEqual(result.Stats, full.Stats) {
+		t.Errorf("stats should be preserved: got %+v, want %+v", result.Stats, full.Stats)
+	}
+
+	// The full result is untouched and can be used for expansion
+	if len(full.Root.Children) != 5000 {
+		t.Errorf("expected original result to keep all children, got %d", len(full.Root.Children))
+	}
+}
+
+func TestSummarizeArraysBelowThreshold(t *testing.T) {
+	left := []any{1.0, 2.0, 3.0}
+	right := []any{1.0, 2.0, 4.0}
+
+	result := SummarizeArrays(Compare(left, right), 10)
+	if result.Root.Summary != nil {
+		t.Error("did not expect a short array to be summarized")
+	}
+	if len(result.Root.Children) != 3 {
+		t.Errorf("expected 3 children, got %d", len(result.Root.Children))
+	}
+}
+
+func TestSummarizeArraysMostlyDifferent(t *testing.T) {
+	left := []any{1.0, 2.0, 3.0, 4.0}
+	right := []any{5.0, 6.0, 7.0, 4.0}
+
+	result := SummarizeArrays(Compare(left, right), 2)
+	if result.Root.Summary != nil {
+		t.Error("did not expect a mostly-different array to be summarized")
+	}
+}
+
+func TestFindNode(t *testing.T) {
+	left := map[string]any{"items": []any{map[string]any{"a": 1.0}}}
+	right := map[string]any{"items": []any{map[string]any{"a": 2.0}}}
+	result := Compare(left, right)
+
+	node := FindNode(&result.Root, ".items[0].a")
+	if node == nil {
+		t.Fatal("expected to find .items[0].a")
+	}
+	if node.Type != DiffChanged {
+		t.Errorf("expected changed node, got %s", node.Type)
+	}
+	if FindNode(&result.Root, ".missing") != nil {
+		t.Error("expected nil for a missing path")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 4950: "4,950", 1234567: "1,234,567"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package diff
 
+import "jtool/internal/jsonschema"
+
 // DiffType represents the type of difference found
 type DiffType string
 
@@ -12,11 +14,30 @@ const (
 
 // DiffNode represents a single node in the diff tree
 type DiffNode struct {
-	Path     string     `json:"path"`               // JSON path (e.g., "$.users[0].name")
-	Type     DiffType   `json:"type"`               // Type of difference
-	Left     any        `json:"left,omitempty"`     // Value from left side (if applicable)
-	Right    any        `json:"right,omitempty"`    // Value from right side (if applicable)
+	Path  string   `json:"path"`            // JSON path (e.g., ".users[0].name"); see FormatPaths for other notations
+	Type  DiffType `json:"type"`            // Type of difference
+	Left  any      `json:"left,omitempty"`  // Value from left side (if applicable)
+	Right any      `json:"right,omitempty"` // Value from right side (if applicable)
+
+	// Exact text of numbers that would lose precision as a JavaScript number
+	// (e.g. 9007199254740993). Empty when Left/Right can be shown as-is.
+	LeftText  string `json:"leftText,omitempty"`
+	RightText string `json:"rightText,omitempty"`
+
 	Children []DiffNode `json:"children,omitempty"` // Nested differences
+	Ignored  string     `json:"ignored,omitempty"`  // Name of the ignore rule that suppressed this node
+
+	Summary *ArraySummary `json:"summary,omitempty"` // Set when equal array elements were collapsed
+}
+
+// ArraySummary describes a long array node whose equal elements were
+// collapsed by SummarizeArrays. Only the differing elements remain in
+// the node's Children.
+type ArraySummary struct {
+	Length      int    `json:"length"`      // Number of elements before summarizing
+	EqualCount  int    `json:"equalCount"`  // Equal elements hidden from Children
+	DiffIndices []int  `json:"diffIndices"` // Indices of the elements kept in Children
+	Text        string `json:"text"`        // e.g. "4,950 equal elements, indices 12, 107 changed"
 }
 
 // DiffStats tracks statistics about the diff
@@ -25,10 +46,30 @@ type DiffStats struct {
 	Removed int `json:"removed"` // Count of removed values
 	Changed int `json:"changed"` // Count of changed values
 	Equal   int `json:"equal"`   // Count of equal values
+
+	Suppressed       int            `json:"suppressed"`                 // Differences hidden by ignore rules
+	SuppressedByRule map[string]int `json:"suppressedByRule,omitempty"` // Rule name -> differences it hid
+}
+
+// IgnoreRule suppresses differences at paths matching Pattern.
+// See the pathmatch package for the pattern syntax.
+type IgnoreRule struct {
+	Name    string `json:"name"`    // Label used in stats (e.g. "timestamp-fields"); defaults to Pattern
+	Pattern string `json:"pattern"` // Path pattern (e.g. ".**.updated_at")
 }
 
 // DiffResult is the top-level result of a diff operation
 type DiffResult struct {
-	Root  DiffNode  `json:"root"`  // Root of the diff tree
-	Stats DiffStats `json:"stats"` // Overall statistics
+	ID    string    `json:"id,omitempty"` // Identifies the comparison to the app, for FindInDiff
+	Root  DiffNode  `json:"root"`         // Root of the diff tree
+	Stats DiffStats `json:"stats"`        // Overall statistics
+
+	Validation *SchemaValidation `json:"validation,omitempty"` // Schema violations of both documents, when a schema was given
+}
+
+// SchemaValidation is the result of validating both compared documents
+// against the same JSON Schema.
+type SchemaValidation struct {
+	Left  *jsonschema.Result `json:"left"`
+	Right *jsonschema.Result `json:"right"`
 }
@@ -0,0 +1,154 @@
+// Package fetch downloads JSON documents over HTTP for the compare panes.
+//
+// Requests are plain GETs with a timeout and a cap on the response size, so
+// a slow or huge endpoint can't hang or exhaust the app. TLS certificates
+// are verified unless explicitly disabled, optionally against an extra CA
+// bundle for internal services. Objects in S3 and Google Cloud Storage are
+// read through the cloud providers' CLIs, which already hold the user's
+// credentials (see Object).
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Defaults for Options fields left at zero.
+const (
+	DefaultTimeout  = 30 * time.Second
+	DefaultMaxBytes = 50 * 1024 * 1024 // 50MB
+)
+
+// maxErrorBody is how much of an error response is quoted in the error.
+const maxErrorBody = 200
+
+// ErrTooLarge is returned when a response is over Options.MaxBytes.
+var ErrTooLarge = errors.New("response too large")
+
+// Options configures a request.
+type Options struct {
+	Headers        map[string]string `json:"headers"`        // Extra request headers, e.g. Authorization
+	TimeoutSeconds int               `json:"timeoutSeconds"` // Whole request, including reading the body; 0 uses DefaultTimeout
+	MaxBytes       int64             `json:"maxBytes"`       // Largest body accepted; 0 uses DefaultMaxBytes
+
+	// InsecureSkipVerify accepts any TLS certificate, e.g. a self-signed
+	// one on a dev server. CAFile instead adds a PEM bundle to the system
+	// roots, which keeps verification on.
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+	CAFile             string `json:"caFile"`
+}
+
+// Result is a successful response.
+type Result struct {
+	Body        string `json:"body"`
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType"`
+	FinalURL    string `json:"finalURL"` // URL after redirects
+}
+
+// Get fetches rawURL. Only http and https URLs are allowed, and responses
+// other than 2xx are errors that quote the start of the body.
+func Get(ctx context.Context, rawURL string, opts Options) (*Result, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q: must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid URL: no host")
+	}
+
+	client, err := newClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := DefaultTimeout
+	if opts.TimeoutSeconds > 0 {
+		timeout = time.Duration(opts.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range opts.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, resp.ContentLength, maxBytes)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, maxBytes)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		text := strings.TrimSpace(string(body))
+		if len(text) > maxErrorBody {
+			text = text[:maxErrorBody] + "…"
+		}
+		if text == "" {
+			return nil, fmt.Errorf("server returned %s", resp.Status)
+		}
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, text)
+	}
+
+	return &Result{
+		Body:        string(body),
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		FinalURL:    resp.Request.URL.String(),
+	}, nil
+}
+
+// newClient builds an HTTP client with the TLS settings of opts.
+func newClient(opts Options) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
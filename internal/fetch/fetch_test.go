@@ -0,0 +1,84 @@
+package fetch
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/doc":
+			if r.Header.Get("Authorization") != "Bearer t0ken" {
+				http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1}`))
+		case "/moved":
+			http.Redirect(w, r, "/doc", http.StatusFound)
+		case "/big":
+			w.Write([]byte(strings.Repeat("x", 100)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	auth := Options{Headers: map[string]string{"Authorization": "Bearer t0ken"}}
+
+	result, err := Get(context.Background(), server.URL+"/moved", auth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Body != `{"id": 1}` || result.StatusCode != 200 || result.ContentType != "application/json" || result.FinalURL != server.URL+"/doc" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	_, err = Get(context.Background(), server.URL+"/doc", Options{})
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected a 401 error quoting the body, got %v", err)
+	}
+
+	_, err = Get(context.Background(), server.URL+"/big", Options{MaxBytes: 10})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+
+	for _, bad := range []string{"file:///etc/passwd", "ftp://example.com/x.json", "http://", "::"} {
+		if _, err := Get(context.Background(), bad, Options{}); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestGet_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	// The test server's certificate is self-signed
+	if _, err := Get(context.Background(), server.URL, Options{}); err == nil {
+		t.Error("expected a certificate error")
+	}
+	if _, err := Get(context.Background(), server.URL, Options{InsecureSkipVerify: true}); err != nil {
+		t.Errorf("unexpected error skipping verification: %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, cert, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := Get(context.Background(), server.URL, Options{CAFile: caFile}); err != nil {
+		t.Errorf("unexpected error with the CA file: %v", err)
+	}
+}
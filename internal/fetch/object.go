@@ -0,0 +1,103 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Object downloads an object from cloud storage: s3://bucket/key with the
+// AWS CLI (aws) or gs://bucket/object with the Google Cloud CLI (gcloud).
+//
+// The CLIs find credentials the way they always do (environment
+// variables, config files, SSO sessions, instance metadata), so any
+// bucket readable from a terminal can be read here without configuring
+// the app. Only opts.TimeoutSeconds and opts.MaxBytes apply.
+func Object(ctx context.Context, uri string, opts Options) ([]byte, error) {
+	name, args, err := objectCommand(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := DefaultTimeout
+	if opts.TimeoutSeconds > 0 {
+		timeout = time.Duration(opts.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("reading %s needs the %s command on the PATH: %w", uri, name, err)
+		}
+		return nil, err
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	body, readErr := io.ReadAll(io.LimitReader(stdout, maxBytes+1))
+	if int64(len(body)) > maxBytes {
+		cancel() // Stops the download
+		cmd.Wait()
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, maxBytes)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		text := strings.TrimSpace(stderr.String())
+		if len(text) > maxErrorBody {
+			text = text[:maxErrorBody] + "…"
+		}
+		if text == "" {
+			return nil, fmt.Errorf("%s failed: %w", name, err)
+		}
+		return nil, fmt.Errorf("%s failed: %s", name, text)
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return body, nil
+}
+
+// objectCommand returns the command that writes the object at uri to
+// stdout.
+func objectCommand(uri string) (string, []string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid URI: %w", err)
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("invalid URI: no bucket")
+	}
+	if key := strings.TrimPrefix(u.Path, "/"); key == "" || strings.HasSuffix(key, "/") {
+		return "", nil, fmt.Errorf("invalid URI: %s is not an object", uri)
+	}
+
+	// The URI is passed on as given, since keys may contain characters
+	// that url.URL would escape differently
+	switch u.Scheme {
+	case "s3":
+		return "aws", []string{"s3", "cp", "--only-show-errors", uri, "-"}, nil
+	case "gs":
+		return "gcloud", []string{"storage", "cat", uri}, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported URI scheme %q: must be s3 or gs", u.Scheme)
+	}
+}
@@ -0,0 +1,79 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestObjectCommand(t *testing.T) {
+	name, args, err := objectCommand("s3://logs/2024/app log.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"s3", "cp", "--only-show-errors", "s3://logs/2024/app log.json", "-"}; name != "aws" || !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected command for s3: %s %v", name, args)
+	}
+
+	name, args, err = objectCommand("gs://snapshots/config.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"storage", "cat", "gs://snapshots/config.json"}; name != "gcloud" || !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected command for gs: %s %v", name, args)
+	}
+
+	for _, bad := range []string{"https://example.com/x.json", "s3://", "s3://bucket", "gs://bucket/dir/", "::"} {
+		if _, _, err := objectCommand(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestObject(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script in place of the AWS CLI")
+	}
+
+	// Stand in for the AWS CLI, which gets the URI as its fourth argument
+	dir := t.TempDir()
+	script := `#!/bin/sh
+case "$4" in
+s3://bucket/doc.json) printf '{"id": 1}' ;;
+s3://bucket/big.json) printf '%0100d' 0 ;;
+*) echo "An error occurred (404) when calling the HeadObject operation: Not Found" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "aws"), []byte(script), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("PATH", dir)
+
+	body, err := Object(context.Background(), "s3://bucket/doc.json", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"id": 1}` {
+		t.Errorf("unexpected body: %s", body)
+	}
+
+	_, err = Object(context.Background(), "s3://bucket/missing.json", Options{})
+	if err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Errorf("expected the CLI's error, got %v", err)
+	}
+
+	_, err = Object(context.Background(), "s3://bucket/big.json", Options{MaxBytes: 10})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+
+	_, err = Object(context.Background(), "gs://bucket/doc.json", Options{})
+	if err == nil || !strings.Contains(err.Error(), "gcloud") {
+		t.Errorf("expected a missing gcloud error, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Package gitfile reads files as they were at a git revision, for
+// comparing a document between branches, tags or commits.
+//
+// It runs the git command rather than reading repositories itself, so
+// anything git understands works: worktrees, packed refs, partial clones
+// and revision expressions like "main~3".
+package gitfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Show returns the content of file at revision rev (e.g. "main", "v1.2.0"
+// or a commit hash) of the repository containing the directory repo. file
+// may be absolute or relative to repo.
+func Show(ctx context.Context, repo, file, rev string) ([]byte, error) {
+	if rev == "" {
+		return nil, fmt.Errorf("no revision given")
+	}
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision %q", rev) // Would be taken as an option
+	}
+
+	object, err := objectName(repo, file, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", repo, "cat-file", "blob", object)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("reading git revisions needs the git command on the PATH: %w", err)
+		}
+		if text := strings.TrimSpace(stderr.String()); text != "" {
+			return nil, errors.New(strings.TrimPrefix(text, "fatal: "))
+		}
+		return nil, fmt.Errorf("git failed: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// objectName returns the git object name of file at rev, e.g.
+// "v1.2.0:./config/app.json". The "./" makes the path relative to repo
+// rather than to the top of the repository, so repo may be a subdirectory.
+func objectName(repo, file, rev string) (string, error) {
+	if file == "" {
+		return "", fmt.Errorf("no file given")
+	}
+	rel := file
+	if filepath.IsAbs(file) {
+		var err error
+		rel, err = filepath.Rel(repo, file)
+		if err != nil {
+			return "", err
+		}
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%s is outside %s", file, repo)
+	}
+	return rev + ":./" + rel, nil
+}
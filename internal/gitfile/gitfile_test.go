@@ -0,0 +1,100 @@
+package gitfile
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestObjectName(t *testing.T) {
+	repo := filepath.Join(t.TempDir(), "repo")
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"config/app.json", "main:./config/app.json"},
+		{"./config/../app.json", "main:./app.json"},
+		{filepath.Join(repo, "config", "app.json"), "main:./config/app.json"},
+	}
+	for _, tt := range tests {
+		got, err := objectName(repo, tt.file, "main")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.file, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.file, tt.expected, got)
+		}
+	}
+
+	for _, bad := range []string{"", "../other/app.json", filepath.Join(filepath.Dir(repo), "app.json")} {
+		if _, err := objectName(repo, bad, "main"); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestShow(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "config", "app.json"), []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	git("init", "-q")
+	if err := os.Mkdir(filepath.Join(repo, "config"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	write(`{"version": 1}`)
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	git("tag", "v1")
+	write(`{"version": 2}`)
+	git("commit", "-q", "-a", "-m", "second")
+
+	ctx := context.Background()
+	for rev, expected := range map[string]string{"v1": `{"version": 1}`, "HEAD": `{"version": 2}`} {
+		content, err := Show(ctx, repo, "config/app.json", rev)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", rev, err)
+		}
+		if string(content) != expected {
+			t.Errorf("%s: expected %s, got %s", rev, expected, content)
+		}
+	}
+
+	// Relative to a subdirectory of the repository
+	content, err := Show(ctx, filepath.Join(repo, "config"), "app.json", "HEAD~1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != `{"version": 1}` {
+		t.Errorf("unexpected content: %s", content)
+	}
+
+	if _, err := Show(ctx, repo, "config/missing.json", "v1"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected a missing path error, got %v", err)
+	}
+	for _, bad := range []string{"", "no-such-branch", "--output=x"} {
+		if _, err := Show(ctx, repo, "config/app.json", bad); err == nil {
+			t.Errorf("expected an error for revision %q", bad)
+		}
+	}
+}
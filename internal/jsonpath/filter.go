@@ -0,0 +1,154 @@
+package jsonpath
+
+import (
+	"reflect"
+	"strings"
+
+	"jtool/internal/parser"
+)
+
+// logicalExpr is a filter condition.
+type logicalExpr interface {
+	// test evaluates the condition with @ bound to current.
+	test(current, root any) bool
+}
+
+type orExpr []logicalExpr
+
+func (e orExpr) test(current, root any) bool {
+	for _, sub := range e {
+		if sub.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpr []logicalExpr
+
+func (e andExpr) test(current, root any) bool {
+	for _, sub := range e {
+		if !sub.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpr struct {
+	expr logicalExpr
+}
+
+func (e notExpr) test(current, root any) bool {
+	return !e.expr.test(current, root)
+}
+
+// existsExpr is true when the query selects at least one value: [?@.isbn]
+type existsExpr struct {
+	query queryOperand
+}
+
+func (e existsExpr) test(current, root any) bool {
+	return len(e.query.nodes(current, root)) > 0
+}
+
+// comparisonExpr compares two operands: [?@.price < 10]
+type comparisonExpr struct {
+	left, right operand
+	op          string
+}
+
+// test follows RFC 9535: a query that selects nothing compares equal only
+// to another query that selects nothing, and ordering (<, >, ...) is only
+// defined between two numbers or two strings.
+func (e comparisonExpr) test(current, root any) bool {
+	a, aok := e.left.eval(current, root)
+	b, bok := e.right.eval(current, root)
+
+	equal := aok == bok && (!aok || valuesEqual(a, b))
+	switch e.op {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	}
+
+	if !aok || !bok {
+		return false
+	}
+	cmp, ok := compareOrdered(a, b)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// valuesEqual compares JSON values, treating numbers by value (1 == 1.0).
+func valuesEqual(a, b any) bool {
+	if cmp, ok := parser.CompareNumbers(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareOrdered compares two numbers or two strings.
+func compareOrdered(a, b any) (int, bool) {
+	if cmp, ok := parser.CompareNumbers(a, b); ok {
+		return cmp, true
+	}
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if aok && bok {
+		return strings.Compare(as, bs), true
+	}
+	return 0, false
+}
+
+// operand is one side of a comparison.
+type operand interface {
+	// eval returns the operand's value; ok is false if it has none
+	// (a query that selected nothing or more than one value).
+	eval(current, root any) (value any, ok bool)
+}
+
+// literalOperand is a constant such as 10, 'abc' or null.
+type literalOperand struct {
+	value any
+}
+
+func (o literalOperand) eval(current, root any) (any, bool) {
+	return o.value, true
+}
+
+// queryOperand is a path relative to @ or to $.
+type queryOperand struct {
+	relative bool // @ rather than $
+	segments []segment
+}
+
+func (o queryOperand) nodes(current, root any) []node {
+	start := root
+	if o.relative {
+		start = current
+	}
+	return evalSegments(o.segments, []node{{value: start}}, root)
+}
+
+// eval returns the single value the query selects.
+func (o queryOperand) eval(current, root any) (any, bool) {
+	nodes := o.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].value, true
+}
@@ -0,0 +1,271 @@
+// Package jsonpath evaluates JSONPath queries against decoded JSON.
+//
+// It implements the commonly used subset of JSONPath (RFC 9535):
+//
+//	$                  the root value
+//	.name  ['name']    object member
+//	[0]  [-1]          array element (negative counts from the end)
+//	.*  [*]            every member or element
+//	..name  ..*        recursive descent
+//	[0,2]  ['a','b']   union of selectors
+//	[1:5:2]            array slice (start:end:step)
+//	[?@.price < 10]    filter; also written [?(@.price < 10)]
+//
+// Filters support ==, !=, <, <=, >, >=, &&, ||, ! and parentheses.
+// Operands are paths relative to the current element (@) or the root ($),
+// or literals: numbers, 'strings', "strings", true, false and null.
+// A path on its own tests for existence: [?@.isbn].
+//
+// As a convenience, expressions may start with "." or "[" instead of "$",
+// and "[]" means "[*]", so jtool paths like ".users[].name" work as queries.
+package jsonpath
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Match is one value selected by a query.
+type Match struct {
+	Path  string `json:"path"`  // Concrete jtool path, e.g. ".users[0].name" ("" is the root)
+	Value any    `json:"value"` // The value at Path
+}
+
+// Expr is a compiled JSONPath expression. It is safe for concurrent use.
+type Expr struct {
+	source   string
+	segments []segment
+}
+
+// Compile parses a JSONPath expression.
+func Compile(expr string) (*Expr, error) {
+	p := &exprParser{src: expr}
+	segments, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{source: expr, segments: segments}, nil
+}
+
+// Query compiles expr and runs it against data.
+func Query(data any, expr string) ([]Match, error) {
+	e, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return e.Query(data), nil
+}
+
+// String returns the source expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Query runs the expression against data (the result of json.Unmarshal or
+// parser.Parse) and returns the matches in document order. Object members
+// are visited in key order so results are deterministic.
+func (e *Expr) Query(data any) []Match {
+	root := node{path: "", value: data}
+	nodes := evalSegments(e.segments, []node{root}, data)
+
+	matches := make([]Match, len(nodes))
+	for i, n := range nodes {
+		matches[i] = Match{Path: n.path, Value: n.value}
+	}
+	return matches
+}
+
+// node is a value together with its concrete path.
+type node struct {
+	path  string
+	value any
+}
+
+// segment is one step of a query: a list of selectors applied to each input
+// node, or to each input node and all its descendants (..).
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector picks values out of a single node.
+type selector interface {
+	// sel appends the nodes selected from n to out. root is the document
+	// root, needed by filters that refer to $.
+	sel(n node, root any, out []node) []node
+}
+
+// evalSegments applies segments in turn, starting from nodes.
+func evalSegments(segments []segment, nodes []node, root any) []node {
+	for _, seg := range segments {
+		var next []node
+		for _, n := range nodes {
+			if seg.descendant {
+				for _, d := range descendants(n, nil) {
+					for _, s := range seg.selectors {
+						next = s.sel(d, root, next)
+					}
+				}
+				continue
+			}
+			for _, s := range seg.selectors {
+				next = s.sel(n, root, next)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// descendants returns n followed by every value nested inside it, depth-first.
+func descendants(n node, out []node) []node {
+	out = append(out, n)
+	for _, child := range children(n) {
+		out = descendants(child, out)
+	}
+	return out
+}
+
+// children returns the members of an object (in key order) or the elements
+// of an array. Other values have no children.
+func children(n node) []node {
+	switch v := n.value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]node, len(keys))
+		for i, k := range keys {
+			out[i] = node{path: n.path + "." + k, value: v[k]}
+		}
+		return out
+	case []any:
+		out := make([]node, len(v))
+		for i, item := range v {
+			out[i] = node{path: indexPath(n.path, i), value: item}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// nameSelector selects an object member by name.
+type nameSelector struct {
+	name string
+}
+
+func (s nameSelector) sel(n node, root any, out []node) []node {
+	if obj, ok := n.value.(map[string]any); ok {
+		if v, ok := obj[s.name]; ok {
+			out = append(out, node{path: n.path + "." + s.name, value: v})
+		}
+	}
+	return out
+}
+
+// wildcardSelector selects every member or element.
+type wildcardSelector struct{}
+
+func (wildcardSelector) sel(n node, root any, out []node) []node {
+	return append(out, children(n)...)
+}
+
+// indexSelector selects one array element; negative indices count from the end.
+type indexSelector struct {
+	index int
+}
+
+func (s indexSelector) sel(n node, root any, out []node) []node {
+	arr, ok := n.value.([]any)
+	if !ok {
+		return out
+	}
+	i := s.index
+	if i < 0 {
+		i += len(arr)
+	}
+	if i < 0 || i >= len(arr) {
+		return out
+	}
+	return append(out, node{path: indexPath(n.path, i), value: arr[i]})
+}
+
+// sliceSelector selects a range of array elements like Python's a[start:end:step].
+// Nil bounds take their defaults, which depend on the direction of step.
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceSelector) sel(n node, root any, out []node) []node {
+	arr, ok := n.value.([]any)
+	if !ok || s.step == 0 {
+		return out
+	}
+	length := len(arr)
+
+	// normalize turns a negative index into one counted from the end
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+
+	if s.step > 0 {
+		lower, upper := 0, length
+		if s.start != nil {
+			lower = clamp(normalize(*s.start), 0, length)
+		}
+		if s.end != nil {
+			upper = clamp(normalize(*s.end), 0, length)
+		}
+		for i := lower; i < upper; i += s.step {
+			out = append(out, node{path: indexPath(n.path, i), value: arr[i]})
+		}
+		return out
+	}
+
+	upper, lower := length-1, -1
+	if s.start != nil {
+		upper = clamp(normalize(*s.start), -1, length-1)
+	}
+	if s.end != nil {
+		lower = clamp(normalize(*s.end), -1, length-1)
+	}
+	for i := upper; i > lower; i += s.step {
+		out = append(out, node{path: indexPath(n.path, i), value: arr[i]})
+	}
+	return out
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// filterSelector selects the members or elements for which expr is true.
+type filterSelector struct {
+	expr logicalExpr
+}
+
+func (s filterSelector) sel(n node, root any, out []node) []node {
+	for _, child := range children(n) {
+		if s.expr.test(child.value, root) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
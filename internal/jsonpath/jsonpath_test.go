@@ -0,0 +1,114 @@
+package jsonpath
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+// store is the classic example document from Stefan Goessner's JSONPath article.
+const store = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"expensive": 10
+}`
+
+func TestQuery(t *testing.T) {
+	data, err := parser.ParseString(store)
+	if err != nil {
+		t.Fatalf("failed to parse store: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected []string // Concrete paths of the matches
+	}{
+		{"root", "$", []string{""}},
+		{"child", "$.store.bicycle.color", []string{".store.bicycle.color"}},
+		{"bracket name", "$['store']['bicycle']", []string{".store.bicycle"}},
+		{"index", "$.store.book[0].title", []string{".store.book[0].title"}},
+		{"negative index", "$.store.book[-1].title", []string{".store.book[3].title"}},
+		{"out of range index", "$.store.book[10]", []string{}},
+		{"wildcard", "$.store.book[*].author", []string{".store.book[0].author", ".store.book[1].author", ".store.book[2].author", ".store.book[3].author"}},
+		{"dot wildcard sorts keys", "$.store.*", []string{".store.bicycle", ".store.book"}},
+		{"union", "$.store.book[0,2].title", []string{".store.book[0].title", ".store.book[2].title"}},
+		{"name union", "$.store.bicycle['price','color']", []string{".store.bicycle.price", ".store.bicycle.color"}},
+		{"slice", "$.store.book[1:3].title", []string{".store.book[1].title", ".store.book[2].title"}},
+		{"slice open end", "$.store.book[-2:].title", []string{".store.book[2].title", ".store.book[3].title"}},
+		{"slice step", "$.store.book[::2].title", []string{".store.book[0].title", ".store.book[2].title"}},
+		{"slice reverse", "$.store.book[::-1].price", []string{".store.book[3].price", ".store.book[2].price", ".store.book[1].price", ".store.book[0].price"}},
+		{"recursive descent", "$..author", []string{".store.book[0].author", ".store.book[1].author", ".store.book[2].author", ".store.book[3].author"}},
+		{"recursive price", "$.store..price", []string{".store.bicycle.price", ".store.book[0].price", ".store.book[1].price", ".store.book[2].price", ".store.book[3].price"}},
+		{"filter comparison", "$.store.book[?(@.price < 10)].title", []string{".store.book[0].title", ".store.book[2].title"}},
+		{"filter without parens", "$.store.book[?@.price < 10].title", []string{".store.book[0].title", ".store.book[2].title"}},
+		{"filter existence", "$..book[?(@.isbn)].title", []string{".store.book[2].title", ".store.book[3].title"}},
+		{"filter negation", "$..book[?(!@.isbn)].title", []string{".store.book[0].title", ".store.book[1].title"}},
+		{"filter root reference", "$..book[?(@.price > $.expensive)].title", []string{".store.book[1].title", ".store.book[3].title"}},
+		{"filter string equality", `$..book[?(@.author == "Herman Melville")].price`, []string{".store.book[2].price"}},
+		{"filter and/or", "$..book[?(@.category == 'fiction' && (@.price < 9 || @.price > 20))].title", []string{".store.book[2].title", ".store.book[3].title"}},
+		{"filter not equal missing", "$..book[?(@.isbn != '0-553-21311-3')].title", []string{".store.book[0].title", ".store.book[1].title", ".store.book[3].title"}},
+		{"jtool path", ".store.book[].price", []string{".store.book[0].price", ".store.book[1].price", ".store.book[2].price", ".store.book[3].price"}},
+		{"missing member", "$.store.nope", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := Query(data, tt.expr)
+			if err != nil {
+				t.Fatalf("Query(%q) returned error: %v", tt.expr, err)
+			}
+
+			got := make([]string, len(matches))
+			for i, m := range matches {
+				got[i] = m.Path
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Query(%q) paths = %v, want %v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	data, err := parser.ParseString(`{"a": {"b c": [1, "x", null]}}`)
+	if err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+
+	matches, err := Query(data, `$.a["b c"][1]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Value != "x" {
+		t.Errorf("expected one match with value x, got %v", matches)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	exprs := []string{
+		"",
+		"store",
+		"$.",
+		"$[",
+		"$['unterminated",
+		"$[?(@.a == )]",
+		"$[?(@.a == 1]",
+		"$[?(1)]",
+		"$.a b",
+		"$[1x]",
+	}
+	for _, expr := range exprs {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Compile(%q) expected error", expr)
+		}
+	}
+}
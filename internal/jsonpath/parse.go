@@ -0,0 +1,446 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// exprParser is a recursive-descent parser for JSONPath expressions.
+type exprParser struct {
+	src string
+	pos int
+}
+
+// errorf reports a syntax error at the current position.
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("jsonpath: %s at position %d in %q", fmt.Sprintf(format, args...), p.pos, p.src)
+}
+
+func (p *exprParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *exprParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *exprParser) skipSpace() {
+	for !p.eof() && strings.IndexByte(" \t\n\r", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips whitespace and advances past tok if it comes next.
+func (p *exprParser) consume(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+// parseQuery parses a whole expression: "$" followed by segments.
+func (p *exprParser) parseQuery() ([]segment, error) {
+	p.skipSpace()
+	switch {
+	case p.peek() == '$':
+		p.pos++
+	case p.peek() == '.' || p.peek() == '[':
+		// jtool path like ".users[].name" - the root is implied
+	default:
+		return nil, p.errorf("expression must start with $")
+	}
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return segments, nil
+}
+
+// parseSegments parses segments until something that can't start one.
+func (p *exprParser) parseSegments() ([]segment, error) {
+	segments := []segment{}
+	for {
+		// Whitespace may separate segments, but only if another follows
+		start := p.pos
+		p.skipSpace()
+
+		switch {
+		case strings.HasPrefix(p.src[p.pos:], ".."):
+			p.pos += 2
+			var seg segment
+			var err error
+			if p.peek() == '[' {
+				seg, err = p.parseBracket()
+			} else {
+				seg, err = p.parseDotSelector()
+			}
+			if err != nil {
+				return nil, err
+			}
+			seg.descendant = true
+			segments = append(segments, seg)
+
+		case p.peek() == '.':
+			p.pos++
+			seg, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+
+		case p.peek() == '[':
+			seg, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+
+		default:
+			p.pos = start
+			return segments, nil
+		}
+	}
+}
+
+// parseDotSelector parses what follows "." or "..": a name or "*".
+func (p *exprParser) parseDotSelector() (segment, error) {
+	if p.peek() == '*' {
+		p.pos++
+		return segment{selectors: []selector{wildcardSelector{}}}, nil
+	}
+	name := p.parseName()
+	if name == "" {
+		return segment{}, p.errorf("expected member name")
+	}
+	return segment{selectors: []selector{nameSelector{name: name}}}, nil
+}
+
+// parseName reads a member name for dot notation.
+func (p *exprParser) parseName() string {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !(r == '_' || r == '-' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r) || r >= utf8.RuneSelf) {
+			break
+		}
+		p.pos += size
+	}
+	return p.src[start:p.pos]
+}
+
+// parseBracket parses "[selector, selector, ...]".
+func (p *exprParser) parseBracket() (segment, error) {
+	p.pos++ // '['
+
+	// "[]" is jtool's notation for every element
+	if p.consume("]") {
+		return segment{selectors: []selector{wildcardSelector{}}}, nil
+	}
+
+	seg := segment{}
+	for {
+		s, err := p.parseSelector()
+		if err != nil {
+			return segment{}, err
+		}
+		seg.selectors = append(seg.selectors, s)
+
+		if p.consume(",") {
+			continue
+		}
+		if p.consume("]") {
+			return seg, nil
+		}
+		return segment{}, p.errorf("expected , or ]")
+	}
+}
+
+// parseSelector parses one selector inside brackets.
+func (p *exprParser) parseSelector() (selector, error) {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector{name: name}, nil
+	case c == '?':
+		p.pos++
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	default:
+		return nil, p.errorf("unexpected %q in brackets", string(c))
+	}
+}
+
+// parseIndexOrSlice parses "3", "-1", "1:5", ":2", "::-1" and so on.
+func (p *exprParser) parseIndexOrSlice() (selector, error) {
+	var parts [3]*int
+	count := 0
+	for {
+		p.skipSpace()
+		if p.peek() == '-' || (p.peek() >= '0' && p.peek() <= '9') {
+			n, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			parts[count] = &n
+		}
+		p.skipSpace()
+		if p.peek() != ':' || count == 2 {
+			break
+		}
+		p.pos++
+		count++
+	}
+
+	if count == 0 {
+		// Plain index
+		if parts[0] == nil {
+			return nil, p.errorf("expected index")
+		}
+		return indexSelector{index: *parts[0]}, nil
+	}
+
+	step := 1
+	if parts[2] != nil {
+		step = *parts[2]
+	}
+	return sliceSelector{start: parts[0], end: parts[1], step: step}, nil
+}
+
+// parseInt parses an optionally negative integer.
+func (p *exprParser) parseInt() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid integer")
+	}
+	return n, nil
+}
+
+// parseString parses a single- or double-quoted string with JSON-style escapes.
+func (p *exprParser) parseString() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.src[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", p.errorf("unterminated string")
+			}
+			esc := p.src[p.pos+1]
+			p.pos += 2
+			switch esc {
+			case '\\', '/', '\'', '"':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if p.pos+4 > len(p.src) {
+					return "", p.errorf("invalid \\u escape")
+				}
+				r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid \\u escape")
+				}
+				b.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				return "", p.errorf("invalid escape \\%c", esc)
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseLogicalOr parses "a || b || ...".
+func (p *exprParser) parseLogicalOr() (logicalExpr, error) {
+	first, err := p.parseLogicalAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := orExpr{first}
+	for p.consume("||") {
+		next, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, next)
+	}
+	if len(or) == 1 {
+		return first, nil
+	}
+	return or, nil
+}
+
+// parseLogicalAnd parses "a && b && ...".
+func (p *exprParser) parseLogicalAnd() (logicalExpr, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	and := andExpr{first}
+	for p.consume("&&") {
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, next)
+	}
+	if len(and) == 1 {
+		return first, nil
+	}
+	return and, nil
+}
+
+// parseUnary parses "!expr", "(expr)", an existence test or a comparison.
+func (p *exprParser) parseUnary() (logicalExpr, error) {
+	p.skipSpace()
+
+	if p.peek() == '!' && !strings.HasPrefix(p.src[p.pos:], "!=") {
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+
+	if p.consume("(") {
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return comparisonExpr{left: left, op: op, right: right}, nil
+		}
+	}
+
+	// No operator: a query on its own tests for existence
+	query, ok := left.(queryOperand)
+	if !ok {
+		return nil, p.errorf("expected comparison operator after literal")
+	}
+	return existsExpr{query: query}, nil
+}
+
+// parseOperand parses a query (@... or $...) or a literal.
+func (p *exprParser) parseOperand() (operand, error) {
+	p.skipSpace()
+	c := p.peek()
+
+	switch {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return queryOperand{relative: c == '@', segments: segments}, nil
+
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return literalOperand{value: s}, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	}
+
+	for _, lit := range []struct {
+		word  string
+		value any
+	}{{"true", true}, {"false", false}, {"null", nil}} {
+		if strings.HasPrefix(p.src[p.pos:], lit.word) {
+			p.pos += len(lit.word)
+			return literalOperand{value: lit.value}, nil
+		}
+	}
+
+	return nil, p.errorf("expected @, $ or a literal")
+}
+
+// parseNumber parses a JSON number literal into a json.Number.
+func (p *exprParser) parseNumber() (operand, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && strings.IndexByte("0123456789.eE+-", p.peek()) >= 0 {
+		p.pos++
+	}
+	text := p.src[start:p.pos]
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return literalOperand{value: json.Number(text)}, nil
+}
@@ -0,0 +1,216 @@
+// Package jsonschema validates decoded JSON against a JSON Schema.
+//
+// It supports the validation vocabulary shared by drafts 4 to 2020-12:
+//
+//	type  enum  const                              any value
+//	allOf  anyOf  oneOf  not  if/then/else         combinators
+//	$ref (to "#", "#/json/pointer" or an $anchor)  reuse, including recursion
+//	properties  patternProperties  additionalProperties  required
+//	propertyNames  minProperties  maxProperties
+//	dependentRequired  dependentSchemas  dependencies      objects
+//	items  prefixItems  additionalItems  contains
+//	minContains  maxContains  minItems  maxItems  uniqueItems  arrays
+//	minLength  maxLength  pattern  format                  strings
+//	minimum  maximum  exclusiveMinimum  exclusiveMaximum  multipleOf
+//
+// format is checked for date-time, date, time, email, hostname, ipv4,
+// ipv6, uri, uuid and regex; other formats are accepted as annotations.
+// Remote $refs, $dynamicRef and unevaluatedProperties/Items are not
+// supported. Patterns use Go's RE2 syntax. Numbers are compared exactly,
+// so large integers and decimals such as multipleOf 0.01 behave as written.
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxViolations caps Result.Violations; TotalViolations keeps counting.
+const MaxViolations = 1000
+
+// maxDepth bounds how deeply schemas may nest while validating, so a
+// $ref cycle that never consumes any of the instance fails cleanly.
+const maxDepth = 512
+
+// Violation is one way in which a value fails the schema.
+type Violation struct {
+	InstancePath string `json:"instancePath"` // Location of the value (e.g. ".items[0].price"), "." for the document itself
+	SchemaPath   string `json:"schemaPath"`   // JSON Pointer to the failing keyword (e.g. "#/properties/items/items/minimum")
+	Keyword      string `json:"keyword"`      // Schema keyword that failed (e.g. "type", "required")
+	Message      string `json:"message"`      // What is wrong
+}
+
+// Result is the outcome of validating one document.
+type Result struct {
+	Valid           bool        `json:"valid"`
+	Violations      []Violation `json:"violations"`      // First MaxViolations violations, in document order
+	TotalViolations int         `json:"totalViolations"` // All violations, including unlisted ones
+}
+
+// Schema is a compiled JSON Schema. It is safe for concurrent use.
+type Schema struct {
+	root     any
+	patterns map[string]*regexp.Regexp // pattern and patternProperties regexes
+	anchors  map[string]any            // $anchor name -> schema
+}
+
+// Compile prepares a schema (the result of json.Unmarshal or parser.Parse)
+// for validation. It fails if the schema is not an object or boolean, has
+// a pattern that doesn't compile or a $ref that can't be resolved.
+func Compile(schema any) (*Schema, error) {
+	switch schema.(type) {
+	case map[string]any, bool:
+	default:
+		return nil, fmt.Errorf("schema must be an object or a boolean")
+	}
+
+	s := &Schema{
+		root:     schema,
+		patterns: make(map[string]*regexp.Regexp),
+		anchors:  make(map[string]any),
+	}
+	var refs []string
+	if err := s.prepare(schema, "#", &refs); err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if _, _, err := s.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Validate compiles schema and validates data against it.
+func Validate(schema, data any) (*Result, error) {
+	s, err := Compile(schema)
+	if err != nil {
+		return nil, err
+	}
+	return s.Validate(data), nil
+}
+
+// Validate checks data against the schema.
+func (s *Schema) Validate(data any) *Result {
+	result := &Result{Violations: []Violation{}}
+	v := &validator{schema: s, report: func(violation Violation) {
+		result.TotalViolations++
+		if len(result.Violations) < MaxViolations {
+			result.Violations = append(result.Violations, violation)
+		}
+	}}
+	v.validate(s.root, "#", data, "", 0)
+	result.Valid = result.TotalViolations == 0
+	return result
+}
+
+// prepare walks a schema, compiling its patterns and collecting $anchors
+// and $refs. Keywords whose values are data rather than schemas (enum,
+// const, default, examples) are skipped.
+func (s *Schema) prepare(node any, path string, refs *[]string) error {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			childPath := path + "/" + escapePointer(key)
+			switch key {
+			case "enum", "const", "default", "examples":
+				continue
+			case "pattern":
+				if p, ok := value.(string); ok {
+					if err := s.compilePattern(p, childPath); err != nil {
+						return err
+					}
+					continue
+				}
+			case "patternProperties":
+				if props, ok := value.(map[string]any); ok {
+					for p := range props {
+						if err := s.compilePattern(p, childPath); err != nil {
+							return err
+						}
+					}
+				}
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					*refs = append(*refs, ref)
+					continue
+				}
+			case "$anchor":
+				if name, ok := value.(string); ok {
+					s.anchors[name] = n
+					continue
+				}
+			}
+			if err := s.prepare(value, childPath, refs); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range n {
+			if err := s.prepare(item, path+"/"+strconv.Itoa(i), refs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compilePattern(pattern, at string) error {
+	if _, ok := s.patterns[pattern]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern at %s: %w", at, err)
+	}
+	s.patterns[pattern] = re
+	return nil
+}
+
+// resolve returns the schema a $ref points to and its schema path.
+func (s *Schema) resolve(ref string) (any, string, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, "", fmt.Errorf("unsupported $ref %q: only references within the schema are supported", ref)
+	}
+	fragment := ref[1:]
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		target, ok := s.anchors[fragment]
+		if !ok {
+			return nil, "", fmt.Errorf("unresolved $ref %q: no such $anchor", ref)
+		}
+		return target, ref, nil
+	}
+
+	node := s.root
+	for _, token := range strings.Split(fragment, "/")[1:] {
+		token = unescapePointer(token)
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, "", fmt.Errorf("unresolved $ref %q", ref)
+			}
+			node = child
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, "", fmt.Errorf("unresolved $ref %q", ref)
+			}
+			node = n[i]
+		default:
+			return nil, "", fmt.Errorf("unresolved $ref %q", ref)
+		}
+	}
+	return node, ref, nil
+}
+
+// escapePointer escapes a JSON Pointer token (RFC 6901).
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,193 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+// check validates input against schema and returns its violations as
+// "instancePath keyword" lines.
+func check(t *testing.T, schema, input string) string {
+	t.Helper()
+	s, err := parser.ParseString(schema)
+	if err != nil {
+		t.Fatalf("invalid test schema: %v", err)
+	}
+	data, err := parser.ParseString(input)
+	if err != nil {
+		t.Fatalf("invalid test input: %v", err)
+	}
+	result, err := Validate(s, data)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	if result.Valid != (result.TotalViolations == 0) {
+		t.Errorf("Valid = %v with %d violations", result.Valid, result.TotalViolations)
+	}
+	lines := make([]string, len(result.Violations))
+	for i, v := range result.Violations {
+		lines[i] = v.InstancePath + " " + v.Keyword
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name, schema, input, want string
+	}{
+		{"true schema", `true`, `{"a":1}`, ""},
+		{"false schema", `false`, `1`, ". false"},
+		{"type", `{"type":"string"}`, `1`, ". type"},
+		{"type list", `{"type":["null","string"]}`, `null`, ""},
+		{"integer accepts 1.0", `{"type":"integer"}`, `1.0`, ""},
+		{"integer rejects 1.5", `{"type":"integer"}`, `1.5`, ". type"},
+		{"number accepts integer", `{"type":"number"}`, `3`, ""},
+		{"enum compares numbers by value", `{"enum":[1,"a"]}`, `1.0`, ""},
+		{"enum", `{"enum":[1,"a"]}`, `"b"`, ". enum"},
+		{"const", `{"const":{"a":[1]}}`, `{"a":[2]}`, ". const"},
+		{"required", `{"required":["a","b"]}`, `{"a":1}`, ".b required"},
+		{"properties", `{"properties":{"a":{"type":"string"}}}`, `{"a":1,"b":2}`, ".a type"},
+		{"additionalProperties false", `{"properties":{"a":{}},"additionalProperties":false}`, `{"a":1,"c":2,"b":3}`, ".b additionalProperties\n.c additionalProperties"},
+		{"additionalProperties schema", `{"patternProperties":{"^x-":{}},"additionalProperties":{"type":"number"}}`, `{"x-a":"s","b":"s"}`, ".b type"},
+		{"patternProperties", `{"patternProperties":{"^n":{"type":"number"}}}`, `{"n1":1,"n2":"x","s":"x"}`, ".n2 type"},
+		{"propertyNames", `{"propertyNames":{"maxLength":3}}`, `{"abcd":1,"abc":2}`, ".abcd maxLength"},
+		{"min and max properties", `{"minProperties":2,"maxProperties":0}`, `{"a":1}`, ". minProperties\n. maxProperties"},
+		{"dependentRequired", `{"dependentRequired":{"card":["billing"]}}`, `{"card":1}`, ". dependentRequired"},
+		{"dependencies", `{"dependencies":{"a":["b"],"c":{"required":["d"]}}}`, `{"a":1,"c":2}`, ". dependentRequired\n.d required"},
+		{"items", `{"items":{"type":"number"}}`, `[1,"a",2,"b"]`, "[1] type\n[3] type"},
+		{"prefixItems", `{"prefixItems":[{"type":"string"}],"items":false}`, `[1,2]`, "[0] type\n[1] items"},
+		{"tuple items", `{"items":[{"type":"string"}],"additionalItems":{"type":"string"}}`, `["a",1]`, "[1] type"},
+		{"contains", `{"contains":{"type":"string"}}`, `[1,2]`, ". contains"},
+		{"maxContains", `{"contains":{"type":"string"},"maxContains":1}`, `["a","b"]`, ". maxContains"},
+		{"min and max items", `{"minItems":2,"maxItems":0}`, `[1]`, ". minItems\n. maxItems"},
+		{"uniqueItems", `{"uniqueItems":true}`, `[1,{"a":1},1.0]`, ". uniqueItems"},
+		{"string length in characters", `{"minLength":2,"maxLength":3}`, `"héllo"`, ". maxLength"},
+		{"pattern", `{"pattern":"^[a-z]+$"}`, `"abc1"`, ". pattern"},
+		{"minimum and maximum", `{"items":{"minimum":5,"maximum":10}}`, `[4,5,10,11]`, "[0] minimum\n[3] maximum"},
+		{"exclusive limits", `{"items":{"exclusiveMinimum":0,"exclusiveMaximum":10}}`, `[0,5,10]`, "[0] exclusiveMinimum\n[2] exclusiveMaximum"},
+		{"draft-04 exclusive", `{"minimum":0,"exclusiveMinimum":true}`, `0`, ". minimum"},
+		{"multipleOf decimal", `{"items":{"multipleOf":0.01}}`, `[19.99,0.005]`, "[1] multipleOf"},
+		{"big integers compare exactly", `{"maximum":12345678901234567890}`, `12345678901234567891`, ". maximum"},
+		{"allOf", `{"allOf":[{"type":"number"},{"minimum":3}]}`, `2`, ". minimum"},
+		{"anyOf", `{"anyOf":[{"type":"string"},{"type":"null"}]}`, `1`, ". anyOf"},
+		{"oneOf", `{"oneOf":[{"type":"number"},{"type":"integer"}]}`, `1`, ". oneOf"},
+		{"not", `{"not":{"type":"null"}}`, `null`, ". not"},
+		{"if then", `{"if":{"properties":{"kind":{"const":"a"}}},"then":{"required":["x"]},"else":{"required":["y"]}}`, `{"kind":"a"}`, ".x required"},
+		{"if else", `{"if":{"properties":{"kind":{"const":"a"}}},"then":{"required":["x"]},"else":{"required":["y"]}}`, `{"kind":"b"}`, ".y required"},
+		{"ref", `{"$defs":{"pos":{"minimum":0}},"properties":{"a":{"$ref":"#/$defs/pos"}}}`, `{"a":-1}`, ".a minimum"},
+		{"ref with siblings", `{"$defs":{"n":{"type":"number"}},"$ref":"#/$defs/n","minimum":2}`, `1`, ". minimum"},
+		{"anchor", `{"$defs":{"n":{"$anchor":"num","type":"number"}},"items":{"$ref":"#num"}}`, `[1,"a"]`, "[1] type"},
+		{"recursive ref", `{"properties":{"children":{"items":{"$ref":"#"}},"name":{"type":"string"}}}`, `{"name":"a","children":[{"name":1}]}`, ".children[0].name type"},
+		{"nested paths", `{"properties":{"items":{"items":{"required":["id"]}}}}`, `{"items":[{"id":1},{}]}`, ".items[1].id required"},
+		{"format", `{"items":{"format":"date"}}`, `["2024-02-29","2023-02-29",1]`, "[1] format"},
+		{"unknown format", `{"format":"color"}`, `"red"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := check(t, tt.schema, tt.input)
+			if got != tt.want {
+				t.Errorf("violations =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_Formats(t *testing.T) {
+	tests := []struct {
+		format, good, bad string
+	}{
+		{"date-time", "2024-01-02T03:04:05.123Z", "2024-01-02 03:04:05"},
+		{"time", "03:04:05+01:00", "3pm"},
+		{"email", "ann@example.com", "ann at example.com"},
+		{"hostname", "api.example.com", "-bad.example.com"},
+		{"ipv4", "192.168.0.1", "::1"},
+		{"ipv6", "2001:db8::1", "192.168.0.1"},
+		{"uri", "https://example.com/a?b=c", "/relative/path"},
+		{"uuid", "123e4567-e89b-12d3-a456-426614174000", "123e4567e89b12d3a456426614174000"},
+		{"regex", "^a+$", "(unclosed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			schema := `{"format":"` + tt.format + `"}`
+			if got := check(t, schema, `"`+tt.good+`"`); got != "" {
+				t.Errorf("%q: unexpected violations %s", tt.good, got)
+			}
+			if got := check(t, schema, `"`+tt.bad+`"`); got != ". format" {
+				t.Errorf("%q: violations = %q, want . format", tt.bad, got)
+			}
+		})
+	}
+}
+
+func TestValidate_Details(t *testing.T) {
+	schema, _ := parser.ParseString(`{"properties":{"items":{"items":{"properties":{"price":{"minimum":0}}}}}}`)
+	data, _ := parser.ParseString(`{"items":[{"price":-1}]}`)
+	result, err := Validate(schema, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Violation{
+		InstancePath: ".items[0].price",
+		SchemaPath:   "#/properties/items/items/properties/price/minimum",
+		Keyword:      "minimum",
+		Message:      "-1 is less than the minimum 0",
+	}
+	if result.Valid || len(result.Violations) != 1 || result.Violations[0] != want {
+		t.Errorf("Validate = %+v, want one violation %+v", result, want)
+	}
+}
+
+func TestValidate_MaxViolations(t *testing.T) {
+	items := make([]any, MaxViolations+5)
+	for i := range items {
+		items[i] = "x"
+	}
+	result, err := Validate(map[string]any{"items": map[string]any{"type": "number"}}, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Violations) != MaxViolations || result.TotalViolations != MaxViolations+5 {
+		t.Errorf("got %d listed / %d total violations", len(result.Violations), result.TotalViolations)
+	}
+}
+
+func TestValidate_RefCycle(t *testing.T) {
+	schema, _ := parser.ParseString(`{"$defs":{"a":{"$ref":"#/$defs/b"},"b":{"$ref":"#/$defs/a"}},"$ref":"#/$defs/a"}`)
+	result, err := Validate(schema, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid {
+		t.Error("expected a $ref cycle to be reported")
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		schema, want string
+	}{
+		{`[1]`, "schema must be an object or a boolean"},
+		{`{"pattern":"("}`, "invalid pattern at #/pattern"},
+		{`{"patternProperties":{"(":{}}}`, "invalid pattern at #/patternProperties"},
+		{`{"$ref":"#/$defs/missing"}`, `unresolved $ref "#/$defs/missing"`},
+		{`{"$ref":"#nope"}`, "no such $anchor"},
+		{`{"$ref":"https://example.com/s.json"}`, "unsupported $ref"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			schema, err := parser.ParseString(tt.schema)
+			if err != nil {
+				t.Fatalf("invalid test schema: %v", err)
+			}
+			_, err = Compile(schema)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,572 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"jtool/internal/parser"
+)
+
+// validator reports the violations of one document.
+type validator struct {
+	schema *Schema
+	report func(Violation)
+}
+
+// fail reports a violation of keyword (found at schemaPath) by the value
+// at instancePath.
+func (v *validator) fail(instancePath, schemaPath, keyword, message string) {
+	if instancePath == "" {
+		instancePath = "."
+	}
+	v.report(Violation{
+		InstancePath: instancePath,
+		SchemaPath:   schemaPath + "/" + escapePointer(keyword),
+		Keyword:      keyword,
+		Message:      message,
+	})
+}
+
+// valid reports whether value satisfies schema, without reporting why not.
+func (v *validator) valid(schema any, schemaPath string, value any, instancePath string, depth int) bool {
+	ok := true
+	sub := &validator{schema: v.schema, report: func(Violation) { ok = false }}
+	sub.validate(schema, schemaPath, value, instancePath, depth)
+	return ok
+}
+
+// validate checks value (at instancePath) against schema (at schemaPath).
+func (v *validator) validate(schema any, schemaPath string, value any, instancePath string, depth int) {
+	if depth > maxDepth {
+		v.fail(instancePath, schemaPath, "$ref", "schemas are nested too deeply (is there a $ref cycle?)")
+		return
+	}
+
+	s, ok := schema.(map[string]any)
+	if !ok {
+		// Boolean schemas: true accepts anything, false nothing
+		if allowed, isBool := schema.(bool); isBool && !allowed {
+			v.report(Violation{
+				InstancePath: orDot(instancePath),
+				SchemaPath:   schemaPath,
+				Keyword:      "false",
+				Message:      "no value is allowed here",
+			})
+		}
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		// Compile checked that every $ref resolves
+		if target, targetPath, err := v.schema.resolve(ref); err == nil {
+			v.validate(target, targetPath, value, instancePath, depth+1)
+		}
+	}
+
+	if types := typeList(s["type"]); len(types) > 0 && !matchesType(types, value) {
+		v.fail(instancePath, schemaPath, "type", fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), typeOf(value)))
+		return // Other keywords would only repeat the mismatch
+	}
+	if enum, ok := s["enum"].([]any); ok && !contains(enum, value) {
+		v.fail(instancePath, schemaPath, "enum", fmt.Sprintf("%s is not one of the allowed values", describe(value)))
+	}
+	if constant, ok := s["const"]; ok && !equal(constant, value) {
+		v.fail(instancePath, schemaPath, "const", fmt.Sprintf("%s is not the required value %s", describe(value), describe(constant)))
+	}
+
+	v.combinators(s, schemaPath, value, instancePath, depth)
+
+	switch val := value.(type) {
+	case string:
+		v.validateString(s, schemaPath, val, instancePath)
+	case float64, json.Number:
+		v.validateNumber(s, schemaPath, val, instancePath)
+	case map[string]any:
+		v.validateObject(s, schemaPath, val, instancePath, depth)
+	case []any:
+		v.validateArray(s, schemaPath, val, instancePath, depth)
+	}
+}
+
+// combinators checks allOf, anyOf, oneOf, not and if/then/else.
+func (v *validator) combinators(s map[string]any, schemaPath string, value any, instancePath string, depth int) {
+	if all, ok := s["allOf"].([]any); ok {
+		for i, sub := range all {
+			v.validate(sub, fmt.Sprintf("%s/allOf/%d", schemaPath, i), value, instancePath, depth+1)
+		}
+	}
+	if options, ok := s["anyOf"].([]any); ok && v.countValid(options, schemaPath+"/anyOf", value, instancePath, depth) == 0 {
+		v.fail(instancePath, schemaPath, "anyOf", "does not match any schema in anyOf")
+	}
+	if options, ok := s["oneOf"].([]any); ok {
+		if n := v.countValid(options, schemaPath+"/oneOf", value, instancePath, depth); n != 1 {
+			v.fail(instancePath, schemaPath, "oneOf", fmt.Sprintf("matches %d schemas in oneOf, expected exactly 1", n))
+		}
+	}
+	if not, ok := s["not"]; ok && v.valid(not, schemaPath+"/not", value, instancePath, depth+1) {
+		v.fail(instancePath, schemaPath, "not", "matches the schema in not")
+	}
+	if cond, ok := s["if"]; ok {
+		if v.valid(cond, schemaPath+"/if", value, instancePath, depth+1) {
+			if then, ok := s["then"]; ok {
+				v.validate(then, schemaPath+"/then", value, instancePath, depth+1)
+			}
+		} else if els, ok := s["else"]; ok {
+			v.validate(els, schemaPath+"/else", value, instancePath, depth+1)
+		}
+	}
+}
+
+// countValid returns how many of the schemas value satisfies.
+func (v *validator) countValid(schemas []any, schemaPath string, value any, instancePath string, depth int) int {
+	n := 0
+	for i, sub := range schemas {
+		if v.valid(sub, fmt.Sprintf("%s/%d", schemaPath, i), value, instancePath, depth+1) {
+			n++
+		}
+	}
+	return n
+}
+
+func (v *validator) validateString(s map[string]any, schemaPath, value, instancePath string) {
+	length := len([]rune(value))
+	if limit, ok := schemaInt(s["minLength"]); ok && length < limit {
+		v.fail(instancePath, schemaPath, "minLength", fmt.Sprintf("length %d is less than minLength %d", length, limit))
+	}
+	if limit, ok := schemaInt(s["maxLength"]); ok && length > limit {
+		v.fail(instancePath, schemaPath, "maxLength", fmt.Sprintf("length %d is greater than maxLength %d", length, limit))
+	}
+	if pattern, ok := s["pattern"].(string); ok && !v.schema.patterns[pattern].MatchString(value) {
+		v.fail(instancePath, schemaPath, "pattern", fmt.Sprintf("%q does not match the pattern %q", value, pattern))
+	}
+	if format, ok := s["format"].(string); ok {
+		if check, known := formats[format]; known && !check(value) {
+			v.fail(instancePath, schemaPath, "format", fmt.Sprintf("%q is not a valid %s", value, format))
+		}
+	}
+}
+
+func (v *validator) validateNumber(s map[string]any, schemaPath string, value any, instancePath string) {
+	// Draft-04 makes exclusiveMinimum/Maximum booleans that modify
+	// minimum/maximum; later drafts make them limits of their own
+	exclusiveMin, _ := s["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := s["exclusiveMaximum"].(bool)
+
+	if limit := s["minimum"]; limit != nil {
+		if cmp, ok := parser.CompareNumbers(value, limit); ok && (cmp < 0 || exclusiveMin && cmp == 0) {
+			v.fail(instancePath, schemaPath, "minimum", fmt.Sprintf("%s is less than the minimum %s", describe(value), describe(limit)))
+		}
+	}
+	if limit := s["maximum"]; limit != nil {
+		if cmp, ok := parser.CompareNumbers(value, limit); ok && (cmp > 0 || exclusiveMax && cmp == 0) {
+			v.fail(instancePath, schemaPath, "maximum", fmt.Sprintf("%s is greater than the maximum %s", describe(value), describe(limit)))
+		}
+	}
+	if limit := s["exclusiveMinimum"]; parser.IsNumber(limit) {
+		if cmp, ok := parser.CompareNumbers(value, limit); ok && cmp <= 0 {
+			v.fail(instancePath, schemaPath, "exclusiveMinimum", fmt.Sprintf("%s is not greater than %s", describe(value), describe(limit)))
+		}
+	}
+	if limit := s["exclusiveMaximum"]; parser.IsNumber(limit) {
+		if cmp, ok := parser.CompareNumbers(value, limit); ok && cmp >= 0 {
+			v.fail(instancePath, schemaPath, "exclusiveMaximum", fmt.Sprintf("%s is not less than %s", describe(value), describe(limit)))
+		}
+	}
+	if step := s["multipleOf"]; step != nil {
+		n, nok := parser.ToRat(value)
+		d, dok := parser.ToRat(step)
+		if nok && dok && d.Sign() != 0 && !new(big.Rat).Quo(n, d).IsInt() {
+			v.fail(instancePath, schemaPath, "multipleOf", fmt.Sprintf("%s is not a multiple of %s", describe(value), describe(step)))
+		}
+	}
+}
+
+func (v *validator) validateObject(s map[string]any, schemaPath string, value map[string]any, instancePath string, depth int) {
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if key, ok := r.(string); ok {
+				if _, present := value[key]; !present {
+					v.fail(instancePath+"."+key, schemaPath, "required", "required property is missing")
+				}
+			}
+		}
+	}
+	if limit, ok := schemaInt(s["minProperties"]); ok && len(value) < limit {
+		v.fail(instancePath, schemaPath, "minProperties", fmt.Sprintf("has %d properties, fewer than minProperties %d", len(value), limit))
+	}
+	if limit, ok := schemaInt(s["maxProperties"]); ok && len(value) > limit {
+		v.fail(instancePath, schemaPath, "maxProperties", fmt.Sprintf("has %d properties, more than maxProperties %d", len(value), limit))
+	}
+
+	// dependencies is the draft-07 spelling of both dependent keywords
+	dependentRequired, _ := s["dependentRequired"].(map[string]any)
+	dependentSchemas, _ := s["dependentSchemas"].(map[string]any)
+	dependentPath := map[string]string{}
+	for key, dep := range asObject(s["dependencies"]) {
+		if _, isList := dep.([]any); isList {
+			dependentRequired = withEntry(dependentRequired, key, dep)
+		} else {
+			dependentSchemas = withEntry(dependentSchemas, key, dep)
+		}
+		dependentPath[key] = schemaPath + "/dependencies/" + escapePointer(key)
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	patternProperties, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	propertyNames, hasPropertyNames := s["propertyNames"]
+
+	// Walk keys in order so violations are reported deterministically
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := instancePath + "." + key
+		child := value[key]
+		if hasPropertyNames {
+			v.validate(propertyNames, schemaPath+"/propertyNames", key, childPath, depth+1)
+		}
+
+		matched := false
+		if prop, ok := properties[key]; ok {
+			matched = true
+			v.validate(prop, schemaPath+"/properties/"+escapePointer(key), child, childPath, depth+1)
+		}
+		for _, pattern := range sortedKeys(patternProperties) {
+			if v.schema.patterns[pattern].MatchString(key) {
+				matched = true
+				v.validate(patternProperties[pattern], schemaPath+"/patternProperties/"+escapePointer(pattern), child, childPath, depth+1)
+			}
+		}
+		if !matched && hasAdditional {
+			if allowed, isBool := additional.(bool); isBool && !allowed {
+				v.fail(childPath, schemaPath, "additionalProperties", "property is not allowed by the schema")
+			} else {
+				v.validate(additional, schemaPath+"/additionalProperties", child, childPath, depth+1)
+			}
+		}
+
+		if deps, ok := dependentRequired[key].([]any); ok {
+			depPath := schemaPath + "/dependentRequired/" + escapePointer(key)
+			if p, ok := dependentPath[key]; ok {
+				depPath = p
+			}
+			for _, d := range deps {
+				if name, ok := d.(string); ok {
+					if _, present := value[name]; !present {
+						v.report(Violation{
+							InstancePath: orDot(instancePath),
+							SchemaPath:   depPath,
+							Keyword:      "dependentRequired",
+							Message:      fmt.Sprintf("property %q is required when %q is present", name, key),
+						})
+					}
+				}
+			}
+		}
+		if dep, ok := dependentSchemas[key]; ok {
+			depPath := schemaPath + "/dependentSchemas/" + escapePointer(key)
+			if p, ok := dependentPath[key]; ok {
+				depPath = p
+			}
+			v.validate(dep, depPath, value, instancePath, depth+1)
+		}
+	}
+}
+
+func (v *validator) validateArray(s map[string]any, schemaPath string, value []any, instancePath string, depth int) {
+	if limit, ok := schemaInt(s["minItems"]); ok && len(value) < limit {
+		v.fail(instancePath, schemaPath, "minItems", fmt.Sprintf("has %d items, fewer than minItems %d", len(value), limit))
+	}
+	if limit, ok := schemaInt(s["maxItems"]); ok && len(value) > limit {
+		v.fail(instancePath, schemaPath, "maxItems", fmt.Sprintf("has %d items, more than maxItems %d", len(value), limit))
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		if i, j, found := duplicate(value); found {
+			v.fail(instancePath, schemaPath, "uniqueItems", fmt.Sprintf("items %d and %d are equal", i, j))
+		}
+	}
+
+	// Positional schemas come from prefixItems (2020-12) or an items array
+	// (earlier drafts); the schema for the remaining items from items or
+	// additionalItems respectively.
+	var prefix []any
+	prefixPath, restKeyword := "", ""
+	var rest any
+	if p, ok := s["prefixItems"].([]any); ok {
+		prefix, prefixPath = p, schemaPath+"/prefixItems"
+		rest, restKeyword = s["items"], "items"
+	} else if p, ok := s["items"].([]any); ok {
+		prefix, prefixPath = p, schemaPath+"/items"
+		rest, restKeyword = s["additionalItems"], "additionalItems"
+	} else {
+		rest, restKeyword = s["items"], "items"
+	}
+
+	for i, item := range value {
+		itemPath := fmt.Sprintf("%s[%d]", instancePath, i)
+		if i < len(prefix) {
+			v.validate(prefix[i], fmt.Sprintf("%s/%d", prefixPath, i), item, itemPath, depth+1)
+			continue
+		}
+		if rest == nil {
+			break
+		}
+		if allowed, isBool := rest.(bool); isBool && !allowed {
+			v.fail(itemPath, schemaPath, restKeyword, fmt.Sprintf("only %d items are allowed", len(prefix)))
+			continue
+		}
+		v.validate(rest, schemaPath+"/"+restKeyword, item, itemPath, depth+1)
+	}
+
+	if contains, ok := s["contains"]; ok {
+		matching := 0
+		for i, item := range value {
+			if v.valid(contains, schemaPath+"/contains", item, fmt.Sprintf("%s[%d]", instancePath, i), depth+1) {
+				matching++
+			}
+		}
+		minimum, ok := schemaInt(s["minContains"])
+		if !ok {
+			minimum = 1
+		}
+		if matching < minimum {
+			keyword := "contains"
+			if _, set := s["minContains"]; set {
+				keyword = "minContains"
+			}
+			v.fail(instancePath, schemaPath, keyword, fmt.Sprintf("%d items match contains, at least %d required", matching, minimum))
+		}
+		if maximum, ok := schemaInt(s["maxContains"]); ok && matching > maximum {
+			v.fail(instancePath, schemaPath, "maxContains", fmt.Sprintf("%d items match contains, at most %d allowed", matching, maximum))
+		}
+	}
+}
+
+// formats checks the values of the format keyword.
+var formats = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05.999999999Z07:00", s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"hostname": isHostname,
+	"ipv4": func(s string) bool {
+		return strings.Count(s, ".") == 3 && !strings.Contains(s, ":") && net.ParseIP(s) != nil
+	},
+	"ipv6": func(s string) bool {
+		return strings.Contains(s, ":") && net.ParseIP(s) != nil
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"uuid": uuidPattern.MatchString,
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isHostname checks RFC 1123 host names: dot-separated labels of letters,
+// digits and inner hyphens, up to 63 characters each and 253 in total.
+func isHostname(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(s, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// typeList returns the "type" keyword as a list ("string" or
+// ["null", "string"]).
+func typeList(t any) []string {
+	switch v := t.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		types := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+// matchesType reports whether value is one of the JSON Schema types.
+func matchesType(types []string, value any) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || t == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type name of a value. Numbers with an
+// integral value are "integer", so 1.0 satisfies {"type": "integer"}.
+func typeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		if r, ok := parser.ToRat(value); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	}
+}
+
+// equal compares JSON values, numbers by value (so 1 equals 1.0).
+func equal(a, b any) bool {
+	if parser.IsNumber(a) || parser.IsNumber(b) {
+		cmp, ok := parser.CompareNumbers(a, b)
+		return ok && cmp == 0
+	}
+	switch a := a.(type) {
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, av := range a {
+			bv, ok := b[key]
+			if !ok || !equal(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+func contains(values []any, value any) bool {
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// duplicate returns the indices of the first two equal items.
+func duplicate(items []any) (int, int, bool) {
+	for j := range items {
+		for i := 0; i < j; i++ {
+			if equal(items[i], items[j]) {
+				return i, j, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// describe writes a value compactly for messages.
+func describe(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(data) > 60 {
+		return string(data[:57]) + "..."
+	}
+	return string(data)
+}
+
+// schemaInt reads a non-negative integer keyword such as maxLength.
+func schemaInt(v any) (int, bool) {
+	r, ok := parser.ToRat(v)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+func asObject(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// withEntry returns a copy of m with key set, leaving the schema itself
+// untouched.
+func withEntry(m map[string]any, key string, value any) map[string]any {
+	out := make(map[string]any, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	out[key] = value
+	return out
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func orDot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,196 @@
+// Package jwt decodes JSON Web Tokens for inspection, so tokens can be read
+// locally instead of being pasted into a website.
+//
+// Signatures are not verified: there is no key to verify them with. Instead
+// the result flags what usually matters when debugging a token, such as an
+// unsigned algorithm or an expiry in the past.
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"jtool/internal/parser"
+)
+
+// Token is a decoded JWT.
+type Token struct {
+	Header    string     `json:"header"`              // Header as pretty-printed JSON
+	Payload   string     `json:"payload"`             // Claims as pretty-printed JSON
+	Algorithm string     `json:"algorithm"`           // "alg" header, e.g. "RS256"
+	Signed    bool       `json:"signed"`              // Token has a signature segment
+	Claims    []Claim    `json:"claims"`              // Registered claims present, in RFC 7519 order
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // "exp" claim, if any
+	Expired   bool       `json:"expired"`             // ExpiresAt is in the past
+	Warnings  []string   `json:"warnings"`            // Problems worth a second look, e.g. alg "none"
+}
+
+// Claim summarizes one registered claim.
+type Claim struct {
+	Name        string     `json:"name"`           // e.g. "exp"
+	Description string     `json:"description"`    // e.g. "Expires at"
+	Value       any        `json:"value"`          // Value as it appears in the payload
+	Time        *time.Time `json:"time,omitempty"` // exp, nbf and iat as times
+}
+
+// registeredClaims are the claims defined by RFC 7519, in its order.
+var registeredClaims = []struct {
+	name, description string
+	isTime            bool
+}{
+	{"iss", "Issuer", false},
+	{"sub", "Subject", false},
+	{"aud", "Audience", false},
+	{"exp", "Expires at", true},
+	{"nbf", "Not valid before", true},
+	{"iat", "Issued at", true},
+	{"jti", "Token ID", false},
+}
+
+// Decode decodes a JWT in compact form (header.payload.signature). A
+// "Bearer " prefix, as copied from an Authorization header, is ignored.
+func Decode(token string) (*Token, error) {
+	return decodeAt(token, time.Now())
+}
+
+// decodeAt is Decode with the current time passed in, for tests.
+func decodeAt(token string, now time.Time) (*Token, error) {
+	token = strings.TrimSpace(token)
+	if prefix := "bearer "; len(token) > len(prefix) && strings.EqualFold(token[:len(prefix)], prefix) {
+		token = strings.TrimSpace(token[len(prefix):])
+	}
+
+	parts := strings.Split(token, ".")
+	switch len(parts) {
+	case 3:
+	case 5:
+		return nil, fmt.Errorf("token is encrypted (JWE); its payload can't be decoded without the key")
+	default:
+		return nil, fmt.Errorf("not a JWT: expected 3 dot-separated parts, found %d", len(parts))
+	}
+
+	header, headerJSON, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	payload, payloadJSON, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+
+	t := &Token{
+		Header:   headerJSON,
+		Payload:  payloadJSON,
+		Signed:   parts[2] != "",
+		Claims:   []Claim{},
+		Warnings: []string{},
+	}
+	t.Algorithm, _ = header["alg"].(string)
+
+	switch {
+	case t.Algorithm == "" || strings.EqualFold(t.Algorithm, "none"):
+		t.Warnings = append(t.Warnings, `token is unsigned (alg "none"); anyone can forge it`)
+	case !t.Signed:
+		t.Warnings = append(t.Warnings, fmt.Sprintf("alg is %s but the signature is missing", t.Algorithm))
+	}
+
+	for _, rc := range registeredClaims {
+		value, ok := payload[rc.name]
+		if !ok {
+			continue
+		}
+		claim := Claim{Name: rc.name, Description: rc.description, Value: value}
+		if rc.isTime {
+			when, ok := numericDate(value)
+			if !ok {
+				t.Warnings = append(t.Warnings, fmt.Sprintf("%s is not a NumericDate (seconds since the epoch)", rc.name))
+			} else {
+				claim.Time = &when
+			}
+		}
+		t.Claims = append(t.Claims, claim)
+	}
+
+	t.Warnings = append(t.Warnings, timeWarnings(t, now)...)
+	return t, nil
+}
+
+// timeWarnings checks the time claims against now.
+func timeWarnings(t *Token, now time.Time) []string {
+	var warnings []string
+	hasExp := false
+	for _, c := range t.Claims {
+		if c.Name == "exp" {
+			hasExp = true // Even if invalid, which is warned about already
+		}
+		if c.Time == nil {
+			continue
+		}
+		when := *c.Time
+		switch c.Name {
+		case "exp":
+			t.ExpiresAt = c.Time
+			if !when.After(now) {
+				t.Expired = true
+				warnings = append(warnings, fmt.Sprintf("token expired %s ago", age(now.Sub(when))))
+			}
+		case "nbf":
+			if when.After(now) {
+				warnings = append(warnings, fmt.Sprintf("token is not valid for another %s", age(when.Sub(now))))
+			}
+		case "iat":
+			if when.After(now) {
+				warnings = append(warnings, "token was issued in the future; check the issuer's clock")
+			}
+		}
+	}
+	if !hasExp {
+		warnings = append(warnings, "token has no expiry (exp)")
+	}
+	return warnings
+}
+
+// decodeSegment base64url-decodes a token segment holding a JSON object and
+// returns it both parsed and pretty-printed.
+func decodeSegment(segment string) (map[string]any, string, error) {
+	// Padding is not allowed in JWTs, but some encoders add it anyway
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, "", fmt.Errorf("not base64url: %w", err)
+	}
+
+	value, err := parser.Parse(data)
+	if err != nil {
+		return nil, "", err
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("expected a JSON object")
+	}
+
+	formatted, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+	return object, string(formatted), nil
+}
+
+// numericDate converts a NumericDate (seconds since the epoch, possibly
+// fractional) to a time.
+func numericDate(v any) (time.Time, bool) {
+	f, ok := parser.Float64(v)
+	if !ok || math.IsInf(f, 0) || math.IsNaN(f) {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true
+}
+
+// age formats a duration for warnings, to the second (e.g. "2h5m0s").
+func age(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
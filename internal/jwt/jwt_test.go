@@ -0,0 +1,132 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeToken builds a compact JWT from JSON header and payload text.
+func makeToken(header, payload, signature string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload)) + "." + signature
+}
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestDecode(t *testing.T) {
+	token := makeToken(
+		`{"alg":"RS256","typ":"JWT"}`,
+		`{"sub":"user-1","aud":["api"],"exp":1717250400,"iat":1717243200,"id":12345678901234567890}`,
+		"c2ln",
+	)
+
+	got, err := decodeAt("Bearer "+token, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Algorithm != "RS256" || !got.Signed {
+		t.Errorf("algorithm = %q, signed = %v; want RS256, true", got.Algorithm, got.Signed)
+	}
+	if !strings.Contains(got.Payload, "\n  \"id\": 12345678901234567890") {
+		t.Errorf("payload not pretty-printed with big integers intact:\n%s", got.Payload)
+	}
+
+	var names []string
+	for _, c := range got.Claims {
+		names = append(names, c.Name)
+	}
+	if want := []string{"sub", "aud", "exp", "iat"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("claims = %v, want %v", names, want)
+	}
+
+	wantExp := time.Date(2024, 6, 1, 14, 0, 0, 0, time.UTC)
+	if got.ExpiresAt == nil || !got.ExpiresAt.Equal(wantExp) || got.Expired {
+		t.Errorf("expiresAt = %v, expired = %v; want %v, false", got.ExpiresAt, got.Expired, wantExp)
+	}
+	if len(got.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", got.Warnings)
+	}
+}
+
+func TestDecode_Warnings(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		expired bool
+		want    []string
+	}{
+		{
+			name:  "unsigned",
+			token: makeToken(`{"alg":"none"}`, `{"exp":1717250400}`, ""),
+			want:  []string{`token is unsigned (alg "none"); anyone can forge it`},
+		},
+		{
+			name:  "missing signature",
+			token: makeToken(`{"alg":"HS256"}`, `{"exp":1717250400}`, ""),
+			want:  []string{"alg is HS256 but the signature is missing"},
+		},
+		{
+			name:    "expired",
+			token:   makeToken(`{"alg":"HS256"}`, `{"exp":1717236000}`, "c2ln"),
+			expired: true,
+			want:    []string{"token expired 2h0m0s ago"},
+		},
+		{
+			name:  "not yet valid",
+			token: makeToken(`{"alg":"HS256"}`, `{"exp":1717250400,"nbf":1717245000}`, "c2ln"),
+			want:  []string{"token is not valid for another 30m0s"},
+		},
+		{
+			name:  "no expiry",
+			token: makeToken(`{"alg":"HS256"}`, `{"sub":"x"}`, "c2ln"),
+			want:  []string{"token has no expiry (exp)"},
+		},
+		{
+			name:  "invalid exp",
+			token: makeToken(`{"alg":"HS256"}`, `{"exp":"tomorrow"}`, "c2ln"),
+			want:  []string{"exp is not a NumericDate (seconds since the epoch)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeAt(tt.token, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Warnings, tt.want) {
+				t.Errorf("warnings = %q, want %q", got.Warnings, tt.want)
+			}
+			if got.Expired != tt.expired {
+				t.Errorf("expired = %v, want %v", got.Expired, tt.expired)
+			}
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"not a token", "hello", "expected 3 dot-separated parts, found 1"},
+		{"encrypted", "a.b.c.d.e", "encrypted (JWE)"},
+		{"bad base64", "!!.e30.", "invalid header: not base64url"},
+		{"not JSON", makeToken(`{}`, `nope`, ""), "invalid payload"},
+		{"not an object", makeToken(`[1]`, `{}`, ""), "invalid header: expected a JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeAt(tt.token, now)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
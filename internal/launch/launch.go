@@ -0,0 +1,79 @@
+// Package launch hands files to other applications: the system file
+// manager and the default editor.
+//
+// Wails' BrowserOpenURL can open file:// URLs, but on most systems that
+// sends them to the browser rather than an editor, and it can't select a
+// file in the file manager.
+package launch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// Reveal shows path in the system file manager: selected in Finder or
+// Explorer, or its folder opened elsewhere (xdg-open can't select files).
+func Reveal(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return start(revealCommand(runtime.GOOS, path))
+}
+
+// Edit opens path in the default text editor, or Notepad on Windows.
+func Edit(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return start(editCommand(runtime.GOOS, path))
+}
+
+// revealCommand returns the command, for the OS goos, that reveals path.
+func revealCommand(goos, path string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"open", "-R", path}
+	case "windows":
+		// explorer reads "/select," and the path as one argument, so the
+		// quotes have to go around the path alone (see setCommandLine)
+		return []string{"explorer", "/select," + windowsQuote(path)}
+	default:
+		return []string{"xdg-open", filepath.Dir(path)}
+	}
+}
+
+// editCommand returns the command, for the OS goos, that opens path in
+// the default text editor.
+func editCommand(goos, path string) []string {
+	switch goos {
+	case "darwin":
+		// -t opens the default text editor whatever the file extension
+		return []string{"open", "-t", path}
+	case "windows":
+		// Windows has no default text editor to ask for, and the file
+		// association for .json or .log may well be a browser or nothing
+		return []string{"notepad", windowsQuote(path)}
+	default:
+		return []string{"xdg-open", path}
+	}
+}
+
+// windowsQuote quotes a path for a Windows command line. Paths can't
+// contain double quotes, so none need escaping.
+func windowsQuote(path string) string {
+	return `"` + path + `"`
+}
+
+// start runs command without waiting for the application it opens.
+func start(command []string) error {
+	cmd := exec.Command(command[0], command[1:]...)
+	setCommandLine(cmd, command)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error running %s: %w", command[0], err)
+	}
+	go cmd.Wait() // Reap the process once the launcher exits
+	return nil
+}
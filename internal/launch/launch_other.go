@@ -0,0 +1,9 @@
+//go:build !windows
+
+package launch
+
+import "os/exec"
+
+// setCommandLine is only needed on Windows, where programs parse their
+// own command line.
+func setCommandLine(cmd *exec.Cmd, command []string) {}
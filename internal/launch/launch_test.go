@@ -0,0 +1,43 @@
+package launch
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCommands(t *testing.T) {
+	path := filepath.Join("logs", "app log.jsonl")
+
+	tests := []struct {
+		goos       string
+		wantReveal []string
+		wantEdit   []string
+	}{
+		{"darwin", []string{"open", "-R", path}, []string{"open", "-t", path}},
+		{"windows", []string{"explorer", `/select,"` + path + `"`}, []string{"notepad", `"` + path + `"`}},
+		{"linux", []string{"xdg-open", "logs"}, []string{"xdg-open", path}},
+		{"freebsd", []string{"xdg-open", "logs"}, []string{"xdg-open", path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			if got := revealCommand(tt.goos, path); !reflect.DeepEqual(got, tt.wantReveal) {
+				t.Errorf("revealCommand = %q, want %q", got, tt.wantReveal)
+			}
+			if got := editCommand(tt.goos, path); !reflect.DeepEqual(got, tt.wantEdit) {
+				t.Errorf("editCommand = %q, want %q", got, tt.wantEdit)
+			}
+		})
+	}
+}
+
+func TestMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	if err := Reveal(missing); err == nil {
+		t.Error("Reveal: expected an error for a missing file")
+	}
+	if err := Edit(missing); err == nil {
+		t.Error("Edit: expected an error for a missing file")
+	}
+}
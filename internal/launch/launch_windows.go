@@ -0,0 +1,17 @@
+//go:build windows
+
+package launch
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// setCommandLine passes command to Windows as written. The Windows
+// commands quote their own arguments: exec.Command would quote
+// `/select,C:\my logs\app.log` as a whole, which explorer doesn't
+// understand.
+func setCommandLine(cmd *exec.Cmd, command []string) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: strings.Join(command, " ")}
+}
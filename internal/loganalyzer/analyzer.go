@@ -7,11 +7,21 @@ package loganalyzer
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strings"
+
+	"jtool/internal/charset"
+	"jtool/internal/decompress"
+	"jtool/internal/parser"
+	"jtool/internal/paths"
 )
 
 // ValueFrequency represents a value and how often it appears.
@@ -22,272 +32,748 @@ type ValueFrequency struct {
 
 // PathSummary holds aggregated information about a JSON path.
 type PathSummary struct {
-	Path          string           `json:"path"`          // The JSON path (e.g., "$.record.name")
-	Count         int              `json:"count"`         // Total occurrences across all objects
-	ObjectHits    int              `json:"objectHits"`    // Number of JSON objects containing this path
-	DistinctCount int              `json:"distinctCount"` // Number of distinct values at this path
-	TopValues     []ValueFrequency `json:"topValues"`     // Top 10 most frequent values
+	Path          string           `json:"path"`              // The JSON path (e.g., ".record.name"), in Options.PathFormat
+	Count         int              `json:"count"`             // Total occurrences across all objects
+	ObjectHits    int              `json:"objectHits"`        // Number of JSON objects containing this path
+	Presence      float64          `json:"presence"`          // Percentage of JSON lines containing this path (100 = always present)
+	DistinctCount int              `json:"distinctCount"`     // Number of distinct values at this path
+	TopValues     []ValueFrequency `json:"topValues"`         // Most frequent values (Options.TopN, 10 by default)
+	Types         map[string]int   `json:"types"`             // JSON type name -> occurrences (e.g. {"number": 9, "string": 1})
+	Strings       *StringStats     `json:"strings,omitempty"` // Length and format profile of string values; nil if none
+	Numbers       *NumberStats     `json:"numbers,omitempty"` // Range and spread of numeric values; nil if none
+	PII           *PIIMatch        `json:"pii,omitempty"`     // Values look like personal data (emails, phone numbers...); nil if not
+
+	// How often the path is there but holds nothing, as opposed to missing
+	// (see Presence). Empty containers are listed at their own path, so a
+	// ".tags" that is [] on most lines shows up next to ".tags[]".
+	Nulls    int     `json:"nulls"`    // Occurrences that are null
+	Empties  int     `json:"empties"`  // Occurrences that are "", [] or {}
+	EmptyPct float64 `json:"emptyPct"` // Nulls and Empties as a percentage of Count
+
+	// Drift timeline: where the path was first and last seen, and whether a
+	// regularly present path starts or stops partway through the input
+	FirstSeenLine int    `json:"firstSeenLine"`           // Line of the first document containing this path
+	LastSeenLine  int    `json:"lastSeenLine"`            // Line of the last document containing this path
+	FirstSeenFile string `json:"firstSeenFile,omitempty"` // File of FirstSeenLine, when several files are analyzed
+	LastSeenFile  string `json:"lastSeenFile,omitempty"`  // File of LastSeenLine, when several files are analyzed
+	Appeared      bool   `json:"appeared"`                // Missing from the start of the input, then regularly present
+	Disappeared   bool   `json:"disappeared"`             // Regularly present, then missing from the rest of the input
+
+	ExampleLines []int `json:"exampleLines"` // Lines of the first 10 documents containing this path; in a batch, from any of the files
+
+	Anomalies []Anomaly `json:"anomalies,omitempty"` // Suspicious value distributions (near-constant, outliers, constant booleans)
 }
 
 // AnalysisResult holds the complete analysis of a log file.
 type AnalysisResult struct {
-	Paths           []PathSummary `json:"paths"`           // All paths found, sorted by count desc
-	TotalLines      int           `json:"totalLines"`      // Total lines in file
-	JSONLines       int           `json:"jsonLines"`       // Lines that were valid JSON
-	SkippedLines    int           `json:"skippedLines"`    // Lines that were not valid JSON
-	TotalPaths      int           `json:"totalPaths"`      // Unique paths found
-	TotalPathOccurs int           `json:"totalPathOccurs"` // Sum of all path counts
+	Paths           []PathSummary `json:"paths"`            // All paths found, sorted by count desc
+	TotalLines      int           `json:"totalLines"`       // Total lines in file
+	JSONLines       int           `json:"jsonLines"`        // Lines that were valid JSON
+	SkippedLines    int           `json:"skippedLines"`     // Lines that were not valid JSON
+	TotalPaths      int           `json:"totalPaths"`       // Unique paths found
+	TotalPathOccurs int           `json:"totalPathOccurs"`  // Sum of all path counts
+	Schema          *paths.Schema `json:"schema"`           // JSON Schema inferred from all JSON lines
+	Approximate     bool          `json:"approximate"`      // DistinctCount and TopValues are estimates (see Options.Approximate)
+	Singer          *SingerReport `json:"singer,omitempty"` // Singer message report; only set when Options.Singer is on
+
+	Validation *ValidationReport `json:"validation,omitempty"` // Conformance to Options.Schema; only set when a schema is given
+
+	ParseFailures *ParseFailureReport `json:"parseFailures,omitempty"` // Why lines were skipped; nil if every non-empty line parsed
+
+	Encoding string `json:"encoding,omitempty"` // Text encoding detected in the file (see charset); empty for in-memory content
+}
+
+// Options controls how values are aggregated during an analysis.
+type Options struct {
+	// Approximate bounds memory on high-cardinality paths (IDs, timestamps).
+	// DistinctCount becomes a HyperLogLog estimate (about 1.6% error) and
+	// TopValues come from a space-saving sketch, so their counts are lower
+	// bounds. By default every distinct value is kept and counts are exact.
+	Approximate bool `json:"approximate"`
+
+	// TopN is how many of the most frequent values are reported per path.
+	// Zero or negative uses DefaultTopN.
+	TopN int `json:"topN"`
+
+	// Singer treats each line as a Singer message: messages are counted by
+	// .type and stream, and RECORDs are validated against the most recent
+	// SCHEMA for their stream. See AnalysisResult.Singer.
+	Singer bool `json:"singer"`
+
+	// EmbeddedJSON analyzes JSON that follows a prefix on a log line, like
+	// `2024-05-01 12:00:01 INFO tap: {"type":"RECORD",...}`, instead of
+	// skipping the line. The first balanced {...} or [...] region that
+	// parses is used.
+	EmbeddedJSON bool `json:"embeddedJSON"`
+
+	// MaxLineSize is the largest line, or multi-line document, analyzed in
+	// bytes. Longer ones are skipped and reported in ParseFailures as
+	// too-large rather than failing the analysis. Zero or negative uses
+	// DefaultMaxLineSize.
+	MaxLineSize int `json:"maxLineSize"`
+
+	// PathFormat is how PathSummary.Path is written: paths.FormatDotted
+	// (".record.id", the default), paths.FormatJSONPath ("$.record.id") or
+	// paths.FormatPointer ("/record/id"). Paths passed back in, e.g. to
+	// ValueFrequencies, are expected in the same format.
+	PathFormat string `json:"pathFormat"`
+
+	// Schema, if set, is a JSON Schema every document is validated against,
+	// e.g. one inferred from a known-good run (AnalysisResult.Schema).
+	// Violations are aggregated by path and keyword in
+	// AnalysisResult.Validation. See package jsonschema for the keywords
+	// checked; a schema that doesn't compile is reported in
+	// ValidationReport.Error.
+	Schema any `json:"schema,omitempty"`
+
+	// Decoder unwraps lines that aren't the application's own log output:
+	// DecoderDocker, DecoderCSV, DecoderCloudWatch, DecoderStackdriver or a
+	// name passed to RegisterDecoder. Empty reads plain lines. Lines a
+	// decoder can't read are reported in ParseFailures as undecodable.
+	Decoder string `json:"decoder,omitempty"`
+
+	// CSVColumn names the column holding JSON for DecoderCSV. If empty, the
+	// first of "json", "message", "payload", "data", "log" or "body" is used.
+	CSVColumn string `json:"csvColumn,omitempty"`
+}
+
+// DefaultTopN is the number of TopValues reported per path by default.
+const DefaultTopN = 10
+
+// DefaultMaxLineSize is the default Options.MaxLineSize (1MB).
+const DefaultMaxLineSize = 1024 * 1024
+
+// topN returns the effective TopN.
+func (o Options) topN() int {
+	if o.TopN <= 0 {
+		return DefaultTopN
+	}
+	return o.TopN
+}
+
+// maxLineSize returns the effective MaxLineSize.
+func (o Options) maxLineSize() int {
+	if o.MaxLineSize <= 0 {
+		return DefaultMaxLineSize
+	}
+	return o.MaxLineSize
+}
+
+// formatPath writes a dotted path in PathFormat.
+func (o Options) formatPath(path string) string {
+	return paths.FormatPath(path, o.PathFormat)
+}
+
+// newScanner returns a documentScanner for these options that passes each
+// document to process.
+func (o Options) newScanner(process func(line int, data any)) *documentScanner {
+	return &documentScanner{embedded: o.EmbeddedJSON, maxSize: o.maxLineSize(), decoder: o.newDecoder(), process: process}
+}
+
+// newValueCounter returns the per-path value counter for these options.
+func (o Options) newValueCounter() valueCounter {
+	if o.Approximate {
+		return newSketchCounter(o.topN())
+	}
+	return make(exactCounter)
 }
 
 // AnalyzeFile reads a file and aggregates JSON path statistics.
+// gzip and zstd compressed files are decompressed transparently, and
+// UTF-16 or Latin-1 text is converted to UTF-8.
 func AnalyzeFile(filePath string) (*AnalysisResult, error) {
-	file, err := os.Open(filePath)
+	return AnalyzeFileWithOptions(filePath, Options{})
+}
+
+// AnalyzeFileWithOptions is AnalyzeFile with control over value aggregation.
+func AnalyzeFileWithOptions(filePath string, opts Options) (*AnalysisResult, error) {
+	return AnalyzeFileContext(context.Background(), filePath, opts)
+}
+
+// AnalyzeFileContext is AnalyzeFileWithOptions, giving up with ctx.Err()
+// soon after ctx is cancelled.
+func AnalyzeFileContext(ctx context.Context, filePath string, opts Options) (*AnalysisResult, error) {
+	agg := newAggregator(opts)
+	totalLines, encoding, err := scanFile(ctx, filePath, agg.scanner())
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	result := agg.result(totalLines)
+	result.Encoding = encoding
+	return result, nil
+}
 
-	// Track path statistics
-	// pathCounts[path] = total occurrences
-	// pathObjects[path] = number of objects containing this path
-	// pathValueFreq[path][value] = count of this value at this path
-	pathCounts := make(map[string]int)
-	pathObjects := make(map[string]int)
-	pathValueFreq := make(map[string]map[string]int)
+// AnalyzeReader analyzes JSON lines read from r until EOF, e.g. stdin or a
+// named pipe. Input is streamed like AnalyzeFile's, including transparent
+// gzip/zstd decompression and encoding detection, so it doesn't need to
+// fit in memory.
+func AnalyzeReader(r io.Reader, opts Options) (*AnalysisResult, error) {
+	agg := newAggregator(opts)
+	docs := agg.scanner()
+	totalLines, encoding, err := readLines(r, docs.maxSize, docs.scanLine)
+	if err != nil {
+		return nil, err
+	}
+	docs.finish()
+	result := agg.result(totalLines)
+	result.Encoding = encoding
+	return result, nil
+}
 
-	totalLines := 0
-	jsonLines := 0
+// AnalyzeString analyzes JSON lines from a string (for smaller inputs).
+// Supports both JSONL (one object per line) and multi-line pretty-printed JSON.
+func AnalyzeString(content string) (*AnalysisResult, error) {
+	return AnalyzeStringWithOptions(content, Options{})
+}
 
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for long lines (default is 64KB, we'll use 1MB)
-	buf := make([]byte, 0, 1024*1024)
-	scanner.Buffer(buf, 1024*1024)
+// AnalyzeStringWithOptions is AnalyzeString with control over value aggregation.
+func AnalyzeStringWithOptions(content string, opts Options) (*AnalysisResult, error) {
+	agg := newAggregator(opts)
+	totalLines := scanString(content, agg.scanner())
+	return agg.result(totalLines), nil
+}
 
-	// Multi-line JSON support: accumulate lines when we detect the start of
-	// a JSON object/array that doesn't parse on a single line.
-	// This handles pretty-printed JSON while keeping the fast path for JSONL.
-	var accumulator strings.Builder
-	inMultiLine := false
-	const maxAccumulatorSize = 1024 * 1024 // 1MB safety limit
-
-	// Helper to process a successfully parsed JSON object
-	processJSON := func(data any) {
-		jsonLines++
-		linePathValues := make(map[string][]string)
-		extractPathsWithValues("", data, linePathValues)
-
-		for path, values := range linePathValues {
-			pathCounts[path] += len(values)
-			pathObjects[path]++
-
-			if pathValueFreq[path] == nil {
-				pathValueFreq[path] = make(map[string]int)
-			}
-			for _, v := range values {
-				pathValueFreq[path][v]++
-			}
-		}
+// ValueFrequencies returns every distinct value at path in a file with its
+// exact count, most frequent first. path uses the same syntax as
+// PathSummary.Path (e.g. ".record.status"). This complements the TopValues
+// of an analysis when the full table is needed for one path. Counts are
+// taken after opts.Decoder (and opts.CSVColumn) unwrap each line, so path
+// refers to the decoded documents, as in the analysis. Only opts.Decoder,
+// opts.CSVColumn, opts.EmbeddedJSON, opts.MaxLineSize and opts.PathFormat
+// apply, so pass the options used for the analysis.
+func ValueFrequencies(filePath, path string, opts Options) ([]ValueFrequency, error) {
+	counts := make(exactCounter)
+	if _, _, err := scanFile(context.Background(), filePath, opts.newScanner(collectValues(path, opts, counts))); err != nil {
+		return nil, err
 	}
+	return counts.top(len(counts)), nil
+}
 
-	for scanner.Scan() {
-		totalLines++
-		line := scanner.Text()
-
-		if inMultiLine {
-			// Continue accumulating lines
-			accumulator.WriteString("\n")
-			accumulator.WriteString(line)
-
-			// Try to parse accumulated content
-			var data any
-			if err := json.Unmarshal([]byte(accumulator.String()), &data); err == nil {
-				// Success! Process and reset
-				processJSON(data)
-				accumulator.Reset()
-				inMultiLine = false
+// ValueFrequenciesString is ValueFrequencies for in-memory content.
+func ValueFrequenciesString(content, path string, opts Options) []ValueFrequency {
+	counts := make(exactCounter)
+	scanString(content, opts.newScanner(collectValues(path, opts, counts)))
+	return counts.top(len(counts))
+}
+
+// collectValues returns a document callback that counts the values at path,
+// which is written in opts.PathFormat.
+func collectValues(path string, opts Options, counts exactCounter) func(line int, data any) {
+	return func(_ int, data any) {
+		values := make(map[string][]string)
+		extractPathsWithValues("", data, values, make(map[string]map[string]int), nil, nil, nil)
+		for p, vs := range values {
+			if opts.formatPath(p) != path {
 				continue
 			}
-
-			// Safety limit - abandon if too large
-			if accumulator.Len() > maxAccumulatorSize {
-				accumulator.Reset()
-				inMultiLine = false
+			for _, v := range vs {
+				counts.add(v)
 			}
-			continue
 		}
+	}
+}
 
-		// Fast path: try single-line parse first (works for JSONL)
-		var data any
-		if err := json.Unmarshal([]byte(line), &data); err == nil {
-			processJSON(data)
-			continue
+// aggregator accumulates path statistics across JSON documents.
+type aggregator struct {
+	opts Options
+
+	// pathCounts[path] = total occurrences
+	// pathObjects[path] = number of objects containing this path
+	// pathValues[path] = values seen at this path (exact or sketched)
+	pathCounts  map[string]int
+	pathObjects map[string]int
+	pathValues  map[string]valueCounter
+	pathTypes   map[string]map[string]int
+	pathStrings map[string]*StringStats
+	pathNumbers map[string]*NumberStats
+	pathEmpties map[string]int // "", [] and {} occurrences per path
+	pathFirst   map[string]sighting
+	pathLast    map[string]sighting
+	pathLines   map[string][]int // First maxExampleLines lines per path
+	schema      *paths.SchemaBuilder
+	formats     map[string]string // DetectFormat of the current document's strings, shared with schema
+	jsonLines   int
+	file        string           // Current file, when several files are analyzed
+	singer      *singerLinter    // nil unless Options.Singer
+	validator   *schemaValidator // nil unless Options.Schema
+	failures    parseFailures
+}
+
+func newAggregator(opts Options) *aggregator {
+	a := &aggregator{
+		opts:        opts,
+		pathCounts:  make(map[string]int),
+		pathObjects: make(map[string]int),
+		pathValues:  make(map[string]valueCounter),
+		pathTypes:   make(map[string]map[string]int),
+		pathStrings: make(map[string]*StringStats),
+		pathNumbers: make(map[string]*NumberStats),
+		pathEmpties: make(map[string]int),
+		pathFirst:   make(map[string]sighting),
+		pathLast:    make(map[string]sighting),
+		pathLines:   make(map[string][]int),
+		schema:      paths.NewSchemaBuilder(),
+		formats:     make(map[string]string),
+	}
+	if opts.Singer {
+		a.singer = newSingerLinter()
+	}
+	if opts.Schema != nil {
+		a.validator = newSchemaValidator(opts.Schema, opts)
+	}
+	return a
+}
+
+// setFile names the file the following documents come from.
+func (a *aggregator) setFile(name string) {
+	a.file = name
+	a.failures.file = name
+	if a.singer != nil {
+		a.singer.file = name
+	}
+	if a.validator != nil {
+		a.validator.file = name
+	}
+}
+
+// scanner returns a documentScanner that feeds this aggregator.
+func (a *aggregator) scanner() *documentScanner {
+	docs := a.opts.newScanner(a.add)
+	docs.fail = a.failures.add
+	return docs
+}
+
+// add processes a successfully parsed JSON document.
+func (a *aggregator) add(line int, data any) {
+	seen := sighting{line: line, file: a.file, doc: a.jsonLines}
+	a.jsonLines++
+	if a.singer != nil {
+		a.singer.add(line, data)
+	}
+	if a.validator != nil {
+		a.validator.add(line, data)
+	}
+	linePathValues := make(map[string][]string)
+	clear(a.formats)
+	extractPathsWithValues("", data, linePathValues, a.pathTypes, a.pathStrings, a.pathNumbers, a.formats)
+	a.schema.AddWithFormats(data, a.detectedFormat)
+
+	for path, values := range linePathValues {
+		a.pathCounts[path] += len(values)
+		a.pathObjects[path]++
+		if _, ok := a.pathFirst[path]; !ok {
+			a.pathFirst[path] = seen
+		}
+		a.pathLast[path] = seen
+		if len(a.pathLines[path]) < maxExampleLines {
+			a.pathLines[path] = append(a.pathLines[path], line)
 		}
 
-		// Check if this might be the start of multi-line JSON
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
-			accumulator.WriteString(line)
-			inMultiLine = true
+		if a.pathValues[path] == nil {
+			a.pathValues[path] = a.opts.newValueCounter()
+		}
+		for _, v := range values {
+			a.pathValues[path].add(v)
+			if isEmptyValue(v) {
+				a.pathEmpties[path]++
+			}
 		}
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+// detectedFormat returns paths.DetectFormat for a string of the current
+// document, reusing the one taken for its string stats.
+func (a *aggregator) detectedFormat(s string) string {
+	if format, ok := a.formats[s]; ok {
+		return format
 	}
+	return paths.DetectFormat(s)
+}
 
-	// Convert to sorted slice (by count descending, then path ascending)
-	paths := make([]PathSummary, 0, len(pathCounts))
+// result builds the analysis, with paths sorted by count descending and
+// then path ascending. The result doesn't share state with the aggregator,
+// so more documents can be added afterwards (see Follower).
+func (a *aggregator) result(totalLines int) *AnalysisResult {
+	summaries := make([]PathSummary, 0, len(a.pathCounts))
 	totalOccurs := 0
 
-	for path, count := range pathCounts {
-		valueFreqs := pathValueFreq[path]
-		topValues := getTopValues(valueFreqs, 10)
+	for path, count := range a.pathCounts {
+		values := a.pathValues[path]
+		first, last := a.pathFirst[path], a.pathLast[path]
+		appeared, disappeared := drift(first, last, a.pathObjects[path], a.jsonLines)
 
-		paths = append(paths, PathSummary{
-			Path:          path,
+		summaries = append(summaries, PathSummary{
+			Path:          a.opts.formatPath(path),
 			Count:         count,
-			ObjectHits:    pathObjects[path],
-			DistinctCount: len(valueFreqs),
-			TopValues:     topValues,
+			ObjectHits:    a.pathObjects[path],
+			Presence:      presence(a.pathObjects[path], a.jsonLines),
+			DistinctCount: values.distinct(),
+			TopValues:     values.top(a.opts.topN()),
+			Types:         maps.Clone(a.pathTypes[path]),
+			Strings:       a.pathStrings[path].clone(),
+			Numbers:       a.pathNumbers[path].clone(),
+			PII:           detectPII(path, a.pathStrings[path]),
+			Nulls:         a.pathTypes[path]["null"],
+			Empties:       a.pathEmpties[path],
+			EmptyPct:      share(a.pathTypes[path]["null"]+a.pathEmpties[path], count),
+			FirstSeenLine: first.line,
+			LastSeenLine:  last.line,
+			FirstSeenFile: first.file,
+			LastSeenFile:  last.file,
+			Appeared:      appeared,
+			Disappeared:   disappeared,
+			ExampleLines:  slices.Clone(a.pathLines[path]),
 		})
+		summary := &summaries[len(summaries)-1]
+		summary.Anomalies = detectAnomalies(*summary)
 		totalOccurs += count
 	}
 
 	// Sort by count descending, then path ascending for ties
-	sort.Slice(paths, func(i, j int) bool {
-		if paths[i].Count != paths[j].Count {
-			return paths[i].Count > paths[j].Count // Descending by count
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count // Descending by count
 		}
-		return paths[i].Path < paths[j].Path // Ascending by path
+		return summaries[i].Path < summaries[j].Path // Ascending by path
 	})
 
-	return &AnalysisResult{
-		Paths:           paths,
+	result := &AnalysisResult{
+		Paths:           summaries,
 		TotalLines:      totalLines,
-		JSONLines:       jsonLines,
-		SkippedLines:    totalLines - jsonLines,
-		TotalPaths:      len(paths),
+		JSONLines:       a.jsonLines,
+		SkippedLines:    max(0, totalLines-a.jsonLines), // A decoded line can hold several documents
+		TotalPaths:      len(summaries),
 		TotalPathOccurs: totalOccurs,
-	}, nil
+		Schema:          a.schema.Schema(paths.Draft202012),
+		Approximate:     a.opts.Approximate,
+		ParseFailures:   a.failures.result(),
+	}
+	if a.singer != nil {
+		result.Singer = a.singer.result()
+	}
+	if a.validator != nil {
+		result.Validation = a.validator.result()
+	}
+	return result
 }
 
-// AnalyzeString analyzes JSON lines from a string (for smaller inputs).
-// Supports both JSONL (one object per line) and multi-line pretty-printed JSON.
-func AnalyzeString(content string) (*AnalysisResult, error) {
-	pathCounts := make(map[string]int)
-	pathObjects := make(map[string]int)
-	pathValueFreq := make(map[string]map[string]int)
+// scanFile feeds every line of a file to docs and returns the number of
+// lines read. gzip and zstd files are decompressed transparently.
+func scanFile(ctx context.Context, filePath string, docs *documentScanner) (int, string, error) {
+	totalLines, encoding, err := scanLines(ctx, filePath, docs.maxSize, docs.scanLine)
+	if err != nil {
+		return 0, "", err
+	}
+	docs.finish()
+	return totalLines, encoding, nil
+}
 
-	totalLines := 0
-	jsonLines := 0
+// lineFunc receives each line read by scanLines. A line longer than the
+// limit has tooLong set and only its first maxLineSize bytes in line.
+// Returning false stops reading.
+type lineFunc func(lineNum int, line string, tooLong bool) bool
+
+// scanLines calls fn for each line of a file, decompressing it and
+// converting it to UTF-8 if needed, until fn returns false. Lines may be
+// arbitrarily long; at most maxLineSize bytes of each are kept. It returns
+// the number of lines read and the encoding detected, or ctx.Err() if ctx
+// is cancelled first.
+func scanLines(ctx context.Context, filePath string, maxLineSize int, fn lineFunc) (int, string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
 
-	// Multi-line JSON support
-	var accumulator strings.Builder
-	inMultiLine := false
-	const maxAccumulatorSize = 1024 * 1024 // 1MB safety limit
+	return readLines(contextReader{ctx, file}, maxLineSize, fn)
+}
 
-	// Helper to process a successfully parsed JSON object
-	processJSON := func(data any) {
-		jsonLines++
-		linePathValues := make(map[string][]string)
-		extractPathsWithValues("", data, linePathValues)
+// contextReader fails once its context is done, so a scan stops at the
+// next buffer refill after it is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
 
-		for path, values := range linePathValues {
-			pathCounts[path] += len(values)
-			pathObjects[path]++
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
 
-			if pathValueFreq[path] == nil {
-				pathValueFreq[path] = make(map[string]int)
-			}
-			for _, v := range values {
-				pathValueFreq[path][v]++
-			}
+// readLines is scanLines for an open reader.
+func readLines(r io.Reader, maxLineSize int, fn lineFunc) (int, string, error) {
+	// Rotated logs are often gzip or zstd compressed
+	content, err := decompress.NewReader(r)
+	if err != nil {
+		return 0, "", err
+	}
+	defer content.Close()
+
+	// Logs exported on Windows are often UTF-16
+	text, encoding, err := charset.NewReader(content)
+	if err != nil {
+		return 0, "", err
+	}
+
+	reader := bufio.NewReaderSize(text, 64*1024)
+	totalLines := 0
+	for {
+		line, tooLong, err := readLine(reader, maxLineSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, "", err
+		}
+		totalLines++
+		if !fn(totalLines, line, tooLong) {
+			break
 		}
 	}
 
+	return totalLines, encoding, nil
+}
+
+// readLine reads the next line from r without its "\n" or "\r\n" ending.
+// A line of any length is consumed, but only its first max bytes are kept;
+// tooLong reports that the rest was dropped. err is io.EOF once the input
+// is exhausted.
+func readLine(r *bufio.Reader, max int) (line string, tooLong bool, err error) {
+	line, tooLong, _, _, err = readRawLine(r, max)
+	return line, tooLong, err
+}
+
+// readRawLine is readLine, also returning how many bytes of input the line
+// took, ending included, and whether it ended with a newline (the last
+// line of the input may not).
+func readRawLine(r *bufio.Reader, max int) (line string, tooLong bool, size int, ended bool, err error) {
+	var buf []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		size += len(chunk)
+		// Keep up to two bytes past the limit so the line ending fits
+		if room := max + 2 - len(buf); room > 0 {
+			buf = append(buf, chunk[:min(len(chunk), room)]...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF && size > 0 {
+			break // Last line without a newline
+		}
+		if err != nil {
+			return "", false, 0, false, err
+		}
+		ended = true
+		break
+	}
+
+	if size > len(buf) {
+		return string(buf[:max]), true, size, ended, nil
+	}
+	buf = bytes.TrimSuffix(buf, []byte("\n"))
+	buf = bytes.TrimSuffix(buf, []byte("\r"))
+	if len(buf) > max {
+		return string(buf[:max]), true, size, ended, nil
+	}
+	return string(buf), false, size, ended, nil
+}
+
+// scanString is scanFile for in-memory content. Empty lines are skipped and
+// not counted.
+func scanString(content string, docs *documentScanner) int {
+	totalLines := 0
+
 	// Split by newlines and process each line
 	lines := strings.Split(content, "\n")
-	for _, line := range lines {
+	for i, line := range lines {
 		if len(line) == 0 {
 			continue
 		}
 		totalLines++
+		// Empty lines aren't counted but still number
+		if len(line) > docs.maxSize {
+			docs.scanLine(i+1, line[:docs.maxSize], true)
+		} else {
+			docs.scanLine(i+1, line, false)
+		}
+	}
+	docs.finish()
 
-		if inMultiLine {
-			// Continue accumulating lines
-			accumulator.WriteString("\n")
-			accumulator.WriteString(line)
-
-			// Try to parse accumulated content
-			var data any
-			if err := json.Unmarshal([]byte(accumulator.String()), &data); err == nil {
-				processJSON(data)
-				accumulator.Reset()
-				inMultiLine = false
-				continue
-			}
+	return totalLines
+}
+
+// documentScanner finds the JSON documents in a sequence of lines and passes
+// each to process. Lines are fed one at a time, so state carries over between
+// reads of a growing file.
+type documentScanner struct {
+	embedded bool                                   // Also look for JSON after a log line prefix
+	maxSize  int                                    // Largest line or multi-line document, in bytes
+	decoder  Decoder                                // Unwraps each input line; nil for plain lines
+	process  func(line int, data any)               // Called with the line each document starts on
+	fail     func(line int, text string, err error) // Called for text that isn't JSON; may be nil
 
-			// Safety limit
-			if accumulator.Len() > maxAccumulatorSize {
-				accumulator.Reset()
-				inMultiLine = false
+	// Multi-line JSON support: accumulate lines when we detect the start of
+	// a JSON object/array that doesn't parse on a single line.
+	// This handles pretty-printed JSON while keeping the fast path for JSONL.
+	// The accumulated text is only parsed once its brackets balance, so a
+	// document spanning n lines costs one parse rather than n.
+	accumulator strings.Builder
+	depth       bracketDepth
+	inMultiLine bool
+	startLine   int
+	lastLine    int // Last line scanned, for text a decoder flushes at the end
+}
+
+// feed processes one line; lineNum is its 1-based line number.
+func (s *documentScanner) feed(lineNum int, line string) {
+	if s.inMultiLine {
+		// Continue accumulating lines
+		s.accumulator.WriteString("\n")
+		s.accumulator.WriteString(line)
+		s.depth.scan(line)
+
+		if !s.depth.closed() {
+			// Safety limit - abandon if too large
+			if s.accumulator.Len() > s.maxSize {
+				s.abandon(fmt.Errorf("%w: over %d bytes", errTooLarge, s.maxSize))
 			}
-			continue
+			return
 		}
 
-		// Fast path: try single-line parse first
-		var data any
-		if err := json.Unmarshal([]byte(line), &data); err == nil {
-			processJSON(data)
-			continue
+		// Brackets balance, so the document is complete or broken
+		data, err := parser.Parse([]byte(s.accumulator.String()))
+		if err != nil {
+			s.abandon(err)
+			return
 		}
+		s.process(s.startLine, data)
+		s.accumulator.Reset()
+		s.inMultiLine = false
+		return
+	}
 
-		// Check if this might be the start of multi-line JSON
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
-			accumulator.WriteString(line)
-			inMultiLine = true
+	// Fast path: try single-line parse first (works for JSONL)
+	data, err := parser.Parse([]byte(line))
+	if err == nil {
+		s.process(lineNum, data)
+		return
+	}
+
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return
+	}
+	looksLikeJSON := strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+
+	// Log lines with a JSON payload after a prefix
+	if s.embedded {
+		data, found, open := findEmbeddedJSON(line)
+		switch {
+		case found:
+			s.process(lineNum, data)
+		case open != "":
+			s.begin(lineNum, open)
+		case looksLikeJSON:
+			s.failed(lineNum, line, err)
+		default:
+			s.failed(lineNum, line, errNotJSON)
 		}
+		return
 	}
 
-	// Convert to sorted slice
-	paths := make([]PathSummary, 0, len(pathCounts))
-	totalOccurs := 0
+	// Check if this might be the start of multi-line JSON
+	switch {
+	case !looksLikeJSON:
+		s.failed(lineNum, line, errNotJSON)
+	case classifyParseError(err) == ParseTruncated:
+		s.begin(lineNum, line)
+	default:
+		s.failed(lineNum, line, err)
+	}
+}
 
-	for path, count := range pathCounts {
-		valueFreqs := pathValueFreq[path]
-		topValues := getTopValues(valueFreqs, 10)
+// scanLine is a lineFunc that feeds lines to the scanner. A line over the
+// size limit is reported instead; if it was part of a multi-line document,
+// the whole document is.
+func (s *documentScanner) scanLine(lineNum int, line string, tooLong bool) bool {
+	s.lastLine = lineNum
+	if !tooLong {
+		if s.decoder == nil {
+			s.feed(lineNum, line)
+			return true
+		}
+		texts, err := s.decoder.Decode(line)
+		s.feedDecoded(lineNum, line, texts, err)
+		return true
+	}
 
-		paths = append(paths, PathSummary{
-			Path:          path,
-			Count:         count,
-			ObjectHits:    pathObjects[path],
-			DistinctCount: len(valueFreqs),
-			TopValues:     topValues,
-		})
-		totalOccurs += count
+	err := fmt.Errorf("%w: line over %d bytes", errTooLarge, s.maxSize)
+	if s.inMultiLine {
+		s.abandon(err)
+	} else {
+		s.failed(lineNum, line, err)
 	}
+	return true
+}
 
-	sort.Slice(paths, func(i, j int) bool {
-		if paths[i].Count != paths[j].Count {
-			return paths[i].Count > paths[j].Count
+// feedDecoded feeds the text a decoder returned for the given line.
+func (s *documentScanner) feedDecoded(lineNum int, line string, texts []string, err error) {
+	if err != nil {
+		s.failed(lineNum, line, fmt.Errorf("%w: %v", errUndecodable, err))
+		return
+	}
+	for _, text := range texts {
+		for _, l := range strings.Split(text, "\n") {
+			if l = strings.TrimSuffix(l, "\r"); l != "" {
+				s.feed(lineNum, l)
+			}
 		}
-		return paths[i].Path < paths[j].Path
-	})
+	}
+}
 
-	return &AnalysisResult{
-		Paths:           paths,
-		TotalLines:      totalLines,
-		JSONLines:       jsonLines,
-		SkippedLines:    totalLines - jsonLines,
-		TotalPaths:      len(paths),
-		TotalPathOccurs: totalOccurs,
-	}, nil
+// finish reports a document still open at the end of the input.
+func (s *documentScanner) finish() {
+	if s.decoder != nil {
+		texts, err := s.decoder.Flush()
+		s.feedDecoded(s.lastLine, "", texts, err)
+	}
+	if s.inMultiLine {
+		s.abandon(errUnterminated)
+	}
+}
+
+// abandon gives up on the multi-line document being accumulated.
+func (s *documentScanner) abandon(err error) {
+	s.failed(s.startLine, s.accumulator.String(), err)
+	s.accumulator.Reset()
+	s.inMultiLine = false
+}
+
+func (s *documentScanner) failed(lineNum int, text string, err error) {
+	if s.fail != nil {
+		s.fail(lineNum, text, err)
+	}
+}
+
+// begin starts accumulating a multi-line document.
+func (s *documentScanner) begin(lineNum int, text string) {
+	s.accumulator.WriteString(text)
+	s.depth = bracketDepth{}
+	s.depth.scan(text)
+	s.inMultiLine = true
+	s.startLine = lineNum
 }
 
 // extractPaths recursively extracts all paths from a JSON value.
 // This is the same algorithm as in the paths package.
 func extractPaths(prefix string, value any, counts map[string]int) {
+	if isEmptyContainer(prefix, value) {
+		counts[prefix]++
+		return
+	}
 	switch v := value.(type) {
 	case map[string]any:
 		for key, val := range v {
@@ -305,24 +791,76 @@ func extractPaths(prefix string, value any, counts map[string]int) {
 	}
 }
 
+// isEmptyContainer reports whether value is an empty object or array below
+// the root. Empty containers are counted as leaf values at their own path,
+// so a field that is always [] or {} still shows up in the analysis.
+func isEmptyContainer(prefix string, value any) bool {
+	if prefix == "" {
+		return false
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
 // extractPathsWithValues extracts paths and their values for distinct counting.
-// Values are converted to strings for comparison.
-func extractPathsWithValues(prefix string, value any, pathValues map[string][]string) {
+// Values are converted to strings for comparison, and each value's JSON
+// type is counted in pathTypes. String and number profiles are skipped when
+// pathStrings or pathNumbers is nil. The DetectFormat result of each profiled
+// string is recorded in formats, if not nil, for reuse by schema inference.
+// Empty containers count as leaf values (see isEmptyContainer).
+func extractPathsWithValues(prefix string, value any, pathValues map[string][]string, pathTypes map[string]map[string]int, pathStrings map[string]*StringStats, pathNumbers map[string]*NumberStats, formats map[string]string) {
 	switch v := value.(type) {
 	case map[string]any:
-		for key, val := range v {
-			childPath := prefix + "." + key
-			extractPathsWithValues(childPath, val, pathValues)
+		if !isEmptyContainer(prefix, v) {
+			for key, val := range v {
+				childPath := prefix + "." + key
+				extractPathsWithValues(childPath, val, pathValues, pathTypes, pathStrings, pathNumbers, formats)
+			}
+			return
 		}
 	case []any:
-		for _, item := range v {
-			childPath := prefix + "[]"
-			extractPathsWithValues(childPath, item, pathValues)
+		if !isEmptyContainer(prefix, v) {
+			for _, item := range v {
+				childPath := prefix + "[]"
+				extractPathsWithValues(childPath, item, pathValues, pathTypes, pathStrings, pathNumbers, formats)
+			}
+			return
 		}
-	default:
-		// Leaf value - convert to string for distinct counting
-		strVal := valueToString(value)
-		pathValues[prefix] = append(pathValues[prefix], strVal)
+	}
+
+	// Leaf value (or empty container) - convert to string for distinct counting
+	strVal := valueToString(value)
+	pathValues[prefix] = append(pathValues[prefix], strVal)
+
+	if pathTypes[prefix] == nil {
+		pathTypes[prefix] = make(map[string]int)
+	}
+	pathTypes[prefix][paths.TypeName(value)]++
+
+	// String length and format profile (skipped when pathStrings is nil)
+	if str, ok := value.(string); ok && pathStrings != nil {
+		if pathStrings[prefix] == nil {
+			pathStrings[prefix] = &StringStats{}
+		}
+		detected, ok := formats[str]
+		if !ok {
+			detected = paths.DetectFormat(str)
+			if formats != nil {
+				formats[str] = detected
+			}
+		}
+		pathStrings[prefix].add(str, detected)
+	}
+	if num, ok := numberValue(value); ok && pathNumbers != nil {
+		if pathNumbers[prefix] == nil {
+			pathNumbers[prefix] = &NumberStats{}
+		}
+		pathNumbers[prefix].add(num)
 	}
 }
 
@@ -338,12 +876,32 @@ func valueToString(v any) string {
 			return "<empty>"
 		}
 		return val
+	case map[string]any:
+		if len(val) == 0 {
+			return "<empty object>"
+		}
+		return fmt.Sprintf("%v", val)
+	case []any:
+		if len(val) == 0 {
+			return "<empty array>"
+		}
+		return fmt.Sprintf("%v", val)
 	case float64:
 		// Format numbers consistently
 		if val == float64(int64(val)) {
 			return fmt.Sprintf("%d", int64(val))
 		}
 		return fmt.Sprintf("%g", val)
+	case json.Number:
+		// Integers keep their exact text so 64-bit IDs stay distinct;
+		// other numbers are formatted like float64 so 1.50 and 1.5 match
+		if _, err := val.Int64(); err == nil || !strings.ContainsAny(string(val), ".eE") {
+			return val.String()
+		}
+		if f, err := val.Float64(); err == nil {
+			return valueToString(f)
+		}
+		return val.String()
 	case bool:
 		if val {
 			return "true"
@@ -380,3 +938,16 @@ func getTopValues(freqs map[string]int, n int) []ValueFrequency {
 	}
 	return values
 }
+
+// presence returns the percentage of JSON lines containing a path.
+func presence(objectHits, jsonLines int) float64 {
+	if jsonLines == 0 {
+		return 0
+	}
+	return float64(objectHits) * 100 / float64(jsonLines)
+}
+
+// isEmptyValue reports whether a valueToString result stands for "", [] or {}.
+func isEmptyValue(v string) bool {
+	return v == "<empty>" || v == "<empty object>" || v == "<empty array>"
+}
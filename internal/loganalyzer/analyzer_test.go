@@ -2,6 +2,8 @@ package loganalyzer
 
 import (
 	"testing"
+
+	"jtool/internal/paths"
 )
 
 func TestAnalyzeString_JSONL(t *testing.T) {
@@ -153,3 +155,255 @@ func TestAnalyzeString_MultiLineArray(t *testing.T) {
 		t.Errorf("expected 1 JSON object (array), got %d", result.JSONLines)
 	}
 }
+
+func TestAnalyzeString_BigIntegers(t *testing.T) {
+	input := `{"id": 9007199254740993}
+{"id": 9007199254740992}
+{"id": 1.50}
+{"id": 1.5}`
+
+	result, err := AnalyzeString(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Paths) != 1 {
+		t.Fatalf("expected 1 path, got %d", len(result.Paths))
+	}
+
+	// The two 64-bit IDs must not collapse into one value,
+	// while 1.50 and 1.5 are the same number
+	if result.Paths[0].DistinctCount != 3 {
+		t.Errorf("expected 3 distinct values, got %d: %+v", result.Paths[0].DistinctCount, result.Paths[0].TopValues)
+	}
+}
+
+func TestAnalyzeString_Schema(t *testing.T) {
+	input := `INFO starting sync
+{"type": "RECORD", "record": {"id": 1, "name": "a"}}
+{"type": "RECORD", "record": {"id": 2}}
+{"type": "STATE", "value": {"bookmark": "2024-01-02"}}`
+
+	result, err := AnalyzeString(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema := result.Schema
+	if schema == nil {
+		t.Fatal("expected a schema")
+	}
+	if len(schema.Required) != 1 || schema.Required[0] != "type" {
+		t.Errorf("expected only \"type\" to be required, got %v", schema.Required)
+	}
+
+	record := schema.Properties["record"]
+	if record == nil {
+		t.Fatalf("expected a record property, got %v", schema.Properties)
+	}
+	if len(record.Required) != 1 || record.Required[0] != "id" {
+		t.Errorf("expected only record.id to be required, got %v", record.Required)
+	}
+
+	bookmark := schema.Properties["value"].Properties["bookmark"]
+	if bookmark.Format != "date" {
+		t.Errorf("expected bookmark format date, got %q", bookmark.Format)
+	}
+}
+
+func TestAnalyzeString_Types(t *testing.T) {
+	input := `{"id": 1}
+{"id": "2"}
+{"id": 3}
+{"id": null}`
+
+	result, err := AnalyzeString(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Paths) != 1 {
+		t.Fatalf("expected 1 path, got %d", len(result.Paths))
+	}
+
+	types := result.Paths[0].Types
+	if types["number"] != 2 || types["string"] != 1 || types["null"] != 1 || len(types) != 3 {
+		t.Errorf("expected 2 numbers, 1 string and 1 null, got %v", types)
+	}
+}
+
+func TestAnalyzeString_Presence(t *testing.T) {
+	input := `{"id": 1, "email": "a@example.com"}
+{"id": 2}
+not json
+{"id": 3, "email": "c@example.com"}
+{"id": 4, "email": null}`
+
+	result, err := AnalyzeString(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	presence := map[string]float64{}
+	for _, p := range result.Paths {
+		presence[p.Path] = p.Presence
+	}
+
+	// Skipped lines don't count: email is in 3 of the 4 JSON lines
+	if presence[".id"] != 100 || presence[".email"] != 75 {
+		t.Errorf("expected .id 100%% and .email 75%%, got %v", presence)
+	}
+}
+
+func TestAnalyzeStringWithOptions_TopN(t *testing.T) {
+	jsonl := `{"color": "red"}
+{"color": "red"}
+{"color": "red"}
+{"color": "green"}
+{"color": "green"}
+{"color": "blue"}`
+
+	tests := []struct {
+		topN     int
+		expected []string
+	}{
+		{0, []string{"red", "green", "blue"}},
+		{1, []string{"red"}},
+		{2, []string{"red", "green"}},
+	}
+
+	for _, tt := range tests {
+		for _, approximate := range []bool{false, true} {
+			result, err := AnalyzeStringWithOptions(jsonl, Options{TopN: tt.topN, Approximate: approximate})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			top := result.Paths[0].TopValues
+			if len(top) != len(tt.expected) {
+				t.Fatalf("TopN=%d approximate=%v: expected %d values, got %+v", tt.topN, approximate, len(tt.expected), top)
+			}
+			for i, v := range tt.expected {
+				if top[i].Value != v {
+					t.Errorf("TopN=%d approximate=%v: expected %s at %d, got %s", tt.topN, approximate, v, i, top[i].Value)
+				}
+			}
+		}
+	}
+}
+
+func TestValueFrequenciesString(t *testing.T) {
+	// 25 distinct ids, more than the default TopN
+	var jsonl string
+	for i := 0; i < 25; i++ {
+		jsonl += `{"id": "` + string(rune('a'+i)) + `", "tags": ["x", "y", "x"]}` + "\n"
+	}
+
+	ids := ValueFrequenciesString(jsonl, ".id", Options{})
+	if len(ids) != 25 {
+		t.Errorf("expected all 25 ids, got %d", len(ids))
+	}
+
+	tags := ValueFrequenciesString(jsonl, ".tags[]", Options{})
+	if len(tags) != 2 || tags[0] != (ValueFrequency{"x", 50}) || tags[1] != (ValueFrequency{"y", 25}) {
+		t.Errorf("expected x=50 and y=25, got %+v", tags)
+	}
+
+	if missing := ValueFrequenciesString(jsonl, ".nope", Options{}); len(missing) != 0 {
+		t.Errorf("expected no values for a missing path, got %+v", missing)
+	}
+
+	// Paths refer to the documents the decoder unwraps, not the wrapper
+	docker := `{"log":"{\"level\":\"info\"}\n","stream":"stdout","time":"2024-01-01T00:00:00Z"}
+{"log":"{\"level\":\"warn\"}\n","stream":"stdout","time":"2024-01-01T00:00:01Z"}
+{"log":"{\"level\":\"info\"}\n","stream":"stdout","time":"2024-01-01T00:00:02Z"}`
+	levels := ValueFrequenciesString(docker, ".level", Options{Decoder: DecoderDocker})
+	if len(levels) != 2 || levels[0] != (ValueFrequency{"info", 2}) || levels[1] != (ValueFrequency{"warn", 1}) {
+		t.Errorf("expected info=2 and warn=1 after decoding, got %+v", levels)
+	}
+	if streams := ValueFrequenciesString(docker, ".stream", Options{Decoder: DecoderDocker}); len(streams) != 0 {
+		t.Errorf("expected no values for a wrapper field, got %+v", streams)
+	}
+}
+
+func TestAnalyzeStringWithOptions_PathFormat(t *testing.T) {
+	jsonl := `{"record": {"id": 1, "tags": ["a", "b"]}}
+{"record": {"id": 2, "tags": ["a"]}}`
+
+	tests := []struct {
+		format string
+		id     string
+		tags   string
+	}{
+		{"", ".record.id", ".record.tags[]"},
+		{paths.FormatJSONPath, "$.record.id", "$.record.tags[]"},
+		{paths.FormatPointer, "/record/id", "/record/tags/-"},
+	}
+
+	for _, tt := range tests {
+		opts := Options{PathFormat: tt.format}
+		result, err := AnalyzeStringWithOptions(jsonl, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		found := make(map[string]bool)
+		for _, p := range result.Paths {
+			found[p.Path] = true
+		}
+		if !found[tt.id] || !found[tt.tags] || len(found) != 2 {
+			t.Errorf("format %q: expected %s and %s, got %+v", tt.format, tt.id, tt.tags, result.Paths)
+		}
+
+		// Paths from the result can be passed back in
+		if tags := ValueFrequenciesString(jsonl, tt.tags, opts); len(tags) != 2 || tags[0] != (ValueFrequency{"a", 2}) {
+			t.Errorf("format %q: expected a=2 and b=1, got %+v", tt.format, tags)
+		}
+	}
+}
+
+func TestAnalyzeString_Empties(t *testing.T) {
+	jsonl := `{"note": "", "tags": [], "meta": {}, "owner": null}
+{"note": "", "tags": [], "meta": {}, "owner": "a"}
+{"note": "", "tags": [], "meta": {"k": 1}, "owner": "b"}
+{"note": "hi", "tags": ["x"], "meta": {"k": 2}, "owner": "c"}`
+
+	result, err := AnalyzeString(jsonl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byPath := make(map[string]PathSummary)
+	for _, p := range result.Paths {
+		byPath[p.Path] = p
+	}
+
+	tests := []struct {
+		path     string
+		count    int
+		nulls    int
+		empties  int
+		emptyPct float64
+	}{
+		{".note", 4, 0, 3, 75},
+		{".owner", 4, 1, 0, 25},
+		{".tags", 3, 0, 3, 100}, // Listed only where empty
+		{".tags[]", 1, 0, 0, 0},
+		{".meta", 2, 0, 2, 100},
+		{".meta.k", 2, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		p, ok := byPath[tt.path]
+		if !ok {
+			t.Errorf("expected path %s", tt.path)
+			continue
+		}
+		if p.Count != tt.count || p.Nulls != tt.nulls || p.Empties != tt.empties || p.EmptyPct != tt.emptyPct {
+			t.Errorf("%s: expected count=%d nulls=%d empties=%d emptyPct=%v, got %d/%d/%d/%v",
+				tt.path, tt.count, tt.nulls, tt.empties, tt.emptyPct, p.Count, p.Nulls, p.Empties, p.EmptyPct)
+		}
+	}
+	if got := byPath[".tags"].TopValues[0].Value; got != "<empty array>" {
+		t.Errorf("expected <empty array> as the .tags value, got %s", got)
+	}
+}
@@ -0,0 +1,122 @@
+package loganalyzer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Kinds of anomaly reported in PathSummary.Anomalies.
+const (
+	AnomalyNearConstant    = "near-constant"    // Over 99% of values identical, with a few exceptions
+	AnomalyOutlier         = "outlier"          // A numeric value far outside the rest
+	AnomalyConstantBoolean = "constant-boolean" // A boolean that is always the same
+)
+
+const (
+	// nearConstantShare is the share of values the most frequent value must
+	// exceed for the others to be reported as exceptions.
+	nearConstantShare = 0.99
+
+	// outlierSigma is how many standard deviations from the mean a value must
+	// be to count as an outlier. Normally distributed data essentially never
+	// gets this far, even over millions of values.
+	outlierSigma = 6
+
+	// minAnomalyValues is the fewest values a path needs before outliers and
+	// constant booleans are reported; on a handful of lines they're noise.
+	minAnomalyValues = 20
+)
+
+// Anomaly is a suspicious pattern in the values of a path, worth a look
+// without eyeballing TopValues for every path.
+type Anomaly struct {
+	Kind    string   `json:"kind"`             // One of the Anomaly* kinds
+	Message string   `json:"message"`          // e.g. "99.8% of values are \"ok\"; 2 are not"
+	Values  []string `json:"values,omitempty"` // The exceptional values, when known
+}
+
+// detectAnomalies checks a path summary for suspicious value distributions.
+// In approximate mode top value counts are lower bounds, so a near-constant
+// path may be missed but isn't reported falsely.
+func detectAnomalies(p PathSummary) []Anomaly {
+	var anomalies []Anomaly
+	if a, ok := nearConstant(p); ok {
+		anomalies = append(anomalies, a)
+	}
+	anomalies = append(anomalies, outliers(p.Numbers)...)
+	if a, ok := constantBoolean(p); ok {
+		anomalies = append(anomalies, a)
+	}
+	return anomalies
+}
+
+// nearConstant flags a path whose most frequent value makes up over 99% of
+// its values but not all of them, e.g. a status that is "ok" on every line
+// but three.
+func nearConstant(p PathSummary) (Anomaly, bool) {
+	if len(p.TopValues) < 2 || p.Count == 0 {
+		return Anomaly{}, false
+	}
+	top := p.TopValues[0]
+	share := float64(top.Count) / float64(p.Count)
+	if share <= nearConstantShare {
+		return Anomaly{}, false
+	}
+
+	var values []string
+	for _, v := range p.TopValues[1:] {
+		values = append(values, v.Value)
+	}
+	return Anomaly{
+		Kind:    AnomalyNearConstant,
+		Message: fmt.Sprintf("%s%% of values are %q; %d are not", formatShare(share), top.Value, p.Count-top.Count),
+		Values:  values,
+	}, true
+}
+
+// outliers flags a minimum or maximum more than outlierSigma standard
+// deviations from the mean.
+func outliers(n *NumberStats) []Anomaly {
+	if n == nil || n.Count < minAnomalyValues || n.StdDev == 0 {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	check := func(label string, value float64) {
+		sigmas := math.Abs(value-n.Mean) / n.StdDev
+		if sigmas < outlierSigma {
+			return
+		}
+		formatted := strconv.FormatFloat(value, 'g', -1, 64)
+		anomalies = append(anomalies, Anomaly{
+			Kind: AnomalyOutlier,
+			Message: fmt.Sprintf("%s %s is %.1f standard deviations from the mean %s",
+				label, formatted, sigmas, strconv.FormatFloat(n.Mean, 'g', 6, 64)),
+			Values: []string{formatted},
+		})
+	}
+	check("min", n.Min)
+	check("max", n.Max)
+	return anomalies
+}
+
+// constantBoolean flags a path whose values are all booleans and all the
+// same, i.e. a flag that never changes.
+func constantBoolean(p PathSummary) (Anomaly, bool) {
+	if len(p.Types) != 1 || p.Types["bool"] < minAnomalyValues || p.DistinctCount != 1 || len(p.TopValues) == 0 {
+		return Anomaly{}, false
+	}
+	return Anomaly{
+		Kind:    AnomalyConstantBoolean,
+		Message: fmt.Sprintf("always %s (%d values)", p.TopValues[0].Value, p.Count),
+		Values:  []string{p.TopValues[0].Value},
+	}, true
+}
+
+// formatShare formats a fraction as a percentage with one decimal, without
+// rounding up to a misleading "100.0".
+func formatShare(share float64) string {
+	pct := math.Floor(share*1000) / 10
+	return strconv.FormatFloat(pct, 'f', 1, 64)
+}
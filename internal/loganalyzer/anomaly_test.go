@@ -0,0 +1,115 @@
+package loganalyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeString_Anomalies(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 500; i++ {
+		status, latency := "ok", 100+i%10
+		if i == 250 {
+			status, latency = "error", 90000
+		}
+		fmt.Fprintf(&b, `{"status": %q, "latency": %d, "retry": false, "level": "info"}`+"\n", status, latency)
+	}
+
+	result, err := AnalyzeString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	kinds := make(map[string][]string)
+	for _, p := range result.Paths {
+		for _, a := range p.Anomalies {
+			kinds[p.Path] = append(kinds[p.Path], a.Kind)
+		}
+	}
+	expected := map[string][]string{
+		".status":  {AnomalyNearConstant},
+		".latency": {AnomalyOutlier},
+		".retry":   {AnomalyConstantBoolean},
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("expected %v, got %v", expected, kinds)
+	}
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  PathSummary
+		expected []Anomaly
+	}{
+		{
+			name: "near constant",
+			summary: PathSummary{
+				Count:     1000,
+				TopValues: []ValueFrequency{{"ok", 998}, {"error", 1}, {"<null>", 1}},
+			},
+			expected: []Anomaly{{
+				Kind:    AnomalyNearConstant,
+				Message: `99.8% of values are "ok"; 2 are not`,
+				Values:  []string{"error", "<null>"},
+			}},
+		},
+		{
+			name: "mostly but not nearly constant",
+			summary: PathSummary{
+				Count:     100,
+				TopValues: []ValueFrequency{{"ok", 99}, {"error", 1}},
+			},
+		},
+		{
+			name:    "no spread",
+			summary: PathSummary{Numbers: &NumberStats{Count: 100, Min: 5, Max: 5, Mean: 5}},
+		},
+		{
+			name:    "too few numbers",
+			summary: PathSummary{Numbers: &NumberStats{Count: 10, Min: 0, Max: 1000, Mean: 100, StdDev: 1}},
+		},
+		{
+			name:    "low outlier",
+			summary: PathSummary{Numbers: &NumberStats{Count: 100, Min: -50, Max: 12, Mean: 10, StdDev: 2}},
+			expected: []Anomaly{{
+				Kind:    AnomalyOutlier,
+				Message: "min -50 is 30.0 standard deviations from the mean 10",
+				Values:  []string{"-50"},
+			}},
+		},
+		{
+			name: "boolean with both values",
+			summary: PathSummary{
+				Count:         100,
+				DistinctCount: 2,
+				Types:         map[string]int{"bool": 100},
+				TopValues:     []ValueFrequency{{"true", 60}, {"false", 40}},
+			},
+		},
+		{
+			name: "constant boolean",
+			summary: PathSummary{
+				Count:         30,
+				DistinctCount: 1,
+				Types:         map[string]int{"bool": 30},
+				TopValues:     []ValueFrequency{{"true", 30}},
+			},
+			expected: []Anomaly{{
+				Kind:    AnomalyConstantBoolean,
+				Message: "always true (30 values)",
+				Values:  []string{"true"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectAnomalies(tt.summary); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package loganalyzer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BatchResult holds the analysis of several files, e.g. a nightly run split
+// across rotated logs.
+type BatchResult struct {
+	Combined *AnalysisResult `json:"combined"` // Statistics across all files, as if they were one file
+	Files    []FileSummary   `json:"files"`    // Per-file totals, in the order the files were given
+}
+
+// FileSummary holds the totals for one file of a batch.
+type FileSummary struct {
+	Path            string `json:"path"`            // File path
+	TotalLines      int    `json:"totalLines"`      // Total lines in the file
+	JSONLines       int    `json:"jsonLines"`       // JSON documents found
+	SkippedLines    int    `json:"skippedLines"`    // Lines that were not JSON
+	TotalPaths      int    `json:"totalPaths"`      // Unique paths in this file
+	TotalPathOccurs int    `json:"totalPathOccurs"` // Sum of path counts in this file
+	Encoding        string `json:"encoding"`        // Text encoding detected (see charset)
+}
+
+// AnalyzeFiles aggregates JSON path statistics across several files.
+func AnalyzeFiles(filePaths []string) (*BatchResult, error) {
+	return AnalyzeFilesWithOptions(filePaths, Options{})
+}
+
+// AnalyzeFilesWithOptions is AnalyzeFiles with control over value aggregation.
+//
+// Files are read in order into one analysis, so with Options.Singer a SCHEMA
+// in one file applies to RECORDs in the files after it. Singer violations
+// name the file they were found in. Any unreadable file fails the batch.
+func AnalyzeFilesWithOptions(filePaths []string, opts Options) (*BatchResult, error) {
+	return AnalyzeFilesContext(context.Background(), filePaths, opts)
+}
+
+// AnalyzeFilesContext is AnalyzeFilesWithOptions, giving up with ctx.Err()
+// soon after ctx is cancelled.
+func AnalyzeFilesContext(ctx context.Context, filePaths []string, opts Options) (*BatchResult, error) {
+	agg := newAggregator(opts)
+	files := make([]FileSummary, 0, len(filePaths))
+	totalLines := 0
+
+	for _, filePath := range filePaths {
+		summary := FileSummary{Path: filePath}
+		pathCounts := make(map[string]int)
+		agg.setFile(filePath)
+
+		docs := agg.scanner()
+		docs.process = func(line int, data any) {
+			summary.JSONLines++
+			extractPaths("", data, pathCounts)
+			agg.add(line, data)
+		}
+		lines, encoding, err := scanFile(ctx, filePath, docs)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		summary.TotalLines = lines
+		summary.Encoding = encoding
+		summary.SkippedLines = max(0, lines-summary.JSONLines)
+		summary.TotalPaths = len(pathCounts)
+		for _, count := range pathCounts {
+			summary.TotalPathOccurs += count
+		}
+		files = append(files, summary)
+		totalLines += lines
+	}
+
+	return &BatchResult{
+		Combined: agg.result(totalLines),
+		Files:    files,
+	}, nil
+}
+
+// AnalyzeDirectory analyzes every file in dir whose name matches pattern
+// (a filepath.Match glob such as "*.jsonl"; empty means every file), in
+// name order. Subdirectories are not searched.
+func AnalyzeDirectory(dir, pattern string, opts Options) (*BatchResult, error) {
+	return AnalyzeDirectoryContext(context.Background(), dir, pattern, opts)
+}
+
+// AnalyzeDirectoryContext is AnalyzeDirectory, giving up with ctx.Err()
+// soon after ctx is cancelled.
+func AnalyzeDirectoryContext(ctx context.Context, dir, pattern string, opts Options) (*BatchResult, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var filePaths []string
+	for _, entry := range entries {
+		if matched, _ := filepath.Match(pattern, entry.Name()); !matched {
+			continue
+		}
+		// Stat follows symlinks, so a "current.log" link still counts
+		path := filepath.Join(dir, entry.Name())
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			filePaths = append(filePaths, path)
+		}
+	}
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("no files in %s match %q", dir, pattern)
+	}
+
+	return AnalyzeFilesContext(ctx, filePaths, opts)
+}
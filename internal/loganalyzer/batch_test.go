@@ -0,0 +1,138 @@
+package loganalyzer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jtool/internal/charset"
+)
+
+func TestAnalyzeFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "tap.log.1")
+	second := filepath.Join(dir, "tap.log")
+	if err := os.WriteFile(first, []byte(`{"type": "SCHEMA", "stream": "users", "schema": {"properties": {"id": {"type": "integer"}}}}
+{"type": "RECORD", "stream": "users", "record": {"id": 1}}
+INFO rotated
+`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(second, []byte(`{"type": "RECORD", "stream": "users", "record": {"id": "2"}}
+`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch, err := AnalyzeFilesWithOptions([]string{first, second}, Options{Singer: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	combined := batch.Combined
+	if combined.TotalLines != 4 || combined.JSONLines != 3 || combined.SkippedLines != 1 {
+		t.Errorf("unexpected combined totals: %+v", combined)
+	}
+
+	if len(batch.Files) != 2 {
+		t.Fatalf("expected 2 file summaries, got %d", len(batch.Files))
+	}
+	expected := []FileSummary{
+		{Path: first, TotalLines: 3, JSONLines: 2, SkippedLines: 1, TotalPaths: 4, TotalPathOccurs: 6, Encoding: charset.UTF8},
+		{Path: second, TotalLines: 1, JSONLines: 1, SkippedLines: 0, TotalPaths: 3, TotalPathOccurs: 3, Encoding: charset.UTF8},
+	}
+	for i, want := range expected {
+		if batch.Files[i] != want {
+			t.Errorf("file %d: expected %+v, got %+v", i, want, batch.Files[i])
+		}
+	}
+
+	// The SCHEMA from the first file applies to the RECORD in the second
+	violations := combined.Singer.Violations
+	if len(violations) != 1 || violations[0].File != second || violations[0].Line != 1 {
+		t.Errorf("expected one violation on line 1 of %s, got %+v", second, violations)
+	}
+
+	// Drift sightings name the file as well as the line
+	for _, p := range combined.Paths {
+		if p.Path == ".record.id" && (p.FirstSeenFile != first || p.LastSeenFile != second || p.LastSeenLine != 1) {
+			t.Errorf("unexpected sightings for .record.id: %+v", p)
+		}
+	}
+
+	if _, err := AnalyzeFiles([]string{first, filepath.Join(dir, "missing.log")}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestAnalyzeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.jsonl":   `{"id": 2}` + "\n",
+		"a.jsonl":   `{"id": 1}` + "\n" + `{"id": 3}` + "\n",
+		"notes.txt": `{"ignored": true}` + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "old.jsonl"), 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch, err := AnalyzeDirectory(dir, "*.jsonl", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Files in name order; the matching directory is skipped
+	if len(batch.Files) != 2 || batch.Files[0].Path != filepath.Join(dir, "a.jsonl") || batch.Files[1].Path != filepath.Join(dir, "b.jsonl") {
+		t.Fatalf("unexpected files: %+v", batch.Files)
+	}
+	if batch.Combined.JSONLines != 3 || batch.Combined.TotalPaths != 1 {
+		t.Errorf("unexpected combined result: %+v", batch.Combined)
+	}
+
+	// Empty pattern means every file
+	all, err := AnalyzeDirectory(dir, "", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all.Files) != 3 {
+		t.Errorf("expected 3 files, got %d", len(all.Files))
+	}
+
+	if _, err := AnalyzeDirectory(dir, "*.csv", Options{}); err == nil {
+		t.Error("expected an error when nothing matches")
+	}
+	if _, err := AnalyzeDirectory(dir, "[", Options{}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestAnalyzeFilesContext_Cancelled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("{\"a\":1}\n{\"a\":2}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := AnalyzeFilesContext(ctx, []string{path}, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("AnalyzeFilesContext: expected context.Canceled, got %v", err)
+	}
+	if _, err := AnalyzeFileContext(ctx, path, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("AnalyzeFileContext: expected context.Canceled, got %v", err)
+	}
+
+	result, err := AnalyzeFileContext(context.Background(), path, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 2 {
+		t.Errorf("expected 2 JSON lines, got %d", result.JSONLines)
+	}
+}
@@ -0,0 +1,298 @@
+package loganalyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxCacheEntries = 32        // Results kept before the least recently stored are evicted
+	cacheHeadSize   = 64 * 1024 // Bytes hashed to tell a rewritten file from an unchanged one
+)
+
+// Cache keeps analysis results on disk so re-analyzing an unchanged file,
+// e.g. the baseline of a comparison, is instant.
+//
+// An entry is reused only if the file still has the same size, modification
+// time and leading 64KB, and was analyzed with the same options. Cache
+// problems are never fatal: a result that can't be read or written is just
+// recomputed.
+type Cache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// fileFingerprint identifies a version of a file.
+type fileFingerprint struct {
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	HeadHash string    `json:"headHash"` // sha256 of the first cacheHeadSize bytes
+}
+
+// cacheEntry is the on-disk form of a cached result.
+type cacheEntry struct {
+	Path        string          `json:"path"`
+	Options     Options         `json:"options"`
+	Fingerprint fileFingerprint `json:"fingerprint"`
+	Result      *AnalysisResult `json:"result"`
+}
+
+// NewCache returns a cache stored in dir, which is created on first use.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// AnalyzeFile is AnalyzeFileWithOptions, reusing a cached result when the
+// file hasn't changed since it was last analyzed with opts.
+func (c *Cache) AnalyzeFile(filePath string, opts Options) (*AnalysisResult, error) {
+	return c.AnalyzeFileContext(context.Background(), filePath, opts)
+}
+
+// AnalyzeFileContext is AnalyzeFile, giving up with ctx.Err() soon after
+// ctx is cancelled. A cancelled analysis is not cached.
+func (c *Cache) AnalyzeFileContext(ctx context.Context, filePath string, opts Options) (*AnalysisResult, error) {
+	fp, err := fingerprint(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if result, ok := c.lookup(filePath, opts, fp); ok {
+		return result, nil
+	}
+
+	result, err := AnalyzeFileContext(ctx, filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.store(filePath, opts, fp, result)
+	return result, nil
+}
+
+// followEntry is the on-disk form of a saved Follower. A followed file
+// grows, so only the part of the fingerprint that can't change is checked:
+// the hash of the first cacheHeadSize bytes the follower had read.
+type followEntry struct {
+	Path        string          `json:"path"`
+	Options     Options         `json:"options"`
+	Fingerprint fileFingerprint `json:"fingerprint"`
+	State       followState     `json:"state"`
+}
+
+// Follower returns a Follower for filePath that resumes where the last one
+// saved with SaveFollower for the same file and options stopped, as long
+// as the file still starts with what it had read. Otherwise, or if nothing
+// was saved, the Follower starts from the beginning.
+func (c *Cache) Follower(filePath string, opts Options) *Follower {
+	f := NewFollower(filePath, opts)
+	if !opts.saveable() {
+		return f
+	}
+
+	c.mu.Lock()
+	data, err := os.ReadFile(c.followPath(filePath, opts))
+	c.mu.Unlock()
+	if err != nil {
+		return f
+	}
+	var entry followEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return f
+	}
+
+	fp, err := followFingerprint(filePath, entry.State.Offset)
+	if err != nil || fp.Size < entry.State.Offset || fp.HeadHash != entry.Fingerprint.HeadHash {
+		return f
+	}
+
+	f.restore(entry.State)
+	return f
+}
+
+// SaveFollower saves how far f has read and what it has found, so a
+// Follower for the same file and options from Follower resumes there, even
+// after a restart. Followers that use a decoder, Singer linting, a schema
+// or approximate counting aren't saved.
+func (c *Cache) SaveFollower(f *Follower) error {
+	if !f.opts.saveable() {
+		return nil
+	}
+
+	f.mu.Lock()
+	fp, err := followFingerprint(f.path, f.offset)
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(followEntry{Path: f.path, Options: f.opts, Fingerprint: fp, State: f.state()})
+	}
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.followPath(f.path, f.opts), data, 0644); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// lookup returns the cached result for this version of the file, if any.
+func (c *Cache) lookup(filePath string, opts Options, fp fileFingerprint) (*AnalysisResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.entryPath(filePath, opts))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Result == nil {
+		return nil, false
+	}
+	if !entry.Fingerprint.ModTime.Equal(fp.ModTime) || entry.Fingerprint.Size != fp.Size || entry.Fingerprint.HeadHash != fp.HeadHash {
+		return nil, false
+	}
+	return entry.Result, true
+}
+
+// store saves a result and evicts the oldest entries beyond maxCacheEntries.
+func (c *Cache) store(filePath string, opts Options, fp fileFingerprint, result *AnalysisResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cacheEntry{Path: filePath, Options: opts, Fingerprint: fp, Result: result})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.entryPath(filePath, opts), data, 0644); err != nil {
+		return err
+	}
+	return c.evict()
+}
+
+// evict removes the least recently stored entries beyond maxCacheEntries.
+func (c *Cache) evict() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type cached struct {
+		name    string
+		modTime time.Time
+	}
+	var files []cached
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cached{e.Name(), info.ModTime()})
+	}
+	if len(files) <= maxCacheEntries {
+		return nil
+	}
+
+	// Newest first, then drop the tail
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	for _, f := range files[maxCacheEntries:] {
+		_ = os.Remove(filepath.Join(c.dir, f.name))
+	}
+	return nil
+}
+
+// entryPath names the cache file for a file path and options.
+func (c *Cache) entryPath(filePath string, opts Options) string {
+	if abs, err := filepath.Abs(filePath); err == nil {
+		filePath = abs
+	}
+	key, _ := json.Marshal(struct {
+		Path    string
+		Options Options
+	}{filePath, opts})
+	sum := sha256.Sum256(key)
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// followPath names the file a Follower for a file path and options is
+// saved in, next to the cached result for the same key.
+func (c *Cache) followPath(filePath string, opts Options) string {
+	return strings.TrimSuffix(c.entryPath(filePath, opts), ".json") + ".follow.json"
+}
+
+// fingerprint reads the size, modification time and leading bytes of a file.
+func fingerprint(filePath string) (fileFingerprint, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+
+	hash, err := headHash(file, cacheHeadSize)
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+
+	return fileFingerprint{
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		HeadHash: hash,
+	}, nil
+}
+
+// followFingerprint is fingerprint for a file being followed, hashing only
+// the first offset bytes (at most cacheHeadSize) the follower has read.
+func followFingerprint(filePath string, offset int64) (fileFingerprint, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+	hash, err := headHash(file, min(offset, cacheHeadSize))
+	if err != nil {
+		return fileFingerprint{}, err
+	}
+
+	return fileFingerprint{
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		HeadHash: hash,
+	}, nil
+}
+
+// headHash returns the sha256 of the first n bytes read from r (fewer if
+// it ends first).
+func headHash(r io.Reader, n int64) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(r, n)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,196 @@
+package loganalyzer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tap.log")
+	if err := os.WriteFile(path, []byte("{\"id\": 1, \"name\": \"a\"}\n{\"id\": 2}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := NewCache(filepath.Join(dir, "cache"))
+
+	first, err := cache.AnalyzeFile(path, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fp, err := fingerprint(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached, ok := cache.lookup(path, Options{}, fp)
+	if !ok {
+		t.Fatal("expected a cached result")
+	}
+	if got, want := mustJSON(t, cached), mustJSON(t, first); got != want {
+		t.Errorf("expected the cached result to match:\n%s\ngot:\n%s", want, got)
+	}
+
+	// Other options are cached separately
+	if _, ok := cache.lookup(path, Options{TopN: 3}, fp); ok {
+		t.Error("expected no cached result for other options")
+	}
+
+	// Appending changes the fingerprint
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.WriteString("{\"id\": 3}\n")
+	f.Close()
+
+	fp, err = fingerprint(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cache.lookup(path, Options{}, fp); ok {
+		t.Error("expected a stale entry to be ignored")
+	}
+	updated, err := cache.AnalyzeFile(path, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.JSONLines != 3 {
+		t.Errorf("expected 3 JSON lines after the append, got %d", updated.JSONLines)
+	}
+}
+
+func TestCache_SameSizeRewrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tap.log")
+	if err := os.WriteFile(path, []byte("{\"a\": 1}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := NewCache(filepath.Join(dir, "cache"))
+	if _, err := cache.AnalyzeFile(path, Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Same size and modification time, different content
+	info, _ := os.Stat(path)
+	if err := os.WriteFile(path, []byte("{\"b\": 1}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	os.Chtimes(path, info.ModTime(), info.ModTime())
+
+	result, err := cache.AnalyzeFile(path, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Paths[0].Path != ".b" {
+		t.Errorf("expected the rewritten content to be analyzed, got %s", result.Paths[0].Path)
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(filepath.Join(dir, "cache"))
+	for i := 0; i < maxCacheEntries+3; i++ {
+		path := filepath.Join(dir, "tap.log")
+		if err := os.WriteFile(path, []byte("{\"a\": 1}\n"), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cache.AnalyzeFile(path, Options{TopN: i + 1}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Keep modification times distinct so eviction order is stable
+		stamp := time.Now().Add(time.Duration(i) * time.Second)
+		os.Chtimes(cache.entryPath(path, Options{TopN: i + 1}), stamp, stamp)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != maxCacheEntries {
+		t.Errorf("expected %d entries, got %d", maxCacheEntries, len(entries))
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func TestCacheFollower(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tap.log")
+	appendTo := func(text string) {
+		t.Helper()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(text); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Strings, numbers, a parse failure, and a document and a line that are
+	// both unfinished when the follower is saved
+	appendTo("INFO starting\n{\"id\": 1, \"email\": \"a@example.com\", \"tags\": [\"x\"]}\n{\"id\": 2.5, \"email\": null}\n{\n  \"id\": 3,\n")
+	appendTo(`{"id": 4, "na`)
+
+	follower := NewCache(filepath.Join(dir, "cache")).Follower(path, Options{})
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := NewCache(filepath.Join(dir, "cache")).SaveFollower(follower); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// After a restart, reading resumes from the saved offset
+	appendTo("me\": \"d\"}\n")
+	appendTo("  \"email\": \"c@example.com\"\n}\n{\"id\": 5}\n")
+	resumed := NewCache(filepath.Join(dir, "cache")).Follower(path, Options{})
+	if resumed.offset != follower.offset {
+		t.Fatalf("expected to resume at offset %d, got %d", follower.offset, resumed.offset)
+	}
+	if _, err := resumed.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The result is the same as reading the whole file at once
+	want, err := AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want.Encoding = ""
+	if got, want := mustJSON(t, resumed.Result()), mustJSON(t, want); got != want {
+		t.Errorf("expected the resumed analysis to match a full one:\n%s\ngot:\n%s", want, got)
+	}
+
+	// A file replaced since the save is read from the beginning
+	if err := os.WriteFile(path, []byte(strings.Repeat("{\"other\": true}\n", 20)), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fresh := NewCache(filepath.Join(dir, "cache")).Follower(path, Options{}); fresh.offset != 0 {
+		t.Errorf("expected a replaced file to start over, got offset %d", fresh.offset)
+	}
+
+	// Followers that can't be saved always start over
+	cache := NewCache(filepath.Join(dir, "singer"))
+	singer := cache.Follower(path, Options{Singer: true})
+	if _, err := singer.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cache.SaveFollower(singer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again := cache.Follower(path, Options{Singer: true}); again.offset != 0 {
+		t.Errorf("expected a Singer follower not to be saved, got offset %d", again.offset)
+	}
+}
@@ -1,7 +1,12 @@
 package loganalyzer
 
 import (
+	"math"
+	"regexp"
 	"sort"
+	"strings"
+
+	"jtool/internal/pathmatch"
 )
 
 // ComparisonStatus represents the comparison state of a JSON path
@@ -23,6 +28,17 @@ type PathComparison struct {
 	CountDelta    int               `json:"countDelta"`
 	ObjectsDelta  int               `json:"objectsDelta"`
 	DistinctDelta int               `json:"distinctDelta"`
+
+	// Deltas relative to the left side, in percent (-100 for removed paths).
+	// nil for added paths, which have no baseline to compare against.
+	CountDeltaPct    *float64 `json:"countDeltaPct,omitempty"`
+	ObjectsDeltaPct  *float64 `json:"objectsDeltaPct,omitempty"`
+	DistinctDeltaPct *float64 `json:"distinctDeltaPct,omitempty"`
+
+	// ValueChanges lists values that appeared, disappeared or sharply
+	// changed frequency, for paths in both analyses. Any value change
+	// marks the path changed, whatever the count deltas.
+	ValueChanges []ValueChange `json:"valueChanges,omitempty"`
 }
 
 // ComparisonStats aggregates statistics across all path comparisons
@@ -35,6 +51,7 @@ type ComparisonStats struct {
 	TotalCountDelta    int `json:"totalCountDelta"`
 	TotalObjectsDelta  int `json:"totalObjectsDelta"`
 	TotalDistinctDelta int `json:"totalDistinctDelta"`
+	IgnoredPaths       int `json:"ignoredPaths"` // Paths left out by CompareOptions.IgnorePaths
 }
 
 // ComparisonResult represents the overall comparison between two log analyses
@@ -43,6 +60,37 @@ type ComparisonResult struct {
 	Stats       ComparisonStats  `json:"stats"`
 	LeftFile    string           `json:"leftFile"`
 	RightFile   string           `json:"rightFile"`
+
+	// VolumeScale is the factor right-side counts were multiplied by when
+	// CompareOptions.NormalizeVolume is on; 0 when counts weren't scaled.
+	VolumeScale float64 `json:"volumeScale,omitempty"`
+}
+
+// CompareOptions controls how two analyses are compared.
+type CompareOptions struct {
+	// ChangeThreshold is the relative move, in percent, a path needs on
+	// count, objects or distinct values to be reported as changed. Paths
+	// in both analyses that move less are reported as equal, though their
+	// deltas are kept. Zero flags any difference.
+	ChangeThreshold float64 `json:"changeThreshold"`
+
+	// NormalizeVolume compares counts per JSON line instead of in total,
+	// so runs that processed different volumes can still be compared
+	// structurally. Right-side Count and ObjectHits are scaled to the left
+	// side's JSONLines before deltas are taken; DistinctCount isn't scaled,
+	// since distinct values don't grow linearly with volume.
+	NormalizeVolume bool `json:"normalizeVolume"`
+
+	// IgnorePaths leaves volatile paths out of the comparison entirely:
+	// they are neither listed nor counted in the stats, apart from
+	// ComparisonStats.IgnoredPaths. Patterns are pathmatch globs, which
+	// also match everything below the path (".value.bookmarks" ignores
+	// ".value.bookmarks.orders.updated_at"), or regular expressions between
+	// slashes searched for anywhere in the path ("/_at$/"). An invalid
+	// regular expression matches nothing. Globs suit the dotted and JSONPath
+	// formats (see Options.PathFormat); regular expressions match paths as
+	// they are written.
+	IgnorePaths []string `json:"ignorePaths"`
 }
 
 // CompareAnalyses compares two analysis results and returns a structured comparison
@@ -51,6 +99,12 @@ type ComparisonResult struct {
 //
 // leftFile and rightFile are optional file paths for display purposes
 func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *ComparisonResult {
+	return CompareAnalysesWithOptions(left, right, leftFile, rightFile, CompareOptions{})
+}
+
+// CompareAnalysesWithOptions is CompareAnalyses with control over what
+// counts as a change.
+func CompareAnalysesWithOptions(left, right *AnalysisResult, leftFile, rightFile string, opts CompareOptions) *ComparisonResult {
 	if left == nil || right == nil {
 		return &ComparisonResult{
 			Comparisons: []PathComparison{},
@@ -60,6 +114,12 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 		}
 	}
 
+	// Scale right-side counts to the left side's volume
+	scale := 1.0
+	if opts.NormalizeVolume && left.JSONLines > 0 && right.JSONLines > 0 {
+		scale = float64(left.JSONLines) / float64(right.JSONLines)
+	}
+
 	// Build maps for O(1) lookup (like Python dict)
 	// In Go, map keys are compared by value, strings are compared efficiently
 	leftMap := make(map[string]PathSummary)
@@ -71,6 +131,11 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 	for _, summary := range right.Paths {
 		rightMap[summary.Path] = summary
 	}
+	scaled := func(summary PathSummary) PathSummary {
+		summary.Count = int(math.Round(float64(summary.Count) * scale))
+		summary.ObjectHits = int(math.Round(float64(summary.ObjectHits) * scale))
+		return summary
+	}
 
 	// Collect all unique paths from both results
 	// Using a map as a set (like Python's set() type)
@@ -83,8 +148,14 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 	}
 
 	// Convert to sorted slice for consistent ordering
+	ignored := ignoreMatcher(opts.IgnorePaths)
 	paths := make([]string, 0, len(allPaths))
+	ignoredPaths := 0
 	for path := range allPaths {
+		if ignored(path) {
+			ignoredPaths++
+			continue
+		}
 		paths = append(paths, path)
 	}
 	sort.Strings(paths)
@@ -96,6 +167,7 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 	for _, path := range paths {
 		leftSummary, inLeft := leftMap[path]
 		rightSummary, inRight := rightMap[path]
+		rightScaled := scaled(rightSummary)
 
 		comparison := PathComparison{
 			Path: path,
@@ -106,11 +178,13 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 			// Path exists in both - compare statistics
 			comparison.Left = &leftSummary
 			comparison.Right = &rightSummary
-			comparison.CountDelta = rightSummary.Count - leftSummary.Count
-			comparison.ObjectsDelta = rightSummary.ObjectHits - leftSummary.ObjectHits
-			comparison.DistinctDelta = rightSummary.DistinctCount - leftSummary.DistinctCount
+			comparison.CountDelta = rightScaled.Count - leftSummary.Count
+			comparison.ObjectsDelta = rightScaled.ObjectHits - leftSummary.ObjectHits
+			comparison.DistinctDelta = rightScaled.DistinctCount - leftSummary.DistinctCount
+			comparison.setDeltaPcts()
+			comparison.ValueChanges = diffTopValues(leftSummary, rightSummary)
 
-			if statsAreEqual(leftSummary, rightSummary) {
+			if len(comparison.ValueChanges) == 0 && (statsAreEqual(leftSummary, rightScaled) || !comparison.exceeds(opts.ChangeThreshold)) {
 				comparison.Status = StatusEqual
 				stats.EqualPaths++
 			} else {
@@ -124,14 +198,15 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 			comparison.CountDelta = -leftSummary.Count
 			comparison.ObjectsDelta = -leftSummary.ObjectHits
 			comparison.DistinctDelta = -leftSummary.DistinctCount
+			comparison.setDeltaPcts()
 			stats.RemovedPaths++
 		} else {
 			// Path only in right - added
 			comparison.Status = StatusAdded
 			comparison.Right = &rightSummary
-			comparison.CountDelta = rightSummary.Count
-			comparison.ObjectsDelta = rightSummary.ObjectHits
-			comparison.DistinctDelta = rightSummary.DistinctCount
+			comparison.CountDelta = rightScaled.Count
+			comparison.ObjectsDelta = rightScaled.ObjectHits
+			comparison.DistinctDelta = rightScaled.DistinctCount
 			stats.AddedPaths++
 		}
 
@@ -144,6 +219,7 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 	}
 
 	stats.TotalPaths = len(comparisons)
+	stats.IgnoredPaths = ignoredPaths
 
 	// Sort comparisons by priority: removed, added, changed, equal
 	// Within each status, sort by absolute delta magnitude (descending)
@@ -166,12 +242,68 @@ func CompareAnalyses(left, right *AnalysisResult, leftFile, rightFile string) *C
 		return comparisons[i].Path < comparisons[j].Path
 	})
 
-	return &ComparisonResult{
+	result := &ComparisonResult{
 		Comparisons: comparisons,
 		Stats:       stats,
 		LeftFile:    leftFile,
 		RightFile:   rightFile,
 	}
+	if scale != 1 {
+		result.VolumeScale = scale
+	}
+	return result
+}
+
+// ignoreMatcher compiles CompareOptions.IgnorePaths into a single match
+// function.
+func ignoreMatcher(patterns []string) func(path string) bool {
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		if len(pattern) < 2 || !strings.HasPrefix(pattern, "/") || !strings.HasSuffix(pattern, "/") {
+			pattern := pattern
+			matchers = append(matchers, func(path string) bool { return pathmatch.MatchPrefix(pattern, path) })
+			continue
+		}
+		if re, err := regexp.Compile(pattern[1 : len(pattern)-1]); err == nil {
+			matchers = append(matchers, re.MatchString)
+		}
+	}
+
+	return func(path string) bool {
+		for _, match := range matchers {
+			if match(path) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// setDeltaPcts fills in the relative deltas from the absolute ones.
+// Call it once Left and the deltas are set.
+func (c *PathComparison) setDeltaPcts() {
+	c.CountDeltaPct = deltaPct(c.CountDelta, c.Left.Count)
+	c.ObjectsDeltaPct = deltaPct(c.ObjectsDelta, c.Left.ObjectHits)
+	c.DistinctDeltaPct = deltaPct(c.DistinctDelta, c.Left.DistinctCount)
+}
+
+// exceeds reports whether any relative delta moves more than threshold percent.
+func (c *PathComparison) exceeds(threshold float64) bool {
+	for _, pct := range []*float64{c.CountDeltaPct, c.ObjectsDeltaPct, c.DistinctDeltaPct} {
+		if pct != nil && math.Abs(*pct) > threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// deltaPct returns delta as a percentage of base, or nil if base is zero.
+func deltaPct(delta, base int) *float64 {
+	if base == 0 {
+		return nil
+	}
+	pct := float64(delta) / float64(base) * 100
+	return &pct
 }
 
 // statsAreEqual checks if two PathSummary objects have identical statistics
@@ -447,3 +447,174 @@ func TestComparisonSorting(t *testing.T) {
 		}
 	}
 }
+
+func TestCompareAnalyses_DeltaPcts(t *testing.T) {
+	left := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".a", Count: 200, ObjectHits: 100, DistinctCount: 10},
+		{Path: ".b", Count: 50, ObjectHits: 50, DistinctCount: 5},
+	}}
+	right := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".a", Count: 150, ObjectHits: 110, DistinctCount: 10},
+		{Path: ".c", Count: 5, ObjectHits: 5, DistinctCount: 1},
+	}}
+
+	byPath := make(map[string]PathComparison)
+	for _, c := range CompareAnalyses(left, right, "", "").Comparisons {
+		byPath[c.Path] = c
+	}
+
+	tests := []struct {
+		path                     string
+		count, objects, distinct *float64
+	}{
+		{".a", ptr(-25.0), ptr(10.0), ptr(0.0)},
+		{".b", ptr(-100.0), ptr(-100.0), ptr(-100.0)},
+		{".c", nil, nil, nil},
+	}
+	for _, tt := range tests {
+		c := byPath[tt.path]
+		for _, m := range []struct {
+			name      string
+			got, want *float64
+		}{
+			{"count", c.CountDeltaPct, tt.count},
+			{"objects", c.ObjectsDeltaPct, tt.objects},
+			{"distinct", c.DistinctDeltaPct, tt.distinct},
+		} {
+			if (m.got == nil) != (m.want == nil) || (m.got != nil && *m.got != *m.want) {
+				t.Errorf("%s %s: expected %v, got %v", tt.path, m.name, deref(m.want), deref(m.got))
+			}
+		}
+	}
+}
+
+func TestCompareAnalysesWithOptions_ChangeThreshold(t *testing.T) {
+	left := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".noise", Count: 1000, ObjectHits: 1000, DistinctCount: 1000},
+		{Path: ".shift", Count: 1000, ObjectHits: 1000, DistinctCount: 10},
+	}}
+	right := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".noise", Count: 1030, ObjectHits: 1030, DistinctCount: 1030},
+		{Path: ".shift", Count: 1000, ObjectHits: 1000, DistinctCount: 20},
+	}}
+
+	result := CompareAnalysesWithOptions(left, right, "", "", CompareOptions{ChangeThreshold: 5})
+
+	status := make(map[string]PathComparison)
+	for _, c := range result.Comparisons {
+		status[c.Path] = c
+	}
+	if got := status[".noise"]; got.Status != StatusEqual || got.CountDelta != 30 {
+		t.Errorf(".noise: expected equal with a delta of 30, got %s with %d", got.Status, got.CountDelta)
+	}
+	if got := status[".shift"].Status; got != StatusChanged {
+		t.Errorf(".shift: expected changed, got %s", got)
+	}
+	if result.Stats.EqualPaths != 1 || result.Stats.ChangedPaths != 1 {
+		t.Errorf("expected 1 equal and 1 changed, got %+v", result.Stats)
+	}
+
+	// Without a threshold any difference is a change
+	if got := CompareAnalyses(left, right, "", "").Stats.ChangedPaths; got != 2 {
+		t.Errorf("expected 2 changed paths without a threshold, got %d", got)
+	}
+}
+
+func ptr(f float64) *float64 { return &f }
+
+func deref(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+func TestCompareAnalysesWithOptions_NormalizeVolume(t *testing.T) {
+	// Baseline of 1000 records vs a candidate of 100 with the same shape,
+	// except .rare which shows up twice as often in the candidate
+	left := &AnalysisResult{JSONLines: 1000, Paths: []PathSummary{
+		{Path: ".id", Count: 1000, ObjectHits: 1000, DistinctCount: 5},
+		{Path: ".rare", Count: 50, ObjectHits: 50, DistinctCount: 2},
+		{Path: ".gone", Count: 10, ObjectHits: 10, DistinctCount: 1},
+	}}
+	right := &AnalysisResult{JSONLines: 100, Paths: []PathSummary{
+		{Path: ".id", Count: 100, ObjectHits: 100, DistinctCount: 5},
+		{Path: ".rare", Count: 10, ObjectHits: 10, DistinctCount: 2},
+		{Path: ".new", Count: 3, ObjectHits: 3, DistinctCount: 1},
+	}}
+
+	result := CompareAnalysesWithOptions(left, right, "", "", CompareOptions{NormalizeVolume: true})
+	if result.VolumeScale != 10 {
+		t.Errorf("expected a volume scale of 10, got %v", result.VolumeScale)
+	}
+
+	byPath := make(map[string]PathComparison)
+	for _, c := range result.Comparisons {
+		byPath[c.Path] = c
+	}
+	tests := []struct {
+		path       string
+		status     ComparisonStatus
+		countDelta int
+	}{
+		{".id", StatusEqual, 0},
+		{".rare", StatusChanged, 50},
+		{".gone", StatusRemoved, -10},
+		{".new", StatusAdded, 30},
+	}
+	for _, tt := range tests {
+		c := byPath[tt.path]
+		if c.Status != tt.status || c.CountDelta != tt.countDelta {
+			t.Errorf("%s: expected %s with a count delta of %d, got %s with %d", tt.path, tt.status, tt.countDelta, c.Status, c.CountDelta)
+		}
+	}
+	if byPath[".id"].Right.Count != 100 {
+		t.Errorf("expected the right summary to keep its raw count, got %d", byPath[".id"].Right.Count)
+	}
+
+	// Without normalization every shared path shrinks
+	if got := CompareAnalyses(left, right, "", "").VolumeScale; got != 0 {
+		t.Errorf("expected no volume scale by default, got %v", got)
+	}
+}
+
+func TestCompareAnalysesWithOptions_IgnorePaths(t *testing.T) {
+	left := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".type", Count: 10, ObjectHits: 10, DistinctCount: 2},
+		{Path: ".value.bookmarks.orders.updated_at", Count: 2, ObjectHits: 2, DistinctCount: 2},
+		{Path: ".record.created_at", Count: 8, ObjectHits: 8, DistinctCount: 8},
+		{Path: ".record.id", Count: 8, ObjectHits: 8, DistinctCount: 8},
+	}}
+	right := &AnalysisResult{Paths: []PathSummary{
+		{Path: ".type", Count: 10, ObjectHits: 10, DistinctCount: 2},
+		{Path: ".value.bookmarks.orders.updated_at", Count: 5, ObjectHits: 5, DistinctCount: 5},
+		{Path: ".value.bookmarks.customers.id", Count: 1, ObjectHits: 1, DistinctCount: 1},
+		{Path: ".record.created_at", Count: 9, ObjectHits: 9, DistinctCount: 9},
+		{Path: ".record.id", Count: 9, ObjectHits: 9, DistinctCount: 9},
+	}}
+
+	result := CompareAnalysesWithOptions(left, right, "", "", CompareOptions{
+		IgnorePaths: []string{".value.bookmarks", "/_at$/", "/[invalid/"},
+	})
+
+	var got []string
+	for _, c := range result.Comparisons {
+		got = append(got, c.Path)
+	}
+	if len(got) != 2 || got[0] != ".record.id" || got[1] != ".type" {
+		t.Errorf("expected only .record.id and .type, got %v", got)
+	}
+
+	want := ComparisonStats{
+		TotalPaths:         2,
+		ChangedPaths:       1,
+		EqualPaths:         1,
+		TotalCountDelta:    1,
+		TotalObjectsDelta:  1,
+		TotalDistinctDelta: 1,
+		IgnoredPaths:       3,
+	}
+	if result.Stats != want {
+		t.Errorf("expected stats %+v, got %+v", want, result.Stats)
+	}
+}
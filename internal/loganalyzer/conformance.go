@@ -0,0 +1,136 @@
+package loganalyzer
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+
+	"jtool/internal/jsonschema"
+)
+
+// ValidationReport summarizes how the documents of a log conform to the
+// JSON Schema in Options.Schema, e.g. one inferred from a known-good run.
+type ValidationReport struct {
+	Documents        int               `json:"documents"`        // Documents validated
+	InvalidDocuments int               `json:"invalidDocuments"` // Documents with at least one violation
+	ByPath           []ViolationGroup  `json:"byPath"`           // Violations grouped by path and keyword, most frequent first
+	Violations       []SchemaViolation `json:"violations"`       // First 1000 violations, in file order
+	TotalViolations  int               `json:"totalViolations"`  // All violations, including unlisted ones
+	Error            string            `json:"error,omitempty"`  // Why the schema couldn't be compiled; nothing is validated then
+}
+
+// ViolationGroup counts the violations of one schema keyword at one path.
+type ViolationGroup struct {
+	Path      string `json:"path"`      // Path with array indices collapsed (".items[].price"), in Options.PathFormat; "." for the document itself
+	Keyword   string `json:"keyword"`   // Schema keyword that failed (e.g. "type", "required", "maxLength")
+	Count     int    `json:"count"`     // Violations in the group
+	Documents int    `json:"documents"` // Documents with at least one violation in the group
+	FirstLine int    `json:"firstLine"` // Line of the first violating document
+	Example   string `json:"example"`   // Message of the first violation
+
+	lastDoc int // Document that last counted towards Documents
+}
+
+// arrayIndex matches the concrete indices in violation paths.
+var arrayIndex = regexp.MustCompile(`\[\d+\]`)
+
+// schemaValidator checks each document against a schema and aggregates the
+// violations.
+type schemaValidator struct {
+	schema *jsonschema.Schema // nil if the schema didn't compile
+	opts   Options
+	report ValidationReport
+	groups map[violationKey]*ViolationGroup
+	file   string // Current file, for violations in a batch
+}
+
+type violationKey struct {
+	path, keyword string
+}
+
+func newSchemaValidator(schema any, opts Options) *schemaValidator {
+	v := &schemaValidator{
+		opts:   opts,
+		groups: make(map[violationKey]*ViolationGroup),
+	}
+	compiled, err := jsonschema.Compile(schema)
+	if err != nil {
+		v.report.Error = err.Error()
+		return v
+	}
+	v.schema = compiled
+	return v
+}
+
+// add validates one JSON document that started on the given line.
+func (v *schemaValidator) add(line int, data any) {
+	if v.schema == nil {
+		return
+	}
+	v.report.Documents++
+	doc := v.report.Documents
+
+	result := v.schema.Validate(data)
+	if result.Valid {
+		return
+	}
+	v.report.InvalidDocuments++
+	v.report.TotalViolations += result.TotalViolations
+
+	// Groups count the violations listed for the document, which are all
+	// but those of a document with more than jsonschema.MaxViolations
+	for _, violation := range result.Violations {
+		path := violation.InstancePath
+		if len(v.report.Violations) < maxViolations {
+			v.report.Violations = append(v.report.Violations, SchemaViolation{
+				File:    v.file,
+				Line:    line,
+				Path:    path,
+				Message: violation.Message,
+			})
+		}
+
+		key := violationKey{arrayIndex.ReplaceAllString(path, "[]"), violation.Keyword}
+		group, ok := v.groups[key]
+		if !ok {
+			group = &ViolationGroup{Keyword: violation.Keyword, FirstLine: line, Example: violation.Message}
+			v.groups[key] = group
+		}
+		group.Count++
+		if group.lastDoc != doc {
+			group.lastDoc = doc
+			group.Documents++
+		}
+	}
+}
+
+// result returns the finished report.
+func (v *schemaValidator) result() *ValidationReport {
+	report := v.report
+	report.Violations = slices.Clone(v.report.Violations)
+	if report.Violations == nil {
+		report.Violations = []SchemaViolation{}
+	}
+
+	report.ByPath = make([]ViolationGroup, 0, len(v.groups))
+	for key, g := range v.groups {
+		group := *g
+		group.lastDoc = 0
+		group.Path = key.path
+		if key.path != "." {
+			group.Path = v.opts.formatPath(key.path)
+		}
+		report.ByPath = append(report.ByPath, group)
+	}
+	sort.Slice(report.ByPath, func(i, j int) bool {
+		a, b := report.ByPath[i], report.ByPath[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Keyword < b.Keyword
+	})
+	return &report
+}
@@ -0,0 +1,84 @@
+package loganalyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+	"jtool/internal/paths"
+)
+
+func TestAnalyzeString_Validation(t *testing.T) {
+	schema, err := parser.ParseString(`{
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"type": "integer"},
+			"items": {"type": "array", "items": {
+				"type": "object",
+				"properties": {"price": {"type": "number", "minimum": 0}}
+			}}
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	jsonl := `{"id": 1, "items": [{"price": 5}]}
+{"id": "2", "items": [{"price": -1}, {"price": -2}]}
+INFO not JSON
+{"items": [{"price": "free"}]}
+[1, 2]`
+
+	result, err := AnalyzeStringWithOptions(jsonl, Options{Schema: schema})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v := result.Validation
+	if v == nil {
+		t.Fatal("expected a validation report")
+	}
+	if v.Documents != 4 || v.InvalidDocuments != 3 || v.TotalViolations != 6 || len(v.Violations) != 6 {
+		t.Errorf("unexpected totals: %+v", v)
+	}
+
+	expected := []ViolationGroup{
+		{Path: ".items[].price", Keyword: "minimum", Count: 2, Documents: 1, FirstLine: 2, Example: "-1 is less than the minimum 0"},
+		{Path: ".", Keyword: "type", Count: 1, Documents: 1, FirstLine: 5, Example: "expected object, got array"},
+		{Path: ".id", Keyword: "required", Count: 1, Documents: 1, FirstLine: 4, Example: "required property is missing"},
+		{Path: ".id", Keyword: "type", Count: 1, Documents: 1, FirstLine: 2, Example: "expected integer, got string"},
+		{Path: ".items[].price", Keyword: "type", Count: 1, Documents: 1, FirstLine: 4, Example: "expected number, got string"},
+	}
+	if !reflect.DeepEqual(v.ByPath, expected) {
+		t.Errorf("expected groups:\n%+v\ngot:\n%+v", expected, v.ByPath)
+	}
+	if got := v.Violations[1]; got.Line != 2 || got.Path != ".items[0].price" {
+		t.Errorf("expected the listed violation to keep its index, got %+v", got)
+	}
+
+	// Group paths follow Options.PathFormat
+	result, err = AnalyzeStringWithOptions(jsonl, Options{Schema: schema, PathFormat: paths.FormatPointer})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Validation.ByPath[0].Path; got != "/items/-/price" {
+		t.Errorf("expected /items/-/price, got %s", got)
+	}
+
+	// No schema, no report
+	if result, _ := AnalyzeString(jsonl); result.Validation != nil {
+		t.Errorf("expected no validation report, got %+v", result.Validation)
+	}
+}
+
+func TestAnalyzeString_ValidationInvalidSchema(t *testing.T) {
+	result, err := AnalyzeStringWithOptions(`{"id": 1}`, Options{Schema: []any{"not a schema"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v := result.Validation
+	if v == nil || v.Error == "" || v.Documents != 0 {
+		t.Errorf("expected a report with the compile error and nothing validated, got %+v", v)
+	}
+}
@@ -0,0 +1,110 @@
+package loganalyzer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteAnalysisCSV writes the path table of an analysis as CSV, one row per
+// path in result order. Types and TopValues are JSON-encoded; string length
+// columns are empty for paths without string values. Percentages and
+// averages are rounded to two decimals.
+func WriteAnalysisCSV(w io.Writer, result *AnalysisResult) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"path", "count", "objectHits", "presence", "distinctCount", "types", "topValues",
+		"minLength", "maxLength", "avgLength", "firstSeenLine", "lastSeenLine", "appeared", "disappeared",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, p := range result.Paths {
+		types, err := json.Marshal(p.Types)
+		if err != nil {
+			return err
+		}
+		topValues, err := json.Marshal(p.TopValues)
+		if err != nil {
+			return err
+		}
+
+		var minLength, maxLength, avgLength string
+		if p.Strings != nil {
+			minLength = strconv.Itoa(p.Strings.MinLength)
+			maxLength = strconv.Itoa(p.Strings.MaxLength)
+			avgLength = formatDecimal(p.Strings.AvgLength)
+		}
+
+		row := []string{
+			p.Path,
+			strconv.Itoa(p.Count),
+			strconv.Itoa(p.ObjectHits),
+			formatDecimal(p.Presence),
+			strconv.Itoa(p.DistinctCount),
+			string(types),
+			string(topValues),
+			minLength,
+			maxLength,
+			avgLength,
+			strconv.Itoa(p.FirstSeenLine),
+			strconv.Itoa(p.LastSeenLine),
+			strconv.FormatBool(p.Appeared),
+			strconv.FormatBool(p.Disappeared),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteComparisonCSV writes the path table of a comparison as CSV, one row
+// per path in result order. Left or right columns are empty for paths that
+// only exist on the other side.
+func WriteComparisonCSV(w io.Writer, result *ComparisonResult) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"path", "status",
+		"leftCount", "rightCount", "countDelta",
+		"leftObjectHits", "rightObjectHits", "objectsDelta",
+		"leftDistinct", "rightDistinct", "distinctDelta",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, c := range result.Comparisons {
+		left := summaryColumns(c.Left)
+		right := summaryColumns(c.Right)
+		row := []string{
+			c.Path, string(c.Status),
+			left[0], right[0], strconv.Itoa(c.CountDelta),
+			left[1], right[1], strconv.Itoa(c.ObjectsDelta),
+			left[2], right[2], strconv.Itoa(c.DistinctDelta),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// summaryColumns returns count, objectHits and distinctCount as CSV cells,
+// or empty cells if the path is missing on this side.
+func summaryColumns(p *PathSummary) [3]string {
+	if p == nil {
+		return [3]string{}
+	}
+	return [3]string{strconv.Itoa(p.Count), strconv.Itoa(p.ObjectHits), strconv.Itoa(p.DistinctCount)}
+}
+
+func formatDecimal(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
@@ -0,0 +1,60 @@
+package loganalyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnalysisCSV(t *testing.T) {
+	result, err := AnalyzeString(`{"name": "alice", "n": 1}
+{"name": "bob"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnalysisCSV(&buf, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `path,count,objectHits,presence,distinctCount,types,topValues,minLength,maxLength,avgLength,firstSeenLine,lastSeenLine,appeared,disappeared
+.name,2,2,100.00,2,"{""string"":2}","[{""value"":""alice"",""count"":1},{""value"":""bob"",""count"":1}]",3,5,4.00,1,2,false,false
+.n,1,1,50.00,1,"{""number"":1}","[{""value"":""1"",""count"":1}]",,,,1,1,false,false
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestWriteComparisonCSV(t *testing.T) {
+	left, _ := AnalyzeString(`{"a": 1, "b": 1}`)
+	right, _ := AnalyzeString(`{"a": 1, "c": 2}
+{"a": 2, "c": 3}`)
+	comparison := CompareAnalyses(left, right, "left.log", "right.log")
+
+	var buf bytes.Buffer
+	if err := WriteComparisonCSV(&buf, comparison); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "path,status,leftCount,rightCount,countDelta,leftObjectHits,rightObjectHits,objectsDelta,leftDistinct,rightDistinct,distinctDelta" {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+
+	rows := make(map[string]string)
+	for _, line := range lines[1:] {
+		rows[strings.SplitN(line, ",", 2)[0]] = line
+	}
+	expected := map[string]string{
+		".a": ".a,changed,1,2,1,1,2,1,1,2,1",
+		".b": ".b,removed,1,,-1,1,,-1,1,,-1",
+		".c": ".c,added,,2,2,,2,2,,2,2",
+	}
+	for path, want := range expected {
+		if rows[path] != want {
+			t.Errorf("%s: expected %q, got %q", path, want, rows[path])
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package loganalyzer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Built-in decoders for Options.Decoder.
+const (
+	DecoderDocker      = "docker"      // Docker json-file logs: {"log":"...","stream":"stdout","time":"..."}
+	DecoderCSV         = "csv"         // CSV with a header row and JSON in one column (Options.CSVColumn)
+	DecoderCloudWatch  = "cloudwatch"  // CloudWatch Logs S3 exports and subscription records
+	DecoderStackdriver = "stackdriver" // Cloud Logging (Stackdriver) LogEntry exports
+)
+
+// errUndecodable wraps decoder errors, so failures are classified as
+// ParseUndecodable rather than as JSON syntax errors.
+var errUndecodable = errors.New("undecodable line")
+
+// Decoder unwraps the log text carried by the lines of a container or
+// export format, so the analyzer sees the application's own lines. Decoding
+// is all that differs between formats: the text returned is then analyzed
+// like any other input, including multi-line and embedded JSON.
+//
+// A decoder is created per input and may keep state between lines, e.g. to
+// join a record split over several lines.
+type Decoder interface {
+	// Decode returns the text carried by one input line: nothing if the line
+	// is only part of a record, or several entries for a line holding a
+	// batch. Text containing newlines is split into lines.
+	Decode(line string) ([]string, error)
+
+	// Flush returns text still buffered at the end of the input.
+	Flush() ([]string, error)
+}
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]func(Options) Decoder{
+		DecoderDocker:      func(Options) Decoder { return &dockerDecoder{} },
+		DecoderCSV:         func(o Options) Decoder { return &csvDecoder{column: o.CSVColumn} },
+		DecoderCloudWatch:  func(Options) Decoder { return cloudWatchDecoder{} },
+		DecoderStackdriver: func(Options) Decoder { return stackdriverDecoder{} },
+	}
+)
+
+// RegisterDecoder makes a decoder available as Options.Decoder = name.
+// newDecoder is called once per input with the analysis options.
+// Registering a built-in name replaces it.
+func RegisterDecoder(name string, newDecoder func(opts Options) Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[name] = newDecoder
+}
+
+// Decoders returns the names of the registered decoders, sorted.
+func Decoders() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newDecoder returns the decoder for opts, or nil for plain JSON lines.
+// An unknown name gets a decoder that rejects every line, so the mistake
+// shows up in ParseFailures instead of as an empty analysis.
+func (o Options) newDecoder() Decoder {
+	if o.Decoder == "" {
+		return nil
+	}
+	decodersMu.RLock()
+	newDecoder, ok := decoders[o.Decoder]
+	decodersMu.RUnlock()
+	if !ok {
+		return unknownDecoder(o.Decoder)
+	}
+	return newDecoder(o)
+}
+
+// unknownDecoder rejects every line.
+type unknownDecoder string
+
+func (d unknownDecoder) Decode(string) ([]string, error) {
+	return nil, fmt.Errorf("unknown decoder %q", string(d))
+}
+
+func (d unknownDecoder) Flush() ([]string, error) { return nil, nil }
+
+// dockerDecoder reads Docker's json-file log driver output. Docker splits
+// lines over 16KB into several entries; only the last ends in a newline, so
+// entries are joined until one does.
+type dockerDecoder struct {
+	partial strings.Builder
+}
+
+func (d *dockerDecoder) Decode(line string) ([]string, error) {
+	var entry struct {
+		Log *string `json:"log"`
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Log == nil {
+		return nil, errors.New("not a Docker log entry")
+	}
+
+	d.partial.WriteString(*entry.Log)
+	if !strings.HasSuffix(*entry.Log, "\n") {
+		return nil, nil
+	}
+	text := d.partial.String()
+	d.partial.Reset()
+	return []string{text}, nil
+}
+
+func (d *dockerDecoder) Flush() ([]string, error) {
+	if d.partial.Len() == 0 {
+		return nil, nil
+	}
+	text := d.partial.String()
+	d.partial.Reset()
+	return []string{text}, nil
+}
+
+// csvJSONColumns are the header names tried when Options.CSVColumn is empty.
+var csvJSONColumns = []string{"json", "message", "payload", "data", "log", "body"}
+
+// csvDecoder reads CSV with a header row and returns the JSON column of each
+// record. Quoted fields may span lines, e.g. pretty-printed JSON.
+type csvDecoder struct {
+	column  string
+	index   int // Column index, once the header is read
+	header  bool
+	pending strings.Builder // Lines of a record with an open quoted field
+}
+
+func (d *csvDecoder) Decode(line string) ([]string, error) {
+	if d.pending.Len() > 0 {
+		d.pending.WriteString("\n")
+	}
+	d.pending.WriteString(line)
+	if strings.Count(d.pending.String(), `"`)%2 == 1 {
+		return nil, nil // Inside a quoted field
+	}
+
+	text := d.pending.String()
+	d.pending.Reset()
+	record, err := csv.NewReader(strings.NewReader(text)).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.header {
+		d.header = true
+		return nil, d.readHeader(record)
+	}
+	if d.index >= len(record) {
+		return nil, fmt.Errorf("record has %d columns, expected at least %d", len(record), d.index+1)
+	}
+	return []string{record[d.index]}, nil
+}
+
+// readHeader finds the JSON column by name.
+func (d *csvDecoder) readHeader(header []string) error {
+	names := csvJSONColumns
+	if d.column != "" {
+		names = []string{d.column}
+	}
+	for _, name := range names {
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				d.index = i
+				return nil
+			}
+		}
+	}
+	if d.column != "" {
+		return fmt.Errorf("no column named %q in the CSV header", d.column)
+	}
+	return fmt.Errorf("no JSON column (%s) in the CSV header; set the CSV column", strings.Join(csvJSONColumns, ", "))
+}
+
+func (d *csvDecoder) Flush() ([]string, error) {
+	if d.pending.Len() == 0 {
+		return nil, nil
+	}
+	d.pending.Reset()
+	return nil, errors.New("input ended inside a quoted CSV field")
+}
+
+// cloudWatchDecoder reads CloudWatch Logs exports: S3 export lines
+// ("2024-05-01T12:00:01.000Z {...}") and subscription filter records
+// ({"messageType":"DATA_MESSAGE","logEvents":[{"message":"..."}]}), as
+// delivered by Firehose or Kinesis after decompression.
+type cloudWatchDecoder struct{}
+
+func (cloudWatchDecoder) Decode(line string) ([]string, error) {
+	if strings.HasPrefix(line, "{") {
+		var record struct {
+			MessageType string `json:"messageType"`
+			LogEvents   []struct {
+				Message string `json:"message"`
+			} `json:"logEvents"`
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil || record.MessageType == "" {
+			return nil, errors.New("not a CloudWatch export line or subscription record")
+		}
+		if record.MessageType != "DATA_MESSAGE" {
+			return nil, nil // CONTROL_MESSAGE health checks carry no log events
+		}
+		messages := make([]string, len(record.LogEvents))
+		for i, event := range record.LogEvents {
+			messages[i] = event.Message
+		}
+		return messages, nil
+	}
+
+	timestamp, message, ok := strings.Cut(line, " ")
+	if !ok {
+		return nil, errors.New("not a CloudWatch export line or subscription record")
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return nil, errors.New("not a CloudWatch export line or subscription record")
+	}
+	return []string{message}, nil
+}
+
+func (cloudWatchDecoder) Flush() ([]string, error) { return nil, nil }
+
+// stackdriverDecoder reads Cloud Logging LogEntry exports (one entry per
+// line), returning jsonPayload or protoPayload as JSON and textPayload as is.
+type stackdriverDecoder struct{}
+
+func (stackdriverDecoder) Decode(line string) ([]string, error) {
+	var entry struct {
+		JSONPayload  json.RawMessage `json:"jsonPayload"`
+		ProtoPayload json.RawMessage `json:"protoPayload"`
+		TextPayload  *string         `json:"textPayload"`
+	}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return nil, errors.New("not a Cloud Logging entry")
+	}
+	switch {
+	case entry.JSONPayload != nil:
+		return []string{compactJSON(entry.JSONPayload)}, nil
+	case entry.ProtoPayload != nil:
+		return []string{compactJSON(entry.ProtoPayload)}, nil
+	case entry.TextPayload != nil:
+		return []string{*entry.TextPayload}, nil
+	}
+	return nil, errors.New("Cloud Logging entry has no payload")
+}
+
+func (stackdriverDecoder) Flush() ([]string, error) { return nil, nil }
+
+// compactJSON returns raw JSON on one line, so a payload isn't split into
+// lines when it is analyzed.
+func compactJSON(raw json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package loganalyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecoders(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		input   string
+		paths   []string
+		json    int
+		failure string // Class of the first parse failure, if any
+	}{
+		{
+			name: "docker",
+			opts: Options{Decoder: DecoderDocker},
+			input: `{"log":"{\"id\": 1}\n","stream":"stdout","time":"2024-05-01T12:00:00Z"}
+{"log":"{\"id\": ","stream":"stdout"}
+{"log":"2}\n","stream":"stdout"}
+{"log":"starting up\n","stream":"stderr"}
+not docker`,
+			paths:   []string{".id"},
+			json:    2,
+			failure: ParseNotJSON,
+		},
+		{
+			name: "csv",
+			opts: Options{Decoder: DecoderCSV},
+			input: `ts,Message
+2024-05-01,"{""id"": 1}"
+2024-05-02,"{
+  ""id"": 2
+}"`,
+			paths: []string{".id"},
+			json:  2,
+		},
+		{
+			name:    "csv without a JSON column",
+			opts:    Options{Decoder: DecoderCSV, CSVColumn: "body"},
+			input:   "ts,message\n2024-05-01,{}",
+			failure: ParseUndecodable,
+		},
+		{
+			name: "cloudwatch",
+			opts: Options{Decoder: DecoderCloudWatch},
+			input: `2024-05-01T12:00:00.000Z {"id": 1}
+{"messageType":"DATA_MESSAGE","logEvents":[{"message":"{\"id\": 2}"},{"message":"{\"id\": 3}"}]}
+{"messageType":"CONTROL_MESSAGE","logEvents":[]}`,
+			paths: []string{".id"},
+			json:  3,
+		},
+		{
+			name: "stackdriver",
+			opts: Options{Decoder: DecoderStackdriver},
+			input: `{"jsonPayload": {"id": 1, "nested": {"ok": true}}, "severity": "INFO"}
+{"textPayload": "{\"id\": 2}"}
+{"severity": "INFO"}`,
+			paths:   []string{".id", ".nested.ok"},
+			json:    2,
+			failure: ParseUndecodable,
+		},
+		{
+			name:    "unknown",
+			opts:    Options{Decoder: "nope"},
+			input:   `{"id": 1}`,
+			failure: ParseUndecodable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := AnalyzeStringWithOptions(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, p := range result.Paths {
+				got = append(got, p.Path)
+			}
+			if tt.paths == nil {
+				tt.paths = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.paths) || result.JSONLines != tt.json {
+				t.Errorf("expected %d documents with %v, got %d with %v", tt.json, tt.paths, result.JSONLines, got)
+			}
+
+			var failure string
+			if result.ParseFailures != nil {
+				failure = result.ParseFailures.Failures[0].Class
+			}
+			if failure != tt.failure {
+				t.Errorf("expected failure %q, got %+v", tt.failure, result.ParseFailures)
+			}
+		})
+	}
+}
+
+// upperDecoder is a registered test decoder that uppercases keys.
+type upperDecoder struct{}
+
+func (upperDecoder) Decode(line string) ([]string, error) {
+	return []string{strings.ToUpper(line)}, nil
+}
+func (upperDecoder) Flush() ([]string, error) { return nil, nil }
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("upper", func(Options) Decoder { return upperDecoder{} })
+	t.Cleanup(func() {
+		decodersMu.Lock()
+		delete(decoders, "upper")
+		decodersMu.Unlock()
+	})
+
+	if names := Decoders(); !reflect.DeepEqual(names, []string{"cloudwatch", "csv", "docker", "stackdriver", "upper"}) {
+		t.Errorf("unexpected decoders: %v", names)
+	}
+
+	result, err := AnalyzeStringWithOptions(`{"id": 1}`, Options{Decoder: "upper"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Paths) != 1 || result.Paths[0].Path != ".ID" {
+		t.Errorf("expected .ID, got %+v", result.Paths)
+	}
+}
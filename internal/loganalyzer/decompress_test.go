@@ -0,0 +1,102 @@
+package loganalyzer
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+
+	"jtool/internal/charset"
+)
+
+func TestAnalyzeFile_Compressed(t *testing.T) {
+	plainFile := filepath.Join("..", "..", "testdata", "multiline_test.log")
+	want, err := AnalyzeFile(plainFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// gzip is written on the fly; the zstd fixture was made with the zstd CLI
+	raw, err := os.ReadFile(plainFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gzFile := filepath.Join(t.TempDir(), "multiline_test.log.1") // No telltale extension
+	f, err := os.Create(gzFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write(raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]string{
+		"gzip": gzFile,
+		"zstd": filepath.Join("..", "..", "testdata", "multiline_test.log.zst"),
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := AnalyzeFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected the same analysis as the uncompressed file, got %d JSON lines and %d paths (want %d and %d)",
+					got.JSONLines, got.TotalPaths, want.JSONLines, want.TotalPaths)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFile_CorruptGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.log.gz")
+	if err := os.WriteFile(path, []byte{0x1f, 0x8b, 0x00, 0x00}, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := AnalyzeFile(path); err == nil {
+		t.Error("expected an error for a truncated gzip file")
+	}
+}
+
+func TestAnalyzeFile_UTF16(t *testing.T) {
+	const log = "{\"msg\": \"café\", \"n\": 1}\r\n{\"msg\": \"日本\", \"n\": 2}\r\n"
+	want, err := AnalyzeString(log)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// As written by PowerShell's Out-File: UTF-16LE with a byte order mark
+	raw := []byte{0xff, 0xfe}
+	for _, unit := range utf16.Encode([]rune(log)) {
+		raw = binary.LittleEndian.AppendUint16(raw, unit)
+	}
+	path := filepath.Join(t.TempDir(), "export.log")
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Encoding != charset.UTF16LE {
+		t.Errorf("expected encoding %s, got %q", charset.UTF16LE, got.Encoding)
+	}
+	got.Encoding = ""
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the same analysis as the UTF-8 text, got %d JSON lines and %d paths (want %d and %d)",
+			got.JSONLines, got.TotalPaths, want.JSONLines, want.TotalPaths)
+	}
+}
@@ -0,0 +1,49 @@
+package loganalyzer
+
+// bracketDepth tracks how deeply nested a stream of JSON text is, one byte
+// at a time, ignoring brackets inside strings. It lets a multi-line document
+// be parsed once when it closes instead of after every line.
+//
+// Only ASCII bytes are significant, so scanning UTF-8 byte by byte is safe.
+type bracketDepth struct {
+	depth    int  // Open brackets minus closed ones
+	inString bool // Inside a string literal
+	escaped  bool // Previous byte was a backslash inside a string
+}
+
+// step advances over one byte of JSON text.
+func (d *bracketDepth) step(c byte) {
+	if d.inString {
+		switch {
+		case d.escaped:
+			d.escaped = false
+		case c == '\\':
+			d.escaped = true
+		case c == '"':
+			d.inString = false
+		}
+		return
+	}
+
+	switch c {
+	case '"':
+		d.inString = true
+	case '{', '[':
+		d.depth++
+	case '}', ']':
+		d.depth--
+	}
+}
+
+// scan advances over text.
+func (d *bracketDepth) scan(text string) {
+	for i := 0; i < len(text); i++ {
+		d.step(text[i])
+	}
+}
+
+// closed reports whether every bracket seen so far has been closed (or
+// over-closed, which the parser will reject).
+func (d *bracketDepth) closed() bool {
+	return d.depth <= 0 && !d.inString
+}
@@ -0,0 +1,77 @@
+package loganalyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBracketDepth(t *testing.T) {
+	tests := []struct {
+		text   string
+		depth  int
+		closed bool
+	}{
+		{`{"a": [1, 2`, 2, false},
+		{`{"a": [1, 2]}`, 0, true},
+		{`{"brace": "}]"`, 1, false},        // Brackets in strings don't count
+		{`{"quote": "say \"}\""}`, 0, true}, // Nor do escaped quotes end the string
+		{`{"path": "C:\\"}`, 0, true},       // An escaped backslash doesn't escape the quote
+		{`{"open": "`, 1, false},
+		{`]`, -1, true},
+	}
+	for _, tt := range tests {
+		var d bracketDepth
+		d.scan(tt.text)
+		if d.depth != tt.depth || d.closed() != tt.closed {
+			t.Errorf("%s: expected depth %d closed=%v, got %d %v", tt.text, tt.depth, tt.closed, d.depth, d.closed())
+		}
+	}
+}
+
+func TestAnalyzeString_LongMultiLineDocument(t *testing.T) {
+	// One pretty-printed document spanning ~20k lines, with brackets inside
+	// strings, followed by a JSONL record
+	var b strings.Builder
+	b.WriteString("{\n  \"items\": [\n")
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, "    {\"id\": %d,\n     \"note\": \"} not the end ]\"}", i)
+	}
+	b.WriteString("\n  ]\n}\n{\"after\": true}\n")
+
+	result, err := AnalyzeString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 2 {
+		t.Fatalf("expected 2 documents, got %d (failures: %+v)", result.JSONLines, result.ParseFailures)
+	}
+
+	counts := make(map[string]int)
+	for _, p := range result.Paths {
+		counts[p.Path] = p.Count
+	}
+	if counts[".items[].id"] != 10000 || counts[".after"] != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+}
+
+func TestAnalyzeString_MultiLineSyntaxError(t *testing.T) {
+	// The broken document is reported once its brackets close, and the
+	// record after it is still read
+	content := "{\n  \"a\": 1\n  \"b\": 2\n}\n{\"c\": 3}"
+
+	result, err := AnalyzeString(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 1 || result.Paths[0].Path != ".c" {
+		t.Errorf("expected only .c to be read, got %+v", result.Paths)
+	}
+	if report := result.ParseFailures; report == nil || report.Total != 1 || report.Failures[0].Line != 1 || report.Failures[0].Class != ParseSyntax {
+		t.Errorf("expected one syntax failure at line 1, got %+v", report)
+	}
+}
@@ -0,0 +1,30 @@
+package loganalyzer
+
+// sighting records where a path was seen.
+type sighting struct {
+	line int    // Line the document starts on
+	file string // File, when several files are analyzed together
+	doc  int    // 0-based index of the document among all JSON documents
+}
+
+// driftDensity is how often a path must occur between its first and last
+// sighting to be considered a regular field. Sparse optional fields are
+// expected to come and go, so they are never flagged as drifting.
+const driftDensity = 0.5
+
+// drift reports whether a path appeared or disappeared partway through the
+// input: it is a regular field within its own range of documents, but that
+// range starts after (or ends before) a meaningful run of documents without
+// it. The run must be at least 2 documents and 1% of the input, so a single
+// SCHEMA line at the top of Singer output doesn't flag every record field.
+func drift(first, last sighting, hits, totalDocs int) (appeared, disappeared bool) {
+	span := last.doc - first.doc + 1
+	if float64(hits) < driftDensity*float64(span) {
+		return false, false
+	}
+
+	minGap := max(2, totalDocs/100)
+	appeared = first.doc >= minGap
+	disappeared = totalDocs-1-last.doc >= minGap
+	return appeared, disappeared
+}
@@ -0,0 +1,69 @@
+package loganalyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeString_Drift(t *testing.T) {
+	// 200 documents: .legacy stops after 120, .added starts at 80,
+	// .optional shows up sparsely throughout
+	var b strings.Builder
+	b.WriteString("INFO starting\n")
+	for i := 0; i < 200; i++ {
+		fields := []string{fmt.Sprintf(`"id": %d`, i)}
+		if i < 120 {
+			fields = append(fields, `"legacy": 1`)
+		}
+		if i >= 80 {
+			fields = append(fields, `"added": 1`)
+		}
+		if i%10 == 5 {
+			fields = append(fields, `"optional": 1`)
+		}
+		b.WriteString("{" + strings.Join(fields, ", ") + "}\n")
+	}
+
+	result, err := AnalyzeString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byPath := make(map[string]PathSummary)
+	for _, p := range result.Paths {
+		byPath[p.Path] = p
+	}
+
+	tests := []struct {
+		path                  string
+		first, last           int
+		appeared, disappeared bool
+	}{
+		{".id", 2, 201, false, false},
+		{".legacy", 2, 121, false, true},
+		{".added", 82, 201, true, false},
+		{".optional", 7, 197, false, false},
+	}
+	for _, tt := range tests {
+		p := byPath[tt.path]
+		if p.FirstSeenLine != tt.first || p.LastSeenLine != tt.last {
+			t.Errorf("%s: expected lines %d-%d, got %d-%d", tt.path, tt.first, tt.last, p.FirstSeenLine, p.LastSeenLine)
+		}
+		if p.Appeared != tt.appeared || p.Disappeared != tt.disappeared {
+			t.Errorf("%s: expected appeared=%v disappeared=%v, got %v %v", tt.path, tt.appeared, tt.disappeared, p.Appeared, p.Disappeared)
+		}
+		if p.FirstSeenFile != "" {
+			t.Errorf("%s: expected no file for a single input, got %q", tt.path, p.FirstSeenFile)
+		}
+	}
+}
+
+func TestDrift_IgnoresLeadingSchemaLine(t *testing.T) {
+	// A record field missing only from the first document isn't drift
+	first := sighting{line: 2, doc: 1}
+	last := sighting{line: 1000, doc: 999}
+	if appeared, disappeared := drift(first, last, 999, 1000); appeared || disappeared {
+		t.Errorf("expected no drift, got appeared=%v disappeared=%v", appeared, disappeared)
+	}
+}
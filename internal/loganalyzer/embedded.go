@@ -0,0 +1,42 @@
+package loganalyzer
+
+import "jtool/internal/parser"
+
+// findEmbeddedJSON finds the first balanced {...} or [...] region of line
+// that parses as JSON, e.g. the payload of
+//
+//	2024-05-01 12:00:01 INFO tap: {"type":"RECORD",...}
+//
+// Regions that balance but don't parse (like a "[INFO]" prefix) are skipped.
+// If a region opens but never closes, open is the rest of the line from
+// there, which may continue as pretty-printed JSON on the following lines.
+func findEmbeddedJSON(line string) (data any, found bool, open string) {
+	for start := 0; start < len(line); start++ {
+		if line[start] != '{' && line[start] != '[' {
+			continue
+		}
+
+		end := balancedEnd(line, start)
+		if end < 0 {
+			return nil, false, line[start:]
+		}
+		if data, err := parser.Parse([]byte(line[start:end])); err == nil {
+			return data, true, ""
+		}
+	}
+	return nil, false, ""
+}
+
+// balancedEnd returns the index just past the bracket that closes the one at
+// start, or -1 if it isn't closed on this line. Brackets inside JSON strings
+// are ignored.
+func balancedEnd(line string, start int) int {
+	var d bracketDepth
+	for i := start; i < len(line); i++ {
+		d.step(line[i])
+		if d.depth == 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,70 @@
+package loganalyzer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFindEmbeddedJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected any
+		open     string
+	}{
+		{"prefixed object", `2024-05-01 12:00:01 INFO tap: {"type":"RECORD"}`, map[string]any{"type": "RECORD"}, ""},
+		{"skips bracketed level", `[INFO] payload=[1,2] done`, []any{json.Number("1"), json.Number("2")}, ""},
+		{"brackets inside strings", `msg {"text":"a } b [","n":"x\"}"}`, map[string]any{"text": "a } b [", "n": `x"}`}, ""},
+		{"trailing text", `got {"a":true} from upstream`, map[string]any{"a": true}, ""},
+		{"unterminated", `INFO tap: {`, nil, "{"},
+		{"no json", `plain log line`, nil, ""},
+		{"balanced but invalid", `set {a, b}`, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, found, open := findEmbeddedJSON(tt.line)
+			if found != (tt.expected != nil) {
+				t.Fatalf("found = %v, expected %v", found, tt.expected)
+			}
+			if found && !reflect.DeepEqual(data, tt.expected) {
+				t.Errorf("expected %#v, got %#v", tt.expected, data)
+			}
+			if open != tt.open {
+				t.Errorf("expected open %q, got %q", tt.open, open)
+			}
+		})
+	}
+}
+
+func TestAnalyzeStringWithOptions_EmbeddedJSON(t *testing.T) {
+	content := `2024-05-01 12:00:01 INFO tap: {"type": "RECORD", "stream": "users"}
+2024-05-01 12:00:02 INFO tap: {"type": "STATE"}
+2024-05-01 12:00:03 INFO tap: {
+  "type": "SCHEMA"
+}
+2024-05-01 12:00:04 INFO sync finished`
+
+	plain, err := AnalyzeString(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.JSONLines != 0 {
+		t.Errorf("expected prefixed lines to be skipped by default, got %d JSON lines", plain.JSONLines)
+	}
+
+	result, err := AnalyzeStringWithOptions(content, Options{EmbeddedJSON: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 3 {
+		t.Errorf("expected 3 JSON documents, got %d", result.JSONLines)
+	}
+
+	freqs := ValueFrequenciesString(content, ".type", Options{EmbeddedJSON: true})
+	expected := []ValueFrequency{{"RECORD", 1}, {"SCHEMA", 1}, {"STATE", 1}}
+	if !reflect.DeepEqual(freqs, expected) {
+		t.Errorf("expected %v, got %v", expected, freqs)
+	}
+}
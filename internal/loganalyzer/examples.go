@@ -0,0 +1,59 @@
+package loganalyzer
+
+import (
+	"context"
+	"strings"
+)
+
+// maxExampleLines bounds PathSummary.ExampleLines per path.
+const maxExampleLines = 10
+
+// SourceRecord is a JSON document as it appears in the source file.
+type SourceRecord struct {
+	Line int    `json:"line"` // Line the document starts on
+	Text string `json:"text"` // Source text, including any log prefix; several lines for pretty-printed JSON
+}
+
+// ExamplesForPath re-reads a file and returns the first limit documents that
+// contain path, in file order. opts must match the analysis the path came
+// from (EmbeddedJSON, MaxLineSize and PathFormat matter). Reading stops as soon as enough
+// examples are found, so drilling into a common path is cheap even on a
+// large file.
+func ExamplesForPath(filePath, path string, limit int, opts Options) ([]SourceRecord, error) {
+	if limit <= 0 {
+		limit = maxExampleLines
+	}
+
+	var records []SourceRecord
+	var raw strings.Builder // Source text of the document being read
+	docs := opts.newScanner(nil)
+	docs.process = func(line int, data any) {
+		counts := make(map[string]int)
+		extractPaths("", data, counts)
+		for p := range counts {
+			if opts.formatPath(p) == path {
+				records = append(records, SourceRecord{Line: line, Text: raw.String()})
+				break
+			}
+		}
+	}
+
+	_, _, err := scanLines(context.Background(), filePath, docs.maxSize, func(lineNum int, line string, tooLong bool) bool {
+		if !docs.inMultiLine {
+			raw.Reset()
+		} else {
+			raw.WriteString("\n")
+		}
+		raw.WriteString(line)
+		docs.scanLine(lineNum, line, tooLong)
+		return len(records) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if records == nil {
+		records = []SourceRecord{}
+	}
+	return records, nil
+}
@@ -1,7 +1,11 @@
 package loganalyzer
 
 import (
+	"bufio"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -61,3 +65,163 @@ func TestAnalyzeFile_MultiLine(t *testing.T) {
 		}
 	}
 }
+
+func TestValueFrequencies(t *testing.T) {
+	testFile := filepath.Join("..", "..", "testdata", "multiline_test.log")
+
+	freqs, err := ValueFrequencies(testFile, ".type", Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ValueFrequency{{"RECORD", 5}, {"STATE", 2}}
+	if len(freqs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, freqs)
+	}
+	for i := range expected {
+		if freqs[i] != expected[i] {
+			t.Errorf("expected %v at %d, got %v", expected[i], i, freqs[i])
+		}
+	}
+
+	if _, err := ValueFrequencies(filepath.Join(t.TempDir(), "missing.log"), ".type", Options{}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestExamplesForPath(t *testing.T) {
+	testFile := filepath.Join("..", "..", "testdata", "multiline_test.log")
+
+	result, err := AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var notes PathSummary
+	for _, p := range result.Paths {
+		if p.Path == ".record.notes" {
+			notes = p
+		}
+	}
+	if len(notes.ExampleLines) != notes.ObjectHits {
+		t.Fatalf("expected %d example lines, got %v", notes.ObjectHits, notes.ExampleLines)
+	}
+
+	examples, err := ExamplesForPath(testFile, ".record.notes", 0, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(examples) != len(notes.ExampleLines) {
+		t.Fatalf("expected %d examples, got %d", len(notes.ExampleLines), len(examples))
+	}
+	for i, example := range examples {
+		if example.Line != notes.ExampleLines[i] {
+			t.Errorf("example %d: expected line %d, got %d", i, notes.ExampleLines[i], example.Line)
+		}
+		if !strings.Contains(example.Text, `"notes"`) {
+			t.Errorf("example %d: expected the source text of the record, got %q", i, example.Text)
+		}
+	}
+
+	limited, err := ExamplesForPath(testFile, ".type", 2, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("expected 2 examples, got %d", len(limited))
+	}
+}
+
+func TestAnalyzeReader(t *testing.T) {
+	testFile := filepath.Join("..", "..", "testdata", "multiline_test.log")
+
+	expected, err := AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := os.Open(testFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	result, err := AnalyzeReader(file, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.TotalLines != expected.TotalLines || result.JSONLines != expected.JSONLines || len(result.Paths) != len(expected.Paths) {
+		t.Errorf("expected %d lines, %d JSON, %d paths; got %d, %d, %d",
+			expected.TotalLines, expected.JSONLines, len(expected.Paths),
+			result.TotalLines, result.JSONLines, len(result.Paths))
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	long := strings.Repeat("x", 200*1024) // Longer than the reader's buffer
+	input := "first\r\n\n" + long + "\nshort\nlast"
+
+	reader := bufio.NewReaderSize(strings.NewReader(input), 16)
+	expected := []struct {
+		line    string
+		tooLong bool
+	}{
+		{"first", false},
+		{"", false},
+		{long[:100], true},
+		{"short", false},
+		{"last", false},
+	}
+	for i, want := range expected {
+		line, tooLong, err := readLine(reader, 100)
+		if err != nil {
+			t.Fatalf("line %d: unexpected error: %v", i+1, err)
+		}
+		if line != want.line || tooLong != want.tooLong {
+			t.Errorf("line %d: expected %.10q (tooLong=%v), got %.10q (%v)", i+1, want.line, want.tooLong, line, tooLong)
+		}
+	}
+	if _, _, err := readLine(reader, 100); err != io.EOF {
+		t.Errorf("expected io.EOF at the end, got %v", err)
+	}
+}
+
+func TestAnalyzeFile_LongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tap.log")
+	long := `{"blob": "` + strings.Repeat("x", 2*1024*1024) + `"}`
+	content := "{\"id\": 1}\n" + long + "\n{\"id\": 2}\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The default limit skips the 2MB line but keeps reading
+	result, err := AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TotalLines != 3 || result.JSONLines != 2 {
+		t.Errorf("expected 3 lines with 2 JSON, got %d and %d", result.TotalLines, result.JSONLines)
+	}
+	report := result.ParseFailures
+	if report == nil || report.Total != 1 || report.Failures[0].Line != 2 || report.Failures[0].Class != ParseTooLarge {
+		t.Fatalf("expected line 2 reported as too large, got %+v", report)
+	}
+
+	// A larger limit analyzes it
+	result, err = AnalyzeFileWithOptions(path, Options{MaxLineSize: 4 * 1024 * 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 3 || result.ParseFailures != nil {
+		t.Errorf("expected all 3 documents, got %d (failures: %+v)", result.JSONLines, result.ParseFailures)
+	}
+
+	// A smaller one also bounds multi-line documents
+	result, err = AnalyzeStringWithOptions("{\n  \"a\": \"0123456789\"\n}\n{\"b\": 1}", Options{MaxLineSize: 16})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 1 || result.ParseFailures == nil || result.ParseFailures.Failures[0].Line != 1 {
+		t.Errorf("expected the document at line 1 to be too large, got %d documents (failures: %+v)", result.JSONLines, result.ParseFailures)
+	}
+}
@@ -0,0 +1,328 @@
+package loganalyzer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"maps"
+	"os"
+	"sync"
+
+	"jtool/internal/paths"
+)
+
+// Follower analyzes a file that is still being written, like `tail -f`.
+// Each Poll reads the lines appended since the previous one and folds them
+// into a running analysis, so a live tap can be watched without re-reading
+// the whole file.
+//
+// Only plain-text files can be followed; compressed files are read as-is.
+// If the file shrinks or its first bytes change (truncated, or replaced by
+// log rotation) the analysis starts over from the beginning of the new
+// content. That check also makes it safe to keep a Follower around and
+// resume it after a pause, or to save it in a Cache and resume it after a
+// restart (see Cache.SaveFollower).
+type Follower struct {
+	path string
+	opts Options
+
+	mu         sync.Mutex
+	agg        *aggregator
+	docs       *documentScanner
+	offset     int64  // Bytes consumed so far
+	partial    []byte // Trailing line without a newline yet, up to the line size limit
+	overflow   bool   // partial was cut at the line size limit
+	head       []byte // First bytes read, to detect a replaced file
+	totalLines int
+}
+
+// followHeadSize is how many leading bytes are compared to detect a
+// replaced file.
+const followHeadSize = 1024
+
+// NewFollower creates a follower for filePath. Nothing is read until Poll.
+func NewFollower(filePath string, opts Options) *Follower {
+	f := &Follower{path: filePath, opts: opts}
+	f.reset()
+	return f
+}
+
+// reset discards all state and starts over from the beginning of the file.
+func (f *Follower) reset() {
+	f.agg = newAggregator(f.opts)
+	f.docs = f.agg.scanner()
+	f.offset = 0
+	f.partial = nil
+	f.overflow = false
+	f.head = nil
+	f.totalLines = 0
+}
+
+// replaced reports whether file no longer starts with the bytes read before.
+func (f *Follower) replaced(file *os.File) (bool, error) {
+	if len(f.head) == 0 {
+		return false, nil
+	}
+	head := make([]byte, len(f.head))
+	if _, err := file.ReadAt(head, 0); err != nil {
+		if err == io.EOF {
+			return true, nil
+		}
+		return false, err
+	}
+	return !bytes.Equal(head, f.head), nil
+}
+
+// Poll reads whatever has been appended since the last call and reports
+// whether any new lines were analyzed. A line is only analyzed once its
+// newline has been written, so half-flushed lines are never misparsed.
+func (f *Follower) Poll() (bool, error) {
+	return f.PollContext(context.Background())
+}
+
+// PollContext is Poll, giving up with ctx.Err() soon after ctx is
+// cancelled. The lines analyzed until then are kept, so the next poll
+// continues after them. The new content is streamed, so the first poll of
+// a large file doesn't need to fit in memory.
+func (f *Follower) PollContext(ctx context.Context) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() < f.offset {
+		f.reset()
+	} else if replaced, err := f.replaced(file); err != nil {
+		return false, err
+	} else if replaced {
+		f.reset()
+	}
+	if info.Size() == f.offset {
+		return false, nil
+	}
+
+	if f.offset == 0 {
+		f.head = make([]byte, min(info.Size(), followHeadSize))
+		if _, err := io.ReadFull(file, f.head); err != nil {
+			f.head = nil
+			return false, err
+		}
+	}
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	reader := bufio.NewReaderSize(contextReader{ctx, io.LimitReader(file, info.Size()-f.offset)}, 64*1024)
+	maxSize := f.docs.maxSize
+	changed := false
+	for {
+		line, tooLong, size, ended, err := readRawLine(reader, maxSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return changed, err
+		}
+		f.offset += int64(size)
+
+		// Join the start of the line read by the previous poll
+		if len(f.partial) > 0 || f.overflow {
+			tooLong = tooLong || f.overflow || len(f.partial)+len(line) > maxSize
+			line = string(f.partial) + line
+			line = line[:min(len(line), maxSize)]
+			f.partial, f.overflow = nil, false
+		}
+		if !ended {
+			// Don't buffer more of an unfinished line than will be analyzed
+			f.partial = []byte(line)
+			f.overflow = tooLong
+			break
+		}
+
+		f.totalLines++
+		f.docs.scanLine(f.totalLines, line, tooLong)
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// Result returns the analysis of every complete line read so far.
+func (f *Follower) Result() *AnalysisResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.agg.result(f.totalLines)
+}
+
+// saveable reports whether a follower with these options can be saved:
+// decoders, Singer linting and schema validation keep state of their own,
+// and sketches aren't serialized, so only plain analyses are.
+func (o Options) saveable() bool {
+	return o.Decoder == "" && !o.Singer && o.Schema == nil && !o.Approximate
+}
+
+// followState is the on-disk form of a Follower (see Cache.SaveFollower).
+type followState struct {
+	Offset     int64           `json:"offset"`
+	Partial    []byte          `json:"partial,omitempty"`
+	Overflow   bool            `json:"overflow,omitempty"`
+	Head       []byte          `json:"head"`
+	TotalLines int             `json:"totalLines"`
+	Aggregate  aggregatorState `json:"aggregate"`
+	Scanner    scannerState    `json:"scanner"`
+}
+
+// aggregatorState is the saved form of an aggregator without a Singer
+// linter, schema validator or sketches.
+type aggregatorState struct {
+	PathCounts  map[string]int              `json:"pathCounts"`
+	PathObjects map[string]int              `json:"pathObjects"`
+	PathValues  map[string]exactCounter     `json:"pathValues"`
+	PathTypes   map[string]map[string]int   `json:"pathTypes"`
+	PathStrings map[string]stringStatsState `json:"pathStrings"`
+	PathNumbers map[string]numberStatsState `json:"pathNumbers"`
+	PathEmpties map[string]int              `json:"pathEmpties"`
+	PathFirst   map[string]sightingState    `json:"pathFirst"`
+	PathLast    map[string]sightingState    `json:"pathLast"`
+	PathLines   map[string][]int            `json:"pathLines"`
+	Schema      *paths.SchemaBuilder        `json:"schema"`
+	JSONLines   int                         `json:"jsonLines"`
+	Failures    ParseFailureReport          `json:"failures"`
+}
+
+type stringStatsState struct {
+	StringStats
+	TotalLength int            `json:"totalLength"`
+	PII         map[string]int `json:"pii,omitempty"`
+}
+
+type numberStatsState struct {
+	NumberStats
+	M2 float64 `json:"m2"`
+}
+
+type sightingState struct {
+	Line int    `json:"line"`
+	File string `json:"file,omitempty"`
+	Doc  int    `json:"doc"`
+}
+
+// scannerState is the saved form of a documentScanner in the middle of a
+// multi-line document.
+type scannerState struct {
+	Accumulator string `json:"accumulator,omitempty"`
+	Depth       int    `json:"depth,omitempty"`
+	InString    bool   `json:"inString,omitempty"`
+	Escaped     bool   `json:"escaped,omitempty"`
+	InMultiLine bool   `json:"inMultiLine,omitempty"`
+	StartLine   int    `json:"startLine,omitempty"`
+	LastLine    int    `json:"lastLine,omitempty"`
+}
+
+// state returns the follower's progress; f.mu must be held.
+func (f *Follower) state() followState {
+	a := f.agg
+	s := followState{
+		Offset:     f.offset,
+		Partial:    f.partial,
+		Overflow:   f.overflow,
+		Head:       f.head,
+		TotalLines: f.totalLines,
+		Aggregate: aggregatorState{
+			PathCounts:  a.pathCounts,
+			PathObjects: a.pathObjects,
+			PathValues:  make(map[string]exactCounter, len(a.pathValues)),
+			PathTypes:   a.pathTypes,
+			PathStrings: make(map[string]stringStatsState, len(a.pathStrings)),
+			PathNumbers: make(map[string]numberStatsState, len(a.pathNumbers)),
+			PathEmpties: a.pathEmpties,
+			PathFirst:   make(map[string]sightingState, len(a.pathFirst)),
+			PathLast:    make(map[string]sightingState, len(a.pathLast)),
+			PathLines:   a.pathLines,
+			Schema:      a.schema,
+			JSONLines:   a.jsonLines,
+			Failures:    a.failures.report,
+		},
+		Scanner: scannerState{
+			Accumulator: f.docs.accumulator.String(),
+			Depth:       f.docs.depth.depth,
+			InString:    f.docs.depth.inString,
+			Escaped:     f.docs.depth.escaped,
+			InMultiLine: f.docs.inMultiLine,
+			StartLine:   f.docs.startLine,
+			LastLine:    f.docs.lastLine,
+		},
+	}
+	for path, values := range a.pathValues {
+		s.Aggregate.PathValues[path] = values.(exactCounter)
+	}
+	for path, stats := range a.pathStrings {
+		s.Aggregate.PathStrings[path] = stringStatsState{*stats, stats.totalLength, stats.pii}
+	}
+	for path, stats := range a.pathNumbers {
+		s.Aggregate.PathNumbers[path] = numberStatsState{*stats, stats.m2}
+	}
+	for path, seen := range a.pathFirst {
+		s.Aggregate.PathFirst[path] = sightingState{seen.line, seen.file, seen.doc}
+	}
+	for path, seen := range a.pathLast {
+		s.Aggregate.PathLast[path] = sightingState{seen.line, seen.file, seen.doc}
+	}
+	return s
+}
+
+// restore picks up from a saved state; f must be new.
+func (f *Follower) restore(s followState) {
+	f.offset = s.Offset
+	f.partial = s.Partial
+	f.overflow = s.Overflow
+	f.head = s.Head
+	f.totalLines = s.TotalLines
+
+	a, saved := f.agg, s.Aggregate
+	maps.Copy(a.pathCounts, saved.PathCounts)
+	maps.Copy(a.pathObjects, saved.PathObjects)
+	for path, values := range saved.PathValues {
+		a.pathValues[path] = values
+	}
+	maps.Copy(a.pathTypes, saved.PathTypes)
+	for path, stats := range saved.PathStrings {
+		restored := stats.StringStats
+		restored.totalLength, restored.pii = stats.TotalLength, stats.PII
+		a.pathStrings[path] = &restored
+	}
+	for path, stats := range saved.PathNumbers {
+		restored := stats.NumberStats
+		restored.m2 = stats.M2
+		a.pathNumbers[path] = &restored
+	}
+	maps.Copy(a.pathEmpties, saved.PathEmpties)
+	for path, seen := range saved.PathFirst {
+		a.pathFirst[path] = sighting{seen.Line, seen.File, seen.Doc}
+	}
+	for path, seen := range saved.PathLast {
+		a.pathLast[path] = sighting{seen.Line, seen.File, seen.Doc}
+	}
+	maps.Copy(a.pathLines, saved.PathLines)
+	if saved.Schema != nil {
+		a.schema = saved.Schema
+	}
+	a.jsonLines = saved.JSONLines
+	a.failures.report = saved.Failures
+
+	d := f.docs
+	d.accumulator.WriteString(s.Scanner.Accumulator)
+	d.depth = bracketDepth{depth: s.Scanner.Depth, inString: s.Scanner.InString, escaped: s.Scanner.Escaped}
+	d.inMultiLine = s.Scanner.InMultiLine
+	d.startLine = s.Scanner.StartLine
+	d.lastLine = s.Scanner.LastLine
+}
@@ -0,0 +1,157 @@
+package loganalyzer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFollower(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tap.log")
+	appendTo := func(text string) {
+		t.Helper()
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(text); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	poll := func(f *Follower, wantChanged bool, wantJSON int) {
+		t.Helper()
+		changed, err := f.Poll()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if changed != wantChanged {
+			t.Errorf("expected changed=%v, got %v", wantChanged, changed)
+		}
+		if got := f.Result().JSONLines; got != wantJSON {
+			t.Errorf("expected %d JSON lines, got %d", wantJSON, got)
+		}
+	}
+
+	follower := NewFollower(path, Options{})
+	if _, err := follower.Poll(); err == nil {
+		t.Error("expected an error before the file exists")
+	}
+
+	appendTo("INFO starting\n{\"id\": 1}\n")
+	poll(follower, true, 1)
+	poll(follower, false, 1) // Nothing new
+
+	// A half-written line waits for its newline
+	appendTo(`{"id": `)
+	poll(follower, false, 1)
+	appendTo("2}\r\n")
+	poll(follower, true, 2)
+
+	// Pretty-printed documents can span polls
+	appendTo("{\n")
+	poll(follower, true, 2)
+	appendTo("  \"id\": 3\n}\n")
+	poll(follower, true, 3)
+
+	result := follower.Result()
+	if result.TotalLines != 6 || result.Paths[0].Count != 3 {
+		t.Errorf("unexpected result: %d lines, %+v", result.TotalLines, result.Paths)
+	}
+
+	// Truncation (e.g. copytruncate rotation) starts the analysis over
+	if err := os.WriteFile(path, []byte("{\"name\": \"x\"}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	poll(follower, true, 1)
+	if got := follower.Result().Paths[0].Path; got != ".name" {
+		t.Errorf("expected only .name after truncation, got %s", got)
+	}
+
+	// Earlier results are unaffected by later polls
+	if result.Paths[0].Count != 3 {
+		t.Errorf("expected an earlier result to stay the same, got %+v", result.Paths[0])
+	}
+}
+
+func TestFollower_ReplacedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tap.log")
+	if err := os.WriteFile(path, []byte("{\"old\": 1}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	follower := NewFollower(path, Options{})
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Replaced by a new, larger file while the follower was paused
+	if err := os.WriteFile(path, []byte("{\"new\": 1}\n{\"new\": 2}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := follower.Result()
+	if result.JSONLines != 2 || len(result.Paths) != 1 || result.Paths[0].Path != ".new" {
+		t.Errorf("expected only the new file's 2 documents, got %d: %+v", result.JSONLines, result.Paths)
+	}
+}
+
+func TestFollower_LongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tap.log")
+	follower := NewFollower(path, Options{MaxLineSize: 32})
+
+	// A long line written in two parts is buffered only up to the limit
+	long := `{"blob": "` + strings.Repeat("x", 100)
+	if err := os.WriteFile(path, []byte("{\"id\": 1}\n"+long), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(follower.partial) > 33 {
+		t.Errorf("expected at most 33 buffered bytes, got %d", len(follower.partial))
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.WriteString("\"}\n{\"id\": 2}\n")
+	f.Close()
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := follower.Result()
+	if result.JSONLines != 2 || result.ParseFailures == nil || result.ParseFailures.Failures[0].Class != ParseTooLarge {
+		t.Errorf("expected 2 documents and a too-large line, got %d (failures: %+v)", result.JSONLines, result.ParseFailures)
+	}
+}
+
+func TestFollower_PollContextCancelled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tap.log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("{\"id\": 1}\n", 100000)), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	follower := NewFollower(path, Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := follower.PollContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// The next poll picks up after whatever was read
+	if _, err := follower.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := follower.Result().JSONLines; got != 100000 {
+		t.Errorf("expected 100000 JSON lines, got %d", got)
+	}
+}
@@ -0,0 +1,54 @@
+package loganalyzer
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// NumberStats profiles the numeric values seen at a path.
+type NumberStats struct {
+	Count  int     `json:"count"`  // Number of numeric values
+	Min    float64 `json:"min"`    // Smallest value
+	Max    float64 `json:"max"`    // Largest value
+	Mean   float64 `json:"mean"`   // Arithmetic mean
+	StdDev float64 `json:"stdDev"` // Population standard deviation
+
+	m2 float64 // Sum of squared differences from the mean (Welford), for StdDev
+}
+
+// clone returns a copy of s, or nil if s is nil.
+func (s *NumberStats) clone() *NumberStats {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+// add records one numeric value. Mean and StdDev are updated with Welford's
+// algorithm, which stays accurate over millions of values.
+func (s *NumberStats) add(value float64) {
+	if s.Count == 0 || value < s.Min {
+		s.Min = value
+	}
+	if s.Count == 0 || value > s.Max {
+		s.Max = value
+	}
+	s.Count++
+	delta := value - s.Mean
+	s.Mean += delta / float64(s.Count)
+	s.m2 += delta * (value - s.Mean)
+	s.StdDev = math.Sqrt(s.m2 / float64(s.Count))
+}
+
+// numberValue returns a JSON number as a float64.
+func numberValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
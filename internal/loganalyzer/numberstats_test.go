@@ -0,0 +1,38 @@
+package loganalyzer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnalyzeString_NumberStats(t *testing.T) {
+	jsonl := `{"n": 2, "s": "x"}
+{"n": 4}
+{"n": 4}
+{"n": 4}
+{"n": 5}
+{"n": 5}
+{"n": 7}
+{"n": 9}`
+
+	result, err := AnalyzeString(jsonl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byPath := make(map[string]PathSummary)
+	for _, p := range result.Paths {
+		byPath[p.Path] = p
+	}
+
+	n := byPath[".n"].Numbers
+	if n == nil {
+		t.Fatal("expected number stats for .n")
+	}
+	if n.Count != 8 || n.Min != 2 || n.Max != 9 || n.Mean != 5 || math.Abs(n.StdDev-2) > 1e-9 {
+		t.Errorf("unexpected number stats: %+v", n)
+	}
+	if byPath[".s"].Numbers != nil {
+		t.Errorf("expected no number stats for a string path, got %+v", byPath[".s"].Numbers)
+	}
+}
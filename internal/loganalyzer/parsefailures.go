@@ -0,0 +1,126 @@
+package loganalyzer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Classes of lines that couldn't be parsed, reported in
+// ParseFailureReport.ByClass.
+const (
+	ParseNotJSON          = "not-json"          // No JSON on the line: plain log output
+	ParseTruncated        = "truncated"         // Input ended inside a document
+	ParseInvalidEscape    = "invalid-escape"    // Bad backslash escape in a string
+	ParseControlCharacter = "control-character" // Raw control character (e.g. a tab or newline) in a string
+	ParseTrailingData     = "trailing-data"     // Extra text after a complete document
+	ParseTooLarge         = "too-large"         // Line or multi-line document over Options.MaxLineSize
+	ParseUndecodable      = "undecodable"       // Line not in the format of Options.Decoder
+	ParseSyntax           = "syntax"            // Any other JSON syntax error
+)
+
+// maxParseFailures caps ParseFailureReport.Failures; Total keeps counting.
+const maxParseFailures = 1000
+
+// maxFailureText is how much of the source text a ParseFailure keeps.
+const maxFailureText = 200
+
+var (
+	errNotJSON      = errors.New("no JSON document on line")
+	errTooLarge     = errors.New("too large")
+	errUnterminated = fmt.Errorf("input ended inside a document: %w", io.ErrUnexpectedEOF)
+)
+
+// ParseFailureReport explains the lines an analysis skipped, so log noise can
+// be told apart from corrupted records.
+type ParseFailureReport struct {
+	ByClass  map[string]int `json:"byClass"`  // Failure class -> number of failures
+	Failures []ParseFailure `json:"failures"` // First 1000 failures, in file order
+	Total    int            `json:"total"`    // All failures, including unlisted ones
+}
+
+// ParseFailure is a line (or multi-line document) that couldn't be parsed.
+type ParseFailure struct {
+	File  string `json:"file,omitempty"` // Set when several files are analyzed
+	Line  int    `json:"line"`           // Line the failed text starts on
+	Class string `json:"class"`          // One of the Parse* classes
+	Error string `json:"error"`          // Parser error message
+	Text  string `json:"text"`           // Start of the failed text, cut at 200 bytes
+}
+
+// parseFailures collects the failures of an analysis.
+type parseFailures struct {
+	report ParseFailureReport
+	file   string // Current file, when several files are analyzed
+}
+
+func (p *parseFailures) add(line int, text string, err error) {
+	class := classifyParseError(err)
+	if p.report.ByClass == nil {
+		p.report.ByClass = make(map[string]int)
+	}
+	p.report.ByClass[class]++
+	p.report.Total++
+
+	if len(p.report.Failures) < maxParseFailures {
+		p.report.Failures = append(p.report.Failures, ParseFailure{
+			File:  p.file,
+			Line:  line,
+			Class: class,
+			Error: err.Error(),
+			Text:  truncateText(text, maxFailureText),
+		})
+	}
+}
+
+// result returns a copy of the report, or nil if nothing failed.
+func (p *parseFailures) result() *ParseFailureReport {
+	if p.report.Total == 0 {
+		return nil
+	}
+	report := p.report
+	report.ByClass = maps.Clone(p.report.ByClass)
+	report.Failures = slices.Clone(p.report.Failures)
+	return &report
+}
+
+// classifyParseError buckets a parser error. encoding/json has no error
+// codes for these cases, so the message is matched; both the current and
+// older wordings are covered.
+func classifyParseError(err error) string {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, errNotJSON):
+		return ParseNotJSON
+	case errors.Is(err, errTooLarge):
+		return ParseTooLarge
+	case errors.Is(err, errUndecodable):
+		return ParseUndecodable
+	case errors.Is(err, io.ErrUnexpectedEOF), strings.Contains(msg, "unexpected end of JSON input"):
+		return ParseTruncated
+	case strings.Contains(msg, "escape"):
+		return ParseInvalidEscape
+	case strings.Contains(msg, "in string"):
+		// The only other characters invalid inside a string are control characters
+		return ParseControlCharacter
+	case strings.Contains(msg, "after top-level value"):
+		return ParseTrailingData
+	default:
+		return ParseSyntax
+	}
+}
+
+// truncateText cuts s to at most n bytes without splitting a UTF-8 character.
+func truncateText(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
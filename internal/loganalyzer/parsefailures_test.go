@@ -0,0 +1,93 @@
+package loganalyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeString_ParseFailures(t *testing.T) {
+	content := strings.Join([]string{
+		`INFO starting tap`,           // 1: log noise
+		`{"id": 1}`,                   // 2
+		`{"name": "bad \q escape"}`,   // 3
+		"{\"name\": \"tab\tinside\"}", // 4
+		`{"id": 2}}`,                  // 5
+		`{"id": 3}`,                   // 6: still parsed after the corrupt lines
+		`{"id": 4, "name" 4}`,         // 7
+		``,                            // 8: empty lines aren't failures
+		`{`,                           // 9: pretty-printed document...
+		`  "id": 5`,                   // 10
+		`}`,                           // 11
+		`{"id": 6, "items": [`,        // 12: cut off at the end of the input
+	}, "\n")
+
+	result, err := AnalyzeString(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.JSONLines != 3 {
+		t.Errorf("expected 3 JSON documents, got %d", result.JSONLines)
+	}
+
+	report := result.ParseFailures
+	if report == nil {
+		t.Fatal("expected a parse failure report")
+	}
+
+	expected := []struct {
+		line  int
+		class string
+	}{
+		{1, ParseNotJSON},
+		{3, ParseInvalidEscape},
+		{4, ParseControlCharacter},
+		{5, ParseTrailingData},
+		{7, ParseSyntax},
+		{12, ParseTruncated},
+	}
+	if report.Total != len(expected) || len(report.Failures) != len(expected) {
+		t.Fatalf("expected %d failures, got %d: %+v", len(expected), report.Total, report.Failures)
+	}
+	for i, want := range expected {
+		got := report.Failures[i]
+		if got.Line != want.line || got.Class != want.class {
+			t.Errorf("failure %d: expected line %d %s, got line %d %s (%s)", i, want.line, want.class, got.Line, got.Class, got.Error)
+		}
+	}
+	if report.ByClass[ParseNotJSON] != 1 || report.ByClass[ParseTruncated] != 1 {
+		t.Errorf("unexpected counts by class: %v", report.ByClass)
+	}
+	if report.Failures[0].Text != "INFO starting tap" {
+		t.Errorf("expected the failed text, got %q", report.Failures[0].Text)
+	}
+}
+
+func TestAnalyzeString_NoParseFailures(t *testing.T) {
+	result, err := AnalyzeString("{\"a\": 1}\n\n{\"a\": 2}\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ParseFailures != nil {
+		t.Errorf("expected no report, got %+v", result.ParseFailures)
+	}
+}
+
+func TestParseFailures_Bounded(t *testing.T) {
+	var failures parseFailures
+	for i := 0; i < maxParseFailures+5; i++ {
+		failures.add(i+1, "noise", errNotJSON)
+	}
+	report := failures.result()
+	if len(report.Failures) != maxParseFailures || report.Total != maxParseFailures+5 {
+		t.Errorf("expected %d listed of %d, got %d of %d", maxParseFailures, maxParseFailures+5, len(report.Failures), report.Total)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if got := truncateText("héllo", 2); got != "h…" {
+		t.Errorf("expected the cut not to split é, got %q", got)
+	}
+	if got := truncateText("short", 10); got != "short" {
+		t.Errorf("expected short text unchanged, got %q", got)
+	}
+}
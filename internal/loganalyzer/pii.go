@@ -0,0 +1,210 @@
+package loganalyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"jtool/internal/pathmatch"
+)
+
+// Kinds of personal data reported in PIIMatch.Kind.
+const (
+	PIIEmail      = "email"
+	PIIPhone      = "phone"
+	PIISSN        = "ssn"         // US Social Security number, e.g. "123-45-6789"
+	PIICreditCard = "credit-card" // 13-19 digits passing the Luhn check
+)
+
+// Confidence levels reported in PIIMatch.Confidence.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// Share of a path's string values that must match for medium and high
+// confidence. A field name that suggests the kind (e.g. ".user.email")
+// raises the confidence one level.
+const (
+	piiMediumShare = 0.5
+	piiHighShare   = 0.9
+)
+
+// PIIMatch flags a path whose values look like personal data, for
+// compliance review of tap output. Only string values are checked.
+type PIIMatch struct {
+	Kind       string  `json:"kind"`       // One of the PII* kinds
+	Confidence string  `json:"confidence"` // ConfidenceLow, ConfidenceMedium or ConfidenceHigh
+	Matches    int     `json:"matches"`    // String values that look like Kind
+	Share      float64 `json:"share"`      // Matches as a percentage of the path's string values
+}
+
+var (
+	ssnPattern   = regexp.MustCompile(`^(\d{3})-(\d{2})-(\d{4})$`)
+	phonePattern = regexp.MustCompile(`^\+?[\d\s().-]+$`)
+	cardPattern  = regexp.MustCompile(`^\d[\d -]+\d$`)
+)
+
+// piiNameHints are field name fragments that suggest a kind of PII.
+var piiNameHints = map[string][]string{
+	PIIEmail:      {"email", "mail"},
+	PIIPhone:      {"phone", "mobile", "tel", "fax"},
+	PIISSN:        {"ssn", "social"},
+	PIICreditCard: {"card", "ccnum", "cc_num", "pan"},
+}
+
+// piiKind returns the kind of PII a string looks like, or "" if none.
+// format is its stringFormat bucket, which already recognises emails.
+func piiKind(s, format string) string {
+	switch {
+	case format == FormatEmail:
+		return PIIEmail
+	case s == "" || format != "" && format != FormatNumericString:
+		// Dates, IP addresses, UUIDs etc. aren't phone or card numbers
+		return ""
+	case !isDialString(s):
+		// Letters etc. rule out all three without running the patterns
+		return ""
+	case isSSN(s):
+		return PIISSN
+	case isCreditCard(s):
+		return PIICreditCard
+	case isPhone(s):
+		return PIIPhone
+	}
+	return ""
+}
+
+// isSSN checks the format and the number ranges that are never issued
+// (area 000, 666 or 9xx, group 00, serial 0000).
+func isSSN(s string) bool {
+	m := ssnPattern.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	area, group, serial := m[1], m[2], m[3]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// isCreditCard checks for 13-19 digits, optionally grouped with spaces or
+// dashes, that pass the Luhn check.
+func isCreditCard(s string) bool {
+	if !cardPattern.MatchString(s) {
+		return false
+	}
+	digits := digitsOf(s)
+	return len(digits) >= 13 && len(digits) <= 19 && luhn(digits)
+}
+
+// isPhone checks for 10-15 digits written with a leading "+" or with
+// separators, so bare numeric IDs aren't mistaken for phone numbers.
+func isPhone(s string) bool {
+	if !phonePattern.MatchString(s) {
+		return false
+	}
+	digits := digitsOf(s)
+	if len(digits) < 10 || len(digits) > 15 {
+		return false
+	}
+	return s[0] == '+' || len(digits) != len(s)
+}
+
+// isDialString reports whether s only has characters an SSN, card or phone
+// number is written with: digits, whitespace, "+", "-", "." and parentheses.
+func isDialString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+		case c == ' ', c == '\t', c == '\n', c == '\v', c == '\f', c == '\r':
+		case c == '+', c == '-', c == '.', c == '(', c == ')':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// luhn reports whether a string of digits has a valid Luhn check digit.
+func luhn(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// digitsOf returns the ASCII digits of s.
+func digitsOf(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// detectPII returns the kind of PII most of the string values at path look
+// like, or nil if none do. path is the dotted path, whose last field name
+// can raise the confidence.
+func detectPII(path string, s *StringStats) *PIIMatch {
+	if s == nil || s.Count == 0 {
+		return nil
+	}
+
+	kind, matches := "", 0
+	for _, k := range []string{PIIEmail, PIIPhone, PIISSN, PIICreditCard} {
+		if s.pii[k] > matches {
+			kind, matches = k, s.pii[k]
+		}
+	}
+	if matches == 0 {
+		return nil
+	}
+
+	share := float64(matches) / float64(s.Count)
+	level := 0
+	switch {
+	case share >= piiHighShare:
+		level = 2
+	case share >= piiMediumShare:
+		level = 1
+	}
+	if level < 2 && nameSuggests(path, kind) {
+		level++
+	}
+
+	return &PIIMatch{
+		Kind:       kind,
+		Confidence: []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}[level],
+		Matches:    matches,
+		Share:      share * 100,
+	}
+}
+
+// nameSuggests reports whether the last field name in path hints at kind.
+func nameSuggests(path, kind string) bool {
+	segments := pathmatch.Split(path)
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.HasPrefix(segments[i], "[") {
+			continue
+		}
+		name := strings.ToLower(segments[i])
+		for _, hint := range piiNameHints[kind] {
+			if strings.Contains(name, hint) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package loganalyzer
+
+import (
+	"testing"
+
+	"jtool/internal/paths"
+)
+
+func TestPIIKind(t *testing.T) {
+	tests := map[string]string{
+		"alice@example.com":   PIIEmail,
+		"123-45-6789":         PIISSN,
+		"666-45-6789":         "",
+		"123-00-6789":         "",
+		"4111 1111 1111 1111": PIICreditCard,
+		"4111111111111111":    PIICreditCard,
+		"4111111111111112":    "", // Fails the Luhn check
+		"+1 (415) 555-2671":   PIIPhone,
+		"+14155552671":        PIIPhone,
+		"415.555.2671":        PIIPhone,
+		"4155552671":          "", // Could be any numeric ID
+		"555-2671":            "", // Too few digits
+		"192.168.100.200":     "",
+		"2024-01-15":          "",
+		"hello":               "",
+	}
+	for input, want := range tests {
+		if got := piiKind(input, stringFormat(input, paths.DetectFormat(input))); got != want {
+			t.Errorf("piiKind(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestAnalyzeString_PII(t *testing.T) {
+	jsonl := `{"contact": "a@example.com", "user": {"mobile": "+44 20 7946 0958"}, "note": "call 555-0100", "ref": "x"}
+{"contact": "b@example.com", "user": {"mobile": "n/a"}, "note": "ok", "ref": "4111111111111111"}
+{"contact": "c@example.com", "user": {"mobile": "n/a"}, "note": "ok", "ref": "y"}
+{"contact": "d@example.com", "user": {"mobile": "n/a"}, "note": "ok", "ref": "z"}`
+
+	result, err := AnalyzeString(jsonl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byPath := make(map[string]PathSummary)
+	for _, p := range result.Paths {
+		byPath[p.Path] = p
+	}
+
+	tests := []struct {
+		path       string
+		kind       string
+		confidence string
+	}{
+		{".contact", PIIEmail, ConfidenceHigh},
+		{".user.mobile", PIIPhone, ConfidenceMedium}, // 25% of values, but the name suggests it
+		{".ref", PIICreditCard, ConfidenceLow},
+	}
+	for _, tt := range tests {
+		pii := byPath[tt.path].PII
+		if pii == nil || pii.Kind != tt.kind || pii.Confidence != tt.confidence {
+			t.Errorf("%s: expected %s with %s confidence, got %+v", tt.path, tt.kind, tt.confidence, pii)
+		}
+	}
+	if pii := byPath[".contact"].PII; pii != nil && (pii.Matches != 4 || pii.Share != 100) {
+		t.Errorf("expected 4 matches (100%%), got %+v", pii)
+	}
+	if pii := byPath[".note"].PII; pii != nil {
+		t.Errorf("expected no PII in .note, got %+v", pii)
+	}
+}
@@ -0,0 +1,123 @@
+package loganalyzer
+
+import (
+	"html/template"
+	"io"
+	"strconv"
+)
+
+// reportSection is one color-coded group of paths in the HTML report.
+type reportSection struct {
+	Title       string
+	Class       string // CSS class for the section color
+	Comparisons []PathComparison
+	Collapsed   bool // Rendered inside a closed <details>
+}
+
+// WriteComparisonHTML renders a comparison as a standalone HTML page: summary
+// stats followed by removed, added, changed and equal paths, color-coded.
+// The page has no external assets, so it can be attached to a sign-off as is.
+func WriteComparisonHTML(w io.Writer, result *ComparisonResult) error {
+	groups := map[ComparisonStatus][]PathComparison{}
+	for _, c := range result.Comparisons {
+		groups[c.Status] = append(groups[c.Status], c)
+	}
+
+	// Same order as the comparison table: most significant first
+	sections := []reportSection{
+		{Title: "Removed paths", Class: "removed", Comparisons: groups[StatusRemoved]},
+		{Title: "Added paths", Class: "added", Comparisons: groups[StatusAdded]},
+		{Title: "Changed paths", Class: "changed", Comparisons: groups[StatusChanged]},
+		{Title: "Equal paths", Class: "equal", Comparisons: groups[StatusEqual], Collapsed: true},
+	}
+
+	return reportTemplate.Execute(w, struct {
+		Result   *ComparisonResult
+		Sections []reportSection
+	}{result, sections})
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	// signed formats a delta with an explicit "+". It returns template.HTML
+	// only so the plus sign isn't escaped to &#43;; the text is just digits.
+	"signed": func(n int) template.HTML {
+		if n > 0 {
+			return template.HTML("+" + strconv.Itoa(n))
+		}
+		return template.HTML(strconv.Itoa(n))
+	},
+	// valueMark prefixes a value change: + appeared, − disappeared, ~ shifted
+	"valueMark": func(status ComparisonStatus) template.HTML {
+		switch status {
+		case StatusAdded:
+			return "+"
+		case StatusRemoved:
+			return "−"
+		}
+		return "~"
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Log comparison{{with .Result.LeftFile}}: {{.}}{{end}}{{with .Result.RightFile}} vs {{.}}{{end}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; padding-left: .5em; border-left: 6px solid; }
+table { border-collapse: collapse; margin: .5em 0; }
+th, td { padding: .3em .8em; border-bottom: 1px solid #d0d7de; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+td.path { font-family: ui-monospace, Menlo, Consolas, monospace; }
+.removed h2, h2.removed { border-color: #cf222e; }
+.added h2, h2.added { border-color: #1a7f37; }
+.changed h2, h2.changed { border-color: #bf8700; }
+.equal h2, h2.equal { border-color: #8c959f; }
+tr.removed { background: #ffebe9; }
+tr.added { background: #dafbe1; }
+tr.changed { background: #fff8c5; }
+.files td { text-align: left; }
+td.values { text-align: left; font-family: ui-monospace, Menlo, Consolas, monospace; }
+td.values span.added { color: #1a7f37; }
+td.values span.removed { color: #cf222e; }
+</style>
+</head>
+<body>
+<h1>Log comparison</h1>
+<table class="files">
+<tr><th>Baseline (left)</th><td>{{or .Result.LeftFile "—"}}</td></tr>
+<tr><th>Candidate (right)</th><td>{{or .Result.RightFile "—"}}</td></tr>
+{{with .Result.VolumeScale}}<tr><th>Right counts scaled by</th><td>{{printf "%.4g" .}}</td></tr>{{end}}
+</table>
+
+<h2 class="summary">Summary</h2>
+{{with .Result.Stats}}<table>
+<tr><th>Total paths</th><td>{{.TotalPaths}}</td></tr>
+<tr class="removed"><th>Removed</th><td>{{.RemovedPaths}}</td></tr>
+<tr class="added"><th>Added</th><td>{{.AddedPaths}}</td></tr>
+<tr class="changed"><th>Changed</th><td>{{.ChangedPaths}}</td></tr>
+<tr><th>Equal</th><td>{{.EqualPaths}}</td></tr>
+<tr><th>Count delta</th><td>{{signed .TotalCountDelta}}</td></tr>
+<tr><th>Objects delta</th><td>{{signed .TotalObjectsDelta}}</td></tr>
+<tr><th>Distinct delta</th><td>{{signed .TotalDistinctDelta}}</td></tr>
+</table>{{end}}
+{{range .Sections}}{{if .Comparisons}}
+<section class="{{.Class}}">
+<h2>{{.Title}} ({{len .Comparisons}})</h2>
+{{if .Collapsed}}<details><summary>Show</summary>{{end}}
+<table>
+<tr><th>Path</th><th>Left count</th><th>Right count</th><th>Count Δ</th><th>Left objects</th><th>Right objects</th><th>Objects Δ</th><th>Left distinct</th><th>Right distinct</th><th>Distinct Δ</th><th>Values</th></tr>
+{{range .Comparisons}}<tr class="{{.Status}}">
+<td class="path">{{.Path}}</td>
+<td>{{with .Left}}{{.Count}}{{end}}</td><td>{{with .Right}}{{.Count}}{{end}}</td><td>{{signed .CountDelta}}</td>
+<td>{{with .Left}}{{.ObjectHits}}{{end}}</td><td>{{with .Right}}{{.ObjectHits}}{{end}}</td><td>{{signed .ObjectsDelta}}</td>
+<td>{{with .Left}}{{.DistinctCount}}{{end}}</td><td>{{with .Right}}{{.DistinctCount}}{{end}}</td><td>{{signed .DistinctDelta}}</td>
+<td class="values">{{range .ValueChanges}}<span class="{{.Status}}">{{valueMark .Status}}{{printf "%q" .Value}}{{if eq .Status "changed"}} {{printf "%.0f" .LeftShare}}%→{{printf "%.0f" .RightShare}}%{{end}}</span> {{end}}</td>
+</tr>
+{{end}}</table>
+{{if .Collapsed}}</details>{{end}}
+</section>
+{{end}}{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,44 @@
+package loganalyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteComparisonHTML(t *testing.T) {
+	left, _ := AnalyzeString(`{"a": 1, "b": 1, "same": 1}`)
+	right, _ := AnalyzeString(`{"a": 1, "c": 2, "same": 1}
+{"a": 2, "c": 3, "same": 1, "<script>": 1}`)
+	comparison := CompareAnalyses(left, right, "baseline.log", "candidate.log")
+
+	var buf bytes.Buffer
+	if err := WriteComparisonHTML(&buf, comparison); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html := buf.String()
+
+	expected := []string{
+		"<title>Log comparison: baseline.log vs candidate.log</title>",
+		"<h2>Removed paths (1)</h2>",
+		"<h2>Added paths (2)</h2>",
+		"<h2>Changed paths (2)</h2>",
+		`<tr class="removed">` + "\n" + `<td class="path">.b</td>`,
+		"<td>+2</td>",
+		"<td>-1</td>",
+		"&lt;script&gt;", // Paths are escaped
+		`<span class="added">+&#34;2&#34;</span>`, // .a gained the value 2
+	}
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+	// Empty sections are left out
+	if strings.Contains(html, "Equal paths") {
+		t.Error("expected no section for equal paths")
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("expected path names to be escaped")
+	}
+}
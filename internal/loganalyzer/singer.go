@@ -0,0 +1,172 @@
+package loganalyzer
+
+import (
+	"maps"
+	"slices"
+	"sort"
+
+	"jtool/internal/jsonschema"
+)
+
+// Singer message types (the .type field of each message).
+const (
+	SingerRecord          = "RECORD"
+	SingerSchema          = "SCHEMA"
+	SingerState           = "STATE"
+	SingerActivateVersion = "ACTIVATE_VERSION"
+)
+
+// maxViolations caps SingerReport.Violations; TotalViolations keeps counting.
+const maxViolations = 1000
+
+// SingerReport summarizes Singer tap output: how many messages of each type
+// were seen, per-stream counts, and RECORDs that don't match the most recent
+// SCHEMA message for their stream.
+type SingerReport struct {
+	MessageCounts   map[string]int    `json:"messageCounts"`   // .type -> number of messages
+	Unclassified    int               `json:"unclassified"`    // JSON lines without a string .type
+	Streams         []SingerStream    `json:"streams"`         // Per-stream counts, sorted by stream name
+	Violations      []SchemaViolation `json:"violations"`      // First 1000 problems, in file order
+	TotalViolations int               `json:"totalViolations"` // All problems found, including unlisted ones
+}
+
+// SingerStream holds message counts for one stream.
+type SingerStream struct {
+	Stream         string `json:"stream"`         // Stream name
+	Records        int    `json:"records"`        // RECORD messages
+	Schemas        int    `json:"schemas"`        // SCHEMA messages
+	InvalidRecords int    `json:"invalidRecords"` // RECORDs with at least one violation
+}
+
+// SchemaViolation is one problem with a Singer message.
+type SchemaViolation struct {
+	File    string `json:"file,omitempty"` // File the message is in, when several files are analyzed together
+	Line    int    `json:"line"`           // Line the message starts on
+	Stream  string `json:"stream"`         // Stream the message belongs to
+	Path    string `json:"path"`           // Location within the record (e.g. ".items[0].price"), "." for the record itself
+	Message string `json:"message"`        // What is wrong
+}
+
+// singerLinter classifies Singer messages and validates RECORDs.
+type singerLinter struct {
+	report  SingerReport
+	streams map[string]*SingerStream
+	schemas map[string]*jsonschema.Schema // Stream -> most recent schema, nil if it didn't compile
+	file    string                        // Current file, for violations in a batch
+}
+
+func newSingerLinter() *singerLinter {
+	return &singerLinter{
+		report:  SingerReport{MessageCounts: make(map[string]int)},
+		streams: make(map[string]*SingerStream),
+		schemas: make(map[string]*jsonschema.Schema),
+	}
+}
+
+// add classifies one JSON document that started on the given line.
+func (l *singerLinter) add(line int, data any) {
+	msg, _ := data.(map[string]any)
+	msgType, ok := msg["type"].(string)
+	if !ok {
+		l.report.Unclassified++
+		return
+	}
+	l.report.MessageCounts[msgType]++
+
+	name, hasStream := msg["stream"].(string)
+	var stream *SingerStream
+	if hasStream {
+		stream = l.stream(name)
+	}
+
+	switch msgType {
+	case SingerSchema:
+		if !hasStream {
+			l.violation(line, "", ".", "SCHEMA message has no stream")
+			return
+		}
+		stream.Schemas++
+		schema, ok := msg["schema"]
+		if !ok {
+			l.violation(line, name, ".", "SCHEMA message has no schema")
+			return
+		}
+		compiled, err := jsonschema.Compile(schema)
+		if err != nil {
+			l.violation(line, name, ".", "invalid schema: "+err.Error())
+		}
+		l.schemas[name] = compiled
+
+	case SingerRecord:
+		if !hasStream {
+			l.violation(line, "", ".", "RECORD message has no stream")
+			return
+		}
+		stream.Records++
+
+		record, ok := msg["record"]
+		if !ok {
+			stream.InvalidRecords++
+			l.violation(line, name, ".", "RECORD message has no record")
+			return
+		}
+		schema, ok := l.schemas[name]
+		if !ok {
+			stream.InvalidRecords++
+			l.violation(line, name, ".", "no SCHEMA message for this stream before the RECORD")
+			return
+		}
+
+		if schema == nil {
+			return // The SCHEMA message was reported instead
+		}
+		result := schema.Validate(record)
+		if result.Valid {
+			return
+		}
+		stream.InvalidRecords++
+		for _, violation := range result.Violations {
+			l.violation(line, name, violation.InstancePath, violation.Message)
+		}
+	}
+}
+
+func (l *singerLinter) stream(name string) *SingerStream {
+	s, ok := l.streams[name]
+	if !ok {
+		s = &SingerStream{Stream: name}
+		l.streams[name] = s
+	}
+	return s
+}
+
+func (l *singerLinter) violation(line int, stream, path, message string) {
+	l.report.TotalViolations++
+	if len(l.report.Violations) < maxViolations {
+		l.report.Violations = append(l.report.Violations, SchemaViolation{
+			File:    l.file,
+			Line:    line,
+			Stream:  stream,
+			Path:    path,
+			Message: message,
+		})
+	}
+}
+
+// result returns the finished report.
+func (l *singerLinter) result() *SingerReport {
+	report := l.report
+	report.MessageCounts = maps.Clone(l.report.MessageCounts)
+	report.Violations = slices.Clone(l.report.Violations)
+	report.Streams = make([]SingerStream, 0, len(l.streams))
+	for _, s := range l.streams {
+		report.Streams = append(report.Streams, *s)
+	}
+	sort.Slice(report.Streams, func(i, j int) bool {
+		return report.Streams[i].Stream < report.Streams[j].Stream
+	})
+	if report.Violations == nil {
+		report.Violations = []SchemaViolation{}
+	}
+	return &report
+}
@@ -0,0 +1,99 @@
+package loganalyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeStringWithOptions_Singer(t *testing.T) {
+	output := `INFO Starting sync
+{"type": "SCHEMA", "stream": "users", "schema": {"type": "object", "properties": {"id": {"type": "integer"}, "email": {"type": ["null", "string"]}}}, "key_properties": ["id"]}
+{"type": "RECORD", "stream": "users", "record": {"id": 1, "email": "a@b.io"}}
+{"type": "RECORD", "stream": "users", "record": {"id": "2", "email": null}}
+{"type": "RECORD", "stream": "orders", "record": {"id": 10}}
+{"type": "STATE", "value": {"bookmarks": {}}}
+{"type": "ACTIVATE_VERSION", "stream": "users", "version": 1}
+{"type": "SCHEMA", "stream": "users", "schema": {"type": "object", "properties": {"id": {"type": "string"}}}}
+{"type": "RECORD", "stream": "users", "record": {"id": "3"}}
+{"not": "singer"}`
+
+	result, err := AnalyzeStringWithOptions(output, Options{Singer: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report := result.Singer
+	if report == nil {
+		t.Fatal("expected a Singer report")
+	}
+
+	expectedCounts := map[string]int{SingerSchema: 2, SingerRecord: 4, SingerState: 1, SingerActivateVersion: 1}
+	if !reflect.DeepEqual(report.MessageCounts, expectedCounts) {
+		t.Errorf("expected counts %v, got %v", expectedCounts, report.MessageCounts)
+	}
+	if report.Unclassified != 1 {
+		t.Errorf("expected 1 unclassified line, got %d", report.Unclassified)
+	}
+
+	expectedStreams := []SingerStream{
+		{Stream: "orders", Records: 1, InvalidRecords: 1},
+		{Stream: "users", Records: 3, Schemas: 2, InvalidRecords: 1},
+	}
+	if !reflect.DeepEqual(report.Streams, expectedStreams) {
+		t.Errorf("expected streams %+v, got %+v", expectedStreams, report.Streams)
+	}
+
+	// The last users RECORD is valid against the second SCHEMA
+	expectedViolations := []SchemaViolation{
+		{Line: 4, Stream: "users", Path: ".id", Message: "expected integer, got string"},
+		{Line: 5, Stream: "orders", Path: ".", Message: "no SCHEMA message for this stream before the RECORD"},
+	}
+	if !reflect.DeepEqual(report.Violations, expectedViolations) {
+		t.Errorf("expected violations %+v, got %+v", expectedViolations, report.Violations)
+	}
+	if report.TotalViolations != 2 {
+		t.Errorf("expected 2 violations, got %d", report.TotalViolations)
+	}
+
+	// Off by default
+	plain, err := AnalyzeString(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.Singer != nil {
+		t.Error("expected no Singer report unless requested")
+	}
+}
+
+func TestAnalyzeStringWithOptions_SingerLineNumbers(t *testing.T) {
+	// Multi-line messages are reported at the line they start on
+	output := "{\"type\": \"SCHEMA\", \"stream\": \"s\", \"schema\": {\"required\": [\"id\"]}}\n\n{\n  \"type\": \"RECORD\",\n  \"stream\": \"s\",\n  \"record\": {}\n}\n"
+
+	result, err := AnalyzeStringWithOptions(output, Options{Singer: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []SchemaViolation{{Line: 3, Stream: "s", Path: ".id", Message: "required property is missing"}}
+	if !reflect.DeepEqual(result.Singer.Violations, expected) {
+		t.Errorf("expected %+v, got %+v", expected, result.Singer.Violations)
+	}
+}
+
+func TestAnalyzeStringWithOptions_SingerInvalidSchema(t *testing.T) {
+	output := `{"type": "SCHEMA", "stream": "s", "schema": {"properties": {"id": {"pattern": "("}}}}
+{"type": "RECORD", "stream": "s", "record": {"id": "1"}}`
+
+	result, err := AnalyzeStringWithOptions(output, Options{Singer: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	violations := result.Singer.Violations
+	if len(violations) != 1 || violations[0].Line != 1 || !strings.HasPrefix(violations[0].Message, "invalid schema: ") {
+		t.Errorf("expected the SCHEMA message to be reported, got %+v", violations)
+	}
+	if streams := result.Singer.Streams; len(streams) != 1 || streams[0].InvalidRecords != 0 {
+		t.Errorf("expected RECORDs not to be validated, got %+v", streams)
+	}
+}
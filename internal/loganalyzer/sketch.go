@@ -0,0 +1,216 @@
+package loganalyzer
+
+import (
+	"container/heap"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// valueCounter tracks the values seen at one path.
+type valueCounter interface {
+	add(value string)
+	distinct() int              // Number of distinct values
+	top(n int) []ValueFrequency // Most frequent values, highest count first
+}
+
+// exactCounter keeps every distinct value. Accurate, but memory grows with
+// the cardinality of the path.
+type exactCounter map[string]int
+
+func (c exactCounter) add(value string)           { c[value]++ }
+func (c exactCounter) distinct() int              { return len(c) }
+func (c exactCounter) top(n int) []ValueFrequency { return getTopValues(c, n) }
+
+// sketchCounter estimates the distinct count with a HyperLogLog and keeps
+// the heavy hitters with a space-saving sketch, so memory per path is fixed
+// regardless of how many distinct values appear.
+type sketchCounter struct {
+	hll  *hyperLogLog
+	topK *spaceSaving
+}
+
+// sketchCapacity is how many candidate values the space-saving sketch keeps
+// per top value reported; extra slots make the reported values more reliable.
+const sketchCapacity = 10
+
+func newSketchCounter(topN int) *sketchCounter {
+	return &sketchCounter{
+		hll:  newHyperLogLog(),
+		topK: newSpaceSaving(topN * sketchCapacity),
+	}
+}
+
+func (c *sketchCounter) add(value string) {
+	c.hll.add(value)
+	c.topK.add(value)
+}
+
+func (c *sketchCounter) distinct() int {
+	// Never report fewer distinct values than the sketch is holding
+	return max(c.hll.estimate(), c.topK.len())
+}
+
+func (c *sketchCounter) top(n int) []ValueFrequency { return c.topK.top(n) }
+
+// hllPrecision is the number of hash bits used to pick a register.
+// 2^12 registers cost 4KB per path for a standard error of about 1.6%.
+const hllPrecision = 12
+
+// hyperLogLog is a cardinality estimator (Flajolet et al., 2007) with the
+// usual linear-counting correction for small cardinalities.
+type hyperLogLog struct {
+	registers [1 << hllPrecision]uint8
+}
+
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{}
+}
+
+func (h *hyperLogLog) add(value string) {
+	x := hash64(value)
+	idx := x >> (64 - hllPrecision)
+	// Rank is the position of the first set bit in the remaining bits
+	rank := uint8(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+func (h *hyperLogLog) estimate() int {
+	const m = float64(len(h.registers))
+	alpha := 0.7213 / (1 + 1.079/m)
+
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	e := alpha * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// Linear counting is far more accurate while registers are sparse
+		e = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(e))
+}
+
+// hash64 hashes a value with FNV-1a and a murmur3 finalizer, since HLL
+// needs well-mixed high bits and FNV alone mixes them poorly for short keys.
+func hash64(value string) uint64 {
+	f := fnv.New64a()
+	f.Write([]byte(value))
+	x := f.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// spaceSaving keeps approximate counts for the most frequent values using
+// the space-saving algorithm (Metwally et al., 2005). When full, a new value
+// replaces the least frequent one and inherits its count as the error bound.
+type spaceSaving struct {
+	capacity int
+	entries  map[string]*ssEntry
+	heap     ssHeap // Min-heap by count, for O(log k) eviction
+}
+
+type ssEntry struct {
+	value string
+	count int // Overestimated count
+	err   int // Maximum overestimation
+	index int // Position in the heap
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*ssEntry),
+	}
+}
+
+func (s *spaceSaving) add(value string) {
+	if e, ok := s.entries[value]; ok {
+		e.count++
+		heap.Fix(&s.heap, e.index)
+		return
+	}
+
+	if len(s.heap) < s.capacity {
+		e := &ssEntry{value: value, count: 1}
+		s.entries[value] = e
+		heap.Push(&s.heap, e)
+		return
+	}
+
+	// Replace the least frequent value
+	e := s.heap[0]
+	delete(s.entries, e.value)
+	e.value = value
+	e.err = e.count
+	e.count++
+	s.entries[value] = e
+	heap.Fix(&s.heap, 0)
+}
+
+func (s *spaceSaving) len() int {
+	return len(s.heap)
+}
+
+// top returns the n most frequent values. Counts are guaranteed lower bounds
+// (count minus error), so a value is never reported as more common than it
+// really is.
+func (s *spaceSaving) top(n int) []ValueFrequency {
+	if len(s.heap) == 0 {
+		return nil
+	}
+
+	values := make([]ValueFrequency, 0, len(s.heap))
+	for _, e := range s.heap {
+		values = append(values, ValueFrequency{Value: e.value, Count: e.count - e.err})
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
+}
+
+// ssHeap implements heap.Interface over space-saving entries.
+type ssHeap []*ssEntry
+
+func (h ssHeap) Len() int           { return len(h) }
+func (h ssHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h ssHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ssHeap) Push(x any) {
+	e := x.(*ssEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ssHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,96 @@
+package loganalyzer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000, 100000} {
+		h := newHyperLogLog()
+		for i := 0; i < n; i++ {
+			h.add(fmt.Sprintf("id-%d", i))
+			h.add(fmt.Sprintf("id-%d", i)) // Duplicates must not count
+		}
+
+		got := h.estimate()
+		// Allow 5%, about three standard errors at this precision
+		if math.Abs(float64(got-n)) > float64(n)*0.05 {
+			t.Errorf("estimate for %d distinct values = %d", n, got)
+		}
+	}
+}
+
+func TestSpaceSaving_Top(t *testing.T) {
+	s := newSpaceSaving(20)
+	// Two heavy hitters buried in a stream of unique values
+	for i := 0; i < 5000; i++ {
+		s.add(fmt.Sprintf("unique-%d", i))
+		if i%5 == 0 {
+			s.add("hot")
+		}
+		if i%10 == 0 {
+			s.add("warm")
+		}
+	}
+
+	top := s.top(2)
+	if len(top) != 2 || top[0].Value != "hot" || top[1].Value != "warm" {
+		t.Fatalf("expected hot and warm first, got %+v", top)
+	}
+	// Counts are lower bounds of the true counts (1000 and 500)
+	if top[0].Count > 1000 || top[0].Count < 900 {
+		t.Errorf("expected hot count close to 1000, got %d", top[0].Count)
+	}
+	if top[1].Count > 500 || top[1].Count < 400 {
+		t.Errorf("expected warm count close to 500, got %d", top[1].Count)
+	}
+}
+
+func TestAnalyzeStringWithOptions_Approximate(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&b, `{"id": %d, "status": "%s"}`+"\n", i, []string{"ok", "ok", "ok", "error"}[i%4])
+	}
+
+	exact, err := AnalyzeString(b.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	approx, err := AnalyzeStringWithOptions(b.String(), Options{Approximate: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if exact.Approximate || !approx.Approximate {
+		t.Errorf("expected Approximate to follow the options, got %v and %v", exact.Approximate, approx.Approximate)
+	}
+
+	byPath := func(r *AnalysisResult) map[string]PathSummary {
+		m := make(map[string]PathSummary)
+		for _, p := range r.Paths {
+			m[p.Path] = p
+		}
+		return m
+	}
+	exactPaths, approxPaths := byPath(exact), byPath(approx)
+
+	// High cardinality: estimate within a few percent
+	if got := approxPaths[".id"].DistinctCount; math.Abs(float64(got-20000)) > 1000 {
+		t.Errorf("expected about 20000 distinct ids, got %d", got)
+	}
+	if exactPaths[".id"].DistinctCount != 20000 {
+		t.Errorf("expected exactly 20000 distinct ids, got %d", exactPaths[".id"].DistinctCount)
+	}
+
+	// Low cardinality: the sketch never overflows, so results match exactly
+	e, a := exactPaths[".status"], approxPaths[".status"]
+	if a.DistinctCount != e.DistinctCount {
+		t.Errorf("expected %d distinct statuses, got %d", e.DistinctCount, a.DistinctCount)
+	}
+	if fmt.Sprint(a.TopValues) != fmt.Sprint(e.TopValues) {
+		t.Errorf("expected top values %v, got %v", e.TopValues, a.TopValues)
+	}
+}
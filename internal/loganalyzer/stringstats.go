@@ -0,0 +1,98 @@
+package loganalyzer
+
+import (
+	"maps"
+	"regexp"
+	"unicode/utf8"
+)
+
+// String format buckets reported in StringStats.Formats.
+const (
+	FormatEmpty         = "empty"
+	FormatUUID          = "uuid"
+	FormatEmail         = "email"
+	FormatISODate       = "iso-date"       // RFC 3339 date or date-time
+	FormatNumericString = "numeric-string" // e.g. "42", "-1.5e3"
+)
+
+// StringStats profiles the string values seen at a path.
+type StringStats struct {
+	Count     int            `json:"count"`             // Number of string values
+	MinLength int            `json:"minLength"`         // Shortest length in characters
+	MaxLength int            `json:"maxLength"`         // Longest length in characters
+	AvgLength float64        `json:"avgLength"`         // Mean length in characters
+	Formats   map[string]int `json:"formats,omitempty"` // Format bucket -> occurrences; unrecognised strings aren't bucketed
+
+	totalLength int            // Sum of lengths, for AvgLength
+	pii         map[string]int // PII kind -> values that look like it (see detectPII)
+}
+
+// clone returns a copy of s, or nil if s is nil.
+func (s *StringStats) clone() *StringStats {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.Formats = maps.Clone(s.Formats)
+	c.pii = maps.Clone(s.pii)
+	return &c
+}
+
+// add records one string value. detected is its paths.DetectFormat result,
+// which the caller shares with schema inference so each value is only
+// sniffed once.
+func (s *StringStats) add(value, detected string) {
+	length := utf8.RuneCountInString(value)
+	if s.Count == 0 || length < s.MinLength {
+		s.MinLength = length
+	}
+	if length > s.MaxLength {
+		s.MaxLength = length
+	}
+	s.Count++
+	s.totalLength += length
+	s.AvgLength = float64(s.totalLength) / float64(s.Count)
+
+	format := stringFormat(value, detected)
+	if format != "" {
+		if s.Formats == nil {
+			s.Formats = make(map[string]int)
+		}
+		s.Formats[format]++
+	}
+	if kind := piiKind(value, format); kind != "" {
+		if s.pii == nil {
+			s.pii = make(map[string]int)
+		}
+		s.pii[kind]++
+	}
+}
+
+var numericPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// stringFormat returns the format bucket for a string, or "" if none applies.
+// detected is paths.DetectFormat(s): besides the buckets above, other formats
+// recognised by the schema inference (e.g. "ipv4", "uri") are reported under
+// their JSON Schema names.
+func stringFormat(s, detected string) string {
+	switch {
+	case s == "":
+		return FormatEmpty
+	case detected == "" && isNumericStart(s[0]) && numericPattern.MatchString(s):
+		// Strings with a detected format (dates, IPs...) are never numeric
+		return FormatNumericString
+	}
+
+	switch detected {
+	case "date-time", "date":
+		return FormatISODate
+	default:
+		return detected
+	}
+}
+
+// isNumericStart reports whether c can begin a numeric string, so most text
+// is ruled out without running numericPattern.
+func isNumericStart(c byte) bool {
+	return c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'
+}
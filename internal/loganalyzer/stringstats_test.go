@@ -0,0 +1,69 @@
+package loganalyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/paths"
+)
+
+func TestStringFormat(t *testing.T) {
+	tests := map[string]string{
+		"":                                     FormatEmpty,
+		"42":                                   FormatNumericString,
+		"-1.5e3":                               FormatNumericString,
+		".5":                                   FormatNumericString,
+		"550e8400-e29b-41d4-a716-446655440000": FormatUUID,
+		"alice@example.com":                    FormatEmail,
+		"2024-01-15":                           FormatISODate,
+		"2024-01-15T10:30:00Z":                 FormatISODate,
+		"192.168.0.1":                          "ipv4",
+		"hello world":                          "",
+		"1.2.3":                                "",
+	}
+	for input, want := range tests {
+		if got := stringFormat(input, paths.DetectFormat(input)); got != want {
+			t.Errorf("stringFormat(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestAnalyzeString_StringStats(t *testing.T) {
+	jsonl := `{"id": "550e8400-e29b-41d4-a716-446655440000", "note": "", "n": 1}
+{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "note": "héllo", "n": 2}
+{"id": "42", "note": "hi", "n": 3}`
+
+	result, err := AnalyzeString(jsonl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byPath := make(map[string]PathSummary)
+	for _, p := range result.Paths {
+		byPath[p.Path] = p
+	}
+
+	id := byPath[".id"].Strings
+	if id == nil {
+		t.Fatal("expected string stats for .id")
+	}
+	if id.Count != 3 || id.MinLength != 2 || id.MaxLength != 36 {
+		t.Errorf("unexpected .id stats: %+v", id)
+	}
+	if want := map[string]int{FormatUUID: 2, FormatNumericString: 1}; !reflect.DeepEqual(id.Formats, want) {
+		t.Errorf("expected .id formats %v, got %v", want, id.Formats)
+	}
+
+	// Lengths count characters, not bytes
+	note := byPath[".note"].Strings
+	if note.MinLength != 0 || note.MaxLength != 5 || note.AvgLength != 7.0/3 {
+		t.Errorf("unexpected .note stats: %+v", note)
+	}
+	if want := map[string]int{FormatEmpty: 1}; !reflect.DeepEqual(note.Formats, want) {
+		t.Errorf("expected .note formats %v, got %v", want, note.Formats)
+	}
+
+	if byPath[".n"].Strings != nil {
+		t.Errorf("expected no string stats for a numeric path, got %+v", byPath[".n"].Strings)
+	}
+}
@@ -0,0 +1,105 @@
+package loganalyzer
+
+import (
+	"math"
+	"sort"
+)
+
+// A value's share of a path's occurrences has to at least double or halve,
+// and move by at least minValueShift percentage points, to be reported as
+// changed. The floor keeps rare values (1 vs 2 occurrences) out of the list.
+const (
+	valueShiftFactor = 2.0
+	minValueShift    = 5.0
+)
+
+// ValueChange is a notable difference in one value of a path between two
+// analyses. Status is added for a value that newly appeared, removed for one
+// that disappeared and changed for one whose frequency moved sharply.
+type ValueChange struct {
+	Value      string           `json:"value"`
+	Status     ComparisonStatus `json:"status"`
+	LeftCount  int              `json:"leftCount"`
+	RightCount int              `json:"rightCount"`
+	LeftShare  float64          `json:"leftShare"`  // Percent of the path's left occurrences
+	RightShare float64          `json:"rightShare"` // Percent of the path's right occurrences
+}
+
+// diffTopValues compares the TopValues of a path present on both sides.
+//
+// TopValues only holds the most frequent values, so a value missing from one
+// side is only reported as added or removed when that side's list is
+// complete (it holds every distinct value). Otherwise the value may just
+// have fallen below the top N, and only values listed on both sides are
+// checked for frequency changes. Shares are relative to each side's Count,
+// so runs of different volume compare fairly.
+func diffTopValues(left, right PathSummary) []ValueChange {
+	leftCounts := valueCounts(left.TopValues)
+	rightCounts := valueCounts(right.TopValues)
+	leftComplete := len(left.TopValues) >= left.DistinctCount
+	rightComplete := len(right.TopValues) >= right.DistinctCount
+
+	var changes []ValueChange
+	add := func(value string, status ComparisonStatus) {
+		changes = append(changes, ValueChange{
+			Value:      value,
+			Status:     status,
+			LeftCount:  leftCounts[value],
+			RightCount: rightCounts[value],
+			LeftShare:  share(leftCounts[value], left.Count),
+			RightShare: share(rightCounts[value], right.Count),
+		})
+	}
+
+	for value, leftCount := range leftCounts {
+		rightCount, ok := rightCounts[value]
+		if !ok {
+			if rightComplete {
+				add(value, StatusRemoved)
+			}
+			continue
+		}
+		if shifted(share(leftCount, left.Count), share(rightCount, right.Count)) {
+			add(value, StatusChanged)
+		}
+	}
+	for value := range rightCounts {
+		if _, ok := leftCounts[value]; !ok && leftComplete {
+			add(value, StatusAdded)
+		}
+	}
+
+	// New values first, then vanished ones, then shifts
+	order := map[ComparisonStatus]int{StatusAdded: 0, StatusRemoved: 1, StatusChanged: 2}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Status != changes[j].Status {
+			return order[changes[i].Status] < order[changes[j].Status]
+		}
+		return changes[i].Value < changes[j].Value
+	})
+	return changes
+}
+
+func valueCounts(values []ValueFrequency) map[string]int {
+	counts := make(map[string]int, len(values))
+	for _, v := range values {
+		counts[v.Value] = v.Count
+	}
+	return counts
+}
+
+// share returns count as a percentage of total.
+func share(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total) * 100
+}
+
+// shifted reports whether a value's share moved enough to be worth showing.
+func shifted(left, right float64) bool {
+	if math.Abs(right-left) < minValueShift {
+		return false
+	}
+	return right >= left*valueShiftFactor || right*valueShiftFactor <= left
+}
@@ -0,0 +1,70 @@
+package loganalyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffTopValues(t *testing.T) {
+	left := PathSummary{
+		Path: ".record.status", Count: 100, DistinctCount: 3,
+		TopValues: []ValueFrequency{{"active", 60}, {"pending", 30}, {"closed", 10}},
+	}
+	right := PathSummary{
+		Path: ".record.status", Count: 200, DistinctCount: 3,
+		TopValues: []ValueFrequency{{"active", 118}, {"closed", 62}, {"cancelled", 20}},
+	}
+
+	want := []ValueChange{
+		{Value: "cancelled", Status: StatusAdded, RightCount: 20, RightShare: 10},
+		{Value: "pending", Status: StatusRemoved, LeftCount: 30, LeftShare: 30},
+		{Value: "closed", Status: StatusChanged, LeftCount: 10, RightCount: 62, LeftShare: 10, RightShare: 31},
+	}
+	if got := diffTopValues(left, right); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDiffTopValues_IncompleteLists(t *testing.T) {
+	// Both sides have more distinct values than they list, so a value
+	// missing from one list may still be there
+	left := PathSummary{
+		Count: 100, DistinctCount: 50,
+		TopValues: []ValueFrequency{{"a", 30}, {"b", 20}},
+	}
+	right := PathSummary{
+		Count: 100, DistinctCount: 50,
+		TopValues: []ValueFrequency{{"a", 31}, {"c", 20}},
+	}
+	if got := diffTopValues(left, right); len(got) != 0 {
+		t.Errorf("expected no changes, got %+v", got)
+	}
+}
+
+func TestDiffTopValues_SmallShifts(t *testing.T) {
+	// Doubling from 1 to 2 occurrences out of 100 is noise
+	left := PathSummary{Count: 100, DistinctCount: 2, TopValues: []ValueFrequency{{"x", 99}, {"y", 1}}}
+	right := PathSummary{Count: 100, DistinctCount: 2, TopValues: []ValueFrequency{{"x", 98}, {"y", 2}}}
+	if got := diffTopValues(left, right); len(got) != 0 {
+		t.Errorf("expected no changes, got %+v", got)
+	}
+}
+
+func TestCompareAnalyses_ValueChangesMarkPathChanged(t *testing.T) {
+	left, _ := AnalyzeString(`{"status": "active"}
+{"status": "pending"}`)
+	right, _ := AnalyzeString(`{"status": "active"}
+{"status": "cancelled"}`)
+
+	result := CompareAnalyses(left, right, "", "")
+	if len(result.Comparisons) != 1 {
+		t.Fatalf("expected 1 comparison, got %d", len(result.Comparisons))
+	}
+	c := result.Comparisons[0]
+	if c.Status != StatusChanged {
+		t.Errorf("expected changed despite equal counts, got %s", c.Status)
+	}
+	if len(c.ValueChanges) != 2 || c.ValueChanges[0].Value != "cancelled" || c.ValueChanges[1].Value != "pending" {
+		t.Errorf("expected cancelled added and pending removed, got %+v", c.ValueChanges)
+	}
+}
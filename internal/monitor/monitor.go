@@ -0,0 +1,163 @@
+// Package monitor re-runs saved comparisons on a schedule and records when
+// their differences change, so jtool can watch two endpoints (or files)
+// that are expected to agree, like a lightweight contract monitor.
+//
+// The package schedules jobs and judges their results; fetching, parsing
+// and normalizing the documents is left to the caller's CompareFunc, so
+// jobs are compared exactly as the app compares panes.
+package monitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"jtool/internal/diff"
+)
+
+// MinInterval is the shortest interval a job may run at.
+const MinInterval = time.Minute
+
+// Source is one side of a monitored comparison: a URL or a local file.
+type Source struct {
+	URL  string `json:"url,omitempty"`  // http(s), s3:// or gs:// URL
+	File string `json:"file,omitempty"` // Path of a local file
+}
+
+// String returns the URL or file of s.
+func (s Source) String() string {
+	if s.URL != "" {
+		return s.URL
+	}
+	return s.File
+}
+
+// Job is a comparison to re-run on a schedule.
+type Job struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Left     Source `json:"left"`
+	Right    Source `json:"right"`
+	Interval string `json:"interval"` // e.g. "15m", "@hourly", "@every 90s" (see ParseInterval)
+	Paused   bool   `json:"paused"`   // Kept but not run on schedule
+
+	// Options configures the comparison. They are kept as raw JSON so this
+	// package doesn't depend on the app's option types; the CompareFunc
+	// reads them.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+// Validate checks that job can be scheduled.
+func (job Job) Validate() error {
+	if (job.Left.URL == "") == (job.Left.File == "") {
+		return fmt.Errorf("left side must have either a URL or a file")
+	}
+	if (job.Right.URL == "") == (job.Right.File == "") {
+		return fmt.Errorf("right side must have either a URL or a file")
+	}
+	_, err := ParseInterval(job.Interval)
+	return err
+}
+
+// CompareFunc compares the two sides of job, with ignore rules applied.
+type CompareFunc func(ctx context.Context, job Job) (*diff.DiffResult, error)
+
+// Run is the outcome of comparing a job once.
+type Run struct {
+	Time        time.Time      `json:"time"`
+	Error       string         `json:"error,omitempty"` // Why the comparison failed, if it did
+	Stats       diff.DiffStats `json:"stats"`
+	Differences int            `json:"differences"` // Added, removed and changed values
+
+	// Fingerprint identifies the differences found, so runs finding the
+	// same differences can be told apart from runs finding new ones.
+	// Changed is set when they differ from the previous run's.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Changed     bool   `json:"changed"`
+
+	// The diff, kept only for runs whose differences Changed so stored
+	// history stays small
+	Result *diff.DiffResult `json:"result,omitempty"`
+}
+
+// Check compares job and returns the run. previous is the job's last run
+// that didn't fail, or nil if there is none: the run is Changed if its
+// differences aren't the same as previous's. The first run is Changed only
+// if it finds differences, and a failed run is never Changed.
+func Check(ctx context.Context, job Job, compare CompareFunc, previous *Run) Run {
+	run := Run{Time: time.Now()}
+	result, err := compare(ctx, job)
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	run.Stats = result.Stats
+	run.Differences = result.Stats.Added + result.Stats.Removed + result.Stats.Changed
+	if run.Differences > 0 {
+		run.Fingerprint = Fingerprint(result)
+	}
+	if previous != nil {
+		run.Changed = run.Fingerprint != previous.Fingerprint
+	} else {
+		run.Changed = run.Differences > 0
+	}
+	if run.Changed {
+		run.Result = result
+	}
+	return run
+}
+
+// Fingerprint returns a hash of the differences in result: the path, type
+// and values of every differing leaf. Equal and ignored values don't count.
+func Fingerprint(result *diff.DiffResult) string {
+	h := sha256.New()
+	var walk func(node *diff.DiffNode)
+	walk = func(node *diff.DiffNode) {
+		if node.Type == diff.DiffEqual {
+			return
+		}
+		if len(node.Children) == 0 {
+			left, _ := json.Marshal(node.Left)
+			right, _ := json.Marshal(node.Right)
+			fmt.Fprintf(h, "%s\x00%s\x00%s%s\x00%s%s\n", node.Type, node.Path, left, node.LeftText, right, node.RightText)
+		}
+		for i := range node.Children {
+			walk(&node.Children[i])
+		}
+	}
+	walk(&result.Root)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// ParseInterval parses how often a job runs: a Go duration ("30m",
+// "1h30m"), "@every" and a duration, or one of "@hourly", "@daily" and
+// "@weekly". Intervals under MinInterval are rejected.
+func ParseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var every time.Duration
+	switch s {
+	case "":
+		return 0, fmt.Errorf("no interval given")
+	case "@hourly":
+		every = time.Hour
+	case "@daily":
+		every = 24 * time.Hour
+	case "@weekly":
+		every = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, "@every")))
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %q: want a duration like \"15m\", \"@every 2h\", \"@hourly\", \"@daily\" or \"@weekly\"", s)
+		}
+		every = d
+	}
+	if every < MinInterval {
+		return 0, fmt.Errorf("interval %s is too short: the minimum is %s", every, MinInterval)
+	}
+	return every, nil
+}
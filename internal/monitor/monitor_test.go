@@ -0,0 +1,114 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"jtool/internal/diff"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := map[string]time.Duration{
+		"15m":         15 * time.Minute,
+		"1h30m":       90 * time.Minute,
+		"@every 2h":   2 * time.Hour,
+		" @hourly ":   time.Hour,
+		"@daily":      24 * time.Hour,
+		"@weekly":     7 * 24 * time.Hour,
+		"@every 1m0s": time.Minute,
+	}
+	for input, expected := range tests {
+		got, err := ParseInterval(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("%q: expected %s, got %s", input, expected, got)
+		}
+	}
+
+	for _, bad := range []string{"", "often", "30s", "@every", "*/5 * * * *"} {
+		if _, err := ParseInterval(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestJobValidate(t *testing.T) {
+	job := Job{Left: Source{URL: "https://a.example.com/v1"}, Right: Source{File: "/tmp/b.json"}, Interval: "@hourly"}
+	if err := job.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	noRight := job
+	noRight.Right = Source{}
+	both := job
+	both.Left.File = "/tmp/a.json"
+	badInterval := job
+	badInterval.Interval = "soon"
+	for name, bad := range map[string]Job{"no right": noRight, "both": both, "bad interval": badInterval} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	job := Job{ID: "users", Left: Source{URL: "https://a.example.com"}, Right: Source{URL: "https://b.example.com"}, Interval: "@hourly"}
+	right := map[string]any{"id": 1.0, "name": "Ada"}
+	compare := func(ctx context.Context, job Job) (*diff.DiffResult, error) {
+		if right == nil {
+			return nil, errors.New("connection refused")
+		}
+		return diff.Compare(map[string]any{"id": 1.0, "name": "Ada"}, right), nil
+	}
+
+	first := Check(context.Background(), job, compare, nil)
+	if first.Error != "" || first.Differences != 0 || first.Changed || first.Result != nil {
+		t.Errorf("expected an unchanged run without differences, got %+v", first)
+	}
+
+	right = map[string]any{"id": 1.0, "name": "Grace"}
+	second := Check(context.Background(), job, compare, &first)
+	if second.Differences != 1 || !second.Changed || second.Result == nil || second.Fingerprint == "" {
+		t.Errorf("expected a changed run with one difference, got %+v", second)
+	}
+
+	third := Check(context.Background(), job, compare, &second)
+	if third.Changed || third.Result != nil || third.Fingerprint != second.Fingerprint {
+		t.Errorf("expected the same differences to be unchanged, got %+v", third)
+	}
+
+	right = map[string]any{"id": 1.0, "name": "Linus"}
+	fourth := Check(context.Background(), job, compare, &third)
+	if !fourth.Changed || fourth.Fingerprint == third.Fingerprint {
+		t.Errorf("expected new differences to be changed, got %+v", fourth)
+	}
+
+	right = nil
+	failed := Check(context.Background(), job, compare, &fourth)
+	if failed.Error != "connection refused" || failed.Changed {
+		t.Errorf("expected an unchanged failed run, got %+v", failed)
+	}
+
+	first = Check(context.Background(), job, compare, nil)
+	if first.Changed {
+		t.Errorf("expected a first failed run to be unchanged, got %+v", first)
+	}
+}
+
+func TestFingerprintIgnoresEqualValues(t *testing.T) {
+	a := diff.Compare(map[string]any{"x": 1.0, "y": "same"}, map[string]any{"x": 2.0, "y": "same"})
+	b := diff.Compare(map[string]any{"x": 1.0, "y": "other"}, map[string]any{"x": 2.0, "y": "other"})
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("expected equal values not to affect the fingerprint")
+	}
+
+	c := diff.Compare(map[string]any{"x": 1.0}, map[string]any{"x": 3.0})
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Error("expected different values to change the fingerprint")
+	}
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleWait is how long Start sleeps when no job is scheduled. Set and
+// Remove wake it early.
+const idleWait = time.Hour
+
+// Scheduler runs jobs at their intervals. Jobs run one at a time, so a
+// slow endpoint delays the others rather than piling up requests.
+type Scheduler struct {
+	run  func(ctx context.Context, job Job)
+	mu   sync.Mutex
+	jobs map[string]*scheduled // Job ID -> job
+	wake chan struct{}         // Signals Start that the schedule changed
+}
+
+// scheduled is a job with its parsed interval and next run time.
+type scheduled struct {
+	job   Job
+	every time.Duration
+	next  time.Time
+}
+
+// NewScheduler creates a scheduler that calls run for each job that is due.
+func NewScheduler(run func(ctx context.Context, job Job)) *Scheduler {
+	return &Scheduler{
+		run:  run,
+		jobs: make(map[string]*scheduled),
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Set adds job, or replaces the job with the same ID. It first runs one
+// interval from now.
+func (s *Scheduler) Set(job Job) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	every, _ := ParseInterval(job.Interval)
+
+	s.mu.Lock()
+	s.jobs[job.ID] = &scheduled{job: job, every: every, next: time.Now().Add(every)}
+	s.mu.Unlock()
+	s.poke()
+	return nil
+}
+
+// Remove stops running the job with the given ID.
+func (s *Scheduler) Remove(id string) {
+	s.mu.Lock()
+	delete(s.jobs, id)
+	s.mu.Unlock()
+	s.poke()
+}
+
+// Start runs jobs as they come due until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for {
+		due, wait := s.due(time.Now())
+		for _, job := range due {
+			if ctx.Err() != nil {
+				return
+			}
+			s.run(ctx, job)
+		}
+		if len(due) > 0 {
+			continue // Running may have taken long enough for more to come due
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// due returns the jobs to run at now, scheduling their next runs, and how
+// long to wait for the next job otherwise. Next runs are counted from now
+// rather than from when the job was due, so waking from sleep doesn't run
+// a job several times to catch up.
+func (s *Scheduler) due(now time.Time) ([]Job, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var jobs []Job
+	wait := idleWait
+	for _, entry := range s.jobs {
+		if entry.job.Paused {
+			continue
+		}
+		if !entry.next.After(now) {
+			jobs = append(jobs, entry.job)
+			entry.next = now.Add(entry.every)
+		}
+		if until := entry.next.Sub(now); until < wait {
+			wait = until
+		}
+	}
+	return jobs, wait
+}
+
+// poke wakes Start to recalculate its wait.
+func (s *Scheduler) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // Already signalled
+	}
+}
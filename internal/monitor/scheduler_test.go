@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSchedulerDue(t *testing.T) {
+	s := NewScheduler(func(ctx context.Context, job Job) {})
+	source := Source{URL: "https://example.com"}
+	for _, job := range []Job{
+		{ID: "hourly", Left: source, Right: source, Interval: "@hourly"},
+		{ID: "daily", Left: source, Right: source, Interval: "@daily"},
+		{ID: "paused", Left: source, Right: source, Interval: "1m", Paused: true},
+	} {
+		if err := s.Set(job); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.Set(Job{ID: "bad", Left: source, Right: source, Interval: "1s"}); err == nil {
+		t.Error("expected an error for a too short interval")
+	}
+
+	start := time.Now()
+	if jobs, wait := s.due(start); len(jobs) != 0 || wait > time.Hour || wait < 59*time.Minute {
+		t.Errorf("expected nothing due for an hour, got %v and %s", jobs, wait)
+	}
+
+	// Several missed runs run once
+	later := start.Add(5*time.Hour + time.Second)
+	jobs, wait := s.due(later)
+	if len(jobs) != 1 || jobs[0].ID != "hourly" || wait != time.Hour {
+		t.Errorf("expected the hourly job then an hour's wait, got %v and %s", jobs, wait)
+	}
+
+	s.Remove("hourly")
+	jobs, wait = s.due(start.Add(25 * time.Hour))
+	if len(jobs) != 1 || jobs[0].ID != "daily" || wait != idleWait {
+		t.Errorf("expected the daily job then the idle wait, got %v and %s", jobs, wait)
+	}
+}
+
+func TestSchedulerStart(t *testing.T) {
+	ran := make(chan string, 1)
+	s := NewScheduler(func(ctx context.Context, job Job) { ran <- job.ID })
+	source := Source{File: "a.json"}
+	if err := s.Set(Job{ID: "soon", Left: source, Right: source, Interval: "1m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.jobs["soon"].next = time.Now().Add(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case id := <-ran:
+		if id != "soon" {
+			t.Errorf("expected job soon to run, got %s", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job didn't run")
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start didn't return after cancel")
+	}
+}
@@ -0,0 +1,109 @@
+package normalize
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// coerceString converts a string to another primitive type according to
+// the coercion options. ok is false when the string should stay a string.
+//
+// Examples (with all coercions enabled and NullStrings ["N/A", ""]):
+//
+//	"true"  → true
+//	"False" → false
+//	"42"    → json.Number("42")
+//	"1.234,56" → json.Number("1234.56") (with LocaleNumbers set for German)
+//	"N/A"   → nil
+//	"hello" → nil, false (the caller keeps the string)
+func coerceString(s string, opts Options) (v any, ok bool) {
+	for _, nullString := range opts.NullStrings {
+		if s == nullString {
+			return nil, true
+		}
+	}
+
+	if opts.CoerceBooleans {
+		switch strings.ToLower(s) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+
+	// Locale numbers come before CoerceNumbers: with "." as the thousands
+	// separator, "1.234" means 1234, not 1.234
+	if opts.LocaleNumbers.DecimalSeparator != "" {
+		if n, ok := parseLocaleNumber(s, opts.LocaleNumbers); ok {
+			return n, true
+		}
+	}
+
+	if opts.CoerceNumbers && isJSONNumber(s) {
+		return json.Number(s), true
+	}
+
+	return nil, false
+}
+
+// isJSONNumber reports whether s is a number literal as defined by the JSON
+// grammar: optional minus, integer part without leading zeros, optional
+// fraction and exponent. Strings like "0x10", "+1", "1." or "NaN" are not.
+func isJSONNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	i := 0
+	if s[i] == '-' {
+		i++
+	}
+
+	// Integer part
+	if i >= len(s) {
+		return false
+	}
+	if s[i] == '0' {
+		i++
+	} else if s[i] >= '1' && s[i] <= '9' {
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+	} else {
+		return false
+	}
+
+	// Fraction
+	if i < len(s) && s[i] == '.' {
+		i++
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+
+	// Exponent
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+
+	return i == len(s)
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,89 @@
+package normalize
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCoercion(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "booleans",
+			input:    `{"a": "true", "b": "FALSE", "c": "yes"}`,
+			opts:     Options{CoerceBooleans: true},
+			expected: `{"a":true,"b":false,"c":"yes"}`,
+		},
+		{
+			name:     "null strings",
+			input:    `{"a": "N/A", "b": "", "c": "null", "d": "n/a"}`,
+			opts:     Options{NullStrings: []string{"null", "N/A", ""}},
+			expected: `{"a":null,"b":null,"c":null,"d":"n/a"}`,
+		},
+		{
+			name:     "null strings after trimming",
+			input:    `{"a": "  N/A "}`,
+			opts:     Options{TrimStrings: true, NullStrings: []string{"N/A"}},
+			expected: `{"a":null}`,
+		},
+		{
+			name:     "coerced nulls removed with NullEqualsAbsent",
+			input:    `{"a": "", "b": 1}`,
+			opts:     Options{NullEqualsAbsent: true, NullStrings: []string{""}},
+			expected: `{"b":1}`,
+		},
+		{
+			name:     "numbers",
+			input:    `{"a": "42", "b": "1.50", "c": "0x10", "d": "1e3"}`,
+			opts:     Options{CoerceNumbers: true},
+			expected: `{"a":42,"b":1.50,"c":"0x10","d":1e3}`,
+		},
+		{
+			name:     "numbers normalized after coercion",
+			input:    `{"a": "1.50", "b": "1e3"}`,
+			opts:     Options{CoerceNumbers: true, NormalizeNumbers: true},
+			expected: `{"a":1.5,"b":1000}`,
+		},
+		{
+			name:  "scoped to a path with overrides",
+			input: `{"csv": {"active": "true"}, "api": {"active": "true"}}`,
+			opts: Options{
+				Overrides: []Override{{Path: ".csv.**", Options: Options{CoerceBooleans: true}}},
+			},
+			expected: `{"api":{"active":"true"},"csv":{"active":true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, tt.opts)
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
+
+func TestIsJSONNumber(t *testing.T) {
+	tests := map[string]bool{
+		"0": true, "-0": true, "42": true, "1.5": true, "1e3": true, "-1.2E-3": true,
+		"": false, "-": false, "01": false, "+1": false, "1.": false, ".5": false,
+		"1e": false, "0x10": false, "NaN": false, "1,000": false, " 1": false,
+	}
+	for input, want := range tests {
+		if got := isJSONNumber(input); got != want {
+			t.Errorf("isJSONNumber(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package normalize
+
+import "time"
+
+// normalizeDate rewrites s in the canonical output layout if it parses
+// with one of the input layouts. Otherwise s is returned unchanged.
+//
+// Example with InputLayouts ["01/02/2006", "2006-01-02"] and OutputLayout "2006-01-02":
+//
+//	"01/02/2024" → "2024-01-02"
+//	"2024-01-02" → "2024-01-02"
+//	"not a date" → "not a date"
+func normalizeDate(s string, opts DateOptions) string {
+	output := opts.OutputLayout
+	if output == "" {
+		output = time.RFC3339
+	}
+
+	for _, layout := range opts.InputLayouts {
+		// time.Parse is strict about the whole string matching the layout,
+		// so ordinary text is never mistaken for a date
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(output)
+		}
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+// fuzzSeeds are starting inputs for the fuzzers, chosen to reach tricky
+// corners: huge and tiny exponents, big integers, deep nesting, and strings
+// that coercion or date parsing might pick up.
+var fuzzSeeds = []string{
+	`{"a": 1, "b": [3, 1, 2], "c": {"d": null}}`,
+	`[1e400, -1e-400, 9007199254740993, 1.0, 1e3, -0, 0.1e1]`,
+	`[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[1]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]]`,
+	`{"s": "  padded  ", "n": "1.50", "b": "TRUE", "x": "N/A", "e": ""}`,
+	`[{"id": 2, "v": "x"}, {"id": 1}, {"v": [null]}, 5, "str", null, true]`,
+	`{"url": "HTTPS://Example.com:443/?b=1&a=2", "uuid": "{3F2504E0-4F89-11D3-9A0C-0305E82C3301}"}`,
+	`"\u00e9\u0065\u0301"`,
+	`null`,
+}
+
+// fuzzOptions are the option sets each fuzz input is normalized with.
+var fuzzOptions = []Options{
+	DefaultOptions(),
+	{
+		SortKeys:         true,
+		NormalizeNumbers: true,
+		RoundNumbers:     3,
+		TrimStrings:      true,
+		NullEqualsAbsent: true,
+		SortArrays:       true,
+		CoerceBooleans:   true,
+		CoerceNumbers:    true,
+		NullStrings:      []string{"", "N/A"},
+		NormalizeURLs:    URLOptions{Enabled: true},
+		NormalizeUUIDs:   true,
+
+		UnicodeNormalization: UnicodeNFC,
+	},
+	{SortKeys: true, SortArraysByKey: "id desc, v"},
+}
+
+// FuzzNormalize checks that normalization never panics and is idempotent:
+// normalizing an already-normalized value must not change it again.
+func FuzzNormalize(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		data, err := parser.ParseString(input)
+		if err != nil {
+			return // Only valid JSON is interesting
+		}
+
+		for i, opts := range fuzzOptions {
+			once := Value(data, opts)
+			twice := Value(once, opts)
+			if !reflect.DeepEqual(once, twice) {
+				t.Fatalf("options %d: normalization is not idempotent for %q:\nonce:  %#v\ntwice: %#v", i, input, once, twice)
+			}
+		}
+	})
+}
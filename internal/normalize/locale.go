@@ -0,0 +1,94 @@
+package normalize
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// parseLocaleNumber parses a number written with locale separators and
+// returns it as a plain JSON number. ok is false if s is not such a number.
+//
+// Examples with DecimalSeparator "," and ThousandsSeparator ".":
+//
+//	"1.234,56"  → "1234.56"
+//	"-1234,5"   → "-1234.5" (grouping is optional)
+//	"1.234.567" → "1234567"
+//	"02134"     → not a number (no separator; see below)
+//	"12.34"     → not a number (groups after the first must have 3 digits)
+//	"1,2,3"     → not a number
+//
+// Strings of plain digits are left alone: they are as often zip codes, IDs
+// or account numbers as quantities, and CoerceNumbers decides whether
+// those become numbers.
+func parseLocaleNumber(s string, opts LocaleNumberOptions) (json.Number, bool) {
+	if opts.ThousandsSeparator == " " {
+		// Many locales group with a no-break or narrow no-break space
+		s = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign = "-"
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, opts.DecimalSeparator)
+	if hasFrac && (fracPart == "" || !allDigits(fracPart)) {
+		return "", false
+	}
+	if !hasFrac && (opts.ThousandsSeparator == "" || !strings.Contains(intPart, opts.ThousandsSeparator)) {
+		return "", false
+	}
+
+	digits, ok := joinGroups(intPart, opts.ThousandsSeparator)
+	if !ok {
+		return "", false
+	}
+
+	// Drop leading zeros so the result is valid JSON ("007,5" → "7.5")
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	if hasFrac {
+		return json.Number(sign + digits + "." + fracPart), true
+	}
+	return json.Number(sign + digits), true
+}
+
+// joinGroups validates the integer part of a locale number and removes the
+// thousands separators. The first group has 1-3 digits and every later
+// group exactly 3; without separators any run of digits is accepted.
+func joinGroups(s, sep string) (string, bool) {
+	if s == "" {
+		return "", false
+	}
+	if sep == "" || !strings.Contains(s, sep) {
+		return s, allDigits(s)
+	}
+
+	groups := strings.Split(s, sep)
+	if len(groups[0]) == 0 || len(groups[0]) > 3 || !allDigits(groups[0]) {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 || !allDigits(g) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// allDigits reports whether s is non-empty and only ASCII digits.
+func allDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package normalize
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseLocaleNumber(t *testing.T) {
+	german := LocaleNumberOptions{DecimalSeparator: ",", ThousandsSeparator: "."}
+	french := LocaleNumberOptions{DecimalSeparator: ",", ThousandsSeparator: " "}
+	swiss := LocaleNumberOptions{DecimalSeparator: ".", ThousandsSeparator: "'"}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     LocaleNumberOptions
+		expected string // "" means not a number
+	}{
+		{"german decimal", "1.234,56", german, "1234.56"},
+		{"german integer", "1.234.567", german, "1234567"},
+		{"german negative", "-0,5", german, "-0.5"},
+		{"grouping optional", "1234,5", german, "1234.5"},
+		{"leading zeros dropped", "007,5", german, "7.5"},
+		{"plain digits", "42", german, ""},
+		{"zip code", "02134", german, ""},
+		{"french spaces", "1 234,56", french, "1234.56"},
+		{"french no-break space", "1\u00a0234,56", french, "1234.56"},
+		{"swiss apostrophe", "1'234.56", swiss, "1234.56"},
+		{"bad group size", "12.34", german, ""},
+		{"empty first group", ".234", german, ""},
+		{"two decimal separators", "1,2,3", german, ""},
+		{"empty fraction", "1,", german, ""},
+		{"text", "abc", german, ""},
+		{"empty", "", german, ""},
+		{"sign only", "-", german, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, ok := parseLocaleNumber(tt.input, tt.opts)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("parseLocaleNumber(%q) = %q, expected not a number", tt.input, n)
+				}
+				return
+			}
+			if !ok || string(n) != tt.expected {
+				t.Errorf("parseLocaleNumber(%q) = %q, %v; want %q", tt.input, n, ok, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocaleNumbersOption(t *testing.T) {
+	opts := Options{
+		NormalizeNumbers: true,
+		LocaleNumbers:    LocaleNumberOptions{DecimalSeparator: ",", ThousandsSeparator: "."},
+	}
+
+	erp := Value(map[string]any{"total": "1.234,50"}, opts)
+	api := Value(map[string]any{"total": json.Number("1234.5")}, opts)
+
+	erpJSON, _ := json.Marshal(erp)
+	apiJSON, _ := json.Marshal(api)
+	if string(erpJSON) != string(apiJSON) {
+		t.Errorf("expected equal after normalization, got %s and %s", erpJSON, apiJSON)
+	}
+}
+
+func TestLocaleNumbersLeavesPlainDigits(t *testing.T) {
+	opts := Options{LocaleNumbers: LocaleNumberOptions{DecimalSeparator: ",", ThousandsSeparator: "."}}
+
+	got := Value(map[string]any{"zip": "02134", "total": "1.234"}, opts)
+	want := map[string]any{"zip": "02134", "total": json.Number("1234")}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("Value() = %s, want %s", gotJSON, wantJSON)
+	}
+
+	opts.CoerceNumbers = true
+	got = Value(map[string]any{"count": "42"}, opts)
+	if n, _ := got.(map[string]any)["count"].(json.Number); n != "42" {
+		t.Errorf("with CoerceNumbers, count = %#v, want json.Number(\"42\")", got.(map[string]any)["count"])
+	}
+}
@@ -0,0 +1,107 @@
+package normalize
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Elements whose content is never visible text
+	htmlInvisible = regexp.MustCompile(`(?is)<(script|style|head|template)\b[^>]*>.*?</(script|style|head|template)\s*>`)
+	htmlComment   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// Any tag, including attributes that contain ">" inside quotes
+	htmlTag = regexp.MustCompile(`(?s)</?[a-zA-Z!][^>"']*(?:(?:"[^"]*"|'[^']*')[^>"']*)*>`)
+	// Tags that separate words when rendered (<p>a</p><p>b</p> reads "a b")
+	htmlBlockTag = regexp.MustCompile(`(?i)</?(p|div|br|li|ul|ol|tr|td|th|table|h[1-6]|section|article|header|footer|blockquote|pre|hr)\b[^>]*>`)
+)
+
+// stripHTML reduces an HTML fragment to its visible text: tags and
+// comments are removed, entities are decoded and whitespace is collapsed.
+// This is a lightweight text extraction for comparing CMS payloads, not a
+// full HTML parser.
+//
+// Examples:
+//
+//	"<p>Hello <b>world</b></p>"       → "Hello world"
+//	"<div>a</div><div>b</div>"       → "a b"
+//	"Fish &amp; chips<br/>"          → "Fish & chips"
+//	"<script>alert(1)</script>Text"  → "Text"
+func stripHTML(s string) string {
+	s = htmlInvisible.ReplaceAllString(s, " ")
+	s = htmlComment.ReplaceAllString(s, "")
+	s = htmlBlockTag.ReplaceAllString(s, " ")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return collapseSpace(s)
+}
+
+var (
+	mdCodeFence  = regexp.MustCompile("(?m)^\\s*(```|~~~).*$")
+	mdImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdRefLink    = regexp.MustCompile(`\[([^\]]*)\]\[[^\]]*\]`)
+	mdLinkDef    = regexp.MustCompile(`(?m)^\s*\[[^\]]+\]:\s+\S+.*$`)
+	mdAutolink   = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdHeading    = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	mdBlockquote = regexp.MustCompile(`(?m)^\s*(>\s?)+`)
+	mdListMarker = regexp.MustCompile(`(?m)^\s*([-*+]|\d+[.)])\s+`)
+	mdRule       = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	mdEmphasis   = regexp.MustCompile(`(\*\*|\*|~~)([^\s*~](?:.*?[^\s*~])?)(\*\*|\*|~~)`)
+	// Underscores only count at word boundaries, so snake_case_names survive
+	mdUnderscore = regexp.MustCompile(`(^|\W)(__|_)([^\s_](?:.*?[^\s_])?)(__|_)(\W|$)`)
+	mdCode       = regexp.MustCompile("`([^`]*)`")
+)
+
+// stripMarkdown reduces Markdown to plain text: formatting characters,
+// link targets and block markers are removed and whitespace is collapsed.
+// Inline HTML is stripped as well.
+//
+// Examples:
+//
+//	"# Title"                          → "Title"
+//	"Some **bold** and _italic_ text"  → "Some bold and italic text"
+//	"See [the docs](https://x.io)"     → "See the docs"
+//	"- one\n- two"                     → "one two"
+func stripMarkdown(s string) string {
+	s = mdCodeFence.ReplaceAllString(s, "")
+	s = mdImage.ReplaceAllString(s, "$1")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdRefLink.ReplaceAllString(s, "$1")
+	s = mdLinkDef.ReplaceAllString(s, "")
+	s = mdAutolink.ReplaceAllString(s, "$1")
+	s = mdRule.ReplaceAllString(s, "")
+	s = mdHeading.ReplaceAllString(s, "")
+	s = mdBlockquote.ReplaceAllString(s, "")
+	s = mdListMarker.ReplaceAllString(s, "")
+	s = mdCode.ReplaceAllString(s, "$1")
+
+	// Emphasis can nest ("***both***"), so repeat until nothing changes
+	for {
+		next := mdEmphasis.ReplaceAllStringFunc(s, func(m string) string {
+			parts := mdEmphasis.FindStringSubmatch(m)
+			if parts[1] != parts[3] {
+				return m // Mismatched markers like "**a*" aren't emphasis
+			}
+			return parts[2]
+		})
+		next = mdUnderscore.ReplaceAllStringFunc(next, func(m string) string {
+			parts := mdUnderscore.FindStringSubmatch(m)
+			if parts[2] != parts[4] {
+				return m
+			}
+			return parts[1] + parts[3] + parts[5]
+		})
+		if next == s {
+			break
+		}
+		s = next
+	}
+
+	return stripHTML(s)
+}
+
+// collapseSpace trims s and replaces every run of whitespace with one space.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,75 @@
+package normalize
+
+import "testing"
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"inline tags", "<p>Hello <b>world</b></p>", "Hello world"},
+		{"block tags separate words", "<div>a</div><div>b</div>", "a b"},
+		{"line break", "one<br/>two", "one two"},
+		{"entities", "Fish &amp; chips &lt;3 &#169;", "Fish & chips <3 ©"},
+		{"script and style removed", "<style>p{}</style><script>alert(1)</script>Text", "Text"},
+		{"comments removed", "a<!-- hidden -->b", "ab"},
+		{"attributes with >", `<a title="x > y" href="/">link</a>`, "link"},
+		{"whitespace collapsed", "  <p>\n  spaced   out \n</p> ", "spaced out"},
+		{"plain text untouched", "1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripHTML(tt.input); got != tt.expected {
+				t.Errorf("stripHTML(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"heading", "# Title", "Title"},
+		{"emphasis", "Some **bold** and _italic_ and *em* text", "Some bold and italic and em text"},
+		{"nested emphasis", "***both***", "both"},
+		{"strikethrough", "~~old~~ new", "old new"},
+		{"snake_case kept", "call my_var_name now", "call my_var_name now"},
+		{"links", "See [the docs](https://x.io) and ![logo](l.png)", "See the docs and logo"},
+		{"autolink", "<https://x.io/a>", "https://x.io/a"},
+		{"lists", "- one\n- two\n1. three", "one two three"},
+		{"blockquote", "> quoted\n> text", "quoted text"},
+		{"inline code", "run `go test`", "run go test"},
+		{"code fence", "```go\nx := 1\n```", "x := 1"},
+		{"inline html", "a <em>b</em>", "a b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripMarkdown(tt.input); got != tt.expected {
+				t.Errorf("stripMarkdown(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripMarkupTransforms(t *testing.T) {
+	opts := Options{Transforms: []TransformRule{
+		{Path: ".body", Kind: TransformStripHTML},
+		{Path: ".summary", Kind: TransformStripMarkdown},
+	}}
+
+	cms := Value(map[string]any{"body": "<p>Same <i>text</i></p>", "summary": "**Same** text"}, opts)
+	api := Value(map[string]any{"body": "Same text", "summary": "Same text"}, opts)
+
+	cmsMap, apiMap := cms.(map[string]any), api.(map[string]any)
+	for _, key := range []string{"body", "summary"} {
+		if cmsMap[key] != apiMap[key] {
+			t.Errorf("%s: expected %q, got %q", key, apiMap[key], cmsMap[key])
+		}
+	}
+}
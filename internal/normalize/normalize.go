@@ -1,9 +1,15 @@
 package normalize
 
 import (
+	"encoding/json"
 	"math"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+
+	"jtool/internal/parser"
+	"jtool/internal/pathmatch"
 )
 
 // Value normalizes a JSON value according to the given options.
@@ -12,78 +18,245 @@ import (
 // The input should be the result of json.Unmarshal into `any`:
 //   - map[string]any for objects
 //   - []any for arrays
-//   - float64 for numbers
+//   - float64 for numbers (or json.Number when parsed with parser.Parse)
 //   - string for strings
 //   - bool for booleans
 //   - nil for null
 func Value(v any, opts Options) any {
+	w := newWalker(opts)
+	return w.valueAt(v, "", opts)
+}
+
+// walker holds the root-level, path-scoped configuration (overrides and
+// transform rules) that is checked at every path during normalization.
+// It is built once per Value call so regexes are only compiled once.
+type walker struct {
+	overrides    []compiledOverride
+	transforms   []compiledTransform
+	sortPaths    []string          // SortArraysByPath patterns, sorted for deterministic matching
+	sortSpecs    map[string]string // SortArraysByPath
+	excludeKeys  map[string]bool   // ExcludeKeys
+	excludePaths []string          // ExcludePaths
+	redact       []string          // RedactPaths
+	salt         string            // RedactSalt
+	report       *Report           // Set by ValueWithReport, nil otherwise
+}
+
+// newWalker prepares a walker from the root options.
+func newWalker(opts Options) *walker {
+	w := &walker{
+		overrides:    compileOverrides(opts.Overrides),
+		transforms:   compileTransforms(opts.Transforms),
+		sortSpecs:    opts.SortArraysByPath,
+		excludePaths: opts.ExcludePaths,
+		redact:       opts.RedactPaths,
+		salt:         opts.RedactSalt,
+	}
+	if len(opts.ExcludeKeys) > 0 {
+		w.excludeKeys = make(map[string]bool, len(opts.ExcludeKeys))
+		for _, key := range opts.ExcludeKeys {
+			w.excludeKeys[key] = true
+		}
+	}
+	for pattern := range opts.SortArraysByPath {
+		w.sortPaths = append(w.sortPaths, pattern)
+	}
+	sort.Strings(w.sortPaths)
+	return w
+}
+
+// valueAt normalizes v, which lives at path in the document.
+func (w *walker) valueAt(v any, path string, opts Options) any {
+	opts = w.resolveOptions(path, opts)
+
+	var result any
 	switch val := v.(type) {
 	case map[string]any:
-		return normalizeObject(val, opts)
+		result = w.normalizeObject(val, path, opts)
 	case []any:
-		return normalizeArray(val, opts)
+		result = w.normalizeArray(val, path, opts)
 	case float64:
-		return normalizeNumber(val, opts)
+		result = normalizeNumber(val, opts)
+		w.noteNumber(val, result, path, opts)
+	case json.Number:
+		result = normalizeJSONNumber(val, opts)
+		w.noteNumber(val, result, path, opts)
 	case string:
-		return normalizeString(val, opts)
+		result = w.normalizeString(val, path, opts)
+		// Coerced numbers get the usual number normalization
+		if n, ok := result.(json.Number); ok {
+			result = normalizeJSONNumber(n, opts)
+		}
 	case bool, nil:
 		// Booleans and nil don't need normalization
-		return val
+		result = val
 	default:
 		// Unknown type, return as-is
-		return val
+		result = val
 	}
+
+	// Custom transforms run last so they see the normalized value,
+	// followed only by redaction
+	result = w.applyTransforms(result, path)
+	return w.applyRedaction(result, path)
 }
 
 // normalizeObject normalizes a JSON object (map).
 //
 // Go maps are unordered, but when we compare them, we want consistent ordering.
 // This function:
-// 1. Optionally removes null values (if NullEqualsAbsent)
-// 2. Recursively normalizes all values
-// 3. Returns a new map (original is not modified)
+// 1. Unicode-normalizes keys (if UnicodeNormalization)
+// 2. Removes excluded keys (ExcludeKeys, ExcludePaths)
+// 3. Recursively normalizes all values
+// 4. Optionally removes null values (if NullEqualsAbsent)
+// 5. Returns a new map (original is not modified)
 //
 // Note: Key sorting happens during comparison, not here.
 // Go maps don't maintain insertion order, so we sort during iteration.
-func normalizeObject(obj map[string]any, opts Options) map[string]any {
+func (w *walker) normalizeObject(obj map[string]any, path string, opts Options) map[string]any {
 	result := make(map[string]any)
 
+	// Keys are normalized like string values, so "café" spelled with a
+	// combining accent matches the composed spelling. Of keys that become
+	// the same, only one is kept (see keepKey).
+	var keys map[string]string
+	if opts.UnicodeNormalization != UnicodeNone {
+		keys = w.normalizeKeys(obj, path, opts.UnicodeNormalization)
+	}
+
 	for key, val := range obj {
-		// Skip null values if NullEqualsAbsent is enabled
-		if opts.NullEqualsAbsent && val == nil {
+		if keys != nil {
+			nkey, ok := keys[key]
+			if !ok {
+				continue
+			}
+			key = nkey
+		}
+		childPath := path + "." + key
+
+		if w.excluded(key, childPath) {
+			w.note(childPath, ActionExcluded)
 			continue
 		}
 
 		// Recursively normalize the value
-		result[key] = Value(val, opts)
+		normalized := w.valueAt(val, childPath, opts)
+
+		// Skip null values if NullEqualsAbsent is enabled for this key.
+		// Checked after normalizing so coerced nulls ("N/A") are dropped too.
+		if normalized == nil && w.resolveOptions(childPath, opts).NullEqualsAbsent {
+			w.note(childPath, ActionNullRemoved)
+			continue
+		}
+
+		result[key] = normalized
 	}
 
 	return result
 }
 
+// normalizeKeys maps the keys of obj that are kept to their normalized
+// form, noting the ones that change.
+func (w *walker) normalizeKeys(obj map[string]any, path string, form UnicodeForm) map[string]string {
+	keys := make(map[string]string, len(obj))
+	kept := make(map[string]string, len(obj)) // Normalized key → original
+	for key := range obj {
+		nkey := normalizeUnicode(key, form)
+		if prev, ok := kept[nkey]; ok {
+			if !keepKey(key, prev, nkey) {
+				continue
+			}
+			delete(keys, prev)
+		}
+		kept[nkey] = key
+		keys[key] = nkey
+	}
+	for key, nkey := range keys {
+		if key != nkey {
+			w.note(path+"."+nkey, ActionUnicode)
+		}
+	}
+	return keys
+}
+
 // normalizeArray normalizes a JSON array.
 //
 // This function:
 // 1. Recursively normalizes all elements
 // 2. Optionally sorts the array (if SortArrays or SortArraysByKey)
 // 3. Returns a new slice (original is not modified)
-func normalizeArray(arr []any, opts Options) []any {
+func (w *walker) normalizeArray(arr []any, path string, opts Options) []any {
 	// First, normalize all elements
 	result := make([]any, len(arr))
 	for i, val := range arr {
-		result[i] = Value(val, opts)
+		childPath := path + "[" + strconv.Itoa(i) + "]"
+		result[i] = w.valueAt(val, childPath, opts)
 	}
 
-	// Sort if requested
-	if opts.SortArraysByKey != "" {
+	// Keep the unsorted order so the report only counts arrays that moved
+	var before []any
+	if w.report != nil {
+		before = append([]any(nil), result...)
+	}
+
+	// Sort if requested - a per-path sort key wins over the global options
+	if spec, ok := w.sortSpecFor(path); ok {
+		sortArrayByKey(result, spec)
+	} else if opts.SortArraysByKey != "" {
 		sortArrayByKey(result, opts.SortArraysByKey)
 	} else if opts.SortArrays {
 		sortArray(result)
 	}
 
+	if before != nil && !reflect.DeepEqual(before, result) {
+		w.note(path, ActionSorted)
+	}
+
 	return result
 }
 
+// sortSpecFor returns the SortArraysByPath spec for the array at path.
+// An exact pattern match wins; otherwise the first matching pattern in
+// sorted order is used.
+func (w *walker) sortSpecFor(path string) (string, bool) {
+	if len(w.sortPaths) == 0 {
+		return "", false
+	}
+	if spec, ok := w.sortSpecs[path]; ok {
+		return spec, true
+	}
+	for _, pattern := range w.sortPaths {
+		if pathmatch.Match(pattern, path) {
+			return w.sortSpecs[pattern], true
+		}
+	}
+	return "", false
+}
+
+// excluded reports whether the field key at path should be dropped.
+func (w *walker) excluded(key, path string) bool {
+	if w.excludeKeys[key] {
+		return true
+	}
+	for _, pattern := range w.excludePaths {
+		if pathmatch.Match(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveOptions returns the options that apply at path: opts with the
+// fields set by every override whose pattern matches, in order.
+func (w *walker) resolveOptions(path string, opts Options) Options {
+	for _, o := range w.overrides {
+		if pathmatch.Match(o.path, path) {
+			o.apply(&opts)
+		}
+	}
+	return opts
+}
+
 // normalizeNumber normalizes a JSON number.
 //
 // JSON numbers are always parsed as float64 in Go.
@@ -94,6 +267,10 @@ func normalizeArray(arr []any, opts Options) []any {
 //   - 1.5 → 1.5 (unchanged)
 //   - 1.0000001 → 1.0000001 (unchanged, not close enough to integer)
 func normalizeNumber(n float64, opts Options) any {
+	if opts.RoundNumbers > 0 {
+		n = roundTo(n, opts.RoundNumbers)
+	}
+
 	if !opts.NormalizeNumbers {
 		return n
 	}
@@ -111,43 +288,162 @@ func normalizeNumber(n float64, opts Options) any {
 }
 
 // normalizeString normalizes a JSON string.
-func normalizeString(s string, opts Options) string {
+// The result is usually a string, but coercion options may turn it into
+// a bool, number or nil.
+func (w *walker) normalizeString(s string, path string, opts Options) any {
+	// step applies one string rewrite and notes it if the string changed
+	step := func(action Action, rewrite func(string) string) {
+		if out := rewrite(s); out != s {
+			w.note(path, action)
+			s = out
+		}
+	}
+
+	if opts.UnicodeNormalization != UnicodeNone {
+		step(ActionUnicode, func(s string) string { return normalizeUnicode(s, opts.UnicodeNormalization) })
+	}
 	if opts.TrimStrings {
-		return strings.TrimSpace(s)
+		step(ActionTrimmed, strings.TrimSpace)
+	}
+	if len(opts.NormalizeDates.InputLayouts) > 0 {
+		step(ActionDate, func(s string) string { return normalizeDate(s, opts.NormalizeDates) })
+	}
+	if opts.NormalizeURLs.Enabled {
+		step(ActionURL, func(s string) string { return normalizeURL(s, opts.NormalizeURLs) })
+	}
+	if opts.NormalizeUUIDs {
+		step(ActionUUID, normalizeUUID)
+	}
+	if v, ok := coerceString(s, opts); ok {
+		w.note(path, ActionCoerced)
+		return v
 	}
 	return s
 }
 
-// sortArrayByKey sorts an array of objects by a specific key.
+// noteNumber reports what number normalization did to before.
+// Only rounding changes a number's value; other changes are to its text.
+func (w *walker) noteNumber(before, after any, path string, opts Options) {
+	if w.report == nil {
+		return
+	}
+	if opts.RoundNumbers > 0 {
+		if cmp, ok := parser.CompareNumbers(before, after); ok && cmp != 0 {
+			w.note(path, ActionRounded)
+			return
+		}
+	}
+	if n, ok := before.(json.Number); ok && after != any(n) {
+		w.note(path, ActionNumber)
+	}
+}
+
+// sortArrayByKey sorts an array of objects by one or more keys.
+//
+// spec is a comma-separated list of keys, each optionally followed by a
+// direction (see parseSortKeys). Keys may be dotted paths into nested objects.
 //
-// Example with key="id":
+// Example with spec="id":
 //
 //	[{"id": 2, "name": "Bob"}, {"id": 1, "name": "Alice"}]
 //	→ [{"id": 1, "name": "Alice"}, {"id": 2, "name": "Bob"}]
 //
-// Non-object elements or objects without the key are sorted to the end.
-func sortArrayByKey(arr []any, key string) {
+// Example with spec="user.team asc, score desc": sort by the nested team
+// name, then by highest score within each team.
+//
+// Elements that are not objects or lack a key are sorted after those that
+// have it; otherwise their original order is kept (stable sort).
+func sortArrayByKey(arr []any, spec string) {
+	keys := parseSortKeys(spec)
+	if len(keys) == 0 {
+		return
+	}
+
 	sort.SliceStable(arr, func(i, j int) bool {
-		// Try to get the key from both elements
-		iObj, iOk := arr[i].(map[string]any)
-		jObj, jOk := arr[j].(map[string]any)
+		for _, key := range keys {
+			iVal, iHas := lookupKey(arr[i], key.path)
+			jVal, jHas := lookupKey(arr[j], key.path)
+
+			switch {
+			case !iHas && !jHas:
+				continue // Neither has this key - try the next one
+			case !iHas:
+				return false // Missing values go last
+			case !jHas:
+				return true
+			}
 
-		if !iOk || !jOk {
-			// Non-objects: keep original order (stable sort)
-			return false
+			cmp := compareValues(iVal, jVal)
+			if cmp == 0 {
+				continue
+			}
+			if key.desc {
+				return cmp > 0
+			}
+			return cmp < 0
 		}
+		return false
+	})
+}
 
-		iVal, iHas := iObj[key]
-		jVal, jHas := jObj[key]
+// sortKey is one parsed entry of a SortArraysByKey spec.
+type sortKey struct {
+	path []string // Key path, e.g. ["user", "id"] for "user.id"
+	desc bool     // Sort descending
+}
 
-		if !iHas || !jHas {
-			// Missing key: keep original order
-			return false
+// parseSortKeys parses a sort spec like "id asc, user.name desc".
+// Direction defaults to ascending and is case-insensitive. Empty entries
+// are skipped, and an unrecognized direction word is treated as part of
+// the key so unusual key names still work.
+func parseSortKeys(spec string) []sortKey {
+	keys := []sortKey{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
 		}
 
-		// Compare the values
-		return compareValues(iVal, jVal) < 0
-	})
+		key := sortKey{}
+		if idx := strings.LastIndexByte(part, ' '); idx >= 0 {
+			switch strings.ToLower(part[idx+1:]) {
+			case "asc":
+				part = strings.TrimSpace(part[:idx])
+			case "desc":
+				key.desc = true
+				part = strings.TrimSpace(part[:idx])
+			}
+		}
+
+		key.path = strings.Split(part, ".")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// lookupKey follows a key path through nested objects.
+// If the element has a literal key equal to the full dotted path (e.g. a
+// key named "user.id"), that is used first.
+func lookupKey(v any, path []string) (any, bool) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	if len(path) > 1 {
+		if val, ok := obj[strings.Join(path, ".")]; ok {
+			return val, true
+		}
+	}
+
+	val, ok := obj[path[0]]
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 1 {
+		return val, true
+	}
+	return lookupKey(val, path[1:])
 }
 
 // sortArray sorts an array of primitives.
@@ -170,6 +466,11 @@ func compareValues(a, b any) int {
 		return aOrder - bOrder
 	}
 
+	// Numbers may be float64 or json.Number - compare them exactly
+	if cmp, ok := parser.CompareNumbers(a, b); ok {
+		return cmp
+	}
+
 	// Same type - compare values
 	switch aVal := a.(type) {
 	case nil:
@@ -210,7 +511,7 @@ func typeOrder(v any) int {
 		return 0
 	case bool:
 		return 1
-	case float64:
+	case float64, json.Number:
 		return 2
 	case string:
 		return 3
@@ -960,3 +960,408 @@ func TestCombinedOptions(t *testing.T) {
 		})
 	}
 }
+
+// TestNormalizeDatesOption tests rewriting date strings to a canonical layout
+func TestNormalizeDatesOption(t *testing.T) {
+	dateOpts := DateOptions{
+		InputLayouts: []string{"01/02/2006", "2006-01-02"},
+		OutputLayout: "2006-01-02",
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "US layout rewritten",
+			input:    `"01/02/2024"`,
+			opts:     Options{NormalizeDates: dateOpts},
+			expected: `"2024-01-02"`,
+		},
+		{
+			name:     "already canonical",
+			input:    `"2024-01-02"`,
+			opts:     Options{NormalizeDates: dateOpts},
+			expected: `"2024-01-02"`,
+		},
+		{
+			name:     "non-date string unchanged",
+			input:    `"hello"`,
+			opts:     Options{NormalizeDates: dateOpts},
+			expected: `"hello"`,
+		},
+		{
+			name:     "nested in object",
+			input:    `{"created": "12/31/2023", "n": 1}`,
+			opts:     Options{NormalizeDates: dateOpts},
+			expected: `{"created":"2023-12-31","n":1}`,
+		},
+		{
+			name:     "default output layout is RFC3339",
+			input:    `"2024-01-02"`,
+			opts:     Options{NormalizeDates: DateOptions{InputLayouts: []string{"2006-01-02"}}},
+			expected: `"2024-01-02T00:00:00Z"`,
+		},
+		{
+			name:     "trimmed before parsing",
+			input:    `"  01/02/2024 "`,
+			opts:     Options{TrimStrings: true, NormalizeDates: dateOpts},
+			expected: `"2024-01-02"`,
+		},
+		{
+			name:     "disabled without input layouts",
+			input:    `"01/02/2024"`,
+			opts:     Options{NormalizeDates: DateOptions{OutputLayout: "2006-01-02"}},
+			expected: `"01/02/2024"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, tt.opts)
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
+
+// TestUnicodeNormalizationOption tests composed vs decomposed strings
+func TestUnicodeNormalizationOption(t *testing.T) {
+	composed := "café"    // é as a single code point
+	decomposed := "café" // e + combining acute accent
+
+	tests := []struct {
+		name      string
+		a, b      string
+		form      UnicodeForm
+		wantEqual bool
+	}{
+		{"disabled keeps forms distinct", composed, decomposed, UnicodeNone, false},
+		{"NFC makes forms equal", composed, decomposed, UnicodeNFC, true},
+		{"NFD makes forms equal", composed, decomposed, UnicodeNFD, true},
+		{"NFKC folds ligatures", "\ufb01le", "file", UnicodeNFKC, true},
+		{"NFC keeps ligatures", "\ufb01le", "file", UnicodeNFC, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{UnicodeNormalization: tt.form}
+			a := Value(tt.a, opts)
+			b := Value(tt.b, opts)
+
+			if (a == b) != tt.wantEqual {
+				t.Errorf("Value(%q) == Value(%q) is %v, want %v", tt.a, tt.b, a == b, tt.wantEqual)
+			}
+		})
+	}
+}
+
+// TestUnicodeNormalizationKeys tests that object keys are normalized too
+func TestUnicodeNormalizationKeys(t *testing.T) {
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+	opts := Options{UnicodeNormalization: UnicodeNFC}
+
+	a := Value(map[string]any{composed: map[string]any{decomposed: 1}}, opts)
+	b := Value(map[string]any{decomposed: map[string]any{composed: 1}}, opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("expected equal after normalization, got %v and %v", a, b)
+	}
+
+	// Of two keys that normalize to the same one, the key already in
+	// normal form is kept
+	got, report := ValueWithReport(map[string]any{composed: 1, decomposed: 2, "nai\u0308ve": 3}, opts)
+	want := map[string]any{composed: 1, "na\u00efve": 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if n := report.Counts[ActionUnicode]; n != 1 {
+		t.Errorf("expected 1 key noted as Unicode-normalized, got %d", n)
+	}
+}
+
+// TestOverridesOption tests applying different options to parts of a document
+func TestOverridesOption(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:  "trim only under .user",
+			input: `{"user": {"name": " Alice ", "address": {"city": " Paris "}}, "note": " keep "}`,
+			opts: Options{
+				Overrides: []Override{
+					{Path: ".user.*", Options: Options{TrimStrings: true}},
+				},
+			},
+			expected: `{"note":" keep ","user":{"address":{"city":"Paris"},"name":"Alice"}}`,
+		},
+		{
+			name:  "sort only .tags",
+			input: `{"tags": ["b", "a"], "steps": ["b", "a"]}`,
+			opts: Options{
+				Overrides: []Override{
+					{Path: ".tags", Options: Options{SortArrays: true}},
+				},
+			},
+			expected: `{"steps":["b","a"],"tags":["a","b"]}`,
+		},
+		{
+			name:  "override disables a global option",
+			input: `{"a": " x ", "raw": " y "}`,
+			opts: Options{
+				TrimStrings: true,
+				Overrides: []Override{
+					{Path: ".raw", Options: Options{}, Set: []string{"TrimStrings"}},
+				},
+			},
+			expected: `{"a":"x","raw":" y "}`,
+		},
+		{
+			name:  "override keeps options it doesn't set",
+			input: `{"a": " x ", "tags": [" b ", " a "]}`,
+			opts: Options{
+				TrimStrings: true,
+				Overrides: []Override{
+					{Path: ".tags", Options: Options{SortArrays: true}},
+				},
+			},
+			expected: `{"a":"x","tags":["a","b"]}`,
+		},
+		{
+			name:  "null removal scoped to path",
+			input: `{"a": null, "meta": {"b": null}}`,
+			opts: Options{
+				Overrides: []Override{
+					{Path: ".meta.**", Options: Options{NullEqualsAbsent: true}},
+				},
+			},
+			expected: `{"a":null,"meta":{}}`,
+		},
+		{
+			name:  "last matching override wins",
+			input: `{"a": " x "}`,
+			opts: Options{
+				Overrides: []Override{
+					{Path: ".*", Options: Options{TrimStrings: true}},
+					{Path: ".a", Options: Options{}, Set: []string{"TrimStrings"}},
+				},
+			},
+			expected: `{"a":" x "}`,
+		},
+		{
+			name:  "array element paths use indices",
+			input: `[" a ", " b "]`,
+			opts: Options{
+				Overrides: []Override{
+					{Path: "[1]", Options: Options{TrimStrings: true}},
+				},
+			},
+			expected: `[" a ","b"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, tt.opts)
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
+
+// TestRoundNumbersOption tests rounding numbers to N decimal places
+func TestRoundNumbersOption(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      float64
+		places    int
+		wantEqual bool
+	}{
+		{"disabled keeps tiny differences", 1.000000001, 1.000000002, 0, false},
+		{"currency rounding", 19.999999999, 20.0, 2, true},
+		{"geo coordinates ninth decimal", 51.5073509001, 51.5073509002, 8, true},
+		{"real difference survives", 1.23, 1.24, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{RoundNumbers: tt.places}
+			a := Value(tt.a, opts)
+			b := Value(tt.b, opts)
+
+			if (a == b) != tt.wantEqual {
+				t.Errorf("Value(%v) = %v, Value(%v) = %v, equal want %v", tt.a, a, tt.b, b, tt.wantEqual)
+			}
+		})
+	}
+}
+
+// TestSortArraysByMultipleKeys tests multi-key, directional and nested sorting
+func TestSortArraysByMultipleKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		spec     string
+		expected string
+	}{
+		{
+			name:     "descending",
+			input:    `[{"id": 1}, {"id": 3}, {"id": 2}]`,
+			spec:     "id desc",
+			expected: `[{"id":3},{"id":2},{"id":1}]`,
+		},
+		{
+			name:     "two keys",
+			input:    `[{"g": "b", "n": 1}, {"g": "a", "n": 1}, {"g": "a", "n": 2}]`,
+			spec:     "g asc, n desc",
+			expected: `[{"g":"a","n":2},{"g":"a","n":1},{"g":"b","n":1}]`,
+		},
+		{
+			name:     "nested key path",
+			input:    `[{"user": {"id": 2}}, {"user": {"id": 1}}]`,
+			spec:     "user.id",
+			expected: `[{"user":{"id":1}},{"user":{"id":2}}]`,
+		},
+		{
+			name:     "literal dotted key preferred",
+			input:    `[{"user.id": 2}, {"user.id": 1}]`,
+			spec:     "user.id",
+			expected: `[{"user.id":1},{"user.id":2}]`,
+		},
+		{
+			name:     "missing key sorts last",
+			input:    `[{"x": 1}, {"id": 2}, "str", {"id": 1}]`,
+			spec:     "id",
+			expected: `[{"id":1},{"id":2},{"x":1},"str"]`,
+		},
+		{
+			name:     "falls back to next key when first is missing",
+			input:    `[{"name": "b"}, {"name": "a"}]`,
+			spec:     "id, name",
+			expected: `[{"name":"a"},{"name":"b"}]`,
+		},
+		{
+			name:     "case-insensitive direction",
+			input:    `[{"id": 1}, {"id": 2}]`,
+			spec:     "id DESC",
+			expected: `[{"id":2},{"id":1}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, Options{SortArraysByKey: tt.spec})
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
+
+// TestSortArraysByPathOption tests per-array sort keys
+func TestSortArraysByPathOption(t *testing.T) {
+	input := `{
+		"users": [{"id": 2}, {"id": 1}],
+		"events": [{"timestamp": "a"}, {"timestamp": "b"}],
+		"groups": [{"members": [{"n": "z"}, {"n": "y"}]}],
+		"steps": [3, 1, 2]
+	}`
+	opts := Options{
+		SortArraysByPath: map[string]string{
+			".users":            "id",
+			".events":           "timestamp desc",
+			".groups[].members": "n",
+		},
+	}
+	expected := `{"events":[{"timestamp":"b"},{"timestamp":"a"}],"groups":[{"members":[{"n":"y"},{"n":"z"}]}],"steps":[3,1,2],"users":[{"id":1},{"id":2}]}`
+
+	var data any
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+
+	result := Value(data, opts)
+
+	resultJSON, _ := json.Marshal(result)
+	if string(resultJSON) != expected {
+		t.Errorf("expected %s, got %s", expected, string(resultJSON))
+	}
+}
+
+func TestExcludeKeysAndPathsOption(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "exclude keys at any depth",
+			input:    `{"id": 1, "etag": "x", "child": {"etag": "y", "name": "a"}}`,
+			opts:     Options{ExcludeKeys: []string{"etag"}},
+			expected: `{"child":{"name":"a"},"id":1}`,
+		},
+		{
+			name:     "exclude exact path",
+			input:    `{"meta": {"requestId": "r1", "version": 2}, "requestId": "keep"}`,
+			opts:     Options{ExcludePaths: []string{".meta.requestId"}},
+			expected: `{"meta":{"version":2},"requestId":"keep"}`,
+		},
+		{
+			name:     "exclude path inside arrays",
+			input:    `{"items": [{"id": 1, "internal": true}, {"id": 2, "internal": false}]}`,
+			opts:     Options{ExcludePaths: []string{".items[].internal"}},
+			expected: `{"items":[{"id":1},{"id":2}]}`,
+		},
+		{
+			name:     "exclude whole subtree",
+			input:    `{"debug": {"trace": [1, 2, 3]}, "ok": true}`,
+			opts:     Options{ExcludePaths: []string{".debug"}},
+			expected: `{"ok":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, tt.opts)
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
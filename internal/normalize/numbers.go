@@ -0,0 +1,156 @@
+package normalize
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// normalizeJSONNumber normalizes a number parsed with json.Decoder.UseNumber.
+//
+// json.Number keeps the original literal, so integers beyond float64's
+// 2^53 limit survive intact. Normalization rewrites the literal text
+// digit by digit (see canonicalNumber) rather than going through float64
+// math, so those values are never corrupted:
+//   - "1.0", "1e3" and "1000.00" → "1", "1000", "1000" (whole numbers)
+//   - "1.50" and "1.5e0" → "1.5" (trailing fraction zeros removed)
+//   - "1.5e-3" and "0.0015" → "0.0015"
+//   - "9007199254740993" → unchanged
+func normalizeJSONNumber(n json.Number, opts Options) any {
+	if opts.RoundNumbers > 0 {
+		n = roundJSONNumber(n, opts.RoundNumbers)
+	}
+
+	if !opts.NormalizeNumbers {
+		return n
+	}
+
+	if canonical, ok := canonicalNumber(string(n)); ok {
+		return json.Number(canonical)
+	}
+	return n
+}
+
+// maxPaddingZeros is how many zeros canonicalNumber will add to write a
+// number in plain notation before switching to scientific notation.
+// 20 keeps every 64-bit integer ("18446744073709551616") in plain form.
+const maxPaddingZeros = 20
+
+// canonicalNumber rewrites a JSON number literal in one canonical textual
+// form, keeping every digit. Numbers with the same value always get the
+// same text, whatever notation they were written in.
+//
+// Plain notation is used unless it would need more than maxPaddingZeros
+// added zeros; then the form is scientific with one digit before the point:
+//
+//	"1e3", "1000", "1000.0", "0.1e4" → "1000"
+//	"-0.0"                           → "0"
+//	"1.5e-3"                         → "0.0015"
+//	"1e400"                          → "1e+400"
+//	"12.5e-30"                       → "1.25e-29"
+//
+// ok is false if s is not a valid number literal.
+func canonicalNumber(s string) (string, bool) {
+	if !isJSONNumber(s) {
+		return "", false
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	// Split off the exponent
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > 1e9 || e < -1e9 {
+			return "", false // Absurd exponent - leave the literal alone
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	// digits × 10^(point - len(digits)), i.e. the decimal point sits
+	// after the first `point` digits
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	point := len(intPart) + exp
+
+	// Leading zeros move the point, trailing zeros change nothing
+	trimmed := strings.TrimLeft(digits, "0")
+	point -= len(digits) - len(trimmed)
+	digits = strings.TrimRight(trimmed, "0")
+
+	if digits == "" {
+		return "0", true // Covers "-0" too
+	}
+
+	var out string
+	switch {
+	case point > len(digits)+maxPaddingZeros || point < -maxPaddingZeros:
+		// Scientific: d.ddd e±x
+		out = digits[:1]
+		if len(digits) > 1 {
+			out += "." + digits[1:]
+		}
+		sci := point - 1
+		if sci >= 0 {
+			out += "e+" + strconv.Itoa(sci)
+		} else {
+			out += "e-" + strconv.Itoa(-sci)
+		}
+	case point >= len(digits):
+		out = digits + strings.Repeat("0", point-len(digits))
+	case point > 0:
+		out = digits[:point] + "." + digits[point:]
+	default:
+		out = "0." + strings.Repeat("0", -point) + digits
+	}
+
+	if negative {
+		out = "-" + out
+	}
+	return out, true
+}
+
+// roundJSONNumber rounds n to the given number of decimal places exactly
+// (half away from zero), returning the shortest decimal text.
+func roundJSONNumber(n json.Number, places int) json.Number {
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok || r.IsInt() && places >= 0 {
+		return n
+	}
+
+	// Scale, round to an integer, and scale back
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	if places < 0 {
+		scale.Inv(scale)
+	}
+	scaled := new(big.Rat).Mul(r, scale)
+
+	// Round half away from zero: add or subtract 1/2 then truncate toward zero
+	half := big.NewRat(1, 2)
+	if scaled.Sign() < 0 {
+		scaled.Sub(scaled, half)
+	} else {
+		scaled.Add(scaled, half)
+	}
+	truncated := new(big.Int).Quo(scaled.Num(), scaled.Denom())
+
+	rounded := new(big.Rat).SetInt(truncated)
+	rounded.Quo(rounded, scale)
+
+	if places <= 0 {
+		return json.Number(rounded.FloatString(0))
+	}
+	text := strings.TrimRight(rounded.FloatString(places), "0")
+	return json.Number(strings.TrimSuffix(text, "."))
+}
+
+// abs returns the absolute value of an int.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
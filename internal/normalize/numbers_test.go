@@ -0,0 +1,95 @@
+package normalize
+
+import (
+	"encoding/json"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+func TestNormalizeJSONNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected json.Number
+	}{
+		{"big integer untouched", "9007199254740993", Options{NormalizeNumbers: true}, "9007199254740993"},
+		{"whole decimal", "1.0", Options{NormalizeNumbers: true}, "1"},
+		{"exponent whole", "1e3", Options{NormalizeNumbers: true}, "1000"},
+		{"trailing zeros", "1.50", Options{NormalizeNumbers: true}, "1.5"},
+		{"negative zero", "-0.0", Options{NormalizeNumbers: true}, "0"},
+		{"disabled keeps text", "1.0", Options{}, "1.0"},
+		{"round decimals", "1.23456", Options{RoundNumbers: 2}, "1.23"},
+		{"round half away from zero", "-2.345", Options{RoundNumbers: 2}, "-2.35"},
+		{"round keeps big integers", "9007199254740993", Options{RoundNumbers: 2}, "9007199254740993"},
+		{"round to whole", "19.999", Options{RoundNumbers: 2, NormalizeNumbers: true}, "20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Value(json.Number(tt.input), tt.opts)
+			if result != tt.expected {
+				t.Errorf("Value(%s) = %v, want %s", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalNumber(t *testing.T) {
+	tests := []struct {
+		inputs   []string // All spellings of the same value
+		expected string
+	}{
+		{[]string{"1000", "1e3", "1E3", "1e+3", "1000.0", "0.1e4", "10000e-1"}, "1000"},
+		{[]string{"0", "-0", "0.0", "0e10", "-0.000e-5"}, "0"},
+		{[]string{"1.5", "1.50", "15e-1", "0.15e1"}, "1.5"},
+		{[]string{"0.0015", "1.5e-3", "15E-4"}, "0.0015"},
+		{[]string{"-2.5", "-25e-1"}, "-2.5"},
+		{[]string{"9007199254740993", "9.007199254740993e15"}, "9007199254740993"},
+		{[]string{"18446744073709551616", "1.8446744073709551616e19"}, "18446744073709551616"},
+		{[]string{"1e400", "10e399", "0.1e401"}, "1e+400"},
+		{[]string{"1.25e-29", "12.5e-30"}, "1.25e-29"},
+		{[]string{"-1.2e-21"}, "-0.0000000000000000000012"}, // 20 padding zeros is still plain
+		{[]string{"-1.2e-22"}, "-1.2e-22"},
+	}
+
+	for _, tt := range tests {
+		for _, input := range tt.inputs {
+			got, ok := canonicalNumber(input)
+			if !ok || got != tt.expected {
+				t.Errorf("canonicalNumber(%q) = %q, %v; want %q", input, got, ok, tt.expected)
+			}
+		}
+	}
+
+	if _, ok := canonicalNumber("1e"); ok {
+		t.Error("expected invalid literal to be rejected")
+	}
+}
+
+func TestSortArraysWithJSONNumbers(t *testing.T) {
+	data, err := parser.ParseString(`[9007199254740993, 9007199254740992, 1.5, 10]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := Value(data, Options{SortArrays: true})
+
+	resultJSON, _ := json.Marshal(result)
+	expected := `[1.5,10,9007199254740992,9007199254740993]`
+	if string(resultJSON) != expected {
+		t.Errorf("expected %s, got %s", expected, resultJSON)
+	}
+}
+
+func TestSortArraysMixedNumberTypes(t *testing.T) {
+	data := []any{json.Number("3"), 1.0, json.Number("2")}
+
+	result := Value(data, Options{SortArrays: true})
+
+	resultJSON, _ := json.Marshal(result)
+	if string(resultJSON) != `[1,2,3]` {
+		t.Errorf("expected [1,2,3], got %s", resultJSON)
+	}
+}
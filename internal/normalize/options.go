@@ -12,10 +12,16 @@ type Options struct {
 	SortKeys bool
 
 	// NormalizeNumbers converts all numbers to a canonical form.
-	// When true: 1.0 becomes 1, 1.00000 becomes 1
-	// Handles floating point representation differences.
+	// When true: 1.0 becomes 1, 1.00000 becomes 1, 1e3 becomes 1000
+	// Handles floating point representation differences. Numbers parsed as
+	// json.Number are rewritten textually, so no precision is lost.
 	NormalizeNumbers bool
 
+	// RoundNumbers rounds numbers to this many decimal places before comparison.
+	// When 2: 19.999999999 becomes 20, 1.23456 becomes 1.23
+	// Zero disables rounding; to round to whole numbers use a TransformRound rule.
+	RoundNumbers int
+
 	// TrimStrings removes leading/trailing whitespace from strings.
 	// When true: "  hello  " becomes "hello"
 	// Use with caution - whitespace may be significant in some contexts.
@@ -35,19 +41,181 @@ type Options struct {
 	// SortArraysByKey sorts arrays of objects by a specific key.
 	// Example: With SortArraysByKey="id",
 	//   [{"id":2}, {"id":1}] becomes [{"id":1}, {"id":2}]
+	// Multiple keys with directions and dotted paths are supported:
+	//   "id asc, name desc" or "user.id, createdAt desc"
 	// Empty string means don't sort by key.
 	SortArraysByKey string
+
+	// SortArraysByPath sets the sort key per array, keyed by path pattern.
+	// Example: {".users": "id", ".events": "timestamp desc"}
+	// Each value uses the SortArraysByKey syntax. A matching entry takes
+	// precedence over SortArraysByKey and SortArrays for that array.
+	// Like Overrides, it is only read from the top-level Options.
+	SortArraysByPath map[string]string
+
+	// NormalizeDates rewrites date strings to one canonical layout.
+	// When InputLayouts is set: "01/02/2024" and "2024-01-02" can both become "2024-01-02"
+	// Strings that don't parse with any input layout are left untouched.
+	NormalizeDates DateOptions
+
+	// NormalizeURLs canonicalizes absolute URL strings.
+	// When Enabled: "HTTPS://Example.com:443/a?b=2&a=1" becomes "https://example.com/a?a=1&b=2"
+	// DropParams additionally removes tracking parameters such as "utm_*".
+	NormalizeURLs URLOptions
+
+	// NormalizeUUIDs canonicalizes UUID strings to lowercase without braces.
+	// When true: "{3F2504E0-4F89-11D3-9A0C-0305E82C3301}" becomes "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	// Useful when comparing identifiers from .NET (upper case, braces) and Java systems.
+	NormalizeUUIDs bool
+
+	// UnicodeNormalization converts strings, including object keys, to a
+	// Unicode normalization form.
+	// When UnicodeNFC: "e\u0301" (e + combining accent) becomes "\u00e9" (é)
+	// Useful for data from macOS filenames or databases that store decomposed text.
+	UnicodeNormalization UnicodeForm
+
+	// CoerceBooleans converts the strings "true" and "false" (any case) to booleans.
+	// When true: "TRUE" becomes true
+	// Useful for CSV-derived JSON where every value is a string.
+	CoerceBooleans bool
+
+	// CoerceNumbers converts strings holding a valid JSON number to numbers.
+	// When true: "42" becomes 42, "1.50" becomes 1.50 (then NormalizeNumbers applies)
+	CoerceNumbers bool
+
+	// NullStrings lists string values that are treated as null.
+	// Example: []string{"null", "N/A", ""} makes "N/A" equal to null
+	// Matching happens after TrimStrings, so "  N/A " matches too when trimming.
+	NullStrings []string
+
+	// LocaleNumbers converts numeric strings written with locale-specific
+	// separators to numbers.
+	// With DecimalSeparator "," and ThousandsSeparator ".": "1.234,56" becomes 1234.56
+	// Useful for comparing exports from European systems against API output.
+	LocaleNumbers LocaleNumberOptions
+
+	// Overrides apply different options to parts of the document.
+	// Example: TrimStrings only under ".user.*" and SortArrays only at ".tags".
+	// See Override for how paths are matched.
+	Overrides []Override
+
+	// Transforms are custom per-path rules (round, lowercase, truncate,
+	// regex-replace, constant) applied after the options above.
+	// Like Overrides, they are only read from the top-level Options.
+	Transforms []TransformRule
+
+	// ExcludeKeys removes object fields with these names at any depth.
+	// Example: []string{"updatedAt", "etag"} drops every "updatedAt" and "etag" key
+	ExcludeKeys []string
+
+	// ExcludePaths removes object fields at matching paths (pathmatch
+	// patterns such as ".meta.requestId" or ".items[].internal").
+	// Unlike diff-time ignore rules, excluded fields are gone from the
+	// normalized document entirely, so they never show up in output.
+	// ExcludeKeys and ExcludePaths are only read from the top-level Options.
+	ExcludePaths []string
+
+	// RedactPaths replaces values at matching paths (pathmatch patterns such
+	// as ".user.email" or ".**.token") so diffs can be shared without leaking
+	// secrets. Redaction runs after every other option and transform.
+	// Like Transforms, this is only read from the top-level Options.
+	RedactPaths []string
+
+	// RedactSalt selects how redacted values are shown.
+	// Empty: every value becomes "<redacted>", so changes are hidden too.
+	// Set: values become "<redacted:1a2b3c4d5e6f>", a salted hash, so equal
+	// values still compare equal and changed values still show as changed.
+	RedactSalt string
+}
+
+// Override changes some of the options used for a subtree of the document.
+//
+// Path is a pathmatch pattern (e.g. ".user.*", ".items[].tags", ".**.id").
+// When a value's path matches, the fields the override sets are taken
+// from Options for that value and everything beneath it; the others keep
+// the value they have at the parent. If several overrides match the same
+// path they apply in order, so list general rules before specific ones.
+//
+// Set names the fields the override sets, with nested fields written as
+// "NormalizeDates.OutputLayout". Naming a field is how an override turns
+// an option off: {Set: ["TrimStrings"]} with a zero Options. When Set is
+// empty the non-zero fields of Options are set. Overrides, Transforms,
+// ExcludeKeys, ExcludePaths, RedactPaths, RedactSalt and SortArraysByPath
+// are only read from the top-level Options; ValidateOverrides rejects an
+// override that sets them.
+type Override struct {
+	Path    string
+	Options Options
+	Set     []string
+}
+
+// UnicodeForm selects a Unicode normalization form for string values.
+type UnicodeForm string
+
+const (
+	UnicodeNone UnicodeForm = ""     // Leave strings as-is
+	UnicodeNFC  UnicodeForm = "NFC"  // Canonical composition (é stays one code point)
+	UnicodeNFD  UnicodeForm = "NFD"  // Canonical decomposition
+	UnicodeNFKC UnicodeForm = "NFKC" // Compatibility composition (also folds ligatures like "ﬁ" → "fi")
+	UnicodeNFKD UnicodeForm = "NFKD" // Compatibility decomposition
+)
+
+// DateOptions configures date normalization.
+// Layouts use Go's reference time (see the time package), e.g. "2006-01-02".
+type DateOptions struct {
+	// InputLayouts are tried in order against every string value.
+	// Empty means date normalization is disabled.
+	InputLayouts []string
+
+	// OutputLayout is the canonical layout for parsed dates.
+	// Defaults to time.RFC3339 when empty.
+	OutputLayout string
+}
+
+// URLOptions configures URL canonicalization.
+type URLOptions struct {
+	// Enabled turns on URL canonicalization: lowercase scheme and host,
+	// strip default ports (:80 for http, :443 for https) and sort query
+	// parameters by name.
+	Enabled bool
+
+	// DropParams lists query parameters to remove. Entries may use glob
+	// syntax, e.g. []string{"utm_*", "fbclid"}. Only used when Enabled.
+	DropParams []string
+}
+
+// LocaleNumberOptions configures parsing of locale-formatted number strings.
+type LocaleNumberOptions struct {
+	// DecimalSeparator separates the integer and fraction parts, e.g. ",".
+	// Empty means locale number parsing is disabled.
+	DecimalSeparator string
+
+	// ThousandsSeparator groups integer digits in threes, e.g. "." or " ".
+	// Optional; when it is " ", no-break spaces are accepted too.
+	ThousandsSeparator string
 }
 
 // DefaultOptions returns sensible defaults for normalization.
 func DefaultOptions() Options {
 	return Options{
-		SortKeys:         true,  // Almost always wanted
-		NormalizeNumbers: true,  // Safe default
-		TrimStrings:      false, // Could change semantics
-		NullEqualsAbsent: false, // Could hide real differences
-		SortArrays:       false, // Order usually matters
-		SortArraysByKey:  "",    // Disabled by default
+		SortKeys:         true,          // Almost always wanted
+		NormalizeNumbers: true,          // Safe default
+		RoundNumbers:     0,             // Could hide real differences
+		TrimStrings:      false,         // Could change semantics
+		NullEqualsAbsent: false,         // Could hide real differences
+		SortArrays:       false,         // Order usually matters
+		SortArraysByKey:  "",            // Disabled by default
+		SortArraysByPath: nil,           // Disabled by default
+		NormalizeDates:   DateOptions{}, // Disabled by default
+		NormalizeURLs:    URLOptions{},  // Disabled by default
+		NormalizeUUIDs:   false,         // Could hide real differences
+
+		UnicodeNormalization: UnicodeNone, // Could change semantics
+		CoerceBooleans:       false,       // Could hide real type changes
+		CoerceNumbers:        false,       // Could hide real type changes
+		NullStrings:          nil,         // Disabled by default
+
+		LocaleNumbers: LocaleNumberOptions{}, // Disabled by default
 	}
 }
 
@@ -57,9 +225,21 @@ func NoNormalization() Options {
 	return Options{
 		SortKeys:         false,
 		NormalizeNumbers: false,
+		RoundNumbers:     0,
 		TrimStrings:      false,
 		NullEqualsAbsent: false,
 		SortArrays:       false,
 		SortArraysByKey:  "",
+		SortArraysByPath: nil,
+		NormalizeDates:   DateOptions{},
+		NormalizeURLs:    URLOptions{},
+		NormalizeUUIDs:   false,
+
+		UnicodeNormalization: UnicodeNone,
+		CoerceBooleans:       false,
+		CoerceNumbers:        false,
+		NullStrings:          nil,
+
+		LocaleNumbers: LocaleNumberOptions{},
 	}
 }
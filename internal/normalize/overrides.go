@@ -0,0 +1,144 @@
+package normalize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var optionsType = reflect.TypeOf(Options{})
+
+// topLevelOnly lists the Options fields that are only read from the
+// top-level Options. An override can't scope them, so ValidateOverrides
+// rejects them and Value ignores them.
+var topLevelOnly = map[string]bool{
+	"Overrides":        true,
+	"Transforms":       true,
+	"ExcludeKeys":      true,
+	"ExcludePaths":     true,
+	"RedactPaths":      true,
+	"RedactSalt":       true,
+	"SortArraysByPath": true,
+}
+
+// compiledOverride is an Override with the fields it sets resolved to
+// reflect index paths into Options.
+type compiledOverride struct {
+	path    string
+	options reflect.Value // Options
+	fields  [][]int
+}
+
+// compileOverrides resolves the fields set by each override. Unknown and
+// top-level-only names in Set are skipped; ValidateOverrides reports them.
+func compileOverrides(overrides []Override) []compiledOverride {
+	compiled := make([]compiledOverride, len(overrides))
+	for i, o := range overrides {
+		c := compiledOverride{path: o.Path, options: reflect.ValueOf(o.Options)}
+		if len(o.Set) == 0 {
+			c.fields = nonZeroFields(c.options, nil)
+		}
+		for _, name := range o.Set {
+			if index, ok := optionField(name); ok {
+				c.fields = append(c.fields, index)
+			}
+		}
+		compiled[i] = c
+	}
+	return compiled
+}
+
+// apply copies the fields the override sets into opts.
+func (c compiledOverride) apply(opts *Options) {
+	dst := reflect.ValueOf(opts).Elem()
+	for _, index := range c.fields {
+		dst.FieldByIndex(index).Set(c.options.FieldByIndex(index))
+	}
+}
+
+// nonZeroFields returns the index paths of the non-zero fields of v, a
+// struct, descending into nested option structs such as DateOptions.
+// Top-level-only fields such as Overrides are left out.
+func nonZeroFields(v reflect.Value, prefix []int) [][]int {
+	var fields [][]int
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if v.Type() == optionsType && topLevelOnly[v.Type().Field(i).Name] {
+			continue
+		}
+		index := append(append([]int{}, prefix...), i)
+		switch {
+		case f.Kind() == reflect.Struct:
+			fields = append(fields, nonZeroFields(f, index)...)
+		case !f.IsZero():
+			fields = append(fields, index)
+		}
+	}
+	return fields
+}
+
+// optionField returns the index path of an Options field named like
+// "TrimStrings" or "NormalizeDates.OutputLayout". Top-level-only fields
+// are not found.
+func optionField(name string) ([]int, bool) {
+	if topLevelOnly[name] {
+		return nil, false
+	}
+	t := optionsType
+	var index []int
+	for _, part := range strings.Split(name, ".") {
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		f, ok := t.FieldByName(part)
+		if !ok || !f.IsExported() {
+			return nil, false
+		}
+		index = append(index, f.Index...)
+		t = f.Type
+	}
+	return index, true
+}
+
+// ValidateOverrides checks that every override has a path and only sets
+// fields an override can scope. Value silently skips unknown and
+// top-level-only fields, so callers taking overrides from users should
+// validate them first.
+func ValidateOverrides(overrides []Override) error {
+	for i, o := range overrides {
+		if o.Path == "" {
+			return fmt.Errorf("override %d: path is required", i)
+		}
+		if fields := topLevelFields(o); len(fields) > 0 {
+			return fmt.Errorf("override %d: %s only apply at the top level", i, strings.Join(fields, ", "))
+		}
+		for _, name := range o.Set {
+			if _, ok := optionField(name); !ok {
+				return fmt.Errorf("override %d: unknown option %q", i, name)
+			}
+		}
+	}
+	return nil
+}
+
+// topLevelFields returns the top-level-only fields an override sets,
+// either by name in Set or, when Set is empty, by being non-zero.
+func topLevelFields(o Override) []string {
+	var fields []string
+	if len(o.Set) > 0 {
+		for _, name := range o.Set {
+			if topLevelOnly[name] {
+				fields = append(fields, name)
+			}
+		}
+		return fields
+	}
+	v := reflect.ValueOf(o.Options)
+	for i := 0; i < v.NumField(); i++ {
+		name := optionsType.Field(i).Name
+		if topLevelOnly[name] && !v.Field(i).IsZero() {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,61 @@
+package normalize
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOverrideMergesNestedOptions(t *testing.T) {
+	opts := Options{
+		NormalizeDates: DateOptions{InputLayouts: []string{"01/02/2006"}, OutputLayout: "2006-01-02"},
+		Overrides: []Override{
+			{Path: ".legacy", Options: Options{NormalizeDates: DateOptions{OutputLayout: "02.01.2006"}}},
+			{Path: ".raw", Set: []string{"NormalizeDates.InputLayouts"}},
+		},
+	}
+
+	got := Value(map[string]any{"when": "03/04/2024", "legacy": "03/04/2024", "raw": "03/04/2024"}, opts)
+	want := map[string]any{"when": "2024-03-04", "legacy": "04.03.2024", "raw": "03/04/2024"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Value() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []Override
+		want      string // "" means valid
+	}{
+		{"valid", []Override{{Path: ".a", Set: []string{"TrimStrings", "NormalizeURLs.DropParams"}}}, ""},
+		{"no set", []Override{{Path: ".a", Options: Options{TrimStrings: true}}}, ""},
+		{"missing path", []Override{{Set: []string{"TrimStrings"}}}, "override 0: path is required"},
+		{"unknown option", []Override{{Path: ".a"}, {Path: ".b", Set: []string{"TrimString"}}}, `override 1: unknown option "TrimString"`},
+		{"unknown nested option", []Override{{Path: ".a", Set: []string{"NormalizeDates.Layout"}}}, `unknown option "NormalizeDates.Layout"`},
+		{"not a struct", []Override{{Path: ".a", Set: []string{"TrimStrings.Enabled"}}}, `unknown option "TrimStrings.Enabled"`},
+		{"overrides", []Override{{Path: ".a", Set: []string{"Overrides"}}}, "override 0: Overrides only apply at the top level"},
+		{"top-level only in set", []Override{{Path: ".a", Set: []string{"TrimStrings", "ExcludeKeys", "RedactPaths"}}}, "ExcludeKeys, RedactPaths only apply at the top level"},
+		{"top-level only by value", []Override{{Path: ".a", Options: Options{
+			TrimStrings:      true,
+			Transforms:       []TransformRule{{Path: ".b"}},
+			ExcludePaths:     []string{".c"},
+			SortArraysByPath: map[string]string{".d": "id"},
+		}}}, "SortArraysByPath, Transforms, ExcludePaths only apply at the top level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverrides(tt.overrides)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
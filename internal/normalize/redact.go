@@ -0,0 +1,53 @@
+package normalize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"jtool/internal/pathmatch"
+)
+
+// Redacted is the placeholder used for redacted values when no salt is set.
+const Redacted = "<redacted>"
+
+// applyRedaction replaces v if path matches one of the RedactPaths.
+//
+// Objects and arrays are redacted as a whole. With a salt, the hash covers
+// the value's JSON encoding (keys sorted by encoding/json), so two sides
+// that normalized to the same value get the same hash.
+func (w *walker) applyRedaction(v any, path string) any {
+	for _, pattern := range w.redact {
+		if pathmatch.Match(pattern, path) {
+			w.note(path, ActionRedacted)
+			return redact(v, w.salt)
+		}
+	}
+	return v
+}
+
+// redact returns the placeholder for v.
+//
+// Example with salt "s3cret":
+//
+//	"alice@example.com" → "<redacted:…>" with 12 hex characters; the same
+//	                       value and salt always give the same placeholder
+func redact(v any, salt string) string {
+	if salt == "" {
+		return Redacted
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Redacted
+	}
+
+	h := sha256.New()
+	h.Write([]byte(salt))
+	h.Write([]byte{0}) // Separator so salt+value boundaries can't shift
+	h.Write(data)
+
+	// 12 hex characters (48 bits) is plenty to tell values apart in a diff
+	// while keeping the placeholder short
+	return "<redacted:" + hex.EncodeToString(h.Sum(nil))[:12] + ">"
+}
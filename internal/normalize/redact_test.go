@@ -0,0 +1,63 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactPathsOption(t *testing.T) {
+	input := map[string]any{
+		"id":    "42",
+		"email": "alice@example.com",
+		"auth":  map[string]any{"token": "abc123"},
+	}
+
+	t.Run("placeholder without salt", func(t *testing.T) {
+		result := Value(input, Options{RedactPaths: []string{".email", ".**.token"}}).(map[string]any)
+
+		if result["email"] != Redacted {
+			t.Errorf("expected email redacted, got %v", result["email"])
+		}
+		if token := result["auth"].(map[string]any)["token"]; token != Redacted {
+			t.Errorf("expected token redacted, got %v", token)
+		}
+		if result["id"] != "42" {
+			t.Errorf("expected id untouched, got %v", result["id"])
+		}
+	})
+
+	t.Run("salted hash keeps equality", func(t *testing.T) {
+		opts := Options{RedactPaths: []string{".email"}, RedactSalt: "s3cret"}
+
+		a := Value(map[string]any{"email": "alice@example.com"}, opts).(map[string]any)["email"].(string)
+		b := Value(map[string]any{"email": "alice@example.com"}, opts).(map[string]any)["email"].(string)
+		c := Value(map[string]any{"email": "bob@example.com"}, opts).(map[string]any)["email"].(string)
+
+		if !strings.HasPrefix(a, "<redacted:") || strings.Contains(a, "alice") {
+			t.Errorf("expected a salted placeholder, got %q", a)
+		}
+		if a != b {
+			t.Errorf("expected equal values to hash equal, got %q and %q", a, b)
+		}
+		if a == c {
+			t.Errorf("expected different values to hash differently, both %q", a)
+		}
+	})
+
+	t.Run("salt changes hash", func(t *testing.T) {
+		a := redact("alice@example.com", "one")
+		b := redact("alice@example.com", "two")
+		if a == b {
+			t.Errorf("expected different salts to give different hashes, both %q", a)
+		}
+	})
+
+	t.Run("runs after normalization", func(t *testing.T) {
+		opts := Options{TrimStrings: true, RedactPaths: []string{".email"}, RedactSalt: "s"}
+		a := Value(map[string]any{"email": " alice@example.com "}, opts).(map[string]any)["email"]
+		b := Value(map[string]any{"email": "alice@example.com"}, opts).(map[string]any)["email"]
+		if a != b {
+			t.Errorf("expected trimmed values to redact equal, got %v and %v", a, b)
+		}
+	})
+}
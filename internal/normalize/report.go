@@ -0,0 +1,113 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action names a kind of change the normalizer made to a value.
+type Action string
+
+const (
+	ActionTrimmed     Action = "trimmed"      // Whitespace trimmed from a string
+	ActionUnicode     Action = "unicode"      // String rewritten to a Unicode normalization form
+	ActionDate        Action = "date"         // Date string rewritten in the output layout
+	ActionURL         Action = "url"          // URL canonicalized
+	ActionUUID        Action = "uuid"         // UUID canonicalized
+	ActionCoerced     Action = "coerced"      // String coerced to a bool, number or null
+	ActionRounded     Action = "rounded"      // Number rounded (RoundNumbers)
+	ActionNumber      Action = "number"       // Number text made canonical ("1.0" → "1")
+	ActionNullRemoved Action = "null-removed" // Null field removed (NullEqualsAbsent)
+	ActionExcluded    Action = "excluded"     // Field removed (ExcludeKeys, ExcludePaths)
+	ActionSorted      Action = "sorted"       // Array elements reordered
+	ActionTransformed Action = "transformed"  // Value changed by a custom transform
+	ActionRedacted    Action = "redacted"     // Value redacted (RedactPaths)
+)
+
+// actionPhrases describes each action for Report.Summary, in display order.
+// Each entry is the verb plus the singular and plural noun.
+var actionPhrases = []struct {
+	action           Action
+	verb             string
+	singular, plural string
+}{
+	{ActionTrimmed, "trimmed", "string", "strings"},
+	{ActionUnicode, "Unicode-normalized", "string", "strings"},
+	{ActionDate, "rewrote", "date", "dates"},
+	{ActionURL, "canonicalized", "URL", "URLs"},
+	{ActionUUID, "canonicalized", "UUID", "UUIDs"},
+	{ActionCoerced, "coerced", "string", "strings"},
+	{ActionRounded, "rounded", "number", "numbers"},
+	{ActionNumber, "normalized", "number", "numbers"},
+	{ActionNullRemoved, "removed", "null", "nulls"},
+	{ActionExcluded, "excluded", "field", "fields"},
+	{ActionSorted, "sorted", "array", "arrays"},
+	{ActionTransformed, "transformed", "value", "values"},
+	{ActionRedacted, "redacted", "value", "values"},
+}
+
+// Change records one action applied at one path.
+type Change struct {
+	Path   string `json:"path"`   // e.g. ".users[0].name" ("" is the root)
+	Action Action `json:"action"` // What was done
+}
+
+// Report describes what normalization did to a document.
+// Only changes that actually altered the data are recorded - trimming a
+// string that had no surrounding whitespace is not a change.
+type Report struct {
+	Counts  map[Action]int `json:"counts"`  // Number of changes per action
+	Changes []Change       `json:"changes"` // Every change, in document walk order
+}
+
+// newReport creates an empty report.
+func newReport() *Report {
+	return &Report{
+		Counts:  make(map[Action]int),
+		Changes: []Change{},
+	}
+}
+
+// add records a change.
+func (r *Report) add(path string, action Action) {
+	r.Counts[action]++
+	r.Changes = append(r.Changes, Change{Path: path, Action: action})
+}
+
+// Summary returns a one-line description of the report, e.g.
+// "trimmed 14 strings, rounded 2 numbers, removed 3 nulls".
+func (r *Report) Summary() string {
+	parts := []string{}
+	for _, p := range actionPhrases {
+		count := r.Counts[p.action]
+		if count == 0 {
+			continue
+		}
+		noun := p.plural
+		if count == 1 {
+			noun = p.singular
+		}
+		parts = append(parts, fmt.Sprintf("%s %d %s", p.verb, count, noun))
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ValueWithReport normalizes v like Value and also returns a report of
+// every change that was made. Useful to find out why two documents that
+// look different compare as equal.
+func ValueWithReport(v any, opts Options) (any, *Report) {
+	w := newWalker(opts)
+	w.report = newReport()
+	result := w.valueAt(v, "", opts)
+	return result, w.report
+}
+
+// note records a change if a report was requested.
+func (w *walker) note(path string, action Action) {
+	if w.report != nil {
+		w.report.add(path, action)
+	}
+}
@@ -0,0 +1,73 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+func TestValueWithReport(t *testing.T) {
+	input, err := parser.ParseString(`{
+		"name": "  Alice ",
+		"city": "Paris",
+		"price": 1.2345,
+		"count": 1.0,
+		"nickname": null,
+		"etag": "abc",
+		"tags": ["b", "a"],
+		"ids": [1, 2]
+	}`)
+	if err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+
+	opts := Options{
+		TrimStrings:      true,
+		NormalizeNumbers: true,
+		RoundNumbers:     2,
+		NullEqualsAbsent: true,
+		SortArrays:       true,
+		ExcludeKeys:      []string{"etag"},
+	}
+	result, report := ValueWithReport(input, opts)
+
+	// The report must not change the result
+	if !reflect.DeepEqual(result, Value(input, opts)) {
+		t.Errorf("ValueWithReport result differs from Value")
+	}
+
+	expectedCounts := map[Action]int{
+		ActionTrimmed:     1, // "city" and "ids" were already canonical
+		ActionRounded:     1,
+		ActionNumber:      1,
+		ActionNullRemoved: 1,
+		ActionExcluded:    1,
+		ActionSorted:      1,
+	}
+	if !reflect.DeepEqual(report.Counts, expectedCounts) {
+		t.Errorf("expected counts %v, got %v", expectedCounts, report.Counts)
+	}
+
+	expectedSummary := "trimmed 1 string, rounded 1 number, normalized 1 number, removed 1 null, excluded 1 field, sorted 1 array"
+	if got := report.Summary(); got != expectedSummary {
+		t.Errorf("expected summary %q, got %q", expectedSummary, got)
+	}
+
+	found := false
+	for _, c := range report.Changes {
+		if c.Path == ".name" && c.Action == ActionTrimmed {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a trimmed change at .name, got %v", report.Changes)
+	}
+}
+
+func TestReportSummaryEmpty(t *testing.T) {
+	_, report := ValueWithReport(map[string]any{"a": "x"}, DefaultOptions())
+	if got := report.Summary(); got != "no changes" {
+		t.Errorf("expected %q, got %q", "no changes", got)
+	}
+}
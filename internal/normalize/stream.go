@@ -0,0 +1,325 @@
+package normalize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"jtool/internal/pathmatch"
+)
+
+// Stream normalizes JSON read from r and writes it to w as compact JSON,
+// without building the whole document in memory.
+//
+// The input is read token by token with json.Decoder and every option is
+// applied on the fly, so the result matches what Value would produce.
+// Memory use depends on the shape of the data, not its total size:
+//   - Arrays are written element by element.
+//   - Objects with SortKeys or UnicodeNormalization hold their encoded
+//     members until the object ends, so keys can be written in order and
+//     keys that normalize to the same one resolved as Value does.
+//   - Arrays that are sorted, and values at paths matched by Transforms or
+//     RedactPaths, need their whole value and are decoded into memory.
+//
+// A 500MB export that is one big array of records therefore streams with
+// only one record in memory at a time. r may also hold several top-level
+// values (e.g. JSON Lines); each is written on its own line.
+func Stream(r io.Reader, w io.Writer, opts Options) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	out := bufio.NewWriter(w)
+	s := &streamer{walker: newWalker(opts), dec: dec}
+
+	count := 0
+	for dec.More() {
+		if _, err := s.value(out, "", opts, nil, false); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		count++
+	}
+
+	// More stops at the end of input or at a stray closing delimiter
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("invalid character after top-level value")
+		}
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("unexpected end of JSON input")
+	}
+
+	// bufio.Writer keeps the first write error, so checking Flush is enough
+	return out.Flush()
+}
+
+// streamWriter is implemented by both *bufio.Writer (the output) and
+// *bytes.Buffer (object members held for key sorting).
+type streamWriter interface {
+	io.Writer
+	io.ByteWriter
+}
+
+// streamer reads tokens from dec and normalizes them with walker.
+type streamer struct {
+	walker  *walker
+	dec     *json.Decoder
+	scratch bytes.Buffer
+}
+
+// member is an encoded object member ("key":value) waiting to be sorted.
+type member struct {
+	key      string
+	original string // The key before Unicode normalization
+	encoded  []byte // nil if the member was dropped
+}
+
+// value normalizes the next value from the decoder and writes prefix followed
+// by the value to out. prefix holds the separator and key that belong in front
+// of the value, so nothing is written when a null member is dropped (dropNull).
+// Returns whether anything was written.
+func (s *streamer) value(out streamWriter, path string, parentOpts Options, prefix []byte, dropNull bool) (bool, error) {
+	w := s.walker
+	opts := w.resolveOptions(path, parentOpts)
+
+	// Transforms and redaction work on whole values
+	if s.needsWholeValue(path) {
+		var v any
+		if err := s.dec.Decode(&v); err != nil {
+			return false, err
+		}
+		return s.write(out, w.valueAt(v, path, parentOpts), prefix, dropNull)
+	}
+
+	tok, err := s.dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		out.Write(prefix)
+		if t == '[' {
+			return true, s.array(out, path, opts)
+		}
+		return true, s.object(out, path, opts)
+	default:
+		// Scalars: string, json.Number, bool or nil
+		return s.write(out, w.valueAt(t, path, parentOpts), prefix, dropNull)
+	}
+}
+
+// array streams the elements of an array whose '[' was just read.
+func (s *streamer) array(out streamWriter, path string, opts Options) error {
+	// Sorting needs every element at once
+	if s.sortsArray(path, opts) {
+		arr := []any{}
+		for s.dec.More() {
+			var v any
+			if err := s.dec.Decode(&v); err != nil {
+				return err
+			}
+			arr = append(arr, v)
+		}
+		if _, err := s.dec.Token(); err != nil { // Closing ']'
+			return err
+		}
+		return s.encode(out, s.walker.normalizeArray(arr, path, opts))
+	}
+
+	out.WriteByte('[')
+	for i := 0; s.dec.More(); i++ {
+		var prefix []byte
+		if i > 0 {
+			prefix = []byte{','}
+		}
+		childPath := path + "[" + strconv.Itoa(i) + "]"
+		if _, err := s.value(out, childPath, opts, prefix, false); err != nil {
+			return err
+		}
+	}
+	if _, err := s.dec.Token(); err != nil { // Closing ']'
+		return err
+	}
+	return out.WriteByte(']')
+}
+
+// object streams the members of an object whose '{' was just read.
+func (s *streamer) object(out streamWriter, path string, opts Options) error {
+	w := s.walker
+	form := opts.UnicodeNormalization
+	buffered := opts.SortKeys || form != UnicodeNone
+	members := []member{}         // Only used when buffered
+	index := make(map[string]int) // Member by key, with UnicodeNormalization
+	wrote := false
+
+	out.WriteByte('{')
+	for s.dec.More() {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		original, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key at %s, got %v", path, tok)
+		}
+		key := original
+		if form != UnicodeNone {
+			key = normalizeUnicode(key, form)
+		}
+		childPath := path + "." + key
+
+		prev, dup := index[key]
+		if dup && !keepKey(original, members[prev].original, key) {
+			if err := s.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if w.excluded(key, childPath) {
+			if err := s.skip(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		dropNull := w.resolveOptions(childPath, opts).NullEqualsAbsent
+		keyJSON, err := s.marshal(key)
+		if err != nil {
+			return err
+		}
+		keyJSON = append(keyJSON, ':')
+
+		if buffered {
+			var buf bytes.Buffer
+			ok, err := s.value(&buf, childPath, opts, keyJSON, dropNull)
+			if err != nil {
+				return err
+			}
+			m := member{key: key, original: original}
+			if ok {
+				m.encoded = buf.Bytes()
+			}
+			if dup {
+				members[prev] = m
+			} else {
+				index[key] = len(members)
+				members = append(members, m)
+			}
+			continue
+		}
+
+		prefix := keyJSON
+		if wrote {
+			prefix = append([]byte{','}, keyJSON...)
+		}
+		ok, err = s.value(out, childPath, opts, prefix, dropNull)
+		if err != nil {
+			return err
+		}
+		wrote = wrote || ok
+	}
+	if _, err := s.dec.Token(); err != nil { // Closing '}'
+		return err
+	}
+
+	// Same order as encoding/json uses for maps
+	if opts.SortKeys {
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].key < members[j].key
+		})
+	}
+	for _, m := range members {
+		if m.encoded == nil {
+			continue
+		}
+		if wrote {
+			out.WriteByte(',')
+		}
+		out.Write(m.encoded)
+		wrote = true
+	}
+
+	return out.WriteByte('}')
+}
+
+// needsWholeValue reports whether a transform or redaction applies at path.
+func (s *streamer) needsWholeValue(path string) bool {
+	for _, ct := range s.walker.transforms {
+		if pathmatch.Match(ct.rule.Path, path) {
+			return true
+		}
+	}
+	for _, pattern := range s.walker.redact {
+		if pathmatch.Match(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortsArray reports whether the array at path will be sorted.
+func (s *streamer) sortsArray(path string, opts Options) bool {
+	_, byPath := s.walker.sortSpecFor(path)
+	return byPath || opts.SortArraysByKey != "" || opts.SortArrays
+}
+
+// skip consumes the next value without decoding it.
+func (s *streamer) skip() error {
+	depth := 0
+	for {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			if d == '[' || d == '{' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// write writes prefix and v unless v is a null member being dropped.
+func (s *streamer) write(out streamWriter, v any, prefix []byte, dropNull bool) (bool, error) {
+	if v == nil && dropNull {
+		return false, nil
+	}
+	out.Write(prefix)
+	return true, s.encode(out, v)
+}
+
+// encode writes v as compact JSON.
+func (s *streamer) encode(out streamWriter, v any) error {
+	data, err := s.marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// marshal encodes v without HTML escaping ("<" stays "<"). The returned
+// slice is a copy, so it stays valid after the next call.
+func (s *streamer) marshal(v any) ([]byte, error) {
+	s.scratch.Reset()
+	enc := json.NewEncoder(&s.scratch)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encode adds a newline after every value
+	return bytes.Clone(bytes.TrimSuffix(s.scratch.Bytes(), []byte{'\n'})), nil
+}
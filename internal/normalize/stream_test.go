@@ -0,0 +1,140 @@
+package normalize
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+// TestStreamMatchesValue checks that streaming gives the same result as
+// normalizing the parsed document with Value.
+func TestStreamMatchesValue(t *testing.T) {
+	input := `{
+		"zeta": " padded ",
+		"alpha": {"b": 1.50, "a": null, "etag": "x"},
+		"items": [{"id": 2, "tags": ["b", "a"]}, {"id": 1, "tags": []}],
+		"big": 9007199254740993,
+		"secret": {"token": "abc"},
+		"status": "N/A",
+		"caf\u00e9": 1, "cafe\u0301": 2, "nai\u0308ve": null
+	}`
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"defaults", DefaultOptions()},
+		{"no normalization but sorted keys", Options{SortKeys: true}},
+		{"strings and nulls", Options{SortKeys: true, TrimStrings: true, NullEqualsAbsent: true, NullStrings: []string{"N/A"}}},
+		{"sorted arrays", Options{SortKeys: true, SortArrays: true, SortArraysByPath: map[string]string{".items": "id"}}},
+		{"exclude and redact", Options{SortKeys: true, ExcludeKeys: []string{"etag"}, RedactPaths: []string{".secret"}, RedactSalt: "s"}},
+		{"transforms", Options{SortKeys: true, Transforms: []TransformRule{{Path: ".items", Kind: TransformConstant, Value: "gone"}}}},
+		{"overrides", Options{SortKeys: true, Overrides: []Override{{Path: ".alpha", Options: Options{SortKeys: true, NullEqualsAbsent: true}}}}},
+		{"unicode keys", Options{SortKeys: true, NullEqualsAbsent: true, UnicodeNormalization: UnicodeNFC}},
+		{"unicode keys decomposed", Options{SortKeys: true, UnicodeNormalization: UnicodeNFD}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := parser.ParseString(input)
+			if err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+			// Stream doesn't escape HTML ("<redacted>"), so neither may the expectation
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(Value(data, tt.opts)); err != nil {
+				t.Fatalf("failed to encode expected value: %v", err)
+			}
+			expected := strings.TrimSuffix(buf.String(), "\n")
+
+			var out bytes.Buffer
+			if err := Stream(strings.NewReader(input), &out, tt.opts); err != nil {
+				t.Fatalf("Stream returned error: %v", err)
+			}
+
+			if got := strings.TrimSuffix(out.String(), "\n"); got != expected {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "keeps key order without SortKeys",
+			input:    `{"b": 1, "a": {"d": 2, "c": 3}}`,
+			opts:     NoNormalization(),
+			expected: "{\"b\":1,\"a\":{\"d\":2,\"c\":3}}\n",
+		},
+		{
+			name:     "normalizes keys in document order",
+			input:    `{"b": 1, "cafe\u0301": 2, "caf\u00e9": 3, "a": 4}`,
+			opts:     Options{UnicodeNormalization: UnicodeNFC},
+			expected: "{\"b\":1,\"café\":3,\"a\":4}\n",
+		},
+		{
+			name:     "drops nulls without dangling commas",
+			input:    `{"a": null, "b": 1, "c": null}`,
+			opts:     Options{NullEqualsAbsent: true},
+			expected: "{\"b\":1}\n",
+		},
+		{
+			name:     "multiple top-level values",
+			input:    "{\"b\": 1.0, \"a\": 2}\n{\"a\": 3}\n",
+			opts:     DefaultOptions(),
+			expected: "{\"a\":2,\"b\":1}\n{\"a\":3}\n",
+		},
+		{
+			name:     "does not escape HTML",
+			input:    `{"html": "<b>&</b>"}`,
+			opts:     DefaultOptions(),
+			expected: "{\"html\":\"<b>&</b>\"}\n",
+		},
+		{
+			name:     "skips excluded subtrees",
+			input:    `{"debug": {"trace": [1, [2, {"x": 3}]]}, "ok": true}`,
+			opts:     Options{ExcludePaths: []string{".debug"}},
+			expected: "{\"ok\":true}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Stream(strings.NewReader(tt.input), &out, tt.opts); err != nil {
+				t.Fatalf("Stream returned error: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	inputs := map[string]string{
+		"empty":           ``,
+		"truncated":       `{"a": [1, 2`,
+		"invalid":         `{"a":}`,
+		"stray delimiter": `{"a": 1}}`,
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Stream(strings.NewReader(input), &out, DefaultOptions()); err == nil {
+				t.Errorf("expected error for %q, got output %q", input, out.String())
+			}
+		})
+	}
+}
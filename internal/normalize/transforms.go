@@ -0,0 +1,173 @@
+package normalize
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"jtool/internal/pathmatch"
+)
+
+// TransformKind identifies a custom transform applied by a TransformRule.
+type TransformKind string
+
+const (
+	TransformRound         TransformKind = "round"          // Round numbers to N decimal places
+	TransformLowercase     TransformKind = "lowercase"      // Lowercase strings
+	TransformTruncate      TransformKind = "truncate"       // Keep the first N characters of strings
+	TransformRegexReplace  TransformKind = "regex-replace"  // Replace regex matches in strings
+	TransformConstant      TransformKind = "constant"       // Replace the value (of any type) with Value
+	TransformStripHTML     TransformKind = "strip-html"     // Reduce HTML strings to their text
+	TransformStripMarkdown TransformKind = "strip-markdown" // Reduce Markdown strings to their text
+)
+
+// TransformRule applies a transform to every value whose path matches Path.
+//
+// Example - strip the query string from .url before comparing:
+//
+//	TransformRule{Path: ".url", Kind: TransformRegexReplace, Pattern: `\?.*$`}
+//
+// Transforms only touch values of the type they make sense for: round skips
+// non-numbers, and the string transforms (lowercase, truncate,
+// regex-replace, strip-html, strip-markdown) skip non-strings.
+// Rules run in order, so later rules see the output of earlier ones.
+type TransformRule struct {
+	Path        string        `json:"path"`        // pathmatch pattern, e.g. ".items[].price"
+	Kind        TransformKind `json:"kind"`        // Which transform to apply
+	N           int           `json:"n"`           // round: decimal places, truncate: max characters
+	Pattern     string        `json:"pattern"`     // regex-replace: regular expression (RE2 syntax)
+	Replacement string        `json:"replacement"` // regex-replace: replacement, may use $1 etc.
+	Value       any           `json:"value"`       // constant: replacement value
+}
+
+// compiledTransform is a TransformRule with its regex compiled.
+type compiledTransform struct {
+	rule  TransformRule
+	regex *regexp.Regexp
+}
+
+// ValidateTransforms checks that every rule is well-formed.
+// Value silently skips invalid rules, so callers taking rules from users
+// should validate them first to report mistakes.
+func ValidateTransforms(rules []TransformRule) error {
+	for i, rule := range rules {
+		if rule.Path == "" {
+			return fmt.Errorf("transform %d: path is required", i)
+		}
+		switch rule.Kind {
+		case TransformRound, TransformLowercase, TransformConstant, TransformStripHTML, TransformStripMarkdown:
+		case TransformTruncate:
+			if rule.N < 0 {
+				return fmt.Errorf("transform %d: truncate length must not be negative", i)
+			}
+		case TransformRegexReplace:
+			if _, err := regexp.Compile(rule.Pattern); err != nil {
+				return fmt.Errorf("transform %d: invalid pattern: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("transform %d: unknown kind %q", i, rule.Kind)
+		}
+	}
+	return nil
+}
+
+// compileTransforms prepares rules for repeated use, dropping invalid ones.
+func compileTransforms(rules []TransformRule) []compiledTransform {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	compiled := make([]compiledTransform, 0, len(rules))
+	for _, rule := range rules {
+		ct := compiledTransform{rule: rule}
+		if rule.Kind == TransformRegexReplace {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				continue
+			}
+			ct.regex = re
+		}
+		compiled = append(compiled, ct)
+	}
+	return compiled
+}
+
+// applyTransforms runs every rule matching path against v.
+func (w *walker) applyTransforms(v any, path string) any {
+	for _, ct := range w.transforms {
+		if pathmatch.Match(ct.rule.Path, path) {
+			out := ct.apply(v)
+			if w.report != nil && !reflect.DeepEqual(out, v) {
+				w.note(path, ActionTransformed)
+			}
+			v = out
+		}
+	}
+	return v
+}
+
+// apply runs a single transform against v.
+func (ct compiledTransform) apply(v any) any {
+	rule := ct.rule
+
+	switch rule.Kind {
+	case TransformConstant:
+		return rule.Value
+
+	case TransformRound:
+		switch n := v.(type) {
+		case float64:
+			return roundTo(n, rule.N)
+		case json.Number:
+			return roundJSONNumber(n, rule.N)
+		}
+
+	case TransformLowercase:
+		if s, ok := v.(string); ok {
+			return strings.ToLower(s)
+		}
+
+	case TransformTruncate:
+		if s, ok := v.(string); ok {
+			runes := []rune(s)
+			if len(runes) > rule.N {
+				return string(runes[:rule.N])
+			}
+		}
+
+	case TransformRegexReplace:
+		if s, ok := v.(string); ok && ct.regex != nil {
+			return ct.regex.ReplaceAllString(s, rule.Replacement)
+		}
+
+	case TransformStripHTML:
+		if s, ok := v.(string); ok {
+			return stripHTML(s)
+		}
+
+	case TransformStripMarkdown:
+		if s, ok := v.(string); ok {
+			return stripMarkdown(s)
+		}
+	}
+
+	return v
+}
+
+// roundTo rounds n to the given number of decimal places (half away from zero).
+// Negative places round to tens, hundreds, etc.
+func roundTo(n float64, places int) float64 {
+	if math.IsInf(n, 0) || math.IsNaN(n) {
+		return n
+	}
+	scale := math.Pow(10, float64(places))
+	rounded := math.Round(n*scale) / scale
+	if math.IsInf(rounded, 0) || math.IsNaN(rounded) {
+		// Scaling overflowed; the number has no digits at that precision anyway
+		return n
+	}
+	return rounded
+}
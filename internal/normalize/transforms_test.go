@@ -0,0 +1,130 @@
+package normalize
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		rules    []TransformRule
+		expected string
+	}{
+		{
+			name:     "strip query string",
+			input:    `{"url": "https://x.io/a?utm=1", "other": "b?c"}`,
+			rules:    []TransformRule{{Path: ".url", Kind: TransformRegexReplace, Pattern: `\?.*$`}},
+			expected: `{"other":"b?c","url":"https://x.io/a"}`,
+		},
+		{
+			name:     "regex replace with capture group",
+			input:    `{"id": "user-42"}`,
+			rules:    []TransformRule{{Path: ".id", Kind: TransformRegexReplace, Pattern: `^user-(\d+)$`, Replacement: "$1"}},
+			expected: `{"id":"42"}`,
+		},
+		{
+			name:     "round prices in array",
+			input:    `{"items": [{"price": 1.23456}, {"price": 2.5}]}`,
+			rules:    []TransformRule{{Path: ".items[].price", Kind: TransformRound, N: 2}},
+			expected: `{"items":[{"price":1.23},{"price":2.5}]}`,
+		},
+		{
+			name:     "lowercase",
+			input:    `{"email": "Alice@Example.COM"}`,
+			rules:    []TransformRule{{Path: ".email", Kind: TransformLowercase}},
+			expected: `{"email":"alice@example.com"}`,
+		},
+		{
+			name:     "truncate counts characters not bytes",
+			input:    `{"s": "héllo world"}`,
+			rules:    []TransformRule{{Path: ".s", Kind: TransformTruncate, N: 5}},
+			expected: `{"s":"héllo"}`,
+		},
+		{
+			name:     "constant replaces containers too",
+			input:    `{"meta": {"a": 1}, "b": 2}`,
+			rules:    []TransformRule{{Path: ".meta", Kind: TransformConstant, Value: "META"}},
+			expected: `{"b":2,"meta":"META"}`,
+		},
+		{
+			name:     "type mismatch leaves value alone",
+			input:    `{"n": 5, "s": "x"}`,
+			rules:    []TransformRule{{Path: ".n", Kind: TransformLowercase}, {Path: ".s", Kind: TransformRound, N: 1}},
+			expected: `{"n":5,"s":"x"}`,
+		},
+		{
+			name:  "rules chain in order",
+			input: `{"s": "ABCDEF"}`,
+			rules: []TransformRule{
+				{Path: ".s", Kind: TransformLowercase},
+				{Path: ".s", Kind: TransformTruncate, N: 3},
+			},
+			expected: `{"s":"abc"}`,
+		},
+		{
+			name:     "invalid regex is skipped",
+			input:    `{"s": "abc"}`,
+			rules:    []TransformRule{{Path: ".s", Kind: TransformRegexReplace, Pattern: `(`}},
+			expected: `{"s":"abc"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data any
+			if err := json.Unmarshal([]byte(tt.input), &data); err != nil {
+				t.Fatalf("failed to parse input: %v", err)
+			}
+
+			result := Value(data, Options{Transforms: tt.rules})
+
+			resultJSON, _ := json.Marshal(result)
+			if string(resultJSON) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(resultJSON))
+			}
+		})
+	}
+}
+
+func TestValidateTransforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []TransformRule
+		wantErr bool
+	}{
+		{"valid", []TransformRule{{Path: ".a", Kind: TransformLowercase}}, false},
+		{"missing path", []TransformRule{{Kind: TransformLowercase}}, true},
+		{"unknown kind", []TransformRule{{Path: ".a", Kind: "explode"}}, true},
+		{"bad regex", []TransformRule{{Path: ".a", Kind: TransformRegexReplace, Pattern: "("}}, true},
+		{"negative truncate", []TransformRule{{Path: ".a", Kind: TransformTruncate, N: -1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransforms(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransforms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	tests := []struct {
+		n        float64
+		places   int
+		expected float64
+	}{
+		{1.005, 2, 1},
+		{1.2345, 2, 1.23},
+		{-1.5, 0, -2},
+		{1234, -2, 1200},
+	}
+	for _, tt := range tests {
+		if got := roundTo(tt.n, tt.places); got != tt.expected {
+			t.Errorf("roundTo(%v, %d) = %v, want %v", tt.n, tt.places, got, tt.expected)
+		}
+	}
+}
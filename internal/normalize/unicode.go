@@ -0,0 +1,35 @@
+package normalize
+
+import "golang.org/x/text/unicode/norm"
+
+// normalizeUnicode converts s to the requested normalization form.
+//
+// The same visible text can be encoded in several ways: "é" may be one
+// code point (U+00E9) or "e" followed by a combining accent (U+0065 U+0301).
+// Normalizing both sides to the same form makes them compare equal.
+// Unknown forms leave s unchanged.
+func normalizeUnicode(s string, form UnicodeForm) string {
+	switch form {
+	case UnicodeNFC:
+		return norm.NFC.String(s)
+	case UnicodeNFD:
+		return norm.NFD.String(s)
+	case UnicodeNFKC:
+		return norm.NFKC.String(s)
+	case UnicodeNFKD:
+		return norm.NFKD.String(s)
+	default:
+		return s
+	}
+}
+
+// keepKey reports whether key is kept over prev, another key of the same
+// object, when both normalize to nkey. The key already in normal form
+// wins, or else the smaller one, so the outcome doesn't depend on the
+// order keys are seen in.
+func keepKey(key, prev, nkey string) bool {
+	if prev == nkey {
+		return false
+	}
+	return key == nkey || key < prev
+}
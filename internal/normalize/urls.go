@@ -0,0 +1,73 @@
+package normalize
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// defaultPorts maps URL schemes to the port that is implied when none is given.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+// normalizeURL rewrites an absolute URL in canonical form.
+// Strings that aren't absolute URLs (no scheme or host) are returned unchanged.
+//
+// Examples:
+//
+//	"HTTP://Example.COM:80/Path" → "http://example.com/Path" (path case is kept)
+//	"https://x.io/?b=2&a=1"      → "https://x.io/?a=1&b=2"
+//	"https://x.io/?a=1&utm_source=mail" with DropParams ["utm_*"] → "https://x.io/?a=1"
+//	"not a url"                  → "not a url"
+func normalizeURL(s string, opts URLOptions) string {
+	// Cheap check first - url.Parse accepts almost anything
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return s
+	}
+
+	// Scheme and host are case-insensitive; the path is not
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	if port := u.Port(); port != "" && port != defaultPorts[u.Scheme] {
+		host += ":" + port
+	}
+	u.Host = host
+
+	if u.RawQuery != "" {
+		query, err := url.ParseQuery(u.RawQuery)
+		if err != nil {
+			return s
+		}
+		for name := range query {
+			if matchesAny(opts.DropParams, name) {
+				query.Del(name)
+			}
+		}
+		// Encode sorts by parameter name; repeated values keep their order
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// matchesAny reports whether name matches any of the glob patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
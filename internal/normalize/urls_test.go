@@ -0,0 +1,53 @@
+package normalize
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     URLOptions
+		expected string
+	}{
+		{"lowercase scheme and host", "HTTPS://Example.COM/Path", URLOptions{Enabled: true}, "https://example.com/Path"},
+		{"strip default http port", "http://example.com:80/a", URLOptions{Enabled: true}, "http://example.com/a"},
+		{"strip default https port", "https://example.com:443/a", URLOptions{Enabled: true}, "https://example.com/a"},
+		{"keep non-default port", "https://example.com:8443/a", URLOptions{Enabled: true}, "https://example.com:8443/a"},
+		{"ipv6 host", "http://[::1]:80/a", URLOptions{Enabled: true}, "http://[::1]/a"},
+		{"ipv6 host with port", "http://[::1]:8080/a", URLOptions{Enabled: true}, "http://[::1]:8080/a"},
+		{"sort query parameters", "https://x.io/cb?state=abc&code=123", URLOptions{Enabled: true}, "https://x.io/cb?code=123&state=abc"},
+		{"keep fragment", "https://x.io/?b=1&a=2#top", URLOptions{Enabled: true}, "https://x.io/?a=2&b=1#top"},
+		{
+			name:     "drop tracking parameters",
+			input:    "https://x.io/?id=7&utm_source=mail&utm_medium=email&fbclid=abc",
+			opts:     URLOptions{Enabled: true, DropParams: []string{"utm_*", "fbclid"}},
+			expected: "https://x.io/?id=7",
+		},
+		{"not a url", "hello world", URLOptions{Enabled: true}, "hello world"},
+		{"relative path", "/a?b=2&a=1", URLOptions{Enabled: true}, "/a?b=2&a=1"},
+		{"no host", "mailto://?x=1", URLOptions{Enabled: true}, "mailto://?x=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeURL(tt.input, tt.opts); got != tt.expected {
+				t.Errorf("normalizeURL(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeURLsOption(t *testing.T) {
+	left := Value(map[string]any{"redirect": "https://Auth.example.com:443/cb?state=x&code=1"}, Options{NormalizeURLs: URLOptions{Enabled: true}})
+	right := Value(map[string]any{"redirect": "https://auth.example.com/cb?code=1&state=x"}, Options{NormalizeURLs: URLOptions{Enabled: true}})
+
+	if left.(map[string]any)["redirect"] != right.(map[string]any)["redirect"] {
+		t.Errorf("expected URLs to normalize equal, got %v and %v", left, right)
+	}
+
+	// Disabled by default
+	untouched := Value("HTTPS://X.IO/?b=1&a=2", DefaultOptions())
+	if untouched != "HTTPS://X.IO/?b=1&a=2" {
+		t.Errorf("expected URL unchanged with default options, got %v", untouched)
+	}
+}
@@ -0,0 +1,54 @@
+package normalize
+
+import "strings"
+
+// normalizeUUID rewrites a UUID string in canonical form: lowercase,
+// hyphenated, without braces or a "urn:uuid:" prefix. Other strings are
+// returned unchanged.
+//
+// Examples:
+//
+//	"{3F2504E0-4F89-11D3-9A0C-0305E82C3301}"        → "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+//	"urn:uuid:3F2504E0-4F89-11D3-9A0C-0305E82C3301" → "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+//	"3f2504e0-4f89-11d3-9a0c-0305e82c3301"          → unchanged
+//	"3F2504E04F8911D39A0C0305E82C3301"              → unchanged (could be any hex hash)
+func normalizeUUID(s string) string {
+	u := s
+	if len(u) == 38 && u[0] == '{' && u[37] == '}' {
+		u = u[1:37]
+	} else if len(u) == 45 && strings.EqualFold(u[:9], "urn:uuid:") {
+		u = u[9:]
+	}
+
+	if !IsUUID(u) {
+		return s
+	}
+	return strings.ToLower(u)
+}
+
+// IsUUID reports whether s has the 8-4-4-4-12 hex digit layout of a UUID,
+// in either case.
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isHexDigit reports whether c is an ASCII hexadecimal digit.
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,40 @@
+package normalize
+
+import "testing"
+
+func TestNormalizeUUID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lowercase", "3f2504e0-4f89-11d3-9a0c-0305e82c3301", "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{"uppercase", "3F2504E0-4F89-11D3-9A0C-0305E82C3301", "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{"braces", "{3F2504E0-4F89-11D3-9A0C-0305E82C3301}", "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{"urn prefix", "urn:uuid:3F2504E0-4F89-11D3-9A0C-0305E82C3301", "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		{"no hyphens unchanged", "3F2504E04F8911D39A0C0305E82C3301", "3F2504E04F8911D39A0C0305E82C3301"},
+		{"unbalanced brace unchanged", "{3F2504E0-4F89-11D3-9A0C-0305E82C3301", "{3F2504E0-4F89-11D3-9A0C-0305E82C3301"},
+		{"non-hex unchanged", "3G2504E0-4F89-11D3-9A0C-0305E82C3301", "3G2504E0-4F89-11D3-9A0C-0305E82C3301"},
+		{"plain text unchanged", "Hello", "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeUUID(tt.input); got != tt.expected {
+				t.Errorf("normalizeUUID(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeUUIDsOption(t *testing.T) {
+	dotnet := Value("{3F2504E0-4F89-11D3-9A0C-0305E82C3301}", Options{NormalizeUUIDs: true})
+	java := Value("3f2504e0-4f89-11d3-9a0c-0305e82c3301", Options{NormalizeUUIDs: true})
+	if dotnet != java {
+		t.Errorf("expected UUIDs to normalize equal, got %v and %v", dotnet, java)
+	}
+
+	if got := Value("{3F2504E0-4F89-11D3-9A0C-0305E82C3301}", DefaultOptions()); got != "{3F2504E0-4F89-11D3-9A0C-0305E82C3301}" {
+		t.Errorf("expected UUID unchanged with default options, got %v", got)
+	}
+}
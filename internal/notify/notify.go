@@ -0,0 +1,79 @@
+// Package notify shows desktop notifications, for events that happen
+// while the user is looking at another window (e.g. a monitored comparison
+// finding new differences).
+//
+// Wails has no notification API, so each OS's own tool is used:
+// osascript on macOS, a PowerShell toast on Windows and notify-send
+// elsewhere.
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// appName is shown as the sender of notifications where the OS allows it.
+const appName = "jtool"
+
+// Environment variables the Windows toast script reads the title and body
+// from, so they never become part of the script's text.
+const (
+	titleEnv = "JTOOL_NOTIFY_TITLE"
+	bodyEnv  = "JTOOL_NOTIFY_BODY"
+)
+
+// Send shows a notification with a title and a line of text. It returns
+// once the notification has been handed to the OS.
+func Send(title, body string) error {
+	command, env := sendCommand(runtime.GOOS, title, body)
+	cmd := exec.Command(command[0], command[1:]...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if text := strings.TrimSpace(string(out)); text != "" {
+			return fmt.Errorf("error running %s: %s", command[0], text)
+		}
+		return fmt.Errorf("error running %s: %w", command[0], err)
+	}
+	return nil
+}
+
+// sendCommand returns the command, for the OS goos, that shows a
+// notification, and any environment variables ("NAME=value") to run it with.
+func sendCommand(goos, title, body string) (command, env []string) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		return []string{"osascript", "-e", script}, nil
+	case "windows":
+		return []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript},
+			[]string{titleEnv + "=" + title, bodyEnv + "=" + body}
+	default:
+		// "--" so a title or body starting with "-" isn't read as an option
+		return []string{"notify-send", "--app-name=" + appName, "--", title, body}, nil
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// toastScript is a PowerShell script that shows a Windows toast with the
+// title and body in the titleEnv and bodyEnv environment variables.
+// PowerShell has several quote characters (including typographic ones),
+// so passing the text this way is safer than quoting it into the script.
+var toastScript = strings.Join([]string{
+	"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null",
+	"$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)",
+	"$text = $xml.GetElementsByTagName('text')",
+	"$text.Item(0).AppendChild($xml.CreateTextNode($env:" + titleEnv + ")) > $null",
+	"$text.Item(1).AppendChild($xml.CreateTextNode($env:" + bodyEnv + ")) > $null",
+	"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('" + appName + "').Show([Windows.UI.Notifications.ToastNotification]::new($xml))",
+}, "; ")
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSendCommand(t *testing.T) {
+	title, body := `Users "v2"`, `3 differences in C:\data`
+
+	got, env := sendCommand("darwin", title, body)
+	expected := []string{"osascript", "-e", `display notification "3 differences in C:\\data" with title "Users \"v2\""`}
+	if !reflect.DeepEqual(got, expected) || env != nil {
+		t.Errorf("darwin: expected %q, got %q (env %q)", expected, got, env)
+	}
+
+	got, env = sendCommand("linux", "-v", body)
+	expected = []string{"notify-send", "--app-name=jtool", "--", "-v", body}
+	if !reflect.DeepEqual(got, expected) || env != nil {
+		t.Errorf("linux: expected %q, got %q (env %q)", expected, got, env)
+	}
+
+	// The text is passed in the environment, never in the script, so no
+	// quote character (including U+2019) can end a string literal early
+	title = "It\u2019s changed'); Remove-Item C:\\ #"
+	got, env = sendCommand("windows", title, body)
+	if got[0] != "powershell" || strings.Contains(got[len(got)-1], "Remove-Item") {
+		t.Errorf("windows: unexpected command %q", got)
+	}
+	expected = []string{"JTOOL_NOTIFY_TITLE=" + title, "JTOOL_NOTIFY_BODY=" + body}
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("windows: expected env %q, got %q", expected, env)
+	}
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Extract finds a JSON document in text that may have more around it, such
+// as a snippet copied from a chat message, a Markdown code fence or a log
+// line: "here you go: {"id": 1} thanks". It returns the document's text and
+// whether anything had to be cut away to find it.
+//
+// Text that parses as a whole is returned as is. Otherwise the first {...}
+// or [...] that parses wins; if nothing does, the error is the one from
+// parsing the whole text, which is the most useful to show.
+func Extract(text string) (doc string, extracted bool, err error) {
+	trimmed := strings.TrimSpace(text)
+	if _, err = ParseString(trimmed); err == nil {
+		return trimmed, trimmed != text, nil
+	}
+
+	if fenced, ok := codeFence(trimmed); ok {
+		if _, ferr := ParseString(fenced); ferr == nil {
+			return fenced, true, nil
+		}
+		trimmed = fenced
+	}
+
+	for start := 0; start < len(trimmed); start++ {
+		if trimmed[start] != '{' && trimmed[start] != '[' {
+			continue
+		}
+		// A decoder stops after one value, so trailing text doesn't matter
+		dec := json.NewDecoder(bytes.NewReader([]byte(trimmed[start:])))
+		dec.UseNumber()
+		var v any
+		if dec.Decode(&v) == nil {
+			return trimmed[start : start+int(dec.InputOffset())], true, nil
+		}
+	}
+	return "", false, err
+}
+
+// codeFence returns the contents of the first Markdown code fence in text,
+// e.g. "```json\n{...}\n```".
+func codeFence(text string) (string, bool) {
+	_, rest, ok := strings.Cut(text, "```")
+	if !ok {
+		return "", false
+	}
+	// Skip the info string ("json") on the opening line
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[i+1:]
+	}
+	body, _, _ := strings.Cut(rest, "```")
+	return strings.TrimSpace(body), true
+}
@@ -0,0 +1,38 @@
+package parser
+
+import "testing"
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		doc       string
+		extracted bool
+	}{
+		{"plain", `{"id": 1}`, `{"id": 1}`, false},
+		{"whitespace", "\n  [1, 2]\n", `[1, 2]`, true},
+		{"code fence", "Here you go:\n```json\n{\"id\": 1}\n```\nThanks!", `{"id": 1}`, true},
+		{"prose", `the response was {"ok": true, "ids": [1, 2]} as expected`, `{"ok": true, "ids": [1, 2]}`, true},
+		{"bracket prefix", `[INFO] 12:00 {"id": 9007199254740993}`, `{"id": 9007199254740993}`, true},
+		{"nested", `x {"a": {"b": "}"}} y`, `{"a": {"b": "}"}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, extracted, err := Extract(tt.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if doc != tt.doc || extracted != tt.extracted {
+				t.Errorf("expected %q (extracted=%v), got %q (extracted=%v)", tt.doc, tt.extracted, doc, extracted)
+			}
+		})
+	}
+
+	if _, _, err := Extract(`{"id": 1,}`); err == nil {
+		t.Error("expected an error for broken JSON")
+	}
+	if _, _, err := Extract("no json here"); err == nil {
+		t.Error("expected an error for plain text")
+	}
+}
@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Lenient rewrites JSON5 or JSONC text (JSON with comments) as standard
+// JSON: comments and trailing commas are dropped, single-quoted strings and
+// unquoted keys are quoted, and JSON5 number forms such as 0x1F, +1, .5
+// and 5. are written in JSON notation. Infinity and NaN have no JSON form
+// and are an error. Line breaks are kept, so line numbers still match.
+func Lenient(text string) (string, error) {
+	var out strings.Builder
+	out.Grow(len(text))
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '/' && i+1 < len(text) && (text[i+1] == '/' || text[i+1] == '*'):
+			end, err := skipComment(text, i)
+			if err != nil {
+				return "", err
+			}
+			// Keep the comment's line breaks so line numbers don't shift
+			out.WriteString(strings.Repeat("\n", strings.Count(text[i:end], "\n")))
+			i = end
+
+		case c == '"' || c == '\'':
+			end, err := writeString(&out, text, i)
+			if err != nil {
+				return "", err
+			}
+			i = end
+
+		case c == ',':
+			// Drop a comma that only precedes a closing bracket
+			next := skipSpaceAndComments(text, i+1)
+			if next < len(text) && (text[next] == '}' || text[next] == ']') {
+				out.WriteByte(' ')
+			} else {
+				out.WriteByte(',')
+			}
+			i++
+
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(text) && isIdentPart(text[end]) {
+				end++
+			}
+			ident := text[i:end]
+			next := skipSpaceAndComments(text, end)
+			switch {
+			case next < len(text) && text[next] == ':':
+				out.WriteString(`"` + ident + `"`)
+			case ident == "true" || ident == "false" || ident == "null":
+				out.WriteString(ident)
+			case ident == "Infinity" || ident == "NaN":
+				return "", fmt.Errorf("line %d: %s can't be represented in JSON", lineAt(text, i), ident)
+			default:
+				return "", fmt.Errorf("line %d: unexpected %q", lineAt(text, i), ident)
+			}
+			i = end
+
+		case c == '+' || c == '-' || c == '.' || c >= '0' && c <= '9':
+			end := numberEnd(text, i)
+			num, err := jsonNumber(text[i:end])
+			if err != nil {
+				return "", fmt.Errorf("line %d: %v", lineAt(text, i), err)
+			}
+			out.WriteString(num)
+			i = end
+
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String(), nil
+}
+
+// ParseLenient is Parse for JSON5 or JSONC input (see Lenient).
+func ParseLenient(s string) (any, error) {
+	strict, err := Lenient(s)
+	if err != nil {
+		return nil, err
+	}
+	return ParseString(strict)
+}
+
+// skipComment returns the index just past the comment starting at i.
+func skipComment(text string, i int) (int, error) {
+	if text[i+1] == '/' {
+		if end := strings.IndexByte(text[i:], '\n'); end >= 0 {
+			return i + end, nil
+		}
+		return len(text), nil
+	}
+	if end := strings.Index(text[i+2:], "*/"); end >= 0 {
+		return i + 2 + end + 2, nil
+	}
+	return 0, fmt.Errorf("line %d: unterminated comment", lineAt(text, i))
+}
+
+// skipSpaceAndComments returns the index of the next character at or after
+// i that isn't whitespace or part of a comment.
+func skipSpaceAndComments(text string, i int) int {
+	for i < len(text) {
+		switch c := text[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(text) && (text[i+1] == '/' || text[i+1] == '*'):
+			end, err := skipComment(text, i)
+			if err != nil {
+				return len(text)
+			}
+			i = end
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// writeString writes the string literal starting at i (quoted with ' or ")
+// as a JSON string and returns the index just past it.
+func writeString(out *strings.Builder, text string, i int) (int, error) {
+	quote := text[i]
+	out.WriteByte('"')
+	for j := i + 1; j < len(text); j++ {
+		c := text[j]
+		switch {
+		case c == quote:
+			out.WriteByte('"')
+			return j + 1, nil
+		case c == '"':
+			out.WriteString(`\"`) // Inside a single-quoted string
+		case c == '\n':
+			return 0, fmt.Errorf("line %d: unterminated string", lineAt(text, i))
+		case c < 0x20:
+			fmt.Fprintf(out, `\u%04x`, c)
+		case c == '\\' && j+1 < len(text):
+			j++
+			switch e := text[j]; e {
+			case '\n':
+				// Line continuation
+			case '\r':
+				if j+1 < len(text) && text[j+1] == '\n' {
+					j++
+				}
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't', 'u':
+				out.WriteByte('\\')
+				out.WriteByte(e)
+			case '\'':
+				out.WriteByte('\'')
+			case 'v':
+				out.WriteString(`\u000b`)
+			case '0':
+				out.WriteString(`\u0000`)
+			case 'x':
+				if j+2 >= len(text) || !isHex(text[j+1]) || !isHex(text[j+2]) {
+					return 0, fmt.Errorf("line %d: invalid \\x escape", lineAt(text, j))
+				}
+				out.WriteString(`\u00` + text[j+1:j+3])
+				j += 2
+			default:
+				out.WriteByte(e) // JSON5 allows escaping any character
+			}
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return 0, fmt.Errorf("line %d: unterminated string", lineAt(text, i))
+}
+
+// numberEnd returns the index just past the number starting at i.
+func numberEnd(text string, i int) int {
+	end := i + 1
+	for end < len(text) {
+		c := text[end]
+		switch {
+		case isIdentPart(c) || c == '.':
+		case (c == '+' || c == '-') && (text[end-1] == 'e' || text[end-1] == 'E') && !strings.ContainsAny(text[i:end], "xX"):
+		default:
+			return end
+		}
+		end++
+	}
+	return end
+}
+
+// jsonNumber rewrites a JSON5 number in JSON notation.
+func jsonNumber(num string) (string, error) {
+	sign := ""
+	body := num
+	if num[0] == '+' || num[0] == '-' {
+		if num[0] == '-' {
+			sign = "-"
+		}
+		body = num[1:]
+	}
+
+	switch {
+	case body == "Infinity" || body == "NaN":
+		return "", fmt.Errorf("%s can't be represented in JSON", num)
+	case strings.HasPrefix(body, "0x") || strings.HasPrefix(body, "0X"):
+		n, ok := new(big.Int).SetString(body[2:], 16)
+		if !ok {
+			return "", fmt.Errorf("invalid number %q", num)
+		}
+		return sign + n.String(), nil
+	case body == "" || body == ".":
+		return "", fmt.Errorf("invalid number %q", num)
+	}
+
+	if body[0] == '.' {
+		body = "0" + body
+	}
+	if dot := strings.IndexByte(body, '.'); dot >= 0 && (dot == len(body)-1 || body[dot+1] == 'e' || body[dot+1] == 'E') {
+		body = body[:dot] + body[dot+1:] // Trailing point: 5. or 5.e3
+	}
+	return sign + body, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// lineAt returns the 1-based line number of offset i.
+func lineAt(text string, i int) int {
+	return strings.Count(text[:i], "\n") + 1
+}
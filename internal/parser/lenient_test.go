@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	input := `// Service config
+{
+  name: 'jtool', /* inline comment */
+  "quoted": "it's",
+  single: 'say "hi"\x21',
+  $id: 0x1F,
+  ratios: [.5, 5., +1, -2.5e+3,],
+  big: 12345678901234567890,
+  url: "http://example.com", // not a comment inside a string
+  nested: { ok: true, none: null, },
+}
+`
+	got, err := ParseLenient(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{
+		"name":   "jtool",
+		"quoted": "it's",
+		"single": `say "hi"!`,
+		"$id":    json.Number("31"),
+		"ratios": []any{json.Number("0.5"), json.Number("5"), json.Number("1"), json.Number("-2.5e+3")},
+		"big":    json.Number("12345678901234567890"),
+		"url":    "http://example.com",
+		"nested": map[string]any{"ok": true, "none": nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLenient() = %v, want %v", got, want)
+	}
+}
+
+func TestLenient_KeepsLines(t *testing.T) {
+	input := "{\n/* one\ntwo */\n\"a\": 1 // end\n}"
+	got, err := Lenient(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(got, "\n") != strings.Count(input, "\n") {
+		t.Errorf("Lenient() changed the number of lines:\n%s", got)
+	}
+}
+
+func TestLenient_StrictJSONUnchanged(t *testing.T) {
+	input := `{"a": [1, -2.5e10, "x\"y\\u00e9"], "b": {"c": null}}`
+	got, err := Lenient(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != input {
+		t.Errorf("Lenient() = %s, want the input unchanged", got)
+	}
+}
+
+func TestLenient_Errors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"infinity", "{a: Infinity}", "line 1: Infinity can't be represented in JSON"},
+		{"negative infinity", "[\n-Infinity]", "line 2: -Infinity can't be represented in JSON"},
+		{"bare word", "[yes]", `unexpected "yes"`},
+		{"unterminated comment", "{} /* oops", "unterminated comment"},
+		{"unterminated string", "['abc\n]", "unterminated string"},
+		{"bad hex", "[0xZZ]", `invalid number "0xZZ"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Lenient(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// Package parser parses JSON without losing numeric precision.
+//
+// encoding/json decodes every number into a float64 by default, which
+// silently corrupts integers above 2^53 (e.g. the 64-bit ID 9007199254740993
+// becomes 9007199254740992). This package decodes numbers as json.Number
+// instead, which keeps the original literal text, and provides helpers to
+// compare numbers exactly regardless of whether they are float64 or
+// json.Number values.
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Parse decodes a single JSON document, keeping numbers as json.Number.
+//
+// The result uses the same types as json.Unmarshal into `any`, except that
+// numbers are json.Number rather than float64. Like json.Unmarshal, trailing
+// data after the document is an error.
+func Parse(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("unexpected end of JSON input")
+		}
+		return nil, err
+	}
+
+	// Make sure there is nothing but whitespace after the document
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+
+	return v, nil
+}
+
+// ParseString is a convenience wrapper around Parse for string input.
+func ParseString(s string) (any, error) {
+	return Parse([]byte(s))
+}
+
+// IsNumber reports whether v is a JSON number (float64 or json.Number).
+func IsNumber(v any) bool {
+	switch v.(type) {
+	case float64, json.Number:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToRat converts a JSON number to an exact rational.
+// Returns false if v is not a number or is not finite.
+func ToRat(v any) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, false
+		}
+		return r, true
+	case json.Number:
+		// Rat.SetString accepts decimals and exponents ("1.5", "1e3")
+		r, ok := new(big.Rat).SetString(string(n))
+		return r, ok
+	default:
+		return nil, false
+	}
+}
+
+// CompareNumbers compares two JSON numbers exactly.
+// Returns negative if a < b, zero if a == b, positive if a > b.
+// ok is false if either value is not a (finite) number.
+//
+// Most numbers are compared as float64: identical literals are equal, and
+// float64 rounding never reorders two values, so only numbers that round
+// to the same float64 without being exactly representable (big integers,
+// long decimals) are compared as big.Rat.
+func CompareNumbers(a, b any) (cmp int, ok bool) {
+	// Fast path: two float64 values compare natively
+	if af, aok := a.(float64); aok {
+		if bf, bok := b.(float64); bok {
+			return compareFloats(af, bf), true
+		}
+	}
+
+	af, aExact, aok := toFloat(a)
+	bf, bExact, bok := toFloat(b)
+	if aok && bok {
+		if _, ok := a.(json.Number); ok && a == b {
+			return 0, true // Identical literals
+		}
+		if af != bf || aExact && bExact {
+			return compareFloats(af, bf), true
+		}
+	}
+
+	ar, aok := ToRat(a)
+	br, bok := ToRat(b)
+	if !aok || !bok {
+		return 0, false
+	}
+	return ar.Cmp(br), true
+}
+
+// maxExactInt is the largest integer below which every integer is a float64.
+const maxExactInt = 1 << 53
+
+// toFloat converts a finite JSON number to the nearest float64, reporting
+// whether that is its exact value. ok is false for non-numbers and for
+// literals that overflow a float64, which only ToRat can handle.
+func toFloat(v any) (f float64, exact, ok bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true, !math.IsInf(n, 0) && !math.IsNaN(n)
+	case json.Number:
+		if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+			return float64(i), -maxExactInt <= i && i <= maxExactInt, true
+		}
+		f, err := strconv.ParseFloat(string(n), 64)
+		return f, false, err == nil && !math.IsInf(f, 0) && !math.IsNaN(f)
+	default:
+		return 0, false, false
+	}
+}
+
+// compareFloats returns -1, 0 or 1 as a is less than, equal to or greater
+// than b.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Float64 converts a JSON number to float64, possibly losing precision.
+func Float64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := strconv.ParseFloat(string(n), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// IsLossy reports whether a json.Number changes value when it goes through
+// a float64 - e.g. 9007199254740993 comes back as 9007199254740992. Such
+// values must be shown using their original text, since JavaScript's
+// JSON.parse would round them. Decimals like 0.1 are not lossy: their
+// shortest float64 form reads back as the same value.
+func IsLossy(n json.Number) bool {
+	// Up to 15 significant digits always survive a float64, unless the value
+	// is out of its normal range
+	if significantDigits(string(n)) <= 15 {
+		f, err := strconv.ParseFloat(string(n), 64)
+		if err == nil && (f == 0 || math.Abs(f) >= 0x1p-1022) {
+			return false
+		}
+	}
+
+	exact, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return false
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return true
+	}
+	roundTrip, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return true
+	}
+	return exact.Cmp(roundTrip) != 0
+}
+
+// significantDigits counts the digits of a number literal's mantissa from
+// the first non-zero one to the last, e.g. 3 for "-0.01230e5".
+func significantDigits(s string) int {
+	count, pending := 0, 0 // pending: zeros that only count if a non-zero follows
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == 'e' || c == 'E':
+			return count
+		case c == '0':
+			if count > 0 {
+				pending++
+			}
+		case c >= '1' && c <= '9':
+			count += pending + 1
+			pending = 0
+		}
+	}
+	return count
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"object", `{"a": 1}`, false},
+		{"array", `[1, 2, 3]`, false},
+		{"scalar", `"hello"`, false},
+		{"trailing whitespace", "{\"a\": 1}\n  ", false},
+		{"empty", ``, true},
+		{"invalid", `{"a":}`, true},
+		{"trailing data", `{"a": 1} {"b": 2}`, true},
+		{"trailing garbage", `1 x`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseString(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseKeepsBigIntegers(t *testing.T) {
+	v, err := ParseString(`{"id": 9007199254740993}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id := v.(map[string]any)["id"]
+	n, ok := id.(json.Number)
+	if !ok {
+		t.Fatalf("expected json.Number, got %T", id)
+	}
+	if n.String() != "9007199254740993" {
+		t.Errorf("expected 9007199254740993, got %s", n)
+	}
+}
+
+func TestCompareNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     any
+		expected int
+	}{
+		{"floats equal", 1.0, 1.0, 0},
+		{"floats less", 1.0, 2.0, -1},
+		{"number text forms equal", json.Number("1.0"), json.Number("1"), 0},
+		{"exponent equal", json.Number("1e3"), json.Number("1000"), 0},
+		{"big integers differ", json.Number("9007199254740993"), json.Number("9007199254740992"), 1},
+		{"mixed types", json.Number("2.5"), 2.5, 0},
+		{"identical big integers", json.Number("12345678901234567890"), json.Number("12345678901234567890"), 0},
+		{"negative integers", json.Number("-5"), json.Number("3"), -1},
+		{"decimals rounding to one float", json.Number("0.1"), json.Number("0.1000000000000000055511151231257827"), -1},
+		{"big integer and float", json.Number("9007199254740993"), 9007199254740992.0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmp, ok := CompareNumbers(tt.a, tt.b)
+			if !ok {
+				t.Fatal("expected numbers to be comparable")
+			}
+			if cmp != tt.expected {
+				t.Errorf("CompareNumbers(%v, %v) = %d, want %d", tt.a, tt.b, cmp, tt.expected)
+			}
+		})
+	}
+
+	if _, ok := CompareNumbers("1", 1.0); ok {
+		t.Error("expected a string not to compare as a number")
+	}
+	if _, ok := CompareNumbers(json.Number("Infinity"), json.Number("1")); ok {
+		t.Error("expected a non-finite literal not to compare as a number")
+	}
+}
+
+func TestIsLossy(t *testing.T) {
+	tests := map[string]bool{
+		"1":                      false,
+		"1.5":                    false,
+		"9007199254740992":       false,
+		"9007199254740993":       true,
+		"12345678901234567890":   true,
+		"0.1":                    false, // Shortest float64 form reads back as 0.1
+		"1e400":                  true,  // Overflows float64
+		"-0.000123":              false,
+		"1000000000000000000000": false, // 1e21 is a float64
+		"0.0":                    false,
+	}
+	for input, want := range tests {
+		if got := IsLossy(json.Number(input)); got != want {
+			t.Errorf("IsLossy(%s) = %v, want %v", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package pathmatch matches jtool JSON paths against simple patterns.
+//
+// Paths are the dotted form used throughout jtool, e.g. ".users[0].name"
+// (diff) or ".users[].name" (paths, loganalyzer). A pattern is written the
+// same way, with a few wildcards:
+//
+//	pattern  matches
+//	.a.*     exactly one object key under "a" (glob syntax like "user_*" works too)
+//	.**.id   any number of segments, including none, before "id"
+//	.a[]     any array index under "a" (so does .a[*])
+//
+// A leading "$" on either the pattern or the path is ignored, so "$.a.b"
+// and ".a.b" are interchangeable.
+package pathmatch
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether the JSON path matches the pattern exactly.
+func Match(pattern, jsonPath string) bool {
+	return matchSegments(Split(pattern), Split(jsonPath))
+}
+
+// MatchPrefix reports whether the pattern matches the path itself or one of
+// its ancestors. This is useful for rules that apply to a whole subtree, e.g.
+// the pattern ".user" matches ".user.name" and ".user.address.city".
+func MatchPrefix(pattern, jsonPath string) bool {
+	patSegs := Split(pattern)
+	pathSegs := Split(jsonPath)
+	for i := len(pathSegs); i >= 0; i-- {
+		if matchSegments(patSegs, pathSegs[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Split breaks a JSON path into segments.
+//
+// Object keys become plain segments and array indices keep their brackets:
+//
+//	".users[0].name" → ["users", "[0]", "name"]
+//	".tags[]"        → ["tags", "[]"]
+func Split(jsonPath string) []string {
+	jsonPath = strings.TrimPrefix(jsonPath, "$")
+
+	segments := []string{}
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(jsonPath); i++ {
+		c := jsonPath[i]
+		switch c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(jsonPath[i:], ']')
+			if end < 0 {
+				// Unterminated bracket - treat the rest as a key
+				current.WriteString(jsonPath[i:])
+				i = len(jsonPath)
+				continue
+			}
+			segments = append(segments, jsonPath[i:i+end+1])
+			i += end
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// matchSegments matches pattern segments against path segments recursively.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		// Try consuming zero or more segments
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if !matchSegment(pattern[0], segments[0]) {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// matchSegment matches a single pattern segment against a single path segment.
+func matchSegment(pattern, segment string) bool {
+	patIsIndex := strings.HasPrefix(pattern, "[")
+	segIsIndex := strings.HasPrefix(segment, "[")
+
+	if patIsIndex {
+		if !segIsIndex {
+			return false
+		}
+		// [] and [*] match any index; otherwise indices must be identical
+		if pattern == "[]" || pattern == "[*]" || segment == "[]" {
+			return true
+		}
+		return pattern == segment
+	}
+
+	if segIsIndex {
+		return false
+	}
+
+	if pattern == "*" {
+		return true
+	}
+
+	// Glob within the segment (e.g. "created_*"). path.Match only treats '/'
+	// specially, which never appears in a segment we care about.
+	matched, err := path.Match(pattern, segment)
+	if err != nil {
+		return pattern == segment
+	}
+	return matched
+}
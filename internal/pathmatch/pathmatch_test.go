@@ -0,0 +1,73 @@
+package pathmatch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"", []string{}},
+		{".", []string{}},
+		{"$.a.b", []string{"a", "b"}},
+		{".users[0].name", []string{"users", "[0]", "name"}},
+		{".tags[]", []string{"tags", "[]"}},
+		{"[][].x", []string{"[]", "[]", "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := Split(tt.path)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Split(%q) = %v, want %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"exact", ".user.name", ".user.name", true},
+		{"exact mismatch", ".user.name", ".user.email", false},
+		{"dollar prefix", "$.user.name", ".user.name", true},
+		{"star one segment", ".user.*", ".user.name", true},
+		{"star does not span", ".user.*", ".user.address.city", false},
+		{"glob within segment", ".*_at", ".updated_at", true},
+		{"double star", ".**.id", ".users[3].profile.id", true},
+		{"double star zero segments", ".**.id", ".id", true},
+		{"any index", ".users[].name", ".users[5].name", true},
+		{"star index", ".users[*].name", ".users[5].name", true},
+		{"specific index", ".users[1].name", ".users[5].name", false},
+		{"collapsed path", ".users[0].name", ".users[].name", true},
+		{"key vs index", ".users.name", ".users[0]", false},
+		{"shorter path", ".a.b", ".a", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.pattern, tt.path); got != tt.expected {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchPrefix(t *testing.T) {
+	if !MatchPrefix(".user", ".user.address.city") {
+		t.Error("expected .user to match descendant path")
+	}
+	if !MatchPrefix(".user", ".user") {
+		t.Error("expected .user to match itself")
+	}
+	if MatchPrefix(".user.name", ".user") {
+		t.Error("did not expect a deeper pattern to match an ancestor")
+	}
+}
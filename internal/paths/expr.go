@@ -0,0 +1,262 @@
+package paths
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"jtool/internal/pathmatch"
+)
+
+// Segment is one step of a jtool path: an object key, an array index,
+// or every element of an array ("[]").
+type Segment struct {
+	Key     string `json:"key,omitempty"`   // Object key (when not an index step)
+	Index   int    `json:"index,omitempty"` // Array index (when IsIndex)
+	IsIndex bool   `json:"isIndex"`         // [n]
+	IsAll   bool   `json:"isAll"`           // []
+}
+
+// PathExpressions holds equivalent accessor expressions for a jtool path.
+type PathExpressions struct {
+	Path       string    `json:"path"`       // The jtool path, e.g. ".users[].profile.city"
+	Segments   []Segment `json:"segments"`   // The path split into steps
+	JQ         string    `json:"jq"`         // e.g. `.users[].profile.city`
+	JavaScript string    `json:"javascript"` // e.g. `data.users.map((x) => x.profile.city)`
+}
+
+// Expressions converts a jtool path to jq and JavaScript.
+//
+// jtool paths don't escape keys, so ".a.b" could be key "b" inside "a" or a
+// single key "a.b". When data (the document the path came from) is given,
+// keys are matched against it to tell these apart; with nil data every "."
+// starts a new key.
+func Expressions(data any, path string) PathExpressions {
+	segments, ok := ResolvePath(data, path)
+	if !ok {
+		segments = ParsePath(path)
+	}
+	return PathExpressions{
+		Path:       path,
+		Segments:   segments,
+		JQ:         ToJQ(segments),
+		JavaScript: ToJavaScript(segments, "data"),
+	}
+}
+
+// ParsePath splits a jtool path on "." and brackets, without looking at
+// any document:
+//
+//	".users[0].name" → key users, index 0, key name
+//	".tags[]"        → key tags, all elements
+func ParsePath(path string) []Segment {
+	parts := pathmatch.Split(path)
+	segments := make([]Segment, 0, len(parts))
+	for _, part := range parts {
+		segments = append(segments, parseSegment(part))
+	}
+	return segments
+}
+
+// parseSegment converts one pathmatch segment ("users", "[0]", "[]").
+func parseSegment(part string) Segment {
+	if part == "[]" || part == "[*]" {
+		return Segment{IsAll: true}
+	}
+	if strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]") {
+		if i, err := strconv.Atoi(part[1 : len(part)-1]); err == nil {
+			return Segment{Index: i, IsIndex: true}
+		}
+	}
+	return Segment{Key: part}
+}
+
+// ResolvePath splits a jtool path by walking data, so keys that themselves
+// contain ".", "[" or "]" are kept whole. ok is false if the path doesn't
+// exist in data.
+//
+// Example: with data {"a.b": {"c": 1}}, ".a.b.c" → key "a.b", key "c".
+func ResolvePath(data any, path string) ([]Segment, bool) {
+	if data == nil {
+		return nil, false
+	}
+	return resolve(data, strings.TrimPrefix(path, "$"), []Segment{})
+}
+
+// resolve matches the remaining path rest against value.
+func resolve(value any, rest string, segments []Segment) ([]Segment, bool) {
+	if rest == "" {
+		return segments, true
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if rest[0] != '.' {
+			return nil, false
+		}
+		// Try the longest matching key first, so "a.b" wins over "a"
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			if strings.HasPrefix(rest[1:], key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+
+		for _, key := range keys {
+			after := rest[1+len(key):]
+			if after != "" && after[0] != '.' && after[0] != '[' {
+				continue // Only a prefix of a longer key name
+			}
+			if result, ok := resolve(v[key], after, append(segments, Segment{Key: key})); ok {
+				return result, true
+			}
+		}
+		return nil, false
+
+	case []any:
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, false
+		}
+		seg := parseSegment(rest[:end+1])
+		after := rest[end+1:]
+
+		switch {
+		case seg.IsAll:
+			// Elements can differ in shape; any element that has the rest will do
+			for _, item := range v {
+				if result, ok := resolve(item, after, append(segments, seg)); ok {
+					return result, true
+				}
+			}
+		case seg.IsIndex && seg.Index >= 0 && seg.Index < len(v):
+			return resolve(v[seg.Index], after, append(segments, seg))
+		}
+		return nil, false
+
+	default:
+		return nil, false
+	}
+}
+
+// Lookup returns the value at a jtool path in data, matching keys as
+// ResolvePath does. ok is false if the path doesn't exist in data or
+// selects every element of an array ("[]") rather than a single value.
+func Lookup(data any, path string) (any, bool) {
+	if strings.TrimPrefix(path, "$") == "" {
+		return data, true // The document itself, even if it's null
+	}
+	segments, ok := ResolvePath(data, path)
+	if !ok {
+		return nil, false
+	}
+	value := data
+	for _, seg := range segments {
+		switch {
+		case seg.IsAll:
+			return nil, false
+		case seg.IsIndex:
+			value = value.([]any)[seg.Index]
+		default:
+			value = value.(map[string]any)[seg.Key]
+		}
+	}
+	return value, true
+}
+
+var (
+	jqIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	jsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// ToJQ renders segments as a jq filter. Keys that aren't plain identifiers
+// are quoted: key "first name" becomes ."first name".
+func ToJQ(segments []Segment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		switch {
+		case seg.IsAll:
+			b.WriteString("[]")
+		case seg.IsIndex:
+			b.WriteString("[" + strconv.Itoa(seg.Index) + "]")
+		case jqIdentifier.MatchString(seg.Key):
+			b.WriteString("." + seg.Key)
+		default:
+			b.WriteString("." + quote(seg.Key))
+		}
+	}
+	if b.Len() == 0 {
+		return "."
+	}
+	// jq needs a "." before a leading bracket: .[0], .[]
+	if s := b.String(); s[0] == '[' {
+		return "." + s
+	}
+	return b.String()
+}
+
+// ToPointer renders segments as a JSON Pointer (RFC 6901). "[]" becomes
+// "-", as in paths extracted with FormatPointer.
+func ToPointer(segments []Segment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		b.WriteByte('/')
+		switch {
+		case seg.IsAll:
+			b.WriteByte('-')
+		case seg.IsIndex:
+			b.WriteString(strconv.Itoa(seg.Index))
+		default:
+			b.WriteString(pointerEscaper.Replace(seg.Key))
+		}
+	}
+	return b.String()
+}
+
+// ToJavaScript renders segments as a JavaScript expression on the variable
+// root. Every "[]" maps over the array, so the result is an array of values:
+//
+//	".users[].profile.city"  → data.users.map((x) => x.profile.city)
+//	".a[].b[].c"             → data.a.flatMap((x) => x.b).map((x) => x.c)
+//	".meta[\"content-type\"]" → data.meta["content-type"]
+func ToJavaScript(segments []Segment, root string) string {
+	expr := root
+	pending := ""    // Accessors not yet applied
+	inArray := false // Whether expr is already an array being mapped over
+	for _, seg := range segments {
+		switch {
+		case seg.IsAll:
+			if inArray {
+				expr += ".flatMap((x) => x" + pending + ")"
+			} else {
+				expr += pending
+			}
+			pending = ""
+			inArray = true
+		case seg.IsIndex:
+			pending += "[" + strconv.Itoa(seg.Index) + "]"
+		case jsIdentifier.MatchString(seg.Key):
+			pending += "." + seg.Key
+		default:
+			pending += "[" + quote(seg.Key) + "]"
+		}
+	}
+
+	if inArray && pending != "" {
+		return expr + ".map((x) => x" + pending + ")"
+	}
+	return expr + pending
+}
+
+// quote returns s as a double-quoted string literal, valid in jq and JavaScript.
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // Encoding a string can't fail
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,111 @@
+package paths
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExpressions(t *testing.T) {
+	var doc any
+	json.Unmarshal([]byte(`{
+		"users": [{"profile": {"city": "Oslo", "first name": "Ada"}, "tags": ["x"]}],
+		"a.b": {"c": 1},
+		"a": {"d": 2},
+		"meta": {"content-type": "json", "x[0]": true},
+		"grid": [[1, 2]]
+	}`), &doc)
+
+	tests := []struct {
+		name   string
+		data   any
+		path   string
+		wantJQ string
+		wantJS string
+	}{
+		{"root", doc, "", ".", "data"},
+		{"nested key", doc, ".a.d", ".a.d", "data.a.d"},
+		{"index", doc, ".users[0].profile.city", ".users[0].profile.city", "data.users[0].profile.city"},
+		{"all elements", doc, ".users[].profile.city", ".users[].profile.city", "data.users.map((x) => x.profile.city)"},
+		{"key with space", doc, ".users[].profile.first name", `.users[].profile."first name"`, `data.users.map((x) => x.profile["first name"])`},
+		{"key with dot", doc, ".a.b.c", `."a.b".c`, `data["a.b"].c`},
+		{"key with dash", doc, ".meta.content-type", `.meta."content-type"`, `data.meta["content-type"]`},
+		{"key with brackets", doc, ".meta.x[0]", `.meta."x[0]"`, `data.meta["x[0]"]`},
+		{"nested arrays", doc, ".grid[][]", ".grid[][]", "data.grid.flatMap((x) => x)"},
+		{"array then key array", doc, ".users[].tags[]", ".users[].tags[]", "data.users.flatMap((x) => x.tags)"},
+		{"no document", nil, ".a.b.c", ".a.b.c", "data.a.b.c"},
+		{"not in document", doc, ".missing.key", ".missing.key", "data.missing.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Expressions(tt.data, tt.path)
+			if got.JQ != tt.wantJQ {
+				t.Errorf("JQ = %s, want %s", got.JQ, tt.wantJQ)
+			}
+			if got.JavaScript != tt.wantJS {
+				t.Errorf("JavaScript = %s, want %s", got.JavaScript, tt.wantJS)
+			}
+		})
+	}
+}
+
+func TestToJQLeadingIndex(t *testing.T) {
+	if got := ToJQ(ParsePath("[0].id")); got != ".[0].id" {
+		t.Errorf("ToJQ = %s, want .[0].id", got)
+	}
+	if got := ToJQ(ParsePath("[]")); got != ".[]" {
+		t.Errorf("ToJQ = %s, want .[]", got)
+	}
+}
+
+func TestToPointer(t *testing.T) {
+	var doc any
+	json.Unmarshal([]byte(`{"a.b": {"c/d": [0, {"e~f": 1}]}}`), &doc)
+
+	segments, ok := ResolvePath(doc, ".a.b.c/d[1].e~f")
+	if !ok {
+		t.Fatal("path not resolved")
+	}
+	if got := ToPointer(segments); got != "/a.b/c~1d/1/e~0f" {
+		t.Errorf("ToPointer = %s, want /a.b/c~1d/1/e~0f", got)
+	}
+	if got := ToPointer(ParsePath(".tags[]")); got != "/tags/-" {
+		t.Errorf("ToPointer = %s, want /tags/-", got)
+	}
+	if got := ToPointer(nil); got != "" {
+		t.Errorf("ToPointer = %q, want the empty pointer", got)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var doc any
+	json.Unmarshal([]byte(`{"a.b": {"c": 1}, "a": {"d": [true, null]}}`), &doc)
+
+	tests := []struct {
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{"", doc, true},
+		{"$", doc, true},
+		{".a.b.c", 1.0, true},
+		{".a.d[0]", true, true},
+		{".a.d[1]", nil, true},
+		{"$.a.d[0]", true, true},
+		{".a.d[2]", nil, false},
+		{".a.d[]", nil, false},
+		{".missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := Lookup(doc, tt.path)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup(%q) ok = %v, want %v", tt.path, ok, tt.wantOK)
+			}
+			if ok && tt.path != "" && tt.path != "$" && got != tt.want {
+				t.Errorf("Lookup(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
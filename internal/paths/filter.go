@@ -0,0 +1,85 @@
+package paths
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"jtool/internal/pathmatch"
+)
+
+// isRegexPattern reports whether a filter pattern is a regular expression,
+// written between slashes like "/^\.users\[\]\./".
+func isRegexPattern(pattern string) bool {
+	return len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/")
+}
+
+// ValidatePatterns checks that every regular expression pattern compiles.
+// Glob patterns are always valid.
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if !isRegexPattern(pattern) {
+			continue
+		}
+		if _, err := regexp.Compile(pattern[1 : len(pattern)-1]); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// pathFilter applies include/exclude patterns, compiling regexes once.
+type pathFilter struct {
+	include []func(string) bool
+	exclude []func(string) bool
+}
+
+func newPathFilter(include, exclude []string) pathFilter {
+	return pathFilter{
+		include: compilePatterns(include),
+		exclude: compilePatterns(exclude),
+	}
+}
+
+// compilePatterns turns filter patterns into match functions.
+//
+// A pattern between slashes is a regular expression searched for anywhere in
+// the path ("/_id$/" matches ".user.account_id"). Anything else is a
+// pathmatch pattern matched against the whole path: "*" is one key, "**" is
+// any number of segments and "[]" is any array element. An invalid regular
+// expression matches nothing.
+func compilePatterns(patterns []string) []func(string) bool {
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		if !isRegexPattern(pattern) {
+			pattern := pattern
+			matchers = append(matchers, func(path string) bool { return pathmatch.Match(pattern, path) })
+			continue
+		}
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			matchers = append(matchers, func(string) bool { return false })
+			continue
+		}
+		matchers = append(matchers, re.MatchString)
+	}
+	return matchers
+}
+
+// keep reports whether path passes the filter. With no include patterns
+// every path is included.
+func (f pathFilter) keep(path string) bool {
+	if len(f.include) > 0 && !anyMatch(f.include, path) {
+		return false
+	}
+	return !anyMatch(f.exclude, path)
+}
+
+func anyMatch(matchers []func(string) bool, path string) bool {
+	for _, match := range matchers {
+		if match(path) {
+			return true
+		}
+	}
+	return false
+}
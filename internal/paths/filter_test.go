@@ -0,0 +1,61 @@
+package paths
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractWithPatterns(t *testing.T) {
+	data := map[string]any{
+		"users": []any{
+			map[string]any{"id": 1.0, "name": "Ada", "created_at": "2024-01-01"},
+		},
+		"meta": map[string]any{"id": "abc", "page": 1.0},
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{"no patterns", nil, nil, []string{".meta.id", ".meta.page", ".users[].created_at", ".users[].id", ".users[].name"}},
+		{"include subtree", []string{".meta.**"}, nil, []string{".meta.id", ".meta.page"}},
+		{"include any depth", []string{"**.id"}, nil, []string{".meta.id", ".users[].id"}},
+		{"include one level", []string{".users[].*"}, nil, []string{".users[].created_at", ".users[].id", ".users[].name"}},
+		{"exclude", nil, []string{".users.**"}, []string{".meta.id", ".meta.page"}},
+		{"include then exclude", []string{"**.id", ".meta.*"}, []string{".meta.page"}, []string{".meta.id", ".users[].id"}},
+		{"regex", []string{`/_at$/`}, nil, []string{".users[].created_at"}},
+		{"regex exclude", nil, []string{`/^\.users/`}, []string{".meta.id", ".meta.page"}},
+		{"invalid regex matches nothing", []string{"/(/"}, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractWithOptions(data, ExtractOptions{
+				IncludePatterns: tt.include,
+				ExcludePatterns: tt.exclude,
+			})
+
+			got := []string{}
+			for _, p := range result.Paths {
+				got = append(got, p.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+			if result.TotalPaths != len(tt.want) {
+				t.Errorf("TotalPaths = %d, want %d", result.TotalPaths, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestValidatePatterns(t *testing.T) {
+	if err := ValidatePatterns([]string{".a.*", "**", "/^x+$/"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidatePatterns([]string{"/(/"}); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
@@ -0,0 +1,90 @@
+package paths
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Row is one leaf value of a flattened document.
+type Row struct {
+	Path  string `json:"path"`  // Concrete path, e.g. ".users[0].name"
+	Value any    `json:"value"` // The leaf value ({} or [] for empty containers)
+	Type  string `json:"type"`  // JSON type name (see TypeName)
+}
+
+// Flatten "explodes" a document into a table of (path, value, type) rows,
+// one per leaf value. Unlike Extract, paths keep their array indices so
+// every row is a distinct value.
+//
+// Rows are in document order as far as Go allows: arrays in index order,
+// object keys alphabetically. Empty objects and arrays get a row of their
+// own so nothing disappears from the table.
+//
+// Example: {"user": {"name": "Ada", "tags": ["x"]}} becomes
+//
+//	.user.name     "Ada"  string
+//	.user.tags[0]  "x"    string
+func Flatten(data any) []Row {
+	rows := []Row{}
+	flatten("", data, &rows)
+	return rows
+}
+
+func flatten(prefix string, value any, rows *[]Row) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			*rows = append(*rows, Row{Path: prefix, Value: v, Type: "object"})
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flatten(prefix+"."+key, v[key], rows)
+		}
+
+	case []any:
+		if len(v) == 0 {
+			*rows = append(*rows, Row{Path: prefix, Value: v, Type: "array"})
+			return
+		}
+		for i, item := range v {
+			flatten(prefix+"["+strconv.Itoa(i)+"]", item, rows)
+		}
+
+	default:
+		*rows = append(*rows, Row{Path: prefix, Value: v, Type: TypeName(v)})
+	}
+}
+
+// WriteCSV writes rows as CSV with a "path,value,type" header.
+// Strings are written as-is; other values as JSON text (42, true, null, []).
+func WriteCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"path", "value", "type"}); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		value, ok := row.Value.(string)
+		if !ok {
+			data, err := json.Marshal(row.Value)
+			if err != nil {
+				return err
+			}
+			value = string(data)
+		}
+		if err := cw.Write([]string{row.Path, value, row.Type}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,62 @@
+package paths
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	input := map[string]any{
+		"user": map[string]any{
+			"name": "Ada",
+			"tags": []any{"x", "y"},
+		},
+		"id":    json.Number("9007199254740993"),
+		"empty": map[string]any{},
+		"none":  []any{},
+		"gone":  nil,
+	}
+
+	rows := Flatten(input)
+
+	expected := []Row{
+		{Path: ".empty", Value: map[string]any{}, Type: "object"},
+		{Path: ".gone", Value: nil, Type: "null"},
+		{Path: ".id", Value: json.Number("9007199254740993"), Type: "number"},
+		{Path: ".none", Value: []any{}, Type: "array"},
+		{Path: ".user.name", Value: "Ada", Type: "string"},
+		{Path: ".user.tags[0]", Value: "x", Type: "string"},
+		{Path: ".user.tags[1]", Value: "y", Type: "string"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	// A scalar document is a single row at the root path
+	if got := Flatten(true); !reflect.DeepEqual(got, []Row{{Path: "", Value: true, Type: "bool"}}) {
+		t.Errorf("unexpected rows for scalar: %v", got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rows := Flatten(map[string]any{
+		"note":  "a, \"quoted\" value",
+		"count": 3.0,
+		"gone":  nil,
+	})
+
+	var b strings.Builder
+	if err := WriteCSV(&b, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "path,value,type\n" +
+		".count,3,number\n" +
+		".gone,null,null\n" +
+		".note,\"a, \"\"quoted\"\" value\",string\n"
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
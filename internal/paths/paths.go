@@ -4,13 +4,30 @@
 package paths
 
 import (
+	"context"
 	"sort"
+	"strconv"
+	"strings"
+
+	"jtool/internal/normalize"
+	"jtool/internal/pathmatch"
 )
 
 // PathInfo holds information about a JSON path.
 type PathInfo struct {
-	Path  string `json:"path"`  // The JSON path (e.g., "$.users.name")
+	Path  string `json:"path"`  // The JSON path (e.g., ".users[].name")
 	Count int    `json:"count"` // How many times this path appears (for arrays)
+	Depth int    `json:"depth"` // Nesting level: ".a" is 1, ".a.b" and ".a[]" are 2
+
+	// Presence is the percentage of parent objects that contain this member,
+	// e.g. 75 if ".users[].email" is in 3 of 4 users - i.e. "is this field
+	// optional?". Nil for array elements (".tags[]"), which have no parent object.
+	Presence *float64       `json:"presence,omitempty"`
+	Types    map[string]int `json:"types"` // JSON type name (see TypeName) -> occurrences
+
+	// Samples holds up to ExtractOptions.SampleValues distinct leaf values
+	// found at this path, in document order. Empty unless requested.
+	Samples []any `json:"samples,omitempty"`
 }
 
 // PathResult is the result of extracting paths from JSON.
@@ -18,11 +35,70 @@ type PathResult struct {
 	Paths      []PathInfo `json:"paths"`      // All paths found, sorted alphabetically
 	TotalPaths int        `json:"totalPaths"` // Total number of unique paths
 	TotalLeafs int        `json:"totalLeafs"` // Total leaf values (sum of counts)
+
+	// Depth metrics, for checking documents against systems that reject
+	// deeply nested input. Each object key and array level adds one.
+	MaxDepth    int         `json:"maxDepth"`    // Depth of the deepest path (0 for a scalar document)
+	DepthCounts map[int]int `json:"depthCounts"` // Depth -> number of unique paths at that depth
+	DeepestPath string      `json:"deepestPath"` // First path (alphabetically) at MaxDepth
+
+	// Truncated is true if ExtractOptions.MaxPaths or MaxDepth cut the
+	// listing short, so the paths shown are not the whole document.
+	Truncated bool `json:"truncated"`
+
+	// Tree holds the same paths nested by parent (only with ExtractOptions.Tree).
+	Tree *PathNode `json:"tree,omitempty"`
 }
 
+// Path formats for ExtractOptions.Format, and for FormatPath in other tools.
+const (
+	FormatDotted   = "dotted"   // ".users[].name" / ".users[0].name" (the default)
+	FormatJSONPath = "jsonpath" // "$.users[].name" / "$.users[0].name"
+	FormatPointer  = "pointer"  // JSON Pointer (RFC 6901): "/users/-/name" / "/users/0/name"
+)
+
 // ExtractOptions configures path extraction behavior.
 type ExtractOptions struct {
-	IncludeContainers bool // If true, include paths to objects and arrays, not just leaf values
+	IncludeContainers bool `json:"includeContainers"` // If true, include paths to objects and arrays, not just leaf values
+	SampleValues      int  `json:"sampleValues"`      // Collect up to this many distinct example values per leaf path (0 = none)
+
+	// IndexedArrays keeps concrete indices (".users[0].name", ".users[1].name")
+	// instead of collapsing every element to "[]". Useful when position carries
+	// meaning, e.g. tuples encoded as arrays. Indices sort numerically.
+	IndexedArrays bool `json:"indexedArrays"`
+
+	// IncludePatterns keeps only paths matching at least one pattern, and
+	// ExcludePatterns then drops paths matching any pattern. Patterns use
+	// pathmatch syntax (".users[].*", "**.id", ".meta.**"), or a regular
+	// expression between slashes ("/_at$/").
+	IncludePatterns []string `json:"includePatterns"`
+	ExcludePatterns []string `json:"excludePatterns"`
+
+	// ExcludeKeys and ExcludePaths drop fields before extraction, exactly as
+	// the normalize options of the same name do, so a listing matches what a
+	// diff with those options compares.
+	ExcludeKeys  []string `json:"excludeKeys"`
+	ExcludePaths []string `json:"excludePaths"`
+
+	// Limits, so pathological documents (e.g. huge arrays of objects that all
+	// have different keys) can't produce an unusable listing. 0 means no limit.
+	//
+	// MaxPaths stops adding new unique paths once this many have been found;
+	// paths already found keep being counted. MaxDepth stops descending at
+	// that depth: a non-empty container there is listed as a path of its own
+	// (whatever IncludeContainers says) to show where the listing was cut.
+	MaxPaths int `json:"maxPaths"`
+	MaxDepth int `json:"maxDepth"`
+
+	// Format is how paths are written in the result: FormatDotted (default),
+	// FormatJSONPath or FormatPointer. With pointers, collapsed array elements are written
+	// as "-" and keys containing "~" or "/" are escaped as "~0" and "~1".
+	// Include/exclude patterns still match the dotted form.
+	Format string `json:"format"`
+
+	// Tree also returns the paths as a nested tree in PathResult.Tree, so a
+	// collapsible schema view doesn't have to re-parse path strings.
+	Tree bool `json:"tree"`
 }
 
 // Extract walks a JSON structure and returns all paths to leaf values.
@@ -33,33 +109,100 @@ func Extract(data any) *PathResult {
 
 // ExtractWithOptions walks a JSON structure and returns paths based on the provided options.
 func ExtractWithOptions(data any, opts ExtractOptions) *PathResult {
-	// Map to count occurrences of each path
-	pathCounts := make(map[string]int)
+	result, _ := ExtractContext(context.Background(), data, opts)
+	return result
+}
+
+// ExtractContext is ExtractWithOptions, giving up with ctx.Err() soon
+// after ctx is cancelled.
+func ExtractContext(ctx context.Context, data any, opts ExtractOptions) (*PathResult, error) {
+	// Count occurrences (and types) of each path
+	c := newCollector()
+	c.ctx = ctx
+	c.maxSamples = opts.SampleValues
+	c.maxPaths = opts.MaxPaths
+	c.filter = newPathFilter(opts.IncludePatterns, opts.ExcludePatterns)
+
+	if len(opts.ExcludeKeys) > 0 || len(opts.ExcludePaths) > 0 {
+		exclude := normalize.NoNormalization()
+		exclude.ExcludeKeys = opts.ExcludeKeys
+		exclude.ExcludePaths = opts.ExcludePaths
+		data = normalize.Value(data, exclude)
+	}
 
 	// Recursively extract paths (starting with empty prefix for jq compatibility)
-	extractPathsWithOptions("", data, pathCounts, opts)
+	extractPathsWithOptions("", 0, data, c, opts)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Convert map to sorted slice
 	// Go maps have random iteration order, so we must sort explicitly
 	// Python's OrderedDict or sorted() handles this
-	paths := make([]PathInfo, 0, len(pathCounts))
+	paths := make([]PathInfo, 0, len(c.counts))
 	totalLeafs := 0
+	depthCounts := make(map[int]int)
 
-	for path, count := range pathCounts {
-		paths = append(paths, PathInfo{Path: path, Count: count})
+	for path, count := range c.counts {
+		info := PathInfo{
+			Path:    path,
+			Count:   count,
+			Depth:   c.depths[path],
+			Types:   c.types[path],
+			Samples: c.samples[path],
+		}
+		if parent, ok := c.parents[path]; ok && c.objects[parent] > 0 {
+			// An object has each key at most once, so count is the number of
+			// parent objects containing the path
+			presence := percent(count, c.objects[parent])
+			info.Presence = &presence
+		}
+		paths = append(paths, info)
 		totalLeafs += count
+		depthCounts[c.depths[path]]++
 	}
 
-	// Sort alphabetically by path
+	// Sort alphabetically by path ([2] before [10] when indices are kept)
 	sort.Slice(paths, func(i, j int) bool {
+		if opts.IndexedArrays {
+			return lessIndexed(paths[i].Path, paths[j].Path)
+		}
 		return paths[i].Path < paths[j].Path
 	})
 
-	return &PathResult{
-		Paths:      paths,
-		TotalPaths: len(paths),
-		TotalLeafs: totalLeafs,
+	// Paths are sorted, so the first path at the greatest depth wins ties
+	maxDepth, deepestPath := 0, ""
+	for _, p := range paths {
+		if p.Depth > maxDepth {
+			maxDepth, deepestPath = p.Depth, p.Path
+		}
+	}
+
+	// Build the tree from the dotted paths; nodes point into paths, so they
+	// see the pointer rewrite below
+	var tree *PathNode
+	if opts.Tree {
+		tree = c.buildTree(paths, opts)
 	}
+
+	// Rewrite in the requested format last, so the order matches the dotted listing
+	if opts.Format == FormatPointer || opts.Format == FormatJSONPath {
+		for i := range paths {
+			paths[i].Path = c.format(paths[i].Path, opts.Format)
+		}
+		deepestPath = c.format(deepestPath, opts.Format)
+	}
+
+	return &PathResult{
+		Tree:        tree,
+		Paths:       paths,
+		TotalPaths:  len(paths),
+		TotalLeafs:  totalLeafs,
+		MaxDepth:    maxDepth,
+		DepthCounts: depthCounts,
+		DeepestPath: deepestPath,
+		Truncated:   c.truncated,
+	}, nil
 }
 
 // extractPaths recursively walks the JSON structure (legacy version, only extracts leaf paths).
@@ -85,34 +228,299 @@ func extractPaths(prefix string, value any, counts map[string]int) {
 	}
 }
 
+// collector accumulates per-path statistics during extraction.
+type collector struct {
+	counts     map[string]int            // Path -> occurrences
+	depths     map[string]int            // Path -> nesting depth
+	types      map[string]map[string]int // Path -> type name -> occurrences
+	samples    map[string][]any          // Path -> distinct leaf values, in document order
+	maxSamples int                       // Samples to keep per path
+	maxPaths   int                       // Unique paths to keep (0 = no limit)
+	truncated  bool                      // Whether a limit dropped anything
+
+	objects  map[string]int    // Path -> objects found there (containers or not)
+	pointers map[string]string // Path -> JSON Pointer form (with FormatPointer only)
+	up       map[string]string // Path -> parent path (with Tree only)
+	segments map[string]string // Path -> its last segment (with Tree only)
+	parents  map[string]string // Object member path -> path of its parent object
+
+	filter pathFilter      // Include/exclude patterns
+	kept   map[string]bool // Cached filter decisions, since paths repeat a lot
+
+	ctx context.Context // Walking stops once this is cancelled
+}
+
+func newCollector() *collector {
+	return &collector{
+		counts:   make(map[string]int),
+		depths:   make(map[string]int),
+		types:    make(map[string]map[string]int),
+		samples:  make(map[string][]any),
+		objects:  make(map[string]int),
+		parents:  make(map[string]string),
+		pointers: map[string]string{"": ""},
+		up:       make(map[string]string),
+		segments: make(map[string]string),
+		kept:     make(map[string]bool),
+		ctx:      context.Background(),
+	}
+}
+
+// sample keeps a leaf value as an example for path, unless the path already
+// has enough samples or this value is one of them.
+func (c *collector) sample(path string, value any) {
+	existing := c.samples[path]
+	if len(existing) >= c.maxSamples {
+		return
+	}
+	// Leaf values (string, number, bool, nil) are comparable with ==
+	for _, v := range existing {
+		if v == value {
+			return
+		}
+	}
+	c.samples[path] = append(existing, value)
+}
+
+// record notes one occurrence of value at path, depth levels deep.
+// It returns false if the path is filtered out or over the MaxPaths limit.
+func (c *collector) record(path string, depth int, value any) bool {
+	keep, seen := c.kept[path]
+	if !seen {
+		keep = c.filter.keep(path)
+		c.kept[path] = keep
+	}
+	if !keep {
+		return false
+	}
+
+	if _, exists := c.counts[path]; !exists && c.maxPaths > 0 && len(c.counts) >= c.maxPaths {
+		c.truncated = true
+		return false
+	}
+
+	c.counts[path]++
+	c.depths[path] = depth
+	if c.types[path] == nil {
+		c.types[path] = make(map[string]int)
+	}
+	c.types[path][TypeName(value)]++
+	return true
+}
+
+// TypeName returns the JSON type of a decoded value:
+// "object", "array", "string", "number", "bool" or "null".
+func TypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case nil:
+		return "null"
+	default:
+		// float64 or json.Number
+		return "number"
+	}
+}
+
 // extractPathsWithOptions recursively walks the JSON structure with options support.
-func extractPathsWithOptions(prefix string, value any, counts map[string]int, opts ExtractOptions) {
+// depth is the nesting level of prefix (0 for the root).
+func extractPathsWithOptions(prefix string, depth int, value any, c *collector, opts ExtractOptions) {
+	// At the depth limit, list a non-empty container itself and stop there
+	if opts.MaxDepth > 0 && depth >= opts.MaxDepth && hasChildren(value) {
+		c.record(prefix, depth, value)
+		c.truncated = true
+		return
+	}
+
 	switch v := value.(type) {
 	case map[string]any:
 		// Object: optionally record the container path, then recurse into each key
 		if opts.IncludeContainers && prefix != "" {
-			counts[prefix]++
+			c.record(prefix, depth, v)
 		}
 
-		for key, val := range v {
+		c.objects[prefix]++
+		for _, key := range objectKeys(v, opts.MaxPaths > 0) {
+			if c.ctx.Err() != nil {
+				return
+			}
 			childPath := prefix + "." + key
-			extractPathsWithOptions(childPath, val, counts, opts)
+			c.parents[childPath] = prefix
+			if opts.Format == FormatPointer {
+				c.pointers[childPath] = c.pointers[prefix] + "/" + pointerEscaper.Replace(key)
+			}
+			if opts.Tree {
+				c.up[childPath], c.segments[childPath] = prefix, key
+			}
+			extractPathsWithOptions(childPath, depth+1, v[key], c, opts)
 		}
 
 	case []any:
 		// Array: optionally record the container path, then recurse into elements
 		if opts.IncludeContainers && prefix != "" {
-			counts[prefix]++
+			c.record(prefix, depth, v)
 		}
 
-		for _, item := range v {
+		for i, item := range v {
+			if c.ctx.Err() != nil {
+				return
+			}
 			childPath := prefix + "[]"
-			extractPathsWithOptions(childPath, item, counts, opts)
+			if opts.IndexedArrays {
+				childPath = prefix + "[" + strconv.Itoa(i) + "]"
+			}
+			if opts.Format == FormatPointer {
+				c.pointers[childPath] = c.pointers[prefix] + "/" + pointerIndex(i, opts.IndexedArrays)
+			}
+			if opts.Tree {
+				c.up[childPath], c.segments[childPath] = prefix, childPath[len(prefix):]
+			}
+			extractPathsWithOptions(childPath, depth+1, item, c, opts)
 		}
 
 	default:
 		// Leaf value (string, number, bool, null)
 		// This is an atomic value - always record the path
-		counts[prefix]++
+		if c.record(prefix, depth, v) {
+			c.sample(prefix, v)
+		}
+	}
+}
+
+// objectKeys returns the keys of obj, sorted if requested. With MaxPaths set,
+// sorting makes the paths kept the same on every run instead of depending
+// on map iteration order.
+func objectKeys(obj map[string]any, sorted bool) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	if sorted {
+		sort.Strings(keys)
+	}
+	return keys
+}
+
+// format writes a dotted path found during extraction in another format.
+// Pointers were recorded while walking, since keys may contain "." or "[".
+func (c *collector) format(path, format string) string {
+	if format == FormatPointer {
+		return c.pointers[path]
+	}
+	return FormatPath(path, format)
+}
+
+// FormatPath rewrites a dotted path (".users[0].name", as produced by the
+// diff and log analyzer) in another format. Unknown formats and
+// FormatDotted return the path unchanged.
+//
+// The dotted form doesn't escape keys, so a key containing "." or "[" is
+// split as if it were several segments; ExtractOptions.Format has no such
+// ambiguity.
+func FormatPath(path, format string) string {
+	switch format {
+	case FormatJSONPath:
+		if strings.HasPrefix(path, "$") {
+			return path
+		}
+		return "$" + path
+	case FormatPointer:
+		var b strings.Builder
+		for _, seg := range pathmatch.Split(path) {
+			b.WriteByte('/')
+			switch {
+			case seg == "[]" || seg == "[*]":
+				b.WriteByte('-')
+			case strings.HasPrefix(seg, "[") && strings.HasSuffix(seg, "]"):
+				b.WriteString(seg[1 : len(seg)-1])
+			default:
+				b.WriteString(pointerEscaper.Replace(seg))
+			}
+		}
+		return b.String()
+	default:
+		return path
+	}
+}
+
+// pointerEscaper escapes a key for use in a JSON Pointer ("~" first, so the
+// "~" introduced for "/" isn't escaped again).
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointerIndex is the JSON Pointer token for an array element: its index,
+// or "-" when elements are collapsed.
+func pointerIndex(i int, indexed bool) string {
+	if indexed {
+		return strconv.Itoa(i)
+	}
+	return "-"
+}
+
+// percent returns part as a percentage of whole (0 if whole is 0).
+func percent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(whole)
+}
+
+// hasChildren reports whether value is a non-empty object or array.
+func hasChildren(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
+
+// lessIndexed orders paths alphabetically, except that array indices compare
+// as numbers so ".a[2]" sorts before ".a[10]".
+func lessIndexed(a, b string) bool {
+	for a != "" && b != "" {
+		ai, aok := leadingIndex(a)
+		bi, bok := leadingIndex(b)
+		if aok && bok {
+			if ai.value != bi.value {
+				return ai.value < bi.value
+			}
+			a, b = a[ai.width:], b[bi.width:]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// index is an array index parsed from the start of a path, e.g. "[12]".
+type index struct {
+	value int
+	width int // Length of the text including brackets
+}
+
+// leadingIndex parses an array index at the start of s.
+func leadingIndex(s string) (index, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return index{}, false
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return index{}, false
+	}
+	n, err := strconv.Atoi(s[1:end])
+	if err != nil {
+		return index{}, false
 	}
+	return index{value: n, width: end + 1}, true
 }
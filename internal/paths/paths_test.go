@@ -1,6 +1,10 @@
 package paths
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -138,3 +142,340 @@ func TestExtractSorted(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractTypes(t *testing.T) {
+	// .items[].price is usually a number but sometimes a string or null
+	input := map[string]any{
+		"items": []any{
+			map[string]any{"price": 1.5},
+			map[string]any{"price": "2.00"},
+			map[string]any{"price": nil},
+			map[string]any{"price": 3.0},
+		},
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{IncludeContainers: true})
+
+	types := map[string]map[string]int{}
+	for _, p := range result.Paths {
+		types[p.Path] = p.Types
+	}
+
+	expected := map[string]map[string]int{
+		".items":         {"array": 1},
+		".items[]":       {"object": 4},
+		".items[].price": {"number": 2, "string": 1, "null": 1},
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected types %v, got %v", expected, types)
+	}
+}
+
+func TestExtractSampleValues(t *testing.T) {
+	input := map[string]any{
+		"users": []any{
+			map[string]any{"city": "Paris", "active": true},
+			map[string]any{"city": "Oslo", "active": true},
+			map[string]any{"city": "Paris", "active": false},
+			map[string]any{"city": "Rome", "active": nil},
+			map[string]any{"city": "Lima"},
+		},
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{SampleValues: 3})
+
+	samples := map[string][]any{}
+	for _, p := range result.Paths {
+		samples[p.Path] = p.Samples
+	}
+
+	expected := map[string][]any{
+		".users[].city":   {"Paris", "Oslo", "Rome"}, // Distinct, first 3 in order
+		".users[].active": {true, false, nil},
+	}
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("expected samples %v, got %v", expected, samples)
+	}
+
+	// No samples unless requested
+	for _, p := range Extract(input).Paths {
+		if p.Samples != nil {
+			t.Errorf("expected no samples for %s by default, got %v", p.Path, p.Samples)
+		}
+	}
+}
+
+func TestExtractDepthMetrics(t *testing.T) {
+	input := map[string]any{
+		"id": 1.0,
+		"a": map[string]any{
+			"b": []any{map[string]any{"c": 1.0}},
+			"z": map[string]any{"y": map[string]any{"x": 1.0}},
+		},
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{IncludeContainers: true})
+
+	if result.MaxDepth != 4 {
+		t.Errorf("expected MaxDepth 4, got %d", result.MaxDepth)
+	}
+	// .a.b[].c and .a.z.y.x are both 4 deep; the alphabetically first wins
+	if result.DeepestPath != ".a.b[].c" {
+		t.Errorf("expected DeepestPath .a.b[].c, got %s", result.DeepestPath)
+	}
+
+	expectedCounts := map[int]int{
+		1: 2, // .a, .id
+		2: 2, // .a.b, .a.z
+		3: 2, // .a.b[], .a.z.y
+		4: 2, // .a.b[].c, .a.z.y.x
+	}
+	if !reflect.DeepEqual(result.DepthCounts, expectedCounts) {
+		t.Errorf("expected depth counts %v, got %v", expectedCounts, result.DepthCounts)
+	}
+
+	// A scalar document has a single root path at depth 0
+	scalar := Extract("hello")
+	if scalar.MaxDepth != 0 || scalar.DeepestPath != "" {
+		t.Errorf("expected depth 0 for a scalar, got %d (%q)", scalar.MaxDepth, scalar.DeepestPath)
+	}
+}
+
+func TestExtractIndexedArrays(t *testing.T) {
+	// A list of [lat, lon] tuples: position matters
+	points := []any{}
+	for i := 0; i < 11; i++ {
+		points = append(points, []any{float64(i), float64(-i)})
+	}
+	input := map[string]any{"points": points[:1], "tags": []any{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}}
+
+	result := ExtractWithOptions(input, ExtractOptions{IndexedArrays: true})
+
+	got := []string{}
+	for _, p := range result.Paths {
+		got = append(got, p.Path)
+	}
+	expected := []string{
+		".points[0][0]", ".points[0][1]",
+		".tags[0]", ".tags[1]", ".tags[2]", ".tags[3]", ".tags[4]", ".tags[5]",
+		".tags[6]", ".tags[7]", ".tags[8]", ".tags[9]", ".tags[10]",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// Without the option, elements still collapse to []
+	collapsed := Extract(input)
+	if collapsed.TotalPaths != 2 {
+		t.Errorf("expected 2 collapsed paths, got %d", collapsed.TotalPaths)
+	}
+}
+
+func TestExtractExcludeKeys(t *testing.T) {
+	input := map[string]any{
+		"id":   float64(1),
+		"meta": map[string]any{"etag": "x", "source": "api"},
+		"user": map[string]any{"etag": "y", "name": "Ann"},
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{ExcludeKeys: []string{"etag"}, ExcludePaths: []string{".meta.source"}})
+
+	got := []string{}
+	for _, p := range result.Paths {
+		got = append(got, p.Path)
+	}
+	expected := []string{".id", ".user.name"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestExtractLimits(t *testing.T) {
+	// Every object has different keys, so paths keep growing
+	items := []any{}
+	for i := 0; i < 50; i++ {
+		items = append(items, map[string]any{fmt.Sprintf("k%02d", i): 1.0, "id": float64(i)})
+	}
+	input := map[string]any{"items": items}
+
+	t.Run("max paths", func(t *testing.T) {
+		result := ExtractWithOptions(input, ExtractOptions{MaxPaths: 5})
+		if result.TotalPaths != 5 || !result.Truncated {
+			t.Fatalf("expected 5 truncated paths, got %d (truncated=%v)", result.TotalPaths, result.Truncated)
+		}
+
+		// Paths found before the limit keep being counted
+		for _, p := range result.Paths {
+			if p.Path == ".items[].id" && p.Count != 50 {
+				t.Errorf("expected .items[].id to be counted 50 times, got %d", p.Count)
+			}
+		}
+
+		// The same paths are kept every time
+		again := ExtractWithOptions(input, ExtractOptions{MaxPaths: 5})
+		if !reflect.DeepEqual(result.Paths, again.Paths) {
+			t.Errorf("expected stable results, got %v and %v", result.Paths, again.Paths)
+		}
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		nested := map[string]any{
+			"a": map[string]any{"b": map[string]any{"c": 1.0}},
+			"x": 1.0,
+			"e": map[string]any{},
+		}
+		result := ExtractWithOptions(nested, ExtractOptions{MaxDepth: 2})
+
+		got := []string{}
+		for _, p := range result.Paths {
+			got = append(got, p.Path)
+		}
+		// .a.b is cut off and listed itself; the empty .e has nothing to cut
+		expected := []string{".a.b", ".x"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+		if !result.Truncated {
+			t.Error("expected Truncated to be set")
+		}
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		result := ExtractWithOptions(input, ExtractOptions{MaxPaths: 1000, MaxDepth: 10})
+		if result.Truncated || result.TotalPaths != 51 {
+			t.Errorf("expected 51 complete paths, got %d (truncated=%v)", result.TotalPaths, result.Truncated)
+		}
+	})
+}
+
+func TestExtractPresence(t *testing.T) {
+	input := map[string]any{
+		"users": []any{
+			map[string]any{"id": 1.0, "email": "a@example.com", "tags": []any{"x", "y"}},
+			map[string]any{"id": 2.0},
+			map[string]any{"id": 3.0, "email": nil},
+			map[string]any{"id": 4.0, "email": "d@example.com"},
+		},
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{IncludeContainers: true})
+
+	presence := map[string]any{}
+	for _, p := range result.Paths {
+		if p.Presence == nil {
+			presence[p.Path] = nil
+		} else {
+			presence[p.Path] = *p.Presence
+		}
+	}
+
+	expected := map[string]any{
+		".users":          100.0,
+		".users[]":        nil, // Array elements have no parent object
+		".users[].id":     100.0,
+		".users[].email":  75.0,
+		".users[].tags":   25.0,
+		".users[].tags[]": nil,
+	}
+	if !reflect.DeepEqual(presence, expected) {
+		t.Errorf("expected %v, got %v", expected, presence)
+	}
+}
+
+func TestExtractPointerFormat(t *testing.T) {
+	input := map[string]any{
+		"users": []any{map[string]any{"name": "Ada", "a/b": 1.0, "m~n": 2.0}},
+		"meta":  map[string]any{"page": 1.0},
+	}
+
+	tests := []struct {
+		name     string
+		opts     ExtractOptions
+		expected []string
+	}{
+		{
+			name:     "collapsed arrays",
+			opts:     ExtractOptions{Format: FormatPointer},
+			expected: []string{"/meta/page", "/users/-/a~1b", "/users/-/m~0n", "/users/-/name"},
+		},
+		{
+			name:     "indexed arrays",
+			opts:     ExtractOptions{Format: FormatPointer, IndexedArrays: true},
+			expected: []string{"/meta/page", "/users/0/a~1b", "/users/0/m~0n", "/users/0/name"},
+		},
+		{
+			name:     "containers",
+			opts:     ExtractOptions{Format: FormatPointer, IncludeContainers: true, IncludePatterns: []string{".users.**"}},
+			expected: []string{"/users", "/users/-", "/users/-/a~1b", "/users/-/m~0n", "/users/-/name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractWithOptions(input, tt.opts)
+			got := []string{}
+			for _, p := range result.Paths {
+				got = append(got, p.Path)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	result := ExtractWithOptions(input, ExtractOptions{Format: FormatPointer})
+	if result.DeepestPath != "/users/-/a~1b" {
+		t.Errorf("expected DeepestPath /users/-/a~1b, got %s", result.DeepestPath)
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		format   string
+		expected string
+	}{
+		{".users[].name", FormatDotted, ".users[].name"},
+		{".users[].name", "", ".users[].name"},
+		{".users[].name", FormatJSONPath, "$.users[].name"},
+		{"$.users[].name", FormatJSONPath, "$.users[].name"},
+		{"", FormatJSONPath, "$"},
+		{".users[].name", FormatPointer, "/users/-/name"},
+		{".users[3].name", FormatPointer, "/users/3/name"},
+		{".a~b[*]", FormatPointer, "/a~0b/-"},
+		{"", FormatPointer, ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatPath(tt.path, tt.format); got != tt.expected {
+			t.Errorf("FormatPath(%q, %q) = %q, expected %q", tt.path, tt.format, got, tt.expected)
+		}
+	}
+
+	// Extraction uses the same notation
+	result := ExtractWithOptions(map[string]any{"a": []any{1.0}}, ExtractOptions{Format: FormatJSONPath, Tree: true})
+	if result.Paths[0].Path != "$.a[]" || result.DeepestPath != "$.a[]" {
+		t.Errorf("expected $.a[], got %s (deepest %s)", result.Paths[0].Path, result.DeepestPath)
+	}
+	if got := result.Tree.Children[0].Path; got != "$.a" {
+		t.Errorf("expected tree node $.a, got %s", got)
+	}
+}
+
+func TestExtractContext_Cancelled(t *testing.T) {
+	data := map[string]any{"a": []any{1.0, 2.0}, "b": map[string]any{"c": "x"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ExtractContext(ctx, data, ExtractOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	result, err := ExtractContext(context.Background(), data, ExtractOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TotalPaths != 2 {
+		t.Errorf("expected 2 paths, got %d", result.TotalPaths)
+	}
+}
@@ -0,0 +1,354 @@
+package paths
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"jtool/internal/normalize"
+)
+
+// JSON Schema dialects InferSchema can declare in "$schema".
+const (
+	Draft07     = "http://json-schema.org/draft-07/schema#"
+	Draft202012 = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// Schema is an inferred JSON Schema. Only the keywords inference produces
+// are modelled; they mean the same thing in draft-07 and 2020-12.
+type Schema struct {
+	SchemaURI  string             `json:"$schema,omitempty"`    // Dialect, only set on the root
+	Type       any                `json:"type,omitempty"`       // A type name, or a list of them
+	Format     string             `json:"format,omitempty"`     // e.g. "date-time", "email", "uuid"
+	Properties map[string]*Schema `json:"properties,omitempty"` // Object members
+	Required   []string           `json:"required,omitempty"`   // Members present in every sample
+	Items      *Schema            `json:"items,omitempty"`      // Array elements (all merged)
+}
+
+// InferSchema produces a 2020-12 JSON Schema describing data.
+//
+// Example:
+//
+//	{"id": 1, "email": "a@b.io", "tags": ["x"]}
+//	→ {"type": "object",
+//	   "properties": {"email": {"type": "string", "format": "email"},
+//	                  "id": {"type": "integer"},
+//	                  "tags": {"type": "array", "items": {"type": "string"}}},
+//	   "required": ["email", "id", "tags"]}
+//
+// Elements of an array are merged into a single "items" schema, so a key
+// that only some elements have is a property but not required.
+func InferSchema(data any) *Schema {
+	b := NewSchemaBuilder()
+	b.Add(data)
+	return b.Schema(Draft202012)
+}
+
+// SchemaBuilder infers one schema from many samples, e.g. every line of a
+// JSONL file. A member is required only if every object sample has it, and
+// a type that differs between samples becomes a list of types.
+type SchemaBuilder struct {
+	root *schemaNode
+}
+
+// NewSchemaBuilder creates a builder with no samples.
+func NewSchemaBuilder() *SchemaBuilder {
+	return &SchemaBuilder{root: newSchemaNode()}
+}
+
+// Add merges a sample (the result of json.Unmarshal or parser.Parse) into the schema.
+func (b *SchemaBuilder) Add(data any) {
+	b.root.add(data, DetectFormat)
+}
+
+// AddWithFormats is Add for a caller that has already run DetectFormat on
+// the sample's strings, e.g. to profile them: format is called instead.
+func (b *SchemaBuilder) AddWithFormats(data any, format func(string) string) {
+	b.root.add(data, format)
+}
+
+// Schema returns the schema inferred so far, declaring the given dialect
+// (Draft07 or Draft202012; empty omits "$schema").
+func (b *SchemaBuilder) Schema(draft string) *Schema {
+	s := b.root.schema()
+	s.SchemaURI = draft
+	return s
+}
+
+// schemaNode accumulates what has been seen at one position in the documents.
+type schemaNode struct {
+	types   map[string]bool        // JSON Schema type names seen
+	objects int                    // Samples that were objects
+	props   map[string]*schemaNode // Members seen in any object sample
+	seen    map[string]int         // Member name -> object samples that had it
+	items   *schemaNode            // Merged array elements (nil until one is seen)
+	strings int                    // Samples that were strings
+	format  string                 // Format of the first string sample
+	mixed   bool                   // A string sample had no format or another one
+}
+
+func newSchemaNode() *schemaNode {
+	return &schemaNode{
+		types: make(map[string]bool),
+		props: make(map[string]*schemaNode),
+		seen:  make(map[string]int),
+	}
+}
+
+// MarshalJSON saves what the builder has inferred so far. UnmarshalJSON
+// restores it, and more samples can then be added, e.g. to resume watching
+// a log file after a restart.
+func (b *SchemaBuilder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.root.state())
+}
+
+// UnmarshalJSON restores a builder saved with MarshalJSON.
+func (b *SchemaBuilder) UnmarshalJSON(data []byte) error {
+	var state schemaNodeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	b.root = state.node()
+	return nil
+}
+
+// schemaNodeState is the saved form of a schemaNode.
+type schemaNodeState struct {
+	Types   []string                    `json:"types,omitempty"`
+	Objects int                         `json:"objects,omitempty"`
+	Props   map[string]*schemaNodeState `json:"props,omitempty"`
+	Seen    map[string]int              `json:"seen,omitempty"`
+	Items   *schemaNodeState            `json:"items,omitempty"`
+	Strings int                         `json:"strings,omitempty"`
+	Format  string                      `json:"format,omitempty"`
+	Mixed   bool                        `json:"mixed,omitempty"`
+}
+
+func (n *schemaNode) state() *schemaNodeState {
+	s := &schemaNodeState{
+		Objects: n.objects,
+		Seen:    n.seen,
+		Strings: n.strings,
+		Format:  n.format,
+		Mixed:   n.mixed,
+	}
+	for t := range n.types {
+		s.Types = append(s.Types, t)
+	}
+	sort.Strings(s.Types)
+	if len(n.props) > 0 {
+		s.Props = make(map[string]*schemaNodeState, len(n.props))
+		for key, child := range n.props {
+			s.Props[key] = child.state()
+		}
+	}
+	if n.items != nil {
+		s.Items = n.items.state()
+	}
+	return s
+}
+
+func (s *schemaNodeState) node() *schemaNode {
+	n := newSchemaNode()
+	if s == nil {
+		return n
+	}
+	for _, t := range s.Types {
+		n.types[t] = true
+	}
+	n.objects = s.Objects
+	for key, child := range s.Props {
+		n.props[key] = child.node()
+	}
+	for key, count := range s.Seen {
+		n.seen[key] = count
+	}
+	if s.Items != nil {
+		n.items = s.Items.node()
+	}
+	n.strings = s.Strings
+	n.format = s.Format
+	n.mixed = s.Mixed
+	return n
+}
+
+// add merges one value into the node. format returns the format of a
+// string (see DetectFormat).
+func (n *schemaNode) add(value any, format func(string) string) {
+	switch v := value.(type) {
+	case map[string]any:
+		n.types["object"] = true
+		n.objects++
+		for key, val := range v {
+			child, ok := n.props[key]
+			if !ok {
+				child = newSchemaNode()
+				n.props[key] = child
+			}
+			child.add(val, format)
+			n.seen[key]++
+		}
+
+	case []any:
+		n.types["array"] = true
+		for _, item := range v {
+			if n.items == nil {
+				n.items = newSchemaNode()
+			}
+			n.items.add(item, format)
+		}
+
+	case string:
+		n.types["string"] = true
+		n.strings++
+		// Once the samples disagree no format can be claimed, so stop detecting
+		switch {
+		case n.mixed:
+		case n.strings == 1:
+			n.format = format(v)
+			n.mixed = n.format == ""
+		default:
+			n.mixed = format(v) != n.format
+		}
+
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			n.types["integer"] = true
+		} else {
+			n.types["number"] = true
+		}
+
+	case json.Number:
+		if isIntegerNumber(v) {
+			n.types["integer"] = true
+		} else {
+			n.types["number"] = true
+		}
+
+	case bool:
+		n.types["boolean"] = true
+
+	case nil:
+		n.types["null"] = true
+	}
+}
+
+// schema converts the accumulated observations into a Schema.
+func (n *schemaNode) schema() *Schema {
+	s := &Schema{}
+
+	types := make([]string, 0, len(n.types))
+	for t := range n.types {
+		// "integer" is a subset of "number", so only keep the wider one
+		if t == "integer" && n.types["number"] {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	switch len(types) {
+	case 0:
+		// No samples (e.g. only empty arrays) - accept anything
+	case 1:
+		s.Type = types[0]
+	default:
+		s.Type = types
+	}
+
+	// A format is only claimed when every string sample had it
+	if n.strings > 0 && !n.mixed {
+		s.Format = n.format
+	}
+
+	if len(n.props) > 0 {
+		s.Properties = make(map[string]*Schema, len(n.props))
+		for key, child := range n.props {
+			s.Properties[key] = child.schema()
+			if n.seen[key] == n.objects {
+				s.Required = append(s.Required, key)
+			}
+		}
+		sort.Strings(s.Required)
+	}
+
+	if n.items != nil {
+		s.Items = n.items.schema()
+	}
+
+	return s
+}
+
+// isIntegerNumber reports whether a number literal is a whole number, e.g.
+// "42", "1.0" or "1e3". Only literals with a fraction or exponent are
+// parsed exactly.
+func isIntegerNumber(n json.Number) bool {
+	if !strings.ContainsAny(string(n), ".eE") {
+		return true
+	}
+	r, ok := new(big.Rat).SetString(string(n))
+	return ok && r.IsInt()
+}
+
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// DetectFormat returns the JSON Schema "format" a string matches, or "".
+func DetectFormat(s string) string {
+	// Each case starts with a cheap check on the shape of s, so most strings
+	// are ruled out without parsing or running a pattern
+	switch {
+	case len(s) > len(time.DateOnly) && startsWithDate(s) && isDateTime(s):
+		return "date-time"
+	case len(s) == len(time.DateOnly) && startsWithDate(s) && isDate(s):
+		return "date"
+	case normalize.IsUUID(s):
+		return "uuid"
+	case strings.IndexByte(s, '@') > 0 && emailPattern.MatchString(s):
+		return "email"
+	case strings.Count(s, ".") == 3 && net.ParseIP(s) != nil:
+		return "ipv4"
+	case strings.Contains(s, ":") && !strings.Contains(s, "/") && net.ParseIP(s) != nil:
+		return "ipv6"
+	case isURI(s):
+		return "uri"
+	default:
+		return ""
+	}
+}
+
+// startsWithDate reports whether s begins like "2024-01-15".
+func startsWithDate(s string) bool {
+	if len(s) < len(time.DateOnly) || s[4] != '-' || s[7] != '-' {
+		return false
+	}
+	for _, i := range []int{0, 1, 2, 3, 5, 6, 8, 9} {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDateTime(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse(time.DateOnly, s)
+	return err == nil
+}
+
+// isURI reports whether s is an absolute URI with a scheme and host.
+func isURI(s string) bool {
+	if !strings.Contains(s, "://") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
@@ -0,0 +1,200 @@
+package paths
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"jtool/internal/parser"
+)
+
+func TestInferSchema(t *testing.T) {
+	data, err := parser.ParseString(`{
+		"id": 1,
+		"price": 9.99,
+		"email": "alice@example.com",
+		"created": "2024-01-02T03:04:05Z",
+		"user": {"uuid": "3f2504e0-4f89-11d3-9a0c-0305e82c3301", "nickname": null},
+		"items": [{"sku": "a", "qty": 1}, {"sku": "b"}],
+		"empty": []
+	}`)
+	if err != nil {
+		t.Fatalf("failed to parse input: %v", err)
+	}
+
+	schema := InferSchema(data)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"created": {"type": "string", "format": "date-time"},
+			"email": {"type": "string", "format": "email"},
+			"empty": {"type": "array"},
+			"id": {"type": "integer"},
+			"items": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"qty": {"type": "integer"}, "sku": {"type": "string"}},
+					"required": ["sku"]
+				}
+			},
+			"price": {"type": "number"},
+			"user": {
+				"type": "object",
+				"properties": {
+					"nickname": {"type": "null"},
+					"uuid": {"type": "string", "format": "uuid"}
+				},
+				"required": ["nickname", "uuid"]
+			}
+		},
+		"required": ["created", "email", "empty", "id", "items", "price", "user"]
+	}`
+
+	assertSchemaJSON(t, schema, expected)
+}
+
+func TestSchemaBuilderMergesSamples(t *testing.T) {
+	b := NewSchemaBuilder()
+	for _, line := range []string{
+		`{"id": 1, "name": "a", "score": 1}`,
+		`{"id": 2, "score": 1.5, "tag": null}`,
+		`{"id": 3, "name": "c", "score": 2, "tag": "x"}`,
+	} {
+		data, err := parser.ParseString(line)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", line, err)
+		}
+		b.Add(data)
+	}
+
+	expected := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"name": {"type": "string"},
+			"score": {"type": "number"},
+			"tag": {"type": ["null", "string"]}
+		},
+		"required": ["id", "score"]
+	}`
+
+	assertSchemaJSON(t, b.Schema(Draft07), expected)
+}
+
+func TestSchemaBuilderFormatsAndNumbers(t *testing.T) {
+	b := NewSchemaBuilder()
+	for _, line := range []string{
+		`{"day": "2024-01-02", "when": "2024-01-02", "note": "x", "n": 1.0, "e": 1e3, "f": 1.5}`,
+		`{"day": "2024-01-03", "when": "later", "note": "2024-01-02", "n": 2, "e": 2, "f": 2}`,
+	} {
+		data, err := parser.ParseString(line)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", line, err)
+		}
+		b.Add(data)
+	}
+
+	// A format is claimed only when every sample has it, whichever comes first
+	expected := `{
+		"type": "object",
+		"properties": {
+			"day": {"type": "string", "format": "date"},
+			"e": {"type": "integer"},
+			"f": {"type": "number"},
+			"n": {"type": "integer"},
+			"note": {"type": "string"},
+			"when": {"type": "string"}
+		},
+		"required": ["day", "e", "f", "n", "note", "when"]
+	}`
+
+	assertSchemaJSON(t, b.Schema(""), expected)
+}
+
+func TestSchemaBuilderSaveAndRestore(t *testing.T) {
+	add := func(b *SchemaBuilder, line string) {
+		t.Helper()
+		data, err := parser.ParseString(line)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", line, err)
+		}
+		b.Add(data)
+	}
+
+	b := NewSchemaBuilder()
+	add(b, `{"id": 1, "day": "2024-01-02", "tags": ["a"], "user": {"name": "x"}}`)
+	saved, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored := NewSchemaBuilder()
+	if err := json.Unmarshal(saved, restored); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertSchemaJSON(t, restored.Schema(""), toJSON(t, b.Schema("")))
+
+	// Samples added after restoring merge as if there had been no break
+	add(b, `{"id": 2.5, "day": "soon", "user": {}}`)
+	add(restored, `{"id": 2.5, "day": "soon", "user": {}}`)
+	assertSchemaJSON(t, restored.Schema(""), toJSON(t, b.Schema("")))
+}
+
+// toJSON marshals v for assertSchemaJSON.
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]string{
+		"2024-01-02T03:04:05Z":                 "date-time",
+		"2024-01-02T03:04:05.123+02:00":        "date-time",
+		"2024-01-02":                           "date",
+		"3F2504E0-4F89-11D3-9A0C-0305E82C3301": "uuid",
+		"bob@example.org":                      "email",
+		"192.168.0.1":                          "ipv4",
+		"::1":                                  "ipv6",
+		"https://example.com/a?b=c":            "uri",
+		"hello":                                "",
+		"12:30":                                "",
+		"not@email":                            "",
+		"2024-01-02 junk":                      "",
+		"20240102":                             "",
+	}
+	for input, want := range tests {
+		if got := DetectFormat(input); got != want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// assertSchemaJSON compares a schema with expected JSON, ignoring formatting.
+func assertSchemaJSON(t *testing.T, schema *Schema, expected string) {
+	t.Helper()
+
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+
+	var gotValue, expectedValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("invalid expected JSON: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotValue, expectedValue) {
+		t.Errorf("schema mismatch\ngot:      %s\nexpected: %s", got, expected)
+	}
+}
@@ -0,0 +1,113 @@
+package paths
+
+import (
+	"sort"
+)
+
+// ShapeStatus describes how a path differs between two documents.
+type ShapeStatus string
+
+const (
+	ShapeEqual       ShapeStatus = "equal"        // Path in both, with the same types
+	ShapeAdded       ShapeStatus = "added"        // Path only in the right document
+	ShapeRemoved     ShapeStatus = "removed"      // Path only in the left document
+	ShapeTypeChanged ShapeStatus = "type-changed" // Path in both, but its types differ
+)
+
+// ShapeChange is the comparison of one path.
+type ShapeChange struct {
+	Path       string      `json:"path"`
+	Status     ShapeStatus `json:"status"`
+	LeftTypes  []string    `json:"leftTypes,omitempty"`  // Types seen on the left, sorted
+	RightTypes []string    `json:"rightTypes,omitempty"` // Types seen on the right, sorted
+}
+
+// ShapeDiff compares the structure of two documents: which paths exist and
+// which JSON types they hold, ignoring the values themselves.
+type ShapeDiff struct {
+	Changes     []ShapeChange `json:"changes"` // Every path from either side, sorted by path
+	Added       int           `json:"added"`
+	Removed     int           `json:"removed"`
+	TypeChanged int           `json:"typeChanged"`
+	Equal       int           `json:"equal"`
+	Identical   bool          `json:"identical"` // True if the shapes match exactly
+}
+
+// CompareShapes answers "did the shape change?" for two documents.
+//
+// Both are extracted with container paths included, so an object that
+// became an array shows up as a type change on the container itself rather
+// than only as removed/added children. Array elements are collapsed to "[]"
+// and how often a path occurs is ignored: a list growing from 2 to 3 items
+// is not a shape change, but an item gaining a field is.
+func CompareShapes(left, right any) *ShapeDiff {
+	opts := ExtractOptions{IncludeContainers: true}
+	leftTypes := typeSets(ExtractWithOptions(left, opts))
+	rightTypes := typeSets(ExtractWithOptions(right, opts))
+
+	// Union of paths from both sides
+	all := make(map[string]bool, len(leftTypes)+len(rightTypes))
+	for path := range leftTypes {
+		all[path] = true
+	}
+	for path := range rightTypes {
+		all[path] = true
+	}
+	sortedPaths := make([]string, 0, len(all))
+	for path := range all {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	result := &ShapeDiff{Changes: make([]ShapeChange, 0, len(sortedPaths))}
+	for _, path := range sortedPaths {
+		lt, inLeft := leftTypes[path]
+		rt, inRight := rightTypes[path]
+
+		change := ShapeChange{Path: path, LeftTypes: lt, RightTypes: rt}
+		switch {
+		case !inLeft:
+			change.Status = ShapeAdded
+			result.Added++
+		case !inRight:
+			change.Status = ShapeRemoved
+			result.Removed++
+		case !equalStrings(lt, rt):
+			change.Status = ShapeTypeChanged
+			result.TypeChanged++
+		default:
+			change.Status = ShapeEqual
+			result.Equal++
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	result.Identical = result.Added == 0 && result.Removed == 0 && result.TypeChanged == 0
+	return result
+}
+
+// typeSets maps each path to the sorted names of the types found there.
+func typeSets(result *PathResult) map[string][]string {
+	sets := make(map[string][]string, len(result.Paths))
+	for _, p := range result.Paths {
+		names := make([]string, 0, len(p.Types))
+		for name := range p.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sets[p.Path] = names
+	}
+	return sets
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package paths
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompareShapes(t *testing.T) {
+	var left, right any
+	json.Unmarshal([]byte(`{
+		"id": 1,
+		"user": {"name": "Ada", "email": "ada@example.com"},
+		"tags": ["a", "b"],
+		"meta": {"page": 1}
+	}`), &left)
+	json.Unmarshal([]byte(`{
+		"id": "1",
+		"user": {"name": "Grace", "phone": "555"},
+		"tags": ["a", "b", "c"],
+		"meta": [1]
+	}`), &right)
+
+	result := CompareShapes(left, right)
+
+	statuses := map[string]ShapeStatus{}
+	for _, c := range result.Changes {
+		statuses[c.Path] = c.Status
+	}
+	expected := map[string]ShapeStatus{
+		".id":         ShapeTypeChanged,
+		".meta":       ShapeTypeChanged, // object became an array
+		".meta.page":  ShapeRemoved,
+		".meta[]":     ShapeAdded,
+		".tags":       ShapeEqual,
+		".tags[]":     ShapeEqual, // More items is not a shape change
+		".user":       ShapeEqual,
+		".user.email": ShapeRemoved,
+		".user.name":  ShapeEqual, // Values are ignored
+		".user.phone": ShapeAdded,
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected %v, got %v", expected, statuses)
+	}
+
+	if result.Added != 2 || result.Removed != 2 || result.TypeChanged != 2 || result.Equal != 4 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	if result.Identical {
+		t.Error("expected shapes to differ")
+	}
+}
+
+func TestCompareShapesIdentical(t *testing.T) {
+	left := map[string]any{"a": []any{1.0, 2.0}, "b": "x"}
+	right := map[string]any{"a": []any{3.0}, "b": "y"}
+
+	result := CompareShapes(left, right)
+	if !result.Identical {
+		t.Errorf("expected identical shapes, got %+v", result.Changes)
+	}
+}
+
+func TestCompareShapesMixedTypes(t *testing.T) {
+	// Types are compared as sets, so the order/count of element types is ignored
+	left := []any{1.0, "a", 2.0}
+	right := []any{"b", 3.0}
+
+	result := CompareShapes(left, right)
+	if !result.Identical {
+		t.Errorf("expected identical shapes, got %+v", result.Changes)
+	}
+
+	result = CompareShapes(left, []any{"b"})
+	if result.TypeChanged != 1 {
+		t.Fatalf("expected 1 type change, got %+v", result.Changes)
+	}
+	c := result.Changes[0]
+	if !reflect.DeepEqual(c.LeftTypes, []string{"number", "string"}) || !reflect.DeepEqual(c.RightTypes, []string{"string"}) {
+		t.Errorf("unexpected types: %v -> %v", c.LeftTypes, c.RightTypes)
+	}
+}
@@ -0,0 +1,46 @@
+package paths
+
+// PathNode is one node of the path tree in PathResult.Tree.
+//
+// Example: the paths ".user.name" and ".user.tags[]" become
+//
+//	"" (root)
+//	└── "user"        .user
+//	    ├── "name"    .user.name
+//	    └── "tags"    .user.tags
+//	        └── "[]"  .user.tags[]
+type PathNode struct {
+	Segment  string      `json:"segment"`            // Last step of the path: a key, "[]" or "[0]"
+	Path     string      `json:"path"`               // Full path, in the requested Format
+	Info     *PathInfo   `json:"info,omitempty"`     // The listed path, nil for intermediate nodes
+	Children []*PathNode `json:"children,omitempty"` // In the order of the flat listing
+}
+
+// buildTree nests the listed paths under their parents. Containers that
+// aren't listed themselves (IncludeContainers off, or filtered out) still
+// get a node, without Info, so every listed path has its ancestors.
+func (c *collector) buildTree(listed []PathInfo, opts ExtractOptions) *PathNode {
+	root := &PathNode{}
+	nodes := map[string]*PathNode{"": root}
+
+	// ensure returns the node for a dotted path, creating it and its ancestors
+	var ensure func(path string) *PathNode
+	ensure = func(path string) *PathNode {
+		if node, ok := nodes[path]; ok {
+			return node
+		}
+		parent := ensure(c.up[path])
+		node := &PathNode{Segment: c.segments[path], Path: path}
+		if opts.Format == FormatPointer || opts.Format == FormatJSONPath {
+			node.Path = c.format(path, opts.Format)
+		}
+		parent.Children = append(parent.Children, node)
+		nodes[path] = node
+		return node
+	}
+
+	for i := range listed {
+		ensure(listed[i].Path).Info = &listed[i]
+	}
+	return root
+}
@@ -0,0 +1,89 @@
+package paths
+
+import (
+	"reflect"
+	"testing"
+)
+
+// treeLines renders a tree as "depth:path(listed)" lines for comparison.
+func treeLines(node *PathNode, depth int, lines *[]string) {
+	for _, child := range node.Children {
+		line := child.Path
+		if child.Info != nil {
+			line += " *"
+		}
+		*lines = append(*lines, string(rune('0'+depth))+":"+child.Segment+" "+line)
+		treeLines(child, depth+1, lines)
+	}
+}
+
+func TestExtractTree(t *testing.T) {
+	input := map[string]any{
+		"user": map[string]any{
+			"name": "Ada",
+			"tags": []any{"x"},
+		},
+		"id": 1.0,
+	}
+
+	tests := []struct {
+		name     string
+		opts     ExtractOptions
+		expected []string
+	}{
+		{
+			name: "leaves only",
+			opts: ExtractOptions{Tree: true},
+			expected: []string{
+				"0:id .id *",
+				"0:user .user",
+				"1:name .user.name *",
+				"1:tags .user.tags",
+				"2:[] .user.tags[] *",
+			},
+		},
+		{
+			name: "containers",
+			opts: ExtractOptions{Tree: true, IncludeContainers: true},
+			expected: []string{
+				"0:id .id *",
+				"0:user .user *",
+				"1:name .user.name *",
+				"1:tags .user.tags *",
+				"2:[] .user.tags[] *",
+			},
+		},
+		{
+			name: "pointers and indices",
+			opts: ExtractOptions{Tree: true, Format: FormatPointer, IndexedArrays: true},
+			expected: []string{
+				"0:id /id *",
+				"0:user /user",
+				"1:name /user/name *",
+				"1:tags /user/tags",
+				"2:[0] /user/tags/0 *",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractWithOptions(input, tt.opts)
+			lines := []string{}
+			treeLines(result.Tree, 0, &lines)
+			if !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, lines)
+			}
+		})
+	}
+
+	// Nodes share the PathInfo of the flat listing
+	result := ExtractWithOptions(input, ExtractOptions{Tree: true})
+	if info := result.Tree.Children[0].Info; info.Count != 1 || info.Types["number"] != 1 {
+		t.Errorf("unexpected info for .id: %+v", info)
+	}
+
+	if Extract(input).Tree != nil {
+		t.Error("expected no tree unless requested")
+	}
+}
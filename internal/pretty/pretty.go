@@ -0,0 +1,310 @@
+// Package pretty formats JSON text for reading and review.
+//
+// Unlike json.MarshalIndent it works on the document as written: object
+// keys keep their source order (unless sorted), duplicate keys are kept
+// and numbers are copied verbatim, so 1.50 and 12345678901234567890 come
+// out exactly as they went in. On top of the indentation it can sort keys,
+// escape or unescape non-ASCII characters, keep short arrays on one line
+// and put any array or object that fits within a line width on one line.
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Options controls the layout of formatted JSON. The zero value indents
+// with two spaces and changes nothing else.
+type Options struct {
+	Indent             int  `json:"indent"`             // Spaces per level; 0 means 2
+	UseTabs            bool `json:"useTabs"`            // Indent with one tab per level instead of spaces
+	SortKeys           bool `json:"sortKeys"`           // Sort object keys; otherwise they keep their source order
+	EscapeNonASCII     bool `json:"escapeNonAscii"`     // Write non-ASCII characters as \uXXXX; otherwise as UTF-8, unescaping them if the input had escapes
+	CompactArraysUnder int  `json:"compactArraysUnder"` // Write arrays of fewer than this many scalars on one line (0 disables)
+	LineWidth          int  `json:"lineWidth"`          // Write arrays and objects that fit within this many columns on one line (0 disables)
+}
+
+// tabWidth is the number of columns a tab indent counts as against
+// LineWidth.
+const tabWidth = 4
+
+// node is a parsed JSON value, holding only output text: scalars as
+// written and object keys quoted, in output order.
+type node struct {
+	scalar string
+	array  bool
+	object bool
+	keys   []string
+	items  []*node
+}
+
+// Format re-formats a JSON document according to opts.
+func Format(text string, opts Options) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+
+	root, err := parseValue(dec, opts)
+	if err != nil {
+		if err == io.EOF {
+			return "", fmt.Errorf("unexpected end of JSON input")
+		}
+		return "", err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return "", fmt.Errorf("invalid character after top-level value")
+	}
+
+	p := &printer{opts: opts}
+	if opts.Indent <= 0 {
+		p.opts.Indent = 2
+	}
+	p.unit = strings.Repeat(" ", p.opts.Indent)
+	p.unitWidth = p.opts.Indent
+	if opts.UseTabs {
+		p.unit, p.unitWidth = "\t", tabWidth
+	}
+	p.write(root, 0, 0, 0)
+	return p.buf.String(), nil
+}
+
+// parseValue reads one value from the token stream. Strings are encoded
+// for output as they are read, so the tree holds only output text.
+func parseValue(dec *json.Decoder, opts Options) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &node{array: t == '[', object: t == '{'}
+		for dec.More() {
+			if n.object {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, keyTok.(string))
+			}
+			child, err := parseValue(dec, opts)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, child)
+		}
+		if _, err := dec.Token(); err != nil { // Closing delimiter
+			return nil, err
+		}
+		if n.object && opts.SortKeys {
+			sortKeys(n)
+		}
+		for i, key := range n.keys {
+			n.keys[i] = encodeString(key, opts.EscapeNonASCII)
+		}
+		return n, nil
+	case string:
+		return &node{scalar: encodeString(t, opts.EscapeNonASCII)}, nil
+	case json.Number:
+		return &node{scalar: t.String()}, nil
+	case bool:
+		if t {
+			return &node{scalar: "true"}, nil
+		}
+		return &node{scalar: "false"}, nil
+	default:
+		return &node{scalar: "null"}, nil
+	}
+}
+
+// sortKeys sorts an object's members by key. The sort is stable, so
+// duplicate keys stay in source order.
+func sortKeys(n *node) {
+	order := make([]int, len(n.keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return n.keys[order[i]] < n.keys[order[j]]
+	})
+	keys := make([]string, len(order))
+	items := make([]*node, len(order))
+	for i, o := range order {
+		keys[i], items[i] = n.keys[o], n.items[o]
+	}
+	n.keys, n.items = keys, items
+}
+
+type printer struct {
+	opts      Options
+	unit      string // One level of indentation
+	unitWidth int    // Columns taken by unit
+	buf       bytes.Buffer
+}
+
+// write writes n at the given depth. column is where n starts on the
+// current line and trailing the number of characters that will follow it
+// on that line (a comma), both used to decide whether n fits on one line.
+func (p *printer) write(n *node, depth, column, trailing int) {
+	if !n.array && !n.object {
+		p.buf.WriteString(n.scalar)
+		return
+	}
+	if len(n.items) == 0 || p.inline(n, column, trailing) {
+		p.writeInline(n)
+		return
+	}
+
+	open, close := byte('['), byte(']')
+	if n.object {
+		open, close = '{', '}'
+	}
+	p.buf.WriteByte(open)
+	childColumn := (depth + 1) * p.unitWidth
+	for i, child := range n.items {
+		p.buf.WriteByte('\n')
+		p.indent(depth + 1)
+		column := childColumn
+		if n.object {
+			p.buf.WriteString(n.keys[i])
+			p.buf.WriteString(": ")
+			column += utf8.RuneCountInString(n.keys[i]) + 2
+		}
+		comma := 0
+		if i < len(n.items)-1 {
+			comma = 1
+		}
+		p.write(child, depth+1, column, comma)
+		if comma == 1 {
+			p.buf.WriteByte(',')
+		}
+	}
+	p.buf.WriteByte('\n')
+	p.indent(depth)
+	p.buf.WriteByte(close)
+}
+
+// inline reports whether a non-empty container goes on one line.
+func (p *printer) inline(n *node, column, trailing int) bool {
+	budget := -1 // Unlimited
+	if p.opts.LineWidth > 0 {
+		budget = p.opts.LineWidth - column - trailing
+		if _, fits := width(n, budget); fits {
+			return true
+		}
+	}
+	if n.array && len(n.items) < p.opts.CompactArraysUnder {
+		for _, item := range n.items {
+			if item.array || item.object {
+				return false
+			}
+		}
+		_, fits := width(n, budget)
+		return fits
+	}
+	return false
+}
+
+// width returns the width of n written on one line, and whether that is
+// within budget (a negative budget is unlimited). It stops measuring once
+// the budget is exceeded.
+func width(n *node, budget int) (int, bool) {
+	if !n.array && !n.object {
+		w := utf8.RuneCountInString(n.scalar)
+		return w, budget < 0 || w <= budget
+	}
+	w := 2 // Brackets
+	for i, child := range n.items {
+		if i > 0 {
+			w += 2 // ", "
+		}
+		if n.object {
+			w += utf8.RuneCountInString(n.keys[i]) + 2 // ": "
+		}
+		rest := -1
+		if budget >= 0 {
+			if rest = budget - w; rest < 0 {
+				return w, false
+			}
+		}
+		cw, fits := width(child, rest)
+		w += cw
+		if !fits {
+			return w, false
+		}
+	}
+	return w, budget < 0 || w <= budget
+}
+
+// writeInline writes a container on one line: [1, 2] or {"a": 1}.
+func (p *printer) writeInline(n *node) {
+	if !n.array && !n.object {
+		p.buf.WriteString(n.scalar)
+		return
+	}
+	open, close := byte('['), byte(']')
+	if n.object {
+		open, close = '{', '}'
+	}
+	p.buf.WriteByte(open)
+	for i, child := range n.items {
+		if i > 0 {
+			p.buf.WriteString(", ")
+		}
+		if n.object {
+			p.buf.WriteString(n.keys[i])
+			p.buf.WriteString(": ")
+		}
+		p.writeInline(child)
+	}
+	p.buf.WriteByte(close)
+}
+
+func (p *printer) indent(depth int) {
+	for i := 0; i < depth; i++ {
+		p.buf.WriteString(p.unit)
+	}
+}
+
+// encodeString quotes s as a JSON string. Unlike encoding/json it leaves
+// <, > and & alone, and escapes non-ASCII characters only if asked to.
+func encodeString(s string, escapeNonASCII bool) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case r > 0x7f && escapeNonASCII:
+			if r > 0xffff {
+				hi, lo := utf16.EncodeRune(r)
+				fmt.Fprintf(&b, `\u%04x\u%04x`, hi, lo)
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
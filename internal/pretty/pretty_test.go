@@ -0,0 +1,124 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "default indent keeps key order",
+			input: `{"b":1,"a":[1,2],"c":{}}`,
+			want:  "{\n  \"b\": 1,\n  \"a\": [\n    1,\n    2\n  ],\n  \"c\": {}\n}",
+		},
+		{
+			name:  "indent width",
+			input: `{"a":[1]}`,
+			opts:  Options{Indent: 4},
+			want:  "{\n    \"a\": [\n        1\n    ]\n}",
+		},
+		{
+			name:  "tabs",
+			input: `{"a":{"b":true}}`,
+			opts:  Options{UseTabs: true},
+			want:  "{\n\t\"a\": {\n\t\t\"b\": true\n\t}\n}",
+		},
+		{
+			name:  "sort keys",
+			input: `{"b":{"y":1,"x":2},"a":null}`,
+			opts:  Options{SortKeys: true},
+			want:  "{\n  \"a\": null,\n  \"b\": {\n    \"x\": 2,\n    \"y\": 1\n  }\n}",
+		},
+		{
+			name:  "numbers verbatim",
+			input: `[1.50, 12345678901234567890, 1e3]`,
+			opts:  Options{LineWidth: 80},
+			want:  `[1.50, 12345678901234567890, 1e3]`,
+		},
+		{
+			name:  "duplicate keys kept",
+			input: `{"a":1,"a":2}`,
+			opts:  Options{LineWidth: 80},
+			want:  `{"a": 1, "a": 2}`,
+		},
+		{
+			name:  "unescape non-ASCII",
+			input: `["caf\u00e9", "\u003ca&b>", "tab\there"]`,
+			opts:  Options{LineWidth: 80},
+			want:  `["café", "<a&b>", "tab\there"]`,
+		},
+		{
+			name:  "escape non-ASCII",
+			input: `{"ключ":"café 😀"}`,
+			opts:  Options{EscapeNonASCII: true, LineWidth: 80},
+			want:  `{"\u043a\u043b\u044e\u0447": "caf\u00e9 \ud83d\ude00"}`,
+		},
+		{
+			name:  "compact short arrays of scalars",
+			input: `{"short":[1,2,3],"long":[1,2,3,4],"nested":[[1]]}`,
+			opts:  Options{CompactArraysUnder: 4},
+			want:  "{\n  \"short\": [1, 2, 3],\n  \"long\": [\n    1,\n    2,\n    3,\n    4\n  ],\n  \"nested\": [\n    [1]\n  ]\n}",
+		},
+		{
+			name:  "line width wraps what doesn't fit",
+			input: `{"id":1,"tags":["alpha","beta"],"owner":{"name":"ann","email":"ann@example.com"}}`,
+			opts:  Options{LineWidth: 40},
+			want:  "{\n  \"id\": 1,\n  \"tags\": [\"alpha\", \"beta\"],\n  \"owner\": {\n    \"name\": \"ann\",\n    \"email\": \"ann@example.com\"\n  }\n}",
+		},
+		{
+			name:  "line width counts the trailing comma",
+			input: `[[1,2],[3,4]]`,
+			opts:  Options{LineWidth: 8},
+			want:  "[\n  [\n    1,\n    2\n  ],\n  [3, 4]\n]",
+		},
+		{
+			name:  "line width limits compact arrays",
+			input: `["aaaaaaaaaa","bbbbbbbbbb"]`,
+			opts:  Options{CompactArraysUnder: 5, LineWidth: 20},
+			want:  "[\n  \"aaaaaaaaaa\",\n  \"bbbbbbbbbb\"\n]",
+		},
+		{
+			name:  "scalar document",
+			input: ` "x" `,
+			want:  `"x"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("Format error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Format =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{``, "unexpected end of JSON input"},
+		{`{"a":1`, "unexpected end of JSON input"},
+		{`{"a":1} x`, "invalid character after top-level value"},
+		{`{"a" 1}`, "invalid character"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Format(tt.input, Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Format(%q) error = %v, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrNoBrowser is returned by PrintPDF when no browser that can print to
+// PDF is installed.
+var ErrNoBrowser = errors.New("printing to PDF needs Google Chrome, Chromium or Microsoft Edge")
+
+// printTimeout bounds how long the browser may take to print.
+const printTimeout = time.Minute
+
+// PrintPDF prints an HTML report to PDF and writes it to w.
+//
+// There is no PDF renderer in Go's standard library, and the webview can't
+// print without showing a dialog, so an installed Chromium-based browser
+// prints the page headless. It honors the report's page size, margins and
+// page numbers.
+func PrintPDF(ctx context.Context, html []byte, w io.Writer) error {
+	browser := findBrowser(runtime.GOOS, os.Getenv)
+	if browser == "" {
+		return ErrNoBrowser
+	}
+
+	dir, err := os.MkdirTemp("", "jtool-report-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	page := filepath.Join(dir, "report.html")
+	if err := os.WriteFile(page, html, 0600); err != nil {
+		return err
+	}
+	pdf := filepath.Join(dir, "report.pdf")
+
+	ctx, cancel := context.WithTimeout(ctx, printTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, browser,
+		"--headless",
+		"--disable-gpu",
+		"--no-pdf-header-footer",
+		"--user-data-dir="+filepath.Join(dir, "profile"), // Don't touch a running browser's profile
+		"--print-to-pdf="+pdf,
+		fileURL(page),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("printing to PDF timed out after %s", printTimeout)
+		}
+		return fmt.Errorf("error printing to PDF: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	data, err := os.ReadFile(pdf)
+	if err != nil {
+		return fmt.Errorf("browser didn't write the PDF: %w", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return fmt.Errorf("browser wrote something other than a PDF")
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// findBrowser returns the first Chromium-based browser found for the OS
+// goos, or "". getenv looks up environment variables.
+func findBrowser(goos string, getenv func(string) string) string {
+	for _, candidate := range browserCandidates(goos, getenv) {
+		if filepath.IsAbs(candidate) {
+			if _, err := os.Stat(candidate); err == nil {
+				return candidate
+			}
+			continue
+		}
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// browserCandidates lists where Chromium-based browsers are installed on
+// the OS goos: absolute paths, or command names to look up on the PATH.
+func browserCandidates(goos string, getenv func(string) string) []string {
+	switch goos {
+	case "darwin":
+		return []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
+		}
+	case "windows":
+		var candidates []string
+		for _, dir := range []string{getenv("ProgramFiles"), getenv("ProgramFiles(x86)"), getenv("LocalAppData")} {
+			if dir == "" {
+				continue
+			}
+			candidates = append(candidates,
+				filepath.Join(dir, "Google", "Chrome", "Application", "chrome.exe"),
+				filepath.Join(dir, "Microsoft", "Edge", "Application", "msedge.exe"),
+			)
+		}
+		return candidates
+	default:
+		return []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "microsoft-edge"}
+	}
+}
+
+// fileURL returns the file:// URL of an absolute path, on any OS.
+func fileURL(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // C:/Users/... on Windows
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBrowserCandidates(t *testing.T) {
+	env := map[string]string{"ProgramFiles": `C:\Program Files`}
+	candidates := browserCandidates("windows", func(name string) string { return env[name] })
+	if len(candidates) != 2 || !strings.HasSuffix(candidates[0], "chrome.exe") || !strings.HasSuffix(candidates[1], "msedge.exe") {
+		t.Errorf("unexpected Windows candidates: %q", candidates)
+	}
+
+	for _, goos := range []string{"darwin", "linux"} {
+		if len(browserCandidates(goos, func(string) string { return "" })) == 0 {
+			t.Errorf("expected browser candidates for %s", goos)
+		}
+	}
+}
+
+func TestFileURL(t *testing.T) {
+	if got := fileURL("/tmp/jtool report/report.html"); got != "file:///tmp/jtool%20report/report.html" {
+		t.Errorf("unexpected URL: %s", got)
+	}
+	if runtime.GOOS == "windows" {
+		if got := fileURL(`C:\Temp\report.html`); got != "file:///C:/Temp/report.html" {
+			t.Errorf("unexpected URL: %s", got)
+		}
+	}
+}
+
+func TestPrintPDF(t *testing.T) {
+	var pdf bytes.Buffer
+	err := PrintPDF(context.Background(), []byte("<p>Hello</p>"), &pdf)
+	if err == ErrNoBrowser {
+		t.Skip("no browser installed to print with")
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(pdf.Bytes(), []byte("%PDF")) {
+		t.Error("expected a PDF")
+	}
+}
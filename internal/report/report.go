@@ -0,0 +1,303 @@
+// Package report renders diff and log comparison results as print-ready
+// documents for audit sign-off: a summary, stats tables and the most
+// significant differences, laid out in pages with table headers repeated
+// on each. The HTML has no external assets, so it can be archived as is or
+// printed to PDF (see PrintPDF).
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"jtool/internal/diff"
+	"jtool/internal/loganalyzer"
+)
+
+// DefaultTopN is how many differences are listed when Options.TopN is 0.
+const DefaultTopN = 100
+
+// maxValueLength is how much of a value is printed before it is cut off.
+const maxValueLength = 300
+
+// Options configures a report.
+type Options struct {
+	Title      string `json:"title"`      // Heading; defaults to "JSON comparison" or "Log comparison"
+	LeftLabel  string `json:"leftLabel"`  // What the left side is, e.g. a file name or "production"
+	RightLabel string `json:"rightLabel"` // What the right side is
+	TopN       int    `json:"topN"`       // Differences listed; 0 means DefaultTopN
+	Notes      string `json:"notes"`      // Shown under the summary, e.g. a change ticket
+	SignOff    bool   `json:"signOff"`    // Ends the report with lines for reviewer, date and signature
+}
+
+// page is what the template renders.
+type page struct {
+	Options
+	Generated string
+	Sides     [2]string // Headings of the left and right side
+	Stats     []stat
+	Columns   []string
+	Values    bool // The third and fourth columns hold JSON values
+	Rows      []row
+	Omitted   int // Differences beyond TopN
+}
+
+// stat is one line of the summary table.
+type stat struct {
+	Label string
+	Value string
+	Class string // added, removed or changed, for color
+}
+
+// row is one listed difference. The first cell is its path.
+type row struct {
+	Class string
+	Cells []string
+}
+
+// WriteDiffHTML renders a JSON diff: counts by type of difference, then
+// the first differences in document order, with both values.
+func WriteDiffHTML(w io.Writer, result *diff.DiffResult, opts Options) error {
+	p := newPage(opts, "JSON comparison")
+	s := result.Stats
+	p.Stats = []stat{
+		{Label: "Removed", Value: strconv.Itoa(s.Removed), Class: "removed"},
+		{Label: "Added", Value: strconv.Itoa(s.Added), Class: "added"},
+		{Label: "Changed", Value: strconv.Itoa(s.Changed), Class: "changed"},
+		{Label: "Equal", Value: strconv.Itoa(s.Equal)},
+	}
+	if s.Suppressed > 0 {
+		p.Stats = append(p.Stats, stat{Label: "Ignored by rules", Value: strconv.Itoa(s.Suppressed)})
+	}
+	p.Columns = []string{"Path", "Change", p.Sides[0], p.Sides[1]}
+	p.Values = true
+
+	var walk func(node *diff.DiffNode)
+	walk = func(node *diff.DiffNode) {
+		if node.Type == diff.DiffEqual {
+			return
+		}
+		if len(node.Children) == 0 {
+			if len(p.Rows) == p.TopN {
+				p.Omitted++
+				return
+			}
+			left, right := "", ""
+			if node.Type != diff.DiffAdded {
+				left = valueText(node.Left, node.LeftText)
+			}
+			if node.Type != diff.DiffRemoved {
+				right = valueText(node.Right, node.RightText)
+			}
+			p.Rows = append(p.Rows, row{Class: string(node.Type), Cells: []string{pathText(node.Path), string(node.Type), left, right}})
+		}
+		for i := range node.Children {
+			walk(&node.Children[i])
+		}
+	}
+	walk(&result.Root)
+
+	return reportTemplate.Execute(w, p)
+}
+
+// WriteLogComparisonHTML renders a log comparison: path counts and total
+// deltas, then the most significant differing paths: removed, added and
+// changed paths, each by the size of their count delta.
+func WriteLogComparisonHTML(w io.Writer, result *loganalyzer.ComparisonResult, opts Options) error {
+	if opts.LeftLabel == "" {
+		opts.LeftLabel = result.LeftFile
+	}
+	if opts.RightLabel == "" {
+		opts.RightLabel = result.RightFile
+	}
+	p := newPage(opts, "Log comparison")
+	s := result.Stats
+	p.Stats = []stat{
+		{Label: "Total paths", Value: strconv.Itoa(s.TotalPaths)},
+		{Label: "Removed", Value: strconv.Itoa(s.RemovedPaths), Class: "removed"},
+		{Label: "Added", Value: strconv.Itoa(s.AddedPaths), Class: "added"},
+		{Label: "Changed", Value: strconv.Itoa(s.ChangedPaths), Class: "changed"},
+		{Label: "Equal", Value: strconv.Itoa(s.EqualPaths)},
+		{Label: "Count delta", Value: signed(s.TotalCountDelta)},
+		{Label: "Objects delta", Value: signed(s.TotalObjectsDelta)},
+		{Label: "Distinct delta", Value: signed(s.TotalDistinctDelta)},
+	}
+	if s.IgnoredPaths > 0 {
+		p.Stats = append(p.Stats, stat{Label: "Ignored paths", Value: strconv.Itoa(s.IgnoredPaths)})
+	}
+	if result.VolumeScale != 0 {
+		p.Stats = append(p.Stats, stat{Label: "Right counts scaled by", Value: fmt.Sprintf("%.4g", result.VolumeScale)})
+	}
+	p.Columns = []string{"Path", "Change", p.Sides[0] + " count", p.Sides[1] + " count", "Count Δ", "Objects Δ", "Distinct Δ"}
+
+	rank := map[loganalyzer.ComparisonStatus]int{loganalyzer.StatusRemoved: 0, loganalyzer.StatusAdded: 1, loganalyzer.StatusChanged: 2}
+	var differing []loganalyzer.PathComparison
+	for _, c := range result.Comparisons {
+		if c.Status != loganalyzer.StatusEqual {
+			differing = append(differing, c)
+		}
+	}
+	sort.SliceStable(differing, func(i, j int) bool {
+		a, b := differing[i], differing[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return abs(a.CountDelta) > abs(b.CountDelta)
+	})
+
+	for _, c := range differing {
+		if len(p.Rows) == p.TopN {
+			p.Omitted = len(differing) - p.TopN
+			break
+		}
+		leftCount, rightCount := "", ""
+		if c.Left != nil {
+			leftCount = strconv.Itoa(c.Left.Count)
+		}
+		if c.Right != nil {
+			rightCount = strconv.Itoa(c.Right.Count)
+		}
+		p.Rows = append(p.Rows, row{Class: string(c.Status), Cells: []string{
+			c.Path, string(c.Status), leftCount, rightCount, signed(c.CountDelta), signed(c.ObjectsDelta), signed(c.DistinctDelta),
+		}})
+	}
+
+	return reportTemplate.Execute(w, p)
+}
+
+// newPage applies the defaults of opts.
+func newPage(opts Options, title string) *page {
+	if opts.Title == "" {
+		opts.Title = title
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = DefaultTopN
+	}
+	p := &page{Options: opts, Generated: time.Now().Format("2006-01-02 15:04 MST"), Sides: [2]string{"Left", "Right"}}
+	if opts.LeftLabel != "" {
+		p.Sides[0] = opts.LeftLabel
+	}
+	if opts.RightLabel != "" {
+		p.Sides[1] = opts.RightLabel
+	}
+	return p
+}
+
+// pathText returns path for display; the root has an empty path.
+func pathText(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// valueText returns a diff value as compact JSON, cut off after
+// maxValueLength characters. text is the exact form of a number too
+// precise for float64, if any.
+func valueText(value any, text string) string {
+	if text == "" {
+		// The template escapes HTML, so the encoder needn't
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(value); err != nil {
+			return fmt.Sprint(value)
+		}
+		text = strings.TrimSuffix(buf.String(), "\n")
+	}
+	if runes := []rune(text); len(runes) > maxValueLength {
+		return string(runes[:maxValueLength]) + "…"
+	}
+	return text
+}
+
+// signed formats a delta with an explicit "+".
+func signed(n int) string {
+	if n > 0 {
+		return "+" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	// text escapes s like the template would, but leaves the "+" of
+	// deltas alone rather than writing &#43;
+	"text": func(s string) template.HTML {
+		return template.HTML(template.HTMLEscapeString(s))
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: A4; margin: 18mm 15mm; @bottom-right { content: "Page " counter(page) " of " counter(pages); font-size: 9pt; } }
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 10pt; margin: 2em; color: #1f2328; }
+@media print { body { margin: 0; } }
+h1 { font-size: 1.6em; margin-bottom: .2em; }
+h2 { font-size: 1.2em; margin-top: 1.5em; break-after: avoid; }
+p.generated { color: #59636e; margin-top: 0; }
+p.notes { white-space: pre-wrap; border-left: 4px solid #d0d7de; padding-left: .8em; }
+table { border-collapse: collapse; margin: .5em 0; width: 100%; }
+table.summary { width: auto; }
+thead { display: table-header-group; }
+tr { break-inside: avoid; }
+th, td { padding: .3em .6em; border-bottom: 1px solid #d0d7de; text-align: left; vertical-align: top; }
+table.summary td { text-align: right; }
+table.differences td:first-child, table.differences td.value { font-family: ui-monospace, Menlo, Consolas, monospace; font-size: .9em; word-break: break-all; }
+tr.removed { background: #ffebe9; }
+tr.added { background: #dafbe1; }
+tr.changed { background: #fff8c5; }
+@media print { tr.removed, tr.added, tr.changed { -webkit-print-color-adjust: exact; print-color-adjust: exact; } }
+section.signoff { margin-top: 3em; break-inside: avoid; }
+section.signoff td { border-bottom: 1px solid #1f2328; height: 2.5em; width: 35%; }
+section.signoff th { border: none; width: 15%; vertical-align: bottom; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="generated">Generated {{.Generated}}</p>
+<table class="summary">
+<tr><th>Left</th><td>{{index .Sides 0}}</td></tr>
+<tr><th>Right</th><td>{{index .Sides 1}}</td></tr>
+</table>
+{{with .Notes}}<p class="notes">{{.}}</p>{{end}}
+
+<h2>Summary</h2>
+<table class="summary">
+{{range .Stats}}<tr{{with .Class}} class="{{.}}"{{end}}><th>{{.Label}}</th><td>{{text .Value}}</td></tr>
+{{end}}</table>
+
+<h2>Differences</h2>
+{{if .Rows}}<table class="differences">
+<thead><tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr class="{{.Class}}">{{range $i, $cell := .Cells}}<td{{if and $.Values (gt $i 1)}} class="value"{{end}}>{{text $cell}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{with .Omitted}}<p>{{.}} more differences not listed.</p>{{end}}
+{{else}}<p>No differences.</p>
+{{end}}
+{{if .SignOff}}<section class="signoff">
+<h2>Sign-off</h2>
+<table>
+<tr><th>Reviewed by</th><td></td><th>Date</th><td></td></tr>
+<tr><th>Signature</th><td></td><th></th><th></th></tr>
+</table>
+</section>
+{{end}}</body>
+</html>
+`))
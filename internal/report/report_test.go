@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"jtool/internal/diff"
+	"jtool/internal/loganalyzer"
+)
+
+func TestWriteDiffHTML(t *testing.T) {
+	left := map[string]any{"name": "Ada", "role": "<admin>", "old": true, "same": 1.0}
+	right := map[string]any{"name": "Ada", "role": "owner", "new": []any{1.0}, "same": 1.0}
+
+	var buf bytes.Buffer
+	opts := Options{LeftLabel: "main", RightLabel: "v2.3.0", Notes: "CHG-1042", SignOff: true}
+	if err := WriteDiffHTML(&buf, diff.Compare(left, right), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html := buf.String()
+
+	expected := []string{
+		"<title>JSON comparison</title>",
+		"<th>Left</th><td>main</td>",
+		`<p class="notes">CHG-1042</p>`,
+		`<tr class="removed"><th>Removed</th><td>1</td></tr>`,
+		"<th>Path</th><th>Change</th><th>main</th><th>v2.3.0</th>",
+		`<tr class="added"><td>.new</td><td>added</td><td class="value"></td><td class="value">[1]</td></tr>`,
+		`<td>.role</td><td>changed</td><td class="value">&#34;&lt;admin&gt;&#34;</td>`,
+		"<h2>Sign-off</h2>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+	if strings.Contains(html, "<td>.name</td>") {
+		t.Error("expected equal values not to be listed")
+	}
+}
+
+func TestWriteDiffHTMLTopN(t *testing.T) {
+	left := map[string]any{"a": 1.0, "b": 1.0, "c": 1.0}
+	right := map[string]any{"a": 2.0, "b": 2.0, "c": 2.0}
+
+	var buf bytes.Buffer
+	if err := WriteDiffHTML(&buf, diff.Compare(left, right), Options{TopN: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html := buf.String()
+	if !strings.Contains(html, "<td>.b</td>") || strings.Contains(html, "<td>.c</td>") {
+		t.Error("expected only the first two differences to be listed")
+	}
+	if !strings.Contains(html, "1 more differences not listed.") {
+		t.Error("expected the omitted differences to be counted")
+	}
+
+	buf.Reset()
+	if err := WriteDiffHTML(&buf, diff.Compare(left, left), Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<p>No differences.</p>") {
+		t.Error("expected an equal diff to say there are no differences")
+	}
+}
+
+func TestWriteLogComparisonHTML(t *testing.T) {
+	left, _ := loganalyzer.AnalyzeString(`{"a": 1, "b": 1}`)
+	right, _ := loganalyzer.AnalyzeString(`{"a": 1, "c": 2}
+{"a": 2, "c": 3}`)
+	comparison := loganalyzer.CompareAnalyses(left, right, "baseline.log", "candidate.log")
+
+	var buf bytes.Buffer
+	if err := WriteLogComparisonHTML(&buf, comparison, Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html := buf.String()
+
+	expected := []string{
+		"<title>Log comparison</title>",
+		"<th>Right</th><td>candidate.log</td>",
+		"<th>baseline.log count</th>",
+		`<tr class="removed"><td>.b</td><td>removed</td><td>1</td><td></td><td>-1</td>`,
+		`<tr class="added"><td>.c</td><td>added</td><td></td><td>2</td><td>+2</td>`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+	// Removed paths come first
+	if strings.Index(html, "<td>.b</td>") > strings.Index(html, "<td>.c</td>") {
+		t.Error("expected removed paths before added paths")
+	}
+}
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"jtool/internal/diff"
+	"jtool/internal/paths"
+)
+
+// DiffMatch is one occurrence of the pattern in a diff.
+type DiffMatch struct {
+	Path string        `json:"path"` // Path of the matched value, in Options.PathFormat
+	Node string        `json:"node"` // Path of the diff node showing it, to scroll to
+	Type diff.DiffType `json:"type"` // Type of that node
+	Side string        `json:"side"` // "left", "right" or "both" for values; "" for paths
+	In   string        `json:"in"`   // "path" or "value": which text matched
+	Text string        `json:"text"` // The matched substring
+
+	// Start and End locate the match within the path or value text, in
+	// UTF-16 code units (see Match).
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// DiffResult holds the matches of a search within a diff.
+type DiffResult struct {
+	Matches   []DiffMatch `json:"matches"`
+	Total     int         `json:"total"`     // Number of matches returned
+	Truncated bool        `json:"truncated"` // True if more matches were found than MaxMatches
+}
+
+// Diff searches the paths and values of a diff tree for pattern, a regular
+// expression unless opts.Literal is set. Matches are in tree order.
+//
+// Equal leaves don't keep their values, so they are looked up in left, the
+// normalized left document the diff was made from. An added or removed
+// container is searched through, with each of its leaves reported against
+// the container's node. Paths are matched and reported in pathFormat (see
+// paths.FormatPath), as the diff shows them.
+func Diff(root diff.DiffNode, left any, pattern, pathFormat string, opts Options) (*DiffResult, error) {
+	re, in, maxMatches, err := opts.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{Matches: []DiffMatch{}}
+	add := func(node *diff.DiffNode, path, side, where, text string) bool {
+		for _, loc := range locations(re, text) {
+			if len(result.Matches) >= maxMatches {
+				result.Truncated = true
+				return false
+			}
+			result.Matches = append(result.Matches, DiffMatch{
+				Path:  path,
+				Node:  paths.FormatPath(node.Path, pathFormat),
+				Type:  node.Type,
+				Side:  side,
+				In:    where,
+				Text:  text[loc[0]:loc[1]],
+				Start: utf16Len(text[:loc[0]]),
+				End:   utf16Len(text[:loc[1]]),
+			})
+		}
+		return true
+	}
+
+	var walk func(node *diff.DiffNode) bool
+	walk = func(node *diff.DiffNode) bool {
+		if len(node.Children) > 0 {
+			for i := range node.Children {
+				if !walk(&node.Children[i]) {
+					return false
+				}
+			}
+			return true
+		}
+
+		// The values shown at this node, by side
+		var sides []string
+		values := map[string]any{}
+		switch node.Type {
+		case diff.DiffEqual:
+			value, ok := paths.Lookup(left, node.Path)
+			if !ok {
+				return true
+			}
+			sides, values["both"] = []string{"both"}, value
+		case diff.DiffAdded:
+			sides, values["right"] = []string{"right"}, node.Right
+		case diff.DiffRemoved:
+			sides, values["left"] = []string{"left"}, node.Left
+		default:
+			sides, values["left"], values["right"] = []string{"left", "right"}, node.Left, node.Right
+		}
+
+		// A changed node's sides usually share paths; match each path once
+		seen := map[string]bool{}
+		for _, side := range sides {
+			for _, row := range paths.Flatten(values[side]) {
+				path := paths.FormatPath(node.Path+row.Path, pathFormat)
+				if in != InValues && !seen[path] {
+					seen[path] = true
+					if !add(node, path, "", "path", path) {
+						return false
+					}
+				}
+				if in != InPaths && !add(node, path, side, "value", valueText(row.Value)) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	walk(&root)
+
+	result.Total = len(result.Matches)
+	return result, nil
+}
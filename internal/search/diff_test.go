@@ -0,0 +1,81 @@
+package search
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"jtool/internal/diff"
+	"jtool/internal/paths"
+)
+
+func TestDiff(t *testing.T) {
+	var left, right any
+	json.Unmarshal([]byte(`{"name": "Ada", "role": "admin", "tags": ["x"]}`), &left)
+	json.Unmarshal([]byte(`{"name": "Ada", "role": "owner", "team": {"lead": "Ada"}}`), &right)
+	result := diff.Compare(left, right)
+
+	type hit struct {
+		Path, Node, Side, In, Text string
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		format   string
+		opts     Options
+		expected []hit
+	}{
+		{
+			name:    "equal, changed and added values",
+			pattern: "Ada",
+			opts:    Options{In: InValues},
+			expected: []hit{
+				{".name", ".name", "both", "value", "Ada"},
+				{".team.lead", ".team", "right", "value", "Ada"},
+			},
+		},
+		{
+			name:    "both sides of a changed value",
+			pattern: "^(admin|owner)$",
+			opts:    Options{In: InValues},
+			expected: []hit{
+				{".role", ".role", "left", "value", "admin"},
+				{".role", ".role", "right", "value", "owner"},
+			},
+		},
+		{
+			name:    "paths are matched once per changed node",
+			pattern: "role",
+			opts:    Options{IgnoreCase: true},
+			expected: []hit{
+				{".role", ".role", "", "path", "role"},
+			},
+		},
+		{
+			name:    "removed array elements in JSONPath",
+			pattern: "$.tags[0]",
+			format:  paths.FormatJSONPath,
+			opts:    Options{In: InPaths, Literal: true},
+			expected: []hit{
+				{"$.tags[0]", "$.tags", "", "path", "$.tags[0]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := Diff(result.Root, left, tt.pattern, tt.format, tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []hit{}
+			for _, m := range found.Matches {
+				got = append(got, hit{m.Path, m.Node, m.Side, m.In, m.Text})
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package search finds regular expression matches in the paths and values of
+// a JSON document.
+//
+// Searching in Go rather than in the webview avoids serializing large
+// documents to JavaScript and scanning them there. Each match reports where
+// the pattern was found within the path or value text, so the UI can
+// highlight it.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"unicode/utf16"
+
+	"jtool/internal/paths"
+)
+
+// Where to look for the pattern.
+const (
+	InPaths  = "paths"  // Only match against paths like ".users[0].name"
+	InValues = "values" // Only match against leaf values
+	InBoth   = "both"   // Both (the default)
+)
+
+// DefaultMaxMatches caps the number of matches returned, so a pattern like
+// "." on a huge document doesn't produce millions of results.
+const DefaultMaxMatches = 10000
+
+// Match is one occurrence of the pattern.
+type Match struct {
+	Path  string `json:"path"`  // Path of the value, with concrete indices
+	Value any    `json:"value"` // The leaf value at Path
+	In    string `json:"in"`    // "path" or "value": which text matched
+	Text  string `json:"text"`  // The matched substring
+
+	// Start and End locate the match within the path or value text. They
+	// count UTF-16 code units, like JavaScript string indices, so the UI can
+	// pass them straight to substring().
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Result holds the matches of a search.
+type Result struct {
+	Matches   []Match `json:"matches"`
+	Total     int     `json:"total"`     // Number of matches returned
+	Truncated bool    `json:"truncated"` // True if more matches were found than MaxMatches
+}
+
+// Options configures a search.
+type Options struct {
+	In         string `json:"in"`         // InPaths, InValues or InBoth ("" means InBoth)
+	MaxMatches int    `json:"maxMatches"` // 0 means DefaultMaxMatches
+	Literal    bool   `json:"literal"`    // Match the pattern as plain text rather than a regular expression
+	IgnoreCase bool   `json:"ignoreCase"` // Match letters regardless of case
+}
+
+// Document searches data for pattern, a regular expression unless
+// opts.Literal is set.
+//
+// Values are matched as text: strings as-is, other leaves as their JSON
+// form ("42", "true", "null"). Every occurrence is reported, so a value
+// containing the pattern twice produces two matches.
+func Document(data any, pattern string, opts Options) (*Result, error) {
+	re, in, maxMatches, err := opts.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Matches: []Match{}}
+	add := func(row paths.Row, where, text string) bool {
+		for _, loc := range locations(re, text) {
+			if len(result.Matches) >= maxMatches {
+				result.Truncated = true
+				return false
+			}
+			result.Matches = append(result.Matches, Match{
+				Path:  row.Path,
+				Value: row.Value,
+				In:    where,
+				Text:  text[loc[0]:loc[1]],
+				Start: utf16Len(text[:loc[0]]),
+				End:   utf16Len(text[:loc[1]]),
+			})
+		}
+		return true
+	}
+
+	for _, row := range paths.Flatten(data) {
+		if in != InValues && !add(row, "path", row.Path) {
+			break
+		}
+		if in != InPaths && !add(row, "value", valueText(row.Value)) {
+			break
+		}
+	}
+
+	result.Total = len(result.Matches)
+	return result, nil
+}
+
+// compile checks opts and returns the expression to search for, with the
+// search target and match limit defaults applied.
+func (opts Options) compile(pattern string) (*regexp.Regexp, string, int, error) {
+	if opts.Literal {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if opts.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	in := opts.In
+	if in == "" {
+		in = InBoth
+	}
+	if in != InPaths && in != InValues && in != InBoth {
+		return nil, "", 0, fmt.Errorf("unknown search target %q (want %q, %q or %q)", in, InPaths, InValues, InBoth)
+	}
+
+	maxMatches := opts.MaxMatches
+	if maxMatches <= 0 {
+		maxMatches = DefaultMaxMatches
+	}
+	return re, in, maxMatches, nil
+}
+
+// locations returns the byte ranges of re in text. Empty matches (e.g. of
+// "x*") are skipped, since they would match every value.
+func locations(re *regexp.Regexp, text string) [][]int {
+	all := re.FindAllStringIndex(text, -1)
+	found := all[:0]
+	for _, loc := range all {
+		if loc[0] != loc[1] {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
+
+// valueText returns the text a value is matched against.
+func valueText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// utf16Len counts the UTF-16 code units in s. Characters outside the Basic
+// Multilingual Plane (like most emoji) take two.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
@@ -0,0 +1,153 @@
+package search
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	var doc any
+	json.Unmarshal([]byte(`{
+		"user": {"name": "Ada Lovelace", "email": "ada@example.com"},
+		"tags": ["admin", "beta"],
+		"age": 36,
+		"active": true
+	}`), &doc)
+
+	type hit struct {
+		Path, In, Text string
+		Start, End     int
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		in       string
+		expected []hit
+	}{
+		{
+			name:    "values only",
+			pattern: "ada",
+			in:      InValues,
+			expected: []hit{
+				{".user.email", "value", "ada", 0, 3},
+			},
+		},
+		{
+			name:    "case-insensitive",
+			pattern: "(?i)ada",
+			in:      InValues,
+			expected: []hit{
+				{".user.email", "value", "ada", 0, 3},
+				{".user.name", "value", "Ada", 0, 3},
+			},
+		},
+		{
+			name:    "paths only",
+			pattern: `tags\[\d\]`,
+			in:      InPaths,
+			expected: []hit{
+				{".tags[0]", "path", "tags[0]", 1, 8},
+				{".tags[1]", "path", "tags[1]", 1, 8},
+			},
+		},
+		{
+			name:    "both",
+			pattern: "beta|ctive",
+			in:      "",
+			expected: []hit{
+				{".active", "path", "ctive", 2, 7},
+				{".tags[1]", "value", "beta", 0, 4},
+			},
+		},
+		{
+			name:    "non-string values match their JSON text",
+			pattern: `^(36|true)$`,
+			in:      InValues,
+			expected: []hit{
+				{".active", "value", "true", 0, 4},
+				{".age", "value", "36", 0, 2},
+			},
+		},
+		{
+			name:     "empty matches are ignored",
+			pattern:  "z*",
+			in:       InBoth,
+			expected: []hit{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Document(doc, tt.pattern, Options{In: tt.in})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []hit{}
+			for _, m := range result.Matches {
+				got = append(got, hit{m.Path, m.In, m.Text, m.Start, m.End})
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if result.Total != len(tt.expected) {
+				t.Errorf("expected total %d, got %d", len(tt.expected), result.Total)
+			}
+		})
+	}
+}
+
+func TestDocumentPositionsAreUTF16(t *testing.T) {
+	// "🎉" is two UTF-16 code units, "é" is one
+	result, err := Document(map[string]any{"s": "🎉é-x"}, "x", Options{In: InValues})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(result.Matches))
+	}
+	if m := result.Matches[0]; m.Start != 4 || m.End != 5 {
+		t.Errorf("expected positions 4-5, got %d-%d", m.Start, m.End)
+	}
+}
+
+func TestDocumentMaxMatches(t *testing.T) {
+	result, err := Document([]any{"aaa", "aa"}, "a", Options{In: InValues, MaxMatches: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 4 || !result.Truncated {
+		t.Errorf("expected 4 truncated matches, got %d (truncated=%v)", result.Total, result.Truncated)
+	}
+}
+
+func TestDocumentErrors(t *testing.T) {
+	if _, err := Document(nil, "(", Options{}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if _, err := Document(nil, "a", Options{In: "keys"}); err == nil {
+		t.Error("expected an error for an unknown search target")
+	}
+}
+
+func TestDocumentLiteralAndIgnoreCase(t *testing.T) {
+	doc := map[string]any{"price": "$1.50 (USD)", "name": "Ada"}
+
+	result, err := Document(doc, "$1.50 (", Options{In: InValues, Literal: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 1 || result.Matches[0].Path != ".price" {
+		t.Errorf("expected one literal match in .price, got %+v", result.Matches)
+	}
+
+	result, err = Document(doc, "ADA", Options{In: InValues, IgnoreCase: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 1 || result.Matches[0].Text != "Ada" {
+		t.Errorf("expected one case-insensitive match of Ada, got %+v", result.Matches)
+	}
+}
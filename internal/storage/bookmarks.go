@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Bookmarks stores macOS security-scoped bookmarks by file path, so files
+// in the history can be reopened in later sessions under the sandbox
+// (see package bookmark).
+type Bookmarks struct {
+	Items map[string][]byte `json:"bookmarks"` // File path -> bookmark data (base64 in the file)
+	mu    sync.RWMutex      `json:"-"`         // Mutex for thread-safe access (not serialized)
+}
+
+const bookmarksFileName = "bookmarks.json" // File name for storing bookmarks
+
+// NewBookmarks creates an empty bookmark store.
+func NewBookmarks() *Bookmarks {
+	return &Bookmarks{
+		Items: make(map[string][]byte),
+	}
+}
+
+// Set adds or replaces the bookmark for path.
+func (b *Bookmarks) Set(path string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Items[path] = data
+}
+
+// Has reports whether a bookmark is stored for path.
+func (b *Bookmarks) Has(path string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	_, ok := b.Items[path]
+	return ok
+}
+
+// Delete removes the bookmark for path, if any.
+func (b *Bookmarks) Delete(path string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.Items, path)
+}
+
+// All returns a copy of every bookmark by path.
+func (b *Bookmarks) All() map[string][]byte {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	items := make(map[string][]byte, len(b.Items))
+	for path, data := range b.Items {
+		items[path] = data
+	}
+	return items
+}
+
+// Clear removes every bookmark.
+func (b *Bookmarks) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Items = make(map[string][]byte)
+}
+
+// Prune checks every bookmark: those for paths not in keep, or that
+// resolve fails on, are dropped, and those resolve returns fresh data for
+// (because they went stale) are replaced. It reports whether anything
+// changed, so the caller knows to save.
+func (b *Bookmarks) Prune(keep map[string]bool, resolve func(path string, data []byte) ([]byte, error)) bool {
+	changed := false
+	for path, data := range b.All() {
+		if !keep[path] {
+			b.Delete(path)
+			changed = true
+			continue
+		}
+		fresh, err := resolve(path, data)
+		if err != nil {
+			b.Delete(path)
+			changed = true
+			continue
+		}
+		if fresh != nil {
+			b.Set(path, fresh)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Save writes the bookmarks to a JSON file in configDir.
+func (b *Bookmarks) Save(configDir string) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, bookmarksFileName), data, 0644)
+}
+
+// LoadBookmarks reads the bookmarks from configDir.
+// If the file doesn't exist, returns an empty store (not an error).
+func LoadBookmarks(configDir string) (*Bookmarks, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, bookmarksFileName))
+	if os.IsNotExist(err) {
+		return NewBookmarks(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var bookmarks Bookmarks
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, err
+	}
+
+	if bookmarks.Items == nil {
+		bookmarks.Items = make(map[string][]byte)
+	}
+
+	return &bookmarks, nil
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBookmarksSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing file loads as an empty store
+	empty, err := LoadBookmarks(dir)
+	if err != nil {
+		t.Fatalf("LoadBookmarks: %v", err)
+	}
+	if len(empty.All()) != 0 {
+		t.Errorf("expected no bookmarks, got %v", empty.All())
+	}
+
+	bookmarks := NewBookmarks()
+	bookmarks.Set("/data/a.json", []byte{0x00, 0xff, 0x10})
+	bookmarks.Set("/data/b.json", []byte("bookmark"))
+	if err := bookmarks.Save(dir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadBookmarks(dir)
+	if err != nil {
+		t.Fatalf("LoadBookmarks: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.All(), bookmarks.All()) {
+		t.Errorf("LoadBookmarks() = %v, want %v", loaded.All(), bookmarks.All())
+	}
+
+	// A file without the bookmarks key still gives a usable store
+	if err := os.WriteFile(filepath.Join(dir, bookmarksFileName), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadBookmarks(dir)
+	if err != nil {
+		t.Fatalf("LoadBookmarks: %v", err)
+	}
+	loaded.Set("/data/c.json", []byte("bookmark"))
+	if !loaded.Has("/data/c.json") {
+		t.Error("expected the bookmark to be stored")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, bookmarksFileName), []byte(`{"bookmarks": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBookmarks(dir); err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestBookmarksPrune(t *testing.T) {
+	errResolve := errors.New("bookmark can't be resolved")
+
+	tests := []struct {
+		name        string
+		items       map[string][]byte
+		keep        map[string]bool
+		resolve     map[string][]byte // Path -> fresh data; missing paths fail to resolve
+		wantItems   map[string][]byte
+		wantChanged bool
+	}{
+		{
+			name:        "all current",
+			items:       map[string][]byte{"/a.json": []byte("a"), "/b.json": []byte("b")},
+			keep:        map[string]bool{"/a.json": true, "/b.json": true},
+			resolve:     map[string][]byte{"/a.json": nil, "/b.json": nil},
+			wantItems:   map[string][]byte{"/a.json": []byte("a"), "/b.json": []byte("b")},
+			wantChanged: false,
+		},
+		{
+			name:        "not in history",
+			items:       map[string][]byte{"/a.json": []byte("a"), "/gone.json": []byte("g")},
+			keep:        map[string]bool{"/a.json": true},
+			resolve:     map[string][]byte{"/a.json": nil, "/gone.json": nil},
+			wantItems:   map[string][]byte{"/a.json": []byte("a")},
+			wantChanged: true,
+		},
+		{
+			name:        "unresolvable",
+			items:       map[string][]byte{"/a.json": []byte("a"), "/moved.json": []byte("m")},
+			keep:        map[string]bool{"/a.json": true, "/moved.json": true},
+			resolve:     map[string][]byte{"/a.json": nil},
+			wantItems:   map[string][]byte{"/a.json": []byte("a")},
+			wantChanged: true,
+		},
+		{
+			name:        "stale replaced",
+			items:       map[string][]byte{"/a.json": []byte("a"), "/stale.json": []byte("old")},
+			keep:        map[string]bool{"/a.json": true, "/stale.json": true},
+			resolve:     map[string][]byte{"/a.json": nil, "/stale.json": []byte("new")},
+			wantItems:   map[string][]byte{"/a.json": []byte("a"), "/stale.json": []byte("new")},
+			wantChanged: true,
+		},
+		{
+			name:        "empty history",
+			items:       map[string][]byte{"/a.json": []byte("a")},
+			keep:        map[string]bool{},
+			resolve:     map[string][]byte{"/a.json": nil},
+			wantItems:   map[string][]byte{},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookmarks := NewBookmarks()
+			for path, data := range tt.items {
+				bookmarks.Set(path, data)
+			}
+
+			resolved := make(map[string]bool)
+			changed := bookmarks.Prune(tt.keep, func(path string, data []byte) ([]byte, error) {
+				resolved[path] = true
+				if !reflect.DeepEqual(data, tt.items[path]) {
+					t.Errorf("resolve(%s) got data %q, want %q", path, data, tt.items[path])
+				}
+				fresh, ok := tt.resolve[path]
+				if !ok {
+					return nil, errResolve
+				}
+				return fresh, nil
+			})
+
+			if changed != tt.wantChanged {
+				t.Errorf("Prune() = %v, want %v", changed, tt.wantChanged)
+			}
+			if got := bookmarks.All(); !reflect.DeepEqual(got, tt.wantItems) {
+				t.Errorf("bookmarks = %q, want %q", got, tt.wantItems)
+			}
+			// Bookmarks outside the history are dropped without resolving them
+			for path := range resolved {
+				if !tt.keep[path] {
+					t.Errorf("resolved %s, which is not in the history", path)
+				}
+			}
+		})
+	}
+}
+
+func TestBookmarksPruneSaved(t *testing.T) {
+	dir := t.TempDir()
+	bookmarks := NewBookmarks()
+	bookmarks.Set("/kept.json", []byte("old"))
+	bookmarks.Set("/gone.json", []byte("g"))
+	if err := bookmarks.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadBookmarks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := loaded.Prune(map[string]bool{"/kept.json": true}, func(path string, data []byte) ([]byte, error) {
+		return []byte("new"), nil
+	})
+	if !changed {
+		t.Fatal("expected Prune to report a change")
+	}
+	if err := loaded.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadBookmarks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]byte{"/kept.json": []byte("new")}
+	if got := reloaded.All(); !reflect.DeepEqual(got, want) {
+		t.Errorf("bookmarks after reload = %q, want %q", got, want)
+	}
+}
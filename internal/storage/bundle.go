@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BundleFormat marks a JSON document as a jtool app data bundle.
+const BundleFormat = "jtool-app-data"
+
+// BundleVersion is the bundle layout written by this version of jtool.
+// Bundles with a later version are rejected rather than half-imported.
+const BundleVersion = 1
+
+// Bundle is all of the app's data in one document, for moving it to
+// another machine or sharing normalization profiles with a team.
+// Bookmarks are left out since they only work on the machine that made
+// them.
+type Bundle struct {
+	Format   string                     `json:"format"`             // Always BundleFormat
+	Version  int                        `json:"version"`            // BundleVersion when written
+	Exported time.Time                  `json:"exported"`           // When the bundle was written
+	Profiles map[string]json.RawMessage `json:"profiles"`           // Normalization profiles by name
+	History  map[string][]string        `json:"history"`            // File history by key, newest first
+	Layout   *Layout                    `json:"layout,omitempty"`   // Window and pane arrangement
+	Settings json.RawMessage            `json:"settings,omitempty"` // App settings, in the app's own format
+}
+
+// How Profiles.Merge handles a profile whose name is already taken by one
+// with different options.
+const (
+	ConflictSkip      = "skip"      // Keep the existing profile (the default)
+	ConflictOverwrite = "overwrite" // Replace it with the imported one
+	ConflictRename    = "rename"    // Import under a new name, e.g. "strict (2)"
+)
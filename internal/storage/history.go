@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 )
 
@@ -16,7 +17,7 @@ type FileHistory struct {
 }
 
 const (
-	maxHistoryPerKey = 10           // Maximum number of paths to store per key
+	maxHistoryPerKey = 10             // Maximum number of paths to store per key
 	historyFileName  = "history.json" // File name for storing history
 )
 
@@ -97,6 +98,30 @@ func (h *FileHistory) GetMostRecent(key string) string {
 	return paths[0]
 }
 
+// Merge adds imported history after the existing paths of each key,
+// keeping the existing ones first and at most maxHistoryPerKey in all.
+// It returns the number of paths added.
+func (h *FileHistory) Merge(imported map[string][]string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	added := 0
+	for key, paths := range imported {
+		existing := h.Paths[key]
+		for _, path := range paths {
+			if len(existing) >= maxHistoryPerKey {
+				break
+			}
+			if !slices.Contains(existing, path) {
+				existing = append(existing, path)
+				added++
+			}
+		}
+		h.Paths[key] = existing
+	}
+	return added
+}
+
 // Save writes the history to a JSON file.
 // The file is stored in the user's config directory.
 func (h *FileHistory) Save(configDir string) error {
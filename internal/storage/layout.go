@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Layout stores the window and pane arrangement so the app reopens the
+// way it was left.
+type Layout struct {
+	Window    WindowState        `json:"window"`    // Size and position of the main window
+	Tab       string             `json:"tab"`       // Selected tab (e.g. "compare", "paths")
+	Splitters map[string]float64 `json:"splitters"` // Splitter name -> share of the space taken by its first pane (0-1)
+	mu        sync.RWMutex       `json:"-"`         // Mutex for thread-safe access (not serialized)
+}
+
+// WindowState is the geometry of the main window. A zero Width means it
+// was never saved.
+type WindowState struct {
+	X         int  `json:"x"`
+	Y         int  `json:"y"`
+	Width     int  `json:"width"`
+	Height    int  `json:"height"`
+	Maximised bool `json:"maximised"`
+}
+
+const layoutFileName = "layout.json" // File name for storing the layout
+
+// NewLayout creates an empty layout.
+func NewLayout() *Layout {
+	return &Layout{
+		Splitters: make(map[string]float64),
+	}
+}
+
+// SetWindow records the window geometry.
+func (l *Layout) SetWindow(w WindowState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Window = w
+}
+
+// GetWindow returns the recorded window geometry.
+func (l *Layout) GetWindow() WindowState {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.Window
+}
+
+// SetTab records the selected tab.
+func (l *Layout) SetTab(tab string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Tab = tab
+}
+
+// SetSplitter records the ratio of a pane splitter.
+func (l *Layout) SetSplitter(name string, ratio float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Splitters[name] = ratio
+}
+
+// Snapshot returns a copy of the layout that is safe to read while the
+// original keeps changing.
+func (l *Layout) Snapshot() *Layout {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	splitters := make(map[string]float64, len(l.Splitters))
+	for name, ratio := range l.Splitters {
+		splitters[name] = ratio
+	}
+	return &Layout{
+		Window:    l.Window,
+		Tab:       l.Tab,
+		Splitters: splitters,
+	}
+}
+
+// Replace takes the window, tab and splitters of other.
+func (l *Layout) Replace(other *Layout) {
+	other = other.Snapshot()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Window = other.Window
+	l.Tab = other.Tab
+	l.Splitters = other.Splitters
+}
+
+// Save writes the layout to a JSON file in configDir.
+func (l *Layout) Save(configDir string) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, layoutFileName), data, 0644)
+}
+
+// LoadLayout reads the layout from configDir.
+// If the file doesn't exist, returns an empty layout (not an error).
+func LoadLayout(configDir string) (*Layout, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, layoutFileName))
+	if os.IsNotExist(err) {
+		return NewLayout(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var layout Layout
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+
+	if layout.Splitters == nil {
+		layout.Splitters = make(map[string]float64)
+	}
+
+	return &layout, nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// Nothing saved yet: an empty layout, which restoreWindow ignores
+	empty, err := LoadLayout(dir)
+	if err != nil {
+		t.Fatalf("LoadLayout: %v", err)
+	}
+	if empty.GetWindow().Width != 0 || empty.Splitters == nil {
+		t.Errorf("expected an empty layout, got %+v", empty.Snapshot())
+	}
+
+	layout := NewLayout()
+	layout.SetWindow(WindowState{X: -1800, Y: 40, Width: 1280, Height: 800, Maximised: true})
+	layout.SetTab("compare")
+	layout.SetSplitter("diff", 0.4)
+	layout.SetSplitter("logs", 0.25)
+	if err := layout.Save(dir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadLayout(dir)
+	if err != nil {
+		t.Fatalf("LoadLayout: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Snapshot(), layout.Snapshot()) {
+		t.Errorf("LoadLayout() = %+v, want %+v", loaded.Snapshot(), layout.Snapshot())
+	}
+
+	// A file without splitters still gives a usable layout
+	if err := os.WriteFile(filepath.Join(dir, layoutFileName), []byte(`{"tab": "paths"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadLayout(dir)
+	if err != nil {
+		t.Fatalf("LoadLayout: %v", err)
+	}
+	loaded.SetSplitter("diff", 0.5)
+	if loaded.Snapshot().Tab != "paths" {
+		t.Errorf("expected the tab to be read, got %+v", loaded.Snapshot())
+	}
+}
+
+func TestLoadLayout_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, layoutFileName), []byte(`{"window": {"x": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if layout, err := LoadLayout(dir); err == nil {
+		t.Errorf("expected an error for a corrupt layout file, got %+v", layout.Snapshot())
+	}
+}
+
+func TestLayoutSnapshotAndReplace(t *testing.T) {
+	layout := NewLayout()
+	layout.SetTab("diff")
+	layout.SetSplitter("diff", 0.3)
+
+	// A snapshot doesn't change with the layout
+	snapshot := layout.Snapshot()
+	layout.SetSplitter("diff", 0.7)
+	layout.SetTab("logs")
+	if snapshot.Tab != "diff" || snapshot.Splitters["diff"] != 0.3 {
+		t.Errorf("expected the snapshot to keep diff/0.3, got %+v", snapshot)
+	}
+
+	// Replace takes everything from the other layout, without sharing it
+	other := NewLayout()
+	other.SetWindow(WindowState{Width: 900, Height: 700})
+	other.SetTab("paths")
+	other.SetSplitter("logs", 0.6)
+	layout.Replace(other)
+	other.SetSplitter("logs", 0.1)
+
+	want := &Layout{
+		Window:    WindowState{Width: 900, Height: 700},
+		Tab:       "paths",
+		Splitters: map[string]float64{"logs": 0.6},
+	}
+	if got := layout.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("after Replace = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"jtool/internal/monitor"
+)
+
+// MaxMonitorRuns is how many runs are kept per monitored comparison.
+const MaxMonitorRuns = 50
+
+// Monitors stores the comparisons re-run on a schedule and the outcome of
+// their recent runs (see package monitor).
+type Monitors struct {
+	Jobs map[string]monitor.Job   `json:"jobs"` // Job ID -> job
+	Runs map[string][]monitor.Run `json:"runs"` // Job ID -> runs, oldest first
+	mu   sync.RWMutex             `json:"-"`    // Mutex for thread-safe access (not serialized)
+}
+
+const monitorsFileName = "monitors.json" // File name for storing monitors
+
+// NewMonitors creates an empty monitor store.
+func NewMonitors() *Monitors {
+	return &Monitors{
+		Jobs: make(map[string]monitor.Job),
+		Runs: make(map[string][]monitor.Run),
+	}
+}
+
+// Set adds job, or replaces the job with the same ID. The runs of a
+// replaced job are kept.
+func (m *Monitors) Set(job monitor.Job) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Jobs[job.ID] = job
+}
+
+// Get returns the job with the given ID.
+func (m *Monitors) Get(id string) (monitor.Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.Jobs[id]
+	return job, ok
+}
+
+// Delete removes a job and its runs.
+func (m *Monitors) Delete(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.Jobs, id)
+	delete(m.Runs, id)
+}
+
+// All returns every job, sorted by name.
+func (m *Monitors) All() []monitor.Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]monitor.Job, 0, len(m.Jobs))
+	for _, job := range m.Jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Name != jobs[j].Name {
+			return jobs[i].Name < jobs[j].Name
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+	return jobs
+}
+
+// AddRun records a run of the job with the given ID, dropping the oldest
+// runs beyond MaxMonitorRuns.
+func (m *Monitors) AddRun(id string, run monitor.Run) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	runs := append(m.Runs[id], run)
+	if len(runs) > MaxMonitorRuns {
+		runs = runs[len(runs)-MaxMonitorRuns:]
+	}
+	m.Runs[id] = runs
+}
+
+// GetRuns returns the recorded runs of a job, newest first.
+func (m *Monitors) GetRuns(id string) []monitor.Run {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	runs := m.Runs[id]
+	result := make([]monitor.Run, len(runs))
+	for i, run := range runs {
+		result[len(runs)-1-i] = run
+	}
+	return result
+}
+
+// LastSuccess returns the newest run of a job that didn't fail, or nil.
+func (m *Monitors) LastSuccess(id string) *monitor.Run {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	runs := m.Runs[id]
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Error == "" {
+			run := runs[i]
+			return &run
+		}
+	}
+	return nil
+}
+
+// Save writes the monitors to a JSON file in configDir.
+func (m *Monitors) Save(configDir string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, monitorsFileName), data, 0644)
+}
+
+// LoadMonitors reads the monitors from configDir.
+// If the file doesn't exist, returns an empty store (not an error).
+func LoadMonitors(configDir string) (*Monitors, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, monitorsFileName))
+	if os.IsNotExist(err) {
+		return NewMonitors(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var monitors Monitors
+	if err := json.Unmarshal(data, &monitors); err != nil {
+		return nil, err
+	}
+
+	if monitors.Jobs == nil {
+		monitors.Jobs = make(map[string]monitor.Job)
+	}
+	if monitors.Runs == nil {
+		monitors.Runs = make(map[string][]monitor.Run)
+	}
+
+	return &monitors, nil
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Profiles stores named bundles of normalization options
+// (e.g. "strict", "api-contract", "singer-output").
+//
+// Options are kept as raw JSON so this package doesn't depend on the
+// app's option types; callers marshal/unmarshal their own struct.
+type Profiles struct {
+	Items map[string]json.RawMessage `json:"profiles"` // Profile name -> options JSON
+	mu    sync.RWMutex               `json:"-"`        // Mutex for thread-safe access (not serialized)
+}
+
+const profilesFileName = "profiles.json" // File name for storing profiles
+
+// NewProfiles creates an empty profile store.
+func NewProfiles() *Profiles {
+	return &Profiles{
+		Items: make(map[string]json.RawMessage),
+	}
+}
+
+// Set adds or replaces the profile with the given name.
+func (p *Profiles) Set(name string, options json.RawMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Items[name] = options
+}
+
+// Get returns the options stored under name.
+// The boolean is false if no such profile exists.
+func (p *Profiles) Get(name string) (json.RawMessage, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	options, ok := p.Items[name]
+	return options, ok
+}
+
+// Delete removes a profile. Returns false if it didn't exist.
+func (p *Profiles) Delete(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.Items[name]; !ok {
+		return false
+	}
+	delete(p.Items, name)
+	return true
+}
+
+// Names returns all profile names in alphabetical order.
+func (p *Profiles) Names() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	names := make([]string, 0, len(p.Items))
+	for name := range p.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// All returns a copy of every profile by name.
+func (p *Profiles) All() map[string]json.RawMessage {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	items := make(map[string]json.RawMessage, len(p.Items))
+	for name, options := range p.Items {
+		items[name] = options
+	}
+	return items
+}
+
+// MergeResult lists what Merge did with each imported profile.
+type MergeResult struct {
+	Added     []string          `json:"added"`     // New names
+	Unchanged []string          `json:"unchanged"` // Already present with the same options
+	Replaced  []string          `json:"replaced"`  // Overwritten (ConflictOverwrite)
+	Renamed   map[string]string `json:"renamed"`   // Imported name -> name used (ConflictRename)
+	Skipped   []string          `json:"skipped"`   // Left as they were (ConflictSkip)
+}
+
+// Merge adds imported profiles. A name already taken by different options
+// is handled as conflict says: one of ConflictSkip (also used when empty),
+// ConflictOverwrite or ConflictRename.
+func (p *Profiles) Merge(items map[string]json.RawMessage, conflict string) MergeResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Go through names in order, so renames are the same on every run
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := MergeResult{
+		Added:     []string{},
+		Unchanged: []string{},
+		Replaced:  []string{},
+		Renamed:   make(map[string]string),
+		Skipped:   []string{},
+	}
+	for _, name := range names {
+		options := items[name]
+		existing, taken := p.Items[name]
+		switch {
+		case !taken:
+			p.Items[name] = options
+			result.Added = append(result.Added, name)
+		case SameProfile(existing, options):
+			result.Unchanged = append(result.Unchanged, name)
+		case conflict == ConflictOverwrite:
+			p.Items[name] = options
+			result.Replaced = append(result.Replaced, name)
+		case conflict == ConflictRename:
+			renamed := p.freeName(name)
+			p.Items[renamed] = options
+			result.Renamed[name] = renamed
+		default:
+			result.Skipped = append(result.Skipped, name)
+		}
+	}
+	return result
+}
+
+// freeName returns "name (2)", "name (3)"... whichever is unused first.
+func (p *Profiles) freeName(name string) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if _, taken := p.Items[candidate]; !taken {
+			return candidate
+		}
+	}
+}
+
+// SameProfile reports whether two profiles hold the same options,
+// ignoring formatting and key order.
+func SameProfile(a, b json.RawMessage) bool {
+	var x, y any
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// Save writes the profiles to a JSON file in configDir.
+func (p *Profiles) Save(configDir string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, profilesFileName), data, 0644)
+}
+
+// LoadProfiles reads profiles from configDir.
+// If the file doesn't exist, returns an empty store (not an error).
+func LoadProfiles(configDir string) (*Profiles, error) {
+	filePath := filepath.Join(configDir, profilesFileName)
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return NewProfiles(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles Profiles
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+
+	if profiles.Items == nil {
+		profiles.Items = make(map[string]json.RawMessage)
+	}
+
+	return &profiles, nil
+}
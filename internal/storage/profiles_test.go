@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProfilesSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	// A missing file loads as an empty store
+	empty, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if len(empty.Names()) != 0 {
+		t.Errorf("expected no profiles, got %v", empty.Names())
+	}
+
+	profiles := NewProfiles()
+	profiles.Set("strict", json.RawMessage(`{"sortKeys": true, "trimStrings": true}`))
+	profiles.Set("api-contract", json.RawMessage(`{"nullEqualsAbsent": true}`))
+	if err := profiles.Save(dir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if want := []string{"api-contract", "strict"}; !reflect.DeepEqual(loaded.Names(), want) {
+		t.Errorf("Names() = %q, want %q", loaded.Names(), want)
+	}
+	// Save indents the options, so compare them decoded
+	options, ok := loaded.Get("strict")
+	var got map[string]any
+	if !ok || json.Unmarshal(options, &got) != nil {
+		t.Fatalf(`Get("strict") = %s, %v`, options, ok)
+	}
+	if want := map[string]any{"sortKeys": true, "trimStrings": true}; !reflect.DeepEqual(got, want) {
+		t.Errorf(`Get("strict") = %v, want %v`, got, want)
+	}
+
+	// A file without the profiles key still gives a usable store
+	if err := os.WriteFile(filepath.Join(dir, profilesFileName), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadProfiles(dir)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	loaded.Set("loose", json.RawMessage(`{}`))
+	if _, ok := loaded.Get("loose"); !ok {
+		t.Error("expected the profile to be stored")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, profilesFileName), []byte(`{"profiles": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProfiles(dir); err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestProfilesDelete(t *testing.T) {
+	profiles := NewProfiles()
+	profiles.Set("strict", json.RawMessage(`{"sortKeys": true}`))
+	profiles.Set("strict", json.RawMessage(`{"sortKeys": false}`)) // Replaces, doesn't add
+
+	if names := profiles.Names(); !reflect.DeepEqual(names, []string{"strict"}) {
+		t.Errorf("Names() = %q, want [strict]", names)
+	}
+	if !profiles.Delete("strict") {
+		t.Error(`Delete("strict") = false, want true`)
+	}
+	if profiles.Delete("strict") {
+		t.Error(`Delete("strict") again = true, want false`)
+	}
+	if _, ok := profiles.Get("strict"); ok {
+		t.Error("expected the profile to be gone")
+	}
+}
+
+func TestProfilesMerge(t *testing.T) {
+	strict := json.RawMessage(`{"sortKeys": true, "trimStrings": true}`)
+	loose := json.RawMessage(`{"sortKeys": false}`)
+	imported := map[string]json.RawMessage{
+		"new":    loose,
+		"same":   json.RawMessage(`{"trimStrings": true, "sortKeys": true}`),
+		"strict": loose,
+	}
+
+	tests := []struct {
+		conflict string
+		want     MergeResult
+		strict   json.RawMessage            // Options stored under "strict" afterwards
+		extra    map[string]json.RawMessage // Profiles added under other names
+	}{
+		{
+			conflict: ConflictSkip,
+			want:     MergeResult{Added: []string{"new"}, Unchanged: []string{"same"}, Replaced: []string{}, Renamed: map[string]string{}, Skipped: []string{"strict"}},
+			strict:   strict,
+		},
+		{
+			conflict: "",
+			want:     MergeResult{Added: []string{"new"}, Unchanged: []string{"same"}, Replaced: []string{}, Renamed: map[string]string{}, Skipped: []string{"strict"}},
+			strict:   strict,
+		},
+		{
+			conflict: ConflictOverwrite,
+			want:     MergeResult{Added: []string{"new"}, Unchanged: []string{"same"}, Replaced: []string{"strict"}, Renamed: map[string]string{}, Skipped: []string{}},
+			strict:   loose,
+		},
+		{
+			conflict: ConflictRename,
+			want:     MergeResult{Added: []string{"new"}, Unchanged: []string{"same"}, Replaced: []string{}, Renamed: map[string]string{"strict": "strict (3)"}, Skipped: []string{}},
+			strict:   strict,
+			extra:    map[string]json.RawMessage{"strict (3)": loose},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.conflict, func(t *testing.T) {
+			p := NewProfiles()
+			p.Set("strict", strict)
+			p.Set("strict (2)", strict) // Taken, so a rename goes on to "(3)"
+			p.Set("same", strict)
+
+			got := p.Merge(imported, tt.conflict)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+			if options, _ := p.Get("strict"); !SameProfile(options, tt.strict) {
+				t.Errorf(`"strict" = %s, want %s`, options, tt.strict)
+			}
+			if options, _ := p.Get("new"); !SameProfile(options, loose) {
+				t.Errorf(`"new" = %s, want %s`, options, loose)
+			}
+			for name, want := range tt.extra {
+				if options, ok := p.Get(name); !ok || !SameProfile(options, want) {
+					t.Errorf("%q = %s, want %s", name, options, want)
+				}
+			}
+			if want := 4 + len(tt.extra); len(p.Names()) != want {
+				t.Errorf("Names() = %q, want %d profiles", p.Names(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session is the working state of the app (pasted pane contents, selected
+// options and loaded files), autosaved while the app runs so it can be
+// restored if the app crashes.
+//
+// A clean shutdown removes the autosaved session with ClearSession. If one
+// is still there at the next launch, RecoverSession sets it aside as the
+// recovered session, so autosaves of the new session don't overwrite it
+// before the user has chosen whether to restore it.
+type Session struct {
+	Saved   time.Time         `json:"saved"`             // When the session was autosaved
+	Tab     string            `json:"tab"`               // Selected tab (e.g. "compare", "logs")
+	Panes   map[string]string `json:"panes"`             // Pane name (e.g. "left", "right") -> content
+	Options map[string]any    `json:"options,omitempty"` // Selected options, as the frontend keeps them
+	Files   []string          `json:"files"`             // Paths of loaded files
+	Dropped []string          `json:"dropped,omitempty"` // Panes left out of Panes for being too large
+}
+
+const (
+	sessionFileName          = "session.json"           // File name for the autosaved session
+	recoveredSessionFileName = "session-recovered.json" // File name for a session left by a crash
+)
+
+// SaveSession writes the session to a JSON file in configDir. The file is
+// replaced atomically, so a crash while saving leaves the previous
+// autosave intact.
+func SaveSession(configDir string, session *Session) error {
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(configDir, sessionFileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(configDir, sessionFileName))
+}
+
+// ClearSession removes the autosaved session from configDir, e.g. on a
+// clean shutdown. It's not an error if there is none.
+func ClearSession(configDir string) error {
+	return removeIfExists(filepath.Join(configDir, sessionFileName))
+}
+
+// RecoverSession sets aside a session autosaved in configDir but never
+// cleared, which means the app didn't shut down cleanly, and returns the
+// recovered session. A session recovered earlier and not yet discarded is
+// returned if there is no newer one. Returns nil (not an error) if there
+// is nothing to recover.
+func RecoverSession(configDir string) (*Session, error) {
+	recovered := filepath.Join(configDir, recoveredSessionFileName)
+	err := os.Rename(filepath.Join(configDir, sessionFileName), recovered)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(recovered)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	if session.Panes == nil {
+		session.Panes = make(map[string]string)
+	}
+
+	return &session, nil
+}
+
+// DiscardRecoveredSession removes the session set aside by RecoverSession,
+// once it has been restored or declined.
+func DiscardRecoveredSession(configDir string) error {
+	return removeIfExists(filepath.Join(configDir, recoveredSessionFileName))
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
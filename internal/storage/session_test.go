@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveAndRecoverSession(t *testing.T) {
+	dir := t.TempDir()
+	saved := &Session{
+		Saved:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Tab:     "compare",
+		Panes:   map[string]string{"left": `{"a": 1}`, "right": `{"a": 2}`},
+		Options: map[string]any{"sortKeys": true},
+		Files:   []string{"/tmp/a.json"},
+		Dropped: []string{"logs"},
+	}
+	if err := SaveSession(dir, saved); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != sessionFileName {
+		t.Errorf("expected only %s in the config directory, got %v", sessionFileName, entries)
+	}
+
+	recovered, err := RecoverSession(dir)
+	if err != nil {
+		t.Fatalf("RecoverSession: %v", err)
+	}
+	if !reflect.DeepEqual(recovered, saved) {
+		t.Errorf("RecoverSession() = %+v, want %+v", recovered, saved)
+	}
+
+	// The autosave was set aside, so the new session's autosaves can't
+	// overwrite it
+	if _, err := os.Stat(filepath.Join(dir, sessionFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be moved aside, got %v", sessionFileName, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, recoveredSessionFileName)); err != nil {
+		t.Errorf("expected %s: %v", recoveredSessionFileName, err)
+	}
+}
+
+func TestRecoverSession_Nothing(t *testing.T) {
+	dir := t.TempDir()
+	recovered, err := RecoverSession(dir)
+	if err != nil || recovered != nil {
+		t.Errorf("RecoverSession() = %+v, %v, want nil, nil", recovered, err)
+	}
+
+	// A clean shutdown clears the autosave, leaving nothing to recover
+	if err := SaveSession(dir, &Session{Tab: "paths"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ClearSession(dir); err != nil {
+		t.Fatalf("ClearSession: %v", err)
+	}
+	recovered, err = RecoverSession(dir)
+	if err != nil || recovered != nil {
+		t.Errorf("RecoverSession() after ClearSession = %+v, %v, want nil, nil", recovered, err)
+	}
+}
+
+func TestRecoverSession_NewerAutosaveWins(t *testing.T) {
+	dir := t.TempDir()
+
+	// A crash leaves a session, which is recovered but never discarded
+	if err := SaveSession(dir, &Session{Tab: "old"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecoverSession(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// It's still offered at the next launch if there is nothing newer
+	recovered, err := RecoverSession(dir)
+	if err != nil || recovered == nil || recovered.Tab != "old" {
+		t.Fatalf("RecoverSession() = %+v, %v, want the old session", recovered, err)
+	}
+
+	// Another crash leaves a newer autosave, which takes its place
+	if err := SaveSession(dir, &Session{Tab: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	recovered, err = RecoverSession(dir)
+	if err != nil || recovered == nil || recovered.Tab != "new" {
+		t.Fatalf("RecoverSession() = %+v, %v, want the new session", recovered, err)
+	}
+	if recovered.Panes == nil {
+		t.Error("expected Panes to be an empty map, not nil")
+	}
+}
+
+func TestDiscardRecoveredSession(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveSession(dir, &Session{Tab: "compare"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecoverSession(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DiscardRecoveredSession(dir); err != nil {
+		t.Fatalf("DiscardRecoveredSession: %v", err)
+	}
+	recovered, err := RecoverSession(dir)
+	if err != nil || recovered != nil {
+		t.Errorf("RecoverSession() after discarding = %+v, %v, want nil, nil", recovered, err)
+	}
+
+	// Discarding again is not an error
+	if err := DiscardRecoveredSession(dir); err != nil {
+		t.Errorf("DiscardRecoveredSession with nothing to discard: %v", err)
+	}
+}
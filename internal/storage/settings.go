@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const settingsFileName = "settings.json" // File name for storing app settings
+
+// SaveSettings writes app settings, in the app's own format, to a JSON file
+// in configDir.
+func SaveSettings(configDir string, settings json.RawMessage) error {
+	// Ensure config directory exists
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(configDir, settingsFileName), settings, 0644)
+}
+
+// LoadSettings reads the app settings from configDir.
+// If they were never saved, returns nil (not an error).
+func LoadSettings(configDir string) (json.RawMessage, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, settingsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
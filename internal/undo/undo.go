@@ -0,0 +1,146 @@
+// Package undo keeps snapshots of a pane's content from before destructive
+// actions (formatting, loading a file...), so they can be undone and
+// redone.
+//
+// The frontend records the content just before each action, and passes
+// the current content when undoing or redoing, so hand edits made since
+// the last action are never lost either.
+package undo
+
+import (
+	"sync"
+	"time"
+)
+
+// Defaults for New arguments left at zero.
+const (
+	DefaultMaxEntries = 50       // Snapshots kept per pane, undo and redo together
+	DefaultMaxBytes   = 64 << 20 // Content kept per pane, undo and redo together
+)
+
+// Snapshot is the content of a pane at one point.
+type Snapshot struct {
+	Content string    `json:"content"`
+	Action  string    `json:"action"` // What replaced this content, e.g. "format" or "load"
+	Time    time.Time `json:"time"`   // When it was recorded
+}
+
+// State says what Undo and Redo would do, for labelling menu items.
+type State struct {
+	CanUndo    bool   `json:"canUndo"`
+	CanRedo    bool   `json:"canRedo"`
+	UndoAction string `json:"undoAction"` // Action Undo reverts, e.g. "load"
+	RedoAction string `json:"redoAction"` // Action Redo repeats
+}
+
+// History is the undo and redo stacks of one pane. It is safe for
+// concurrent use.
+type History struct {
+	mu         sync.Mutex
+	undo, redo []Snapshot // Oldest first
+	maxEntries int
+	maxBytes   int
+}
+
+// New creates an empty history keeping at most maxEntries snapshots and
+// maxBytes of content; the oldest are dropped first. Zero uses the
+// defaults.
+func New(maxEntries, maxBytes int) *History {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &History{maxEntries: maxEntries, maxBytes: maxBytes}
+}
+
+// Record saves content from just before action replaces it. Anything that
+// could be redone is discarded, as in an editor. Recording the same
+// content as the latest snapshot only updates its action.
+func (h *History) Record(content, action string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.redo = nil
+	if n := len(h.undo); n > 0 && h.undo[n-1].Content == content {
+		h.undo[n-1].Action = action
+		return
+	}
+	h.undo = append(h.undo, Snapshot{Content: content, Action: action, Time: time.Now()})
+	h.trim()
+}
+
+// Undo returns the content from before the latest action, saving current
+// for Redo. ok is false if there is nothing to undo.
+func (h *History) Undo(current string) (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.move(&h.undo, &h.redo, current)
+}
+
+// Redo returns the content an Undo replaced, saving current for Undo
+// again. ok is false if there is nothing to redo.
+func (h *History) Redo(current string) (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.move(&h.redo, &h.undo, current)
+}
+
+// move pops the latest snapshot from one stack and pushes current, under
+// the same action, onto the other.
+func (h *History) move(from, to *[]Snapshot, current string) (Snapshot, bool) {
+	n := len(*from)
+	if n == 0 {
+		return Snapshot{}, false
+	}
+	snapshot := (*from)[n-1]
+	*from = (*from)[:n-1]
+	*to = append(*to, Snapshot{Content: current, Action: snapshot.Action, Time: time.Now()})
+	h.trim()
+	return snapshot, true
+}
+
+// State reports what Undo and Redo would do.
+func (h *History) State() State {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var s State
+	if n := len(h.undo); n > 0 {
+		s.CanUndo, s.UndoAction = true, h.undo[n-1].Action
+	}
+	if n := len(h.redo); n > 0 {
+		s.CanRedo, s.RedoAction = true, h.redo[n-1].Action
+	}
+	return s
+}
+
+// Clear discards every snapshot.
+func (h *History) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.undo, h.redo = nil, nil
+}
+
+// trim drops the oldest snapshots, undo ones first, until the limits are
+// met. The latest undo snapshot is always kept, however large.
+func (h *History) trim() {
+	size := 0
+	for _, s := range h.undo {
+		size += len(s.Content)
+	}
+	for _, s := range h.redo {
+		size += len(s.Content)
+	}
+
+	for len(h.undo)+len(h.redo) > 1 && (len(h.undo)+len(h.redo) > h.maxEntries || size > h.maxBytes) {
+		if len(h.undo) > 1 || len(h.redo) == 0 {
+			size -= len(h.undo[0].Content)
+			h.undo = h.undo[1:]
+		} else {
+			size -= len(h.redo[0].Content)
+			h.redo = h.redo[1:]
+		}
+	}
+}
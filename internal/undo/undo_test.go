@@ -0,0 +1,87 @@
+package undo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	h := New(0, 0)
+	if _, ok := h.Undo("x"); ok {
+		t.Fatal("expected nothing to undo")
+	}
+
+	// Hand-edited content is replaced by formatting, then by a file load
+	h.Record(`{"a":1}`, "format")
+	h.Record("{\n  \"a\": 1\n}", "load")
+
+	want := State{CanUndo: true, UndoAction: "load"}
+	if got := h.State(); got != want {
+		t.Errorf("State = %+v, want %+v", got, want)
+	}
+
+	s, ok := h.Undo(`{"loaded":true}`)
+	if !ok || s.Content != "{\n  \"a\": 1\n}" || s.Action != "load" {
+		t.Fatalf("Undo = %+v, %v", s, ok)
+	}
+	s, ok = h.Undo(s.Content)
+	if !ok || s.Content != `{"a":1}` {
+		t.Fatalf("second Undo = %+v, %v", s, ok)
+	}
+	if got := h.State(); got.CanUndo || !got.CanRedo || got.RedoAction != "format" {
+		t.Errorf("State after undoing everything = %+v", got)
+	}
+
+	s, ok = h.Redo(s.Content)
+	if !ok || s.Content != "{\n  \"a\": 1\n}" {
+		t.Fatalf("Redo = %+v, %v", s, ok)
+	}
+	s, ok = h.Redo(s.Content)
+	if !ok || s.Content != `{"loaded":true}` {
+		t.Fatalf("second Redo = %+v, %v", s, ok)
+	}
+	if _, ok := h.Redo(s.Content); ok {
+		t.Error("expected nothing left to redo")
+	}
+}
+
+func TestRecordClearsRedo(t *testing.T) {
+	h := New(0, 0)
+	h.Record("a", "format")
+	h.Undo("b")
+	h.Record("a", "minify")
+	if got := h.State(); got.CanRedo || got.UndoAction != "minify" {
+		t.Errorf("State = %+v, want only the minify to undo", got)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	h := New(3, 0)
+	for _, content := range []string{"1", "2", "3", "4", "5"} {
+		h.Record(content, "format")
+	}
+	var undone []string
+	current := "6"
+	for {
+		s, ok := h.Undo(current)
+		if !ok {
+			break
+		}
+		undone = append(undone, s.Content)
+		current = s.Content
+	}
+	if got := strings.Join(undone, ","); got != "5,4,3" {
+		t.Errorf("undone %s, want the 3 latest snapshots 5,4,3", got)
+	}
+
+	// The latest snapshot is kept even if it alone is over the size limit
+	h = New(0, 10)
+	h.Record("small", "format")
+	h.Record(strings.Repeat("x", 100), "load")
+	if s, ok := h.Undo(""); !ok || len(s.Content) != 100 {
+		t.Errorf("expected the large snapshot to be kept, got %d bytes, %v", len(s.Content), ok)
+	}
+	if _, ok := h.Undo(""); ok {
+		t.Error("expected the older snapshot to be dropped to stay under the size limit")
+	}
+}
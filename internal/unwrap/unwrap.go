@@ -0,0 +1,131 @@
+// Package unwrap decodes text that arrives wrapped in a transport encoding,
+// such as a base64 event payload or a percent-encoded query parameter, and
+// formats the result when it turns out to be JSON.
+package unwrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"jtool/internal/parser"
+)
+
+// Encodings accepted by Decode.
+const (
+	Base64    = "base64"    // Standard alphabet (+/), padding optional
+	Base64URL = "base64url" // URL-safe alphabet (-_), padding optional
+	Percent   = "url"       // Percent-encoding, with "+" as a space
+)
+
+// maxGunzipped bounds the output of a gzipped payload.
+const maxGunzipped = 50 * 1024 * 1024 // 50MB
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Result is decoded text.
+type Result struct {
+	Text    string `json:"text"`    // Decoded text; pretty-printed if IsJSON, a hex dump if Binary
+	IsJSON  bool   `json:"isJSON"`  // The decoded text is a JSON object or array
+	Gzipped bool   `json:"gzipped"` // The decoded bytes were gzip data and were decompressed
+	Binary  bool   `json:"binary"`  // The decoded bytes aren't UTF-8 text
+}
+
+// Decode decodes text in the given encoding. Base64 payloads that decode to
+// gzip data, as CloudWatch Logs subscriptions deliver them, are also
+// decompressed.
+func Decode(text, encoding string) (*Result, error) {
+	var data []byte
+	var err error
+	switch encoding {
+	case Base64:
+		data, err = decodeBase64(text, base64.StdEncoding)
+	case Base64URL:
+		data, err = decodeBase64(text, base64.URLEncoding)
+	case Percent:
+		var s string
+		s, err = url.QueryUnescape(strings.TrimSpace(text))
+		data = []byte(s)
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", encoding, err)
+	}
+
+	result := &Result{}
+	if bytes.HasPrefix(data, gzipMagic) {
+		if data, err = gunzip(data); err != nil {
+			return nil, fmt.Errorf("error decompressing gzip data: %w", err)
+		}
+		result.Gzipped = true
+	}
+
+	if !utf8.Valid(data) {
+		result.Text = hex.Dump(data)
+		result.Binary = true
+		return result, nil
+	}
+
+	result.Text = string(data)
+	if formatted, ok := formatJSON(data); ok {
+		result.Text = formatted
+		result.IsJSON = true
+	}
+	return result, nil
+}
+
+// decodeBase64 decodes base64 text with or without padding. Whitespace is
+// ignored, since encoded payloads are often wrapped at 76 columns.
+func decodeBase64(text string, enc *base64.Encoding) ([]byte, error) {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+	return enc.WithPadding(base64.NoPadding).DecodeString(strings.TrimRight(text, "="))
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	out, err := io.ReadAll(io.LimitReader(gz, maxGunzipped+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxGunzipped {
+		return nil, fmt.Errorf("decompressed data is larger than %d bytes", maxGunzipped)
+	}
+	return out, nil
+}
+
+// formatJSON pretty-prints data if it's a JSON object or array. Scalars are
+// left alone: "42" or "true" decoded from a parameter is rarely meant as JSON.
+func formatJSON(data []byte) (string, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' && trimmed[0] != '[' {
+		return "", false
+	}
+	value, err := parser.Parse(trimmed)
+	if err != nil {
+		return "", false
+	}
+	formatted, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", false
+	}
+	return string(formatted), true
+}
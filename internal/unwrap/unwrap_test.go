@@ -0,0 +1,106 @@
+package unwrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(`{"logEvents":[]}`))
+	w.Close()
+
+	tests := []struct {
+		name     string
+		text     string
+		encoding string
+		want     Result
+	}{
+		{
+			name:     "base64 JSON",
+			text:     "eyJhIjoxfQ==",
+			encoding: Base64,
+			want:     Result{Text: "{\n  \"a\": 1\n}", IsJSON: true},
+		},
+		{
+			name:     "base64 without padding, wrapped",
+			text:     "eyJh\nIjox fQ",
+			encoding: Base64,
+			want:     Result{Text: "{\n  \"a\": 1\n}", IsJSON: true},
+		},
+		{
+			name:     "base64url",
+			text:     base64.RawURLEncoding.EncodeToString([]byte(`["??>"]`)),
+			encoding: Base64URL,
+			want:     Result{Text: "[\n  \"??\\u003e\"\n]", IsJSON: true},
+		},
+		{
+			name:     "plain text",
+			text:     base64.StdEncoding.EncodeToString([]byte("hello")),
+			encoding: Base64,
+			want:     Result{Text: "hello"},
+		},
+		{
+			name:     "scalar is not JSON",
+			text:     "42",
+			encoding: Percent,
+			want:     Result{Text: "42"},
+		},
+		{
+			name:     "percent-encoded",
+			text:     "%7B%22q%22%3A%22a+b%22%7D",
+			encoding: Percent,
+			want:     Result{Text: "{\n  \"q\": \"a b\"\n}", IsJSON: true},
+		},
+		{
+			name:     "gzipped",
+			text:     base64.StdEncoding.EncodeToString(gz.Bytes()),
+			encoding: Base64,
+			want:     Result{Text: "{\n  \"logEvents\": []\n}", IsJSON: true, Gzipped: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.text, tt.encoding)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("Decode() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_Binary(t *testing.T) {
+	got, err := Decode(base64.StdEncoding.EncodeToString([]byte{0xff, 0x00, 0x41}), Base64)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Binary || !strings.HasPrefix(got.Text, "00000000  ff 00 41") {
+		t.Errorf("Decode() = %+v, want a hex dump", *got)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		text, encoding, want string
+	}{
+		{"not base64!", Base64, "invalid base64"},
+		{"a+b/", Base64URL, "invalid base64url"},
+		{"%zz", Percent, "invalid url"},
+		{"x", "rot13", `unknown encoding "rot13"`},
+	}
+
+	for _, tt := range tests {
+		_, err := Decode(tt.text, tt.encoding)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Decode(%q, %q) error = %v, want one containing %q", tt.text, tt.encoding, err, tt.want)
+		}
+	}
+}
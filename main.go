@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/menu"
@@ -15,9 +16,18 @@ import (
 var assets embed.FS
 
 func main() {
+	// Headless commands like `jtool analyze -` never start the GUI
+	exitIfCLI()
+
 	// Create an instance of the app structure
 	app := NewApp()
 
+	// Defaults from ~/.config/jtool/config.(json|yaml) and JTOOL_* variables
+	app.loadConfig()
+
+	// Files passed on the command line, e.g. by a file association
+	app.openFiles(fileArgs(os.Args[1:]))
+
 	// Create the application menu
 	appMenu := createAppMenu(app)
 
@@ -33,16 +43,27 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
 		OnStartup:        app.startup,
+		OnBeforeClose:    app.beforeClose,
 		OnShutdown:       app.shutdown,
 		Menu:             appMenu,
 		Bind: []interface{}{
 			app,
 		},
+		// Launching jtool again, e.g. "Open With" on Windows or Linux, hands
+		// the files to the running window
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               "com.wails.jtool",
+			OnSecondInstanceLaunch: app.onSecondInstance,
+		},
 		Mac: &mac.Options{
 			About: &mac.AboutInfo{
 				Title:   "jtool",
 				Message: "A JSON diff and analysis tool",
 			},
+			// Double-clicked files and "Open With" on macOS
+			OnFileOpen: func(path string) {
+				app.openFiles([]string{path})
+			},
 		},
 	})
 
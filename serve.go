@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"jtool/internal/config"
+	"jtool/internal/loganalyzer"
+)
+
+// defaultMaxRequestSize bounds request bodies for `jtool serve`.
+const defaultMaxRequestSize = 100 << 20 // 100 MB
+
+// serveWriteTimeout bounds how long `jtool serve` spends on a request,
+// from reading its body to writing the response. Analyzing a large log
+// file can take minutes.
+const serveWriteTimeout = 10 * time.Minute
+
+// errOutsideRoot is returned for request paths outside the -root directory.
+var errOutsideRoot = errors.New("path is outside the served directory")
+
+// runServe implements `jtool serve [flags]`: it exposes the comparison and
+// log analysis bindings over HTTP so CI jobs can call them without starting
+// a process per file. Responses are the same structs the GUI receives.
+//
+// Requests may name files instead of sending their contents; those are read
+// from the server's filesystem, limited to -root when it is set. So that
+// web pages can't make the browser call the server, it only listens on
+// localhost by default, answers requests addressed to a loopback host and
+// requires a JSON Content-Type, which cross-origin forms can't send.
+// Options a request leaves out default to cfg's.
+func runServe(args []string, cfg *config.Config, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: jtool serve [flags]")
+		fmt.Fprintln(stderr, "Endpoints (POST, JSON in and out): /diff, /analyze, /compare-logs")
+		fmt.Fprintln(stderr, "Requests must be addressed to localhost and have Content-Type: application/json.")
+		fs.PrintDefaults()
+	}
+
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on")
+	maxSize := fs.Int64("max-request-size", defaultMaxRequestSize, "largest request body accepted, in bytes")
+	root := fs.String("root", "", "only read files named in requests from this directory (default: any file)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var files fileRoot
+	if *root != "" {
+		dir, err := filepath.Abs(*root)
+		if err == nil {
+			dir, err = filepath.EvalSymlinks(dir)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "jtool: invalid -root: %v\n", err)
+			return 2
+		}
+		files = fileRoot(dir)
+	}
+
+	app := NewApp()
+	app.config = cfg
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newServeMux(app, *maxSize, files),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      serveWriteTimeout,
+	}
+
+	// Stop accepting requests on Ctrl-C, letting ones in flight finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(stderr, "jtool: listening on %s\n", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "jtool: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// diffRequest is the body of POST /diff. Each side is either an inline JSON
+// document or the path of a file holding one.
+type diffRequest struct {
+	Left      json.RawMessage  `json:"left"`
+	Right     json.RawMessage  `json:"right"`
+	LeftPath  string           `json:"leftPath"`
+	RightPath string           `json:"rightPath"`
+	Options   NormalizeOptions `json:"options"`
+}
+
+// analyzeRequest is the body of POST /analyze: log lines inline as content,
+// or the path of a log file.
+type analyzeRequest struct {
+	Content string              `json:"content"`
+	Path    string              `json:"path"`
+	Options loganalyzer.Options `json:"options"`
+}
+
+// compareLogsRequest is the body of POST /compare-logs. Each side is either
+// inline log lines or the path of a log file.
+type compareLogsRequest struct {
+	Left      string                     `json:"left"`
+	Right     string                     `json:"right"`
+	LeftPath  string                     `json:"leftPath"`
+	RightPath string                     `json:"rightPath"`
+	Options   loganalyzer.CompareOptions `json:"options"`
+
+	AnalyzeOptions loganalyzer.Options `json:"analyzeOptions"` // How both sides are analyzed
+}
+
+// newServeMux routes the `jtool serve` endpoints to app's bindings. Paths
+// in requests are resolved in root.
+func newServeMux(app *App, maxSize int64, root fileRoot) http.Handler {
+	mux := http.NewServeMux()
+	readFile := func(path string) (string, error) {
+		path, err := root.resolve(path)
+		if err != nil {
+			return "", err
+		}
+		return app.ReadFilePath(path)
+	}
+	analyzeFile := func(path string, opts loganalyzer.Options) (*loganalyzer.AnalysisResult, error) {
+		path, err := root.resolve(path)
+		if err != nil {
+			return nil, err
+		}
+		return app.AnalyzeLogFilePathWithOptions(path, opts)
+	}
+
+	mux.HandleFunc("POST /diff", func(w http.ResponseWriter, r *http.Request) {
+		// Only the config's options are defaulted, so a request means the
+		// same with or without a config file unless it sets them
+		req := diffRequest{Options: app.withConfig(NormalizeOptions{})}
+		if !decodeRequest(w, r, maxSize, &req) {
+			return
+		}
+		left, err := requestDocument("left", req.Left, req.LeftPath, readFile)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		right, err := requestDocument("right", req.Right, req.RightPath, readFile)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		result, err := app.CompareJSONWithOptions(left, right, req.Options)
+		if result != nil {
+			// Requests share app; don't keep one client's documents around
+			// for the session-based bindings
+			app.forgetDiff(result.ID)
+		}
+		writeResponse(w, result, err)
+	})
+
+	mux.HandleFunc("POST /analyze", func(w http.ResponseWriter, r *http.Request) {
+		req := analyzeRequest{Options: app.defaultAnalyzeOptions()}
+		if !decodeRequest(w, r, maxSize, &req) {
+			return
+		}
+		if req.Path != "" && req.Content != "" {
+			writeError(w, fmt.Errorf("send either content or path, not both"))
+			return
+		}
+		var result *loganalyzer.AnalysisResult
+		var err error
+		if req.Path != "" {
+			result, err = analyzeFile(req.Path, req.Options)
+		} else {
+			result, err = app.AnalyzeLogStringWithOptions(req.Content, req.Options)
+		}
+		writeResponse(w, result, err)
+	})
+
+	mux.HandleFunc("POST /compare-logs", func(w http.ResponseWriter, r *http.Request) {
+		req := compareLogsRequest{Options: app.GetDefaultLogCompareOptions(), AnalyzeOptions: app.defaultAnalyzeOptions()}
+		if !decodeRequest(w, r, maxSize, &req) {
+			return
+		}
+		left, err := analyzeRequestSide("left", req.Left, req.LeftPath, req.AnalyzeOptions, app, analyzeFile)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		right, err := analyzeRequestSide("right", req.Right, req.RightPath, req.AnalyzeOptions, app, analyzeFile)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, app.CompareLogAnalysesWithOptions(left, right, req.LeftPath, req.RightPath, req.Options))
+	})
+
+	return checkRequest(mux)
+}
+
+// checkRequest answers requests that a web page could have made instead of
+// passing them to next: those addressed to a host other than localhost
+// (DNS rebinding) and those without a JSON body (cross-origin forms).
+func checkRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			writeJSON(w, http.StatusForbidden, map[string]string{"error": fmt.Sprintf("requests must be addressed to localhost, not %q", r.Host)})
+			return
+		}
+		if r.Method == http.MethodPost {
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				writeJSON(w, http.StatusUnsupportedMediaType, map[string]string{"error": "Content-Type must be application/json"})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether the Host header host, with or without a
+// port, names this machine's loopback interface.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// fileRoot is the directory request paths must be in, or "" for anywhere.
+// It is absolute and has no symlinks.
+type fileRoot string
+
+// resolve returns the file path in a request names: relative paths are
+// taken from root. Paths that lead outside root, including through a
+// symlink, are rejected with errOutsideRoot.
+func (root fileRoot) resolve(path string) (string, error) {
+	if root == "" || path == "" {
+		return path, nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(string(root), path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("file not found: %s", path)
+		}
+		return "", err
+	}
+	rel, err := filepath.Rel(string(root), resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s: %w", path, errOutsideRoot)
+	}
+	return resolved, nil
+}
+
+// defaultAnalyzeOptions returns the log analysis options requests start
+// from: the zero options, with the config's path format.
+func (a *App) defaultAnalyzeOptions() loganalyzer.Options {
+	var opts loganalyzer.Options
+	if a.config != nil {
+		opts.PathFormat = a.config.PathFormat
+	}
+	return opts
+}
+
+// requestDocument returns one side of a diff request, reading it from path
+// if the document wasn't sent inline.
+func requestDocument(side string, doc json.RawMessage, path string, read func(string) (string, error)) (string, error) {
+	switch {
+	case len(doc) > 0 && path != "":
+		return "", fmt.Errorf("send either %s or %sPath, not both", side, side)
+	case len(doc) > 0:
+		return string(doc), nil
+	case path != "":
+		return read(path)
+	default:
+		return "", fmt.Errorf("no %s document provided", side)
+	}
+}
+
+// analyzeRequestSide analyzes one side of a compare-logs request, reading
+// files with analyzeFile.
+func analyzeRequestSide(side, content, path string, opts loganalyzer.Options, app *App, analyzeFile func(string, loganalyzer.Options) (*loganalyzer.AnalysisResult, error)) (*loganalyzer.AnalysisResult, error) {
+	switch {
+	case content != "" && path != "":
+		return nil, fmt.Errorf("send either %s or %sPath, not both", side, side)
+	case path != "":
+		result, err := analyzeFile(path, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", side, err)
+		}
+		return result, nil
+	default:
+		return app.AnalyzeLogStringWithOptions(content, opts)
+	}
+}
+
+// decodeRequest reads a JSON request body into v, answering 400 and
+// returning false if it isn't valid. Unknown fields are rejected so a
+// misspelled option doesn't silently fall back to its default.
+func decodeRequest(w http.ResponseWriter, r *http.Request, maxSize int64, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeJSON(w, status, map[string]string{"error": fmt.Sprintf("invalid request: %v", err)})
+		return false
+	}
+	return true
+}
+
+// writeResponse writes a binding's result as JSON, or its error.
+func writeResponse(w http.ResponseWriter, result any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// writeError answers {"error": ...} with status 400: every error a binding
+// returns is caused by the request (bad JSON, a missing file...). Paths
+// outside -root get 403.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, errOutsideRoot) {
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveRequest sends a JSON POST to handler as a local client would.
+func serveRequest(handler http.Handler, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Host = "127.0.0.1:8080"
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeResponse decodes a response body into a generic JSON object.
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+	}
+	return body
+}
+
+func TestServe_Endpoints(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.jsonl")
+	if err := os.WriteFile(logFile, []byte(`{"level": "info"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logPath, _ := json.Marshal(logFile)
+	handler := newServeMux(NewApp(), defaultMaxRequestSize, "")
+
+	tests := []struct {
+		path, body string
+		check      func(t *testing.T, body map[string]any)
+	}{
+		{"/diff", `{"left": {"a": 1}, "right": {"a": 2}}`, func(t *testing.T, body map[string]any) {
+			if stats, _ := body["stats"].(map[string]any); stats["changed"] != 1.0 {
+				t.Errorf("stats = %v, want 1 change", body["stats"])
+			}
+		}},
+		{"/analyze", `{"content": "{\"a\": 1}\n{\"a\": 2}"}`, func(t *testing.T, body map[string]any) {
+			if body["jsonLines"] != 2.0 {
+				t.Errorf("jsonLines = %v, want 2", body["jsonLines"])
+			}
+		}},
+		{"/compare-logs", `{"left": "{\"a\": 1}", "rightPath": ` + string(logPath) + `}`, func(t *testing.T, body map[string]any) {
+			if body["rightFile"] != logFile {
+				t.Errorf("rightFile = %v, want %s", body["rightFile"], logFile)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := serveRequest(handler, tt.path, tt.body)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+			}
+			tt.check(t, decodeResponse(t, rec))
+		})
+	}
+}
+
+func TestServe_DiffForgetsSession(t *testing.T) {
+	app := NewApp()
+	handler := newServeMux(app, defaultMaxRequestSize, "")
+
+	rec := serveRequest(handler, "/diff", `{"left": {"a": {"b": 1}}, "right": {"a": {"b": 2}}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+
+	// The next request must not reach the previous client's documents
+	if _, err := app.ExpandDiffNode(".a", 0, 10); err == nil || !strings.Contains(err.Error(), "no comparison") {
+		t.Errorf("expected no comparison to expand after a served diff, got %v", err)
+	}
+	if _, err := app.UpdateAndRediff("left", `{"a": {"b": 3}}`); err == nil {
+		t.Error("expected UpdateAndRediff to fail after a served diff")
+	}
+}
+
+func TestServe_BadRequests(t *testing.T) {
+	handler := newServeMux(NewApp(), 64, "")
+
+	tests := []struct {
+		name, path, body string
+		want             int
+	}{
+		{"invalid JSON", "/diff", `{"left":`, http.StatusBadRequest},
+		{"unknown field", "/analyze", `{"contents": ""}`, http.StatusBadRequest},
+		{"invalid document", "/diff", `{"left": 1, "right": "x", "leftPath": "a.json"}`, http.StatusBadRequest},
+		{"missing side", "/compare-logs", `{"left": "{}", "leftPath": "a.log"}`, http.StatusBadRequest},
+		{"too large", "/analyze", `{"content": "` + strings.Repeat("x", 100) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveRequest(handler, tt.path, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if body := decodeResponse(t, rec); body["error"] == nil {
+				t.Errorf("response %v has no error", body)
+			}
+		})
+	}
+}
+
+func TestServe_RejectsBrowserRequests(t *testing.T) {
+	handler := newServeMux(NewApp(), defaultMaxRequestSize, "")
+	body := `{"left": {}, "right": {}}`
+
+	tests := []struct {
+		name, host, contentType string
+		want                    int
+	}{
+		{"localhost", "localhost:8080", "application/json; charset=utf-8", http.StatusOK},
+		{"IPv6 loopback", "[::1]:8080", "application/json", http.StatusOK},
+		{"other host", "attacker.example:8080", "application/json", http.StatusForbidden},
+		{"form", "127.0.0.1:8080", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"text", "127.0.0.1:8080", "text/plain", http.StatusUnsupportedMediaType},
+		{"no content type", "127.0.0.1:8080", "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/diff", strings.NewReader(body))
+			req.Host = tt.host
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestServe_Root(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	for _, file := range []string{filepath.Join(root, "in.json"), filepath.Join(outside, "out.json")} {
+		if err := os.WriteFile(file, []byte(`{"a": 1}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "out.json"), filepath.Join(root, "link.json")); err != nil {
+		t.Skipf("can't create symlinks: %v", err)
+	}
+	dir, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := newServeMux(NewApp(), defaultMaxRequestSize, fileRoot(dir))
+
+	tests := []struct {
+		name, path string
+		want       int
+	}{
+		{"relative", "in.json", http.StatusOK},
+		{"absolute", filepath.Join(root, "in.json"), http.StatusOK},
+		{"outside", filepath.Join(outside, "out.json"), http.StatusForbidden},
+		{"dot-dot", filepath.Join("..", filepath.Base(outside), "out.json"), http.StatusForbidden},
+		{"symlink out", "link.json", http.StatusForbidden},
+		{"missing", "missing.json", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, _ := json.Marshal(tt.path)
+			rec := serveRequest(handler, "/diff", `{"leftPath": `+string(path)+`, "right": {"a": 1}}`)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}